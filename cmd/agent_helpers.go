@@ -46,6 +46,10 @@ func extractApplyBase(result apply.ApplyResult) *apply.ApplyResultBase {
 		return &r.ApplyResultBase
 	case apply.ExtensionConfigApplyResult:
 		return &r.ApplyResultBase
+	case *apply.BreakpointSetApplyResult:
+		return &r.ApplyResultBase
+	case apply.BreakpointSetApplyResult:
+		return &r.ApplyResultBase
 	default:
 		return nil
 	}
@@ -63,6 +67,14 @@ func buildApplySuggestions(results []apply.ApplyResult) []string {
 		return nil
 	}
 
+	// Breakpoint sets are not addressable by ID; point at the breakpoint commands instead.
+	if base.ResourceType == string(apply.ResourceBreakpointSet) && base.Action != apply.ActionUnchanged {
+		return []string{
+			"Verify with 'dtctl get breakpoints'",
+			"Follow snapshots with 'dtctl logs breakpoint --follow'",
+		}
+	}
+
 	switch base.Action {
 	case apply.ActionCreated:
 		return []string{
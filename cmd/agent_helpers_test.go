@@ -169,6 +169,24 @@ func TestBuildApplySuggestions_Unchanged(t *testing.T) {
 	}
 }
 
+func TestBuildApplySuggestions_BreakpointSet(t *testing.T) {
+	results := []apply.ApplyResult{
+		&apply.BreakpointSetApplyResult{
+			ApplyResultBase: apply.ApplyResultBase{
+				Action: apply.ActionUpdated, ResourceType: "breakpoint_set", ID: "ws-1",
+			},
+		},
+	}
+
+	suggestions := buildApplySuggestions(results)
+	if len(suggestions) != 2 {
+		t.Fatalf("expected 2 suggestions, got %d", len(suggestions))
+	}
+	if suggestions[0] != "Verify with 'dtctl get breakpoints'" {
+		t.Errorf("unexpected suggestion[0]: %q", suggestions[0])
+	}
+}
+
 func TestBuildApplySuggestions_EmptyResults(t *testing.T) {
 	suggestions := buildApplySuggestions(nil)
 	if suggestions != nil {
@@ -10,7 +10,9 @@ import (
 	"github.com/dynatrace-oss/dtctl/pkg/apply"
 	"github.com/dynatrace-oss/dtctl/pkg/client"
 	"github.com/dynatrace-oss/dtctl/pkg/output"
+	"github.com/dynatrace-oss/dtctl/pkg/resources/dashboard"
 	"github.com/dynatrace-oss/dtctl/pkg/resources/document"
+	"github.com/dynatrace-oss/dtctl/pkg/resources/notebook"
 	"github.com/dynatrace-oss/dtctl/pkg/util/template"
 )
 
@@ -56,12 +58,19 @@ making it easy to deploy the same resource across multiple environments.
 
 Supported resource types:
   - Workflows (automation)
-  - Dashboards
-  - Notebooks
+  - Dashboards (documents or dashboard specs, see 'dtctl build dashboard')
+  - Notebooks (documents or notebook specs, see 'dtctl build notebook')
   - SLOs
   - Grail buckets
   - Settings objects
   - Extension monitoring configurations
+  - Live Debugger breakpoint sets (experimental)
+
+Breakpoint sets:
+  A file with a 'breakpoints' list describes the Live Debugger workspace of the
+  current project. Apply creates missing breakpoints, updates conditions and
+  enabled state, removes breakpoints not listed in the file and, when 'filters'
+  is present, replaces the workspace filters. Use --dry-run to list the changes.
 
 Array input (bulk apply):
   Files containing an array of resources (e.g., from 'dtctl get settings --schema ...
@@ -72,6 +81,12 @@ Examples:
   # Create a new dashboard and stamp the ID back into the file
   dtctl apply -f dashboard.yaml --write-id
 
+  # Build and apply a dashboard spec with queries in .dql files
+  dtctl apply -f spec.yaml
+
+  # Apply a dashboard exported with 'dtctl export dashboard --explode'
+  dtctl apply -f service-health/dashboard.yaml
+
   # Update existing dashboard (file exported with 'get' command includes ID)
   dtctl get dashboard my-dash -o yaml > dashboard.yaml
   # Edit dashboard.yaml...
@@ -93,6 +108,10 @@ Examples:
   # Edit rum-settings.yaml (modify values for specific applications)...
   dtctl apply -f rum-settings.yaml  # Updates all settings in the file
 
+  # Share a debugging setup with the team
+  dtctl apply -f breakpoints.yaml --dry-run
+  dtctl apply -f breakpoints.yaml
+
   # Apply with template variables
   dtctl apply -f dashboard.yaml --set environment=prod --set owner=team-a
 
@@ -138,6 +157,17 @@ resources in sync with their file definitions.
 			return fmt.Errorf("failed to read file: %w", err)
 		}
 
+		// Dashboard and notebook specs are compiled to a document first
+		switch {
+		case dashboard.IsSpec(fileData):
+			fileData, err = buildDashboardSpec(file, fileData)
+		case notebook.IsSpec(fileData):
+			fileData, err = buildNotebookSpec(file, fileData)
+		}
+		if err != nil {
+			return err
+		}
+
 		// Parse template variables
 		var templateVars map[string]interface{}
 		if len(setFlags) > 0 {
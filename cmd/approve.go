@@ -0,0 +1,224 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/dynatrace-oss/dtctl/pkg/approval"
+	"github.com/dynatrace-oss/dtctl/pkg/config"
+	"github.com/dynatrace-oss/dtctl/pkg/output"
+	"github.com/dynatrace-oss/dtctl/pkg/prompt"
+	"github.com/dynatrace-oss/dtctl/pkg/safety"
+)
+
+// approvalFile is the countersigned change request passed via --approval
+var approvalFile string
+
+// approveYes skips the confirmation prompt of 'dtctl approve'
+var approveYes bool
+
+// ChangeRequestError reports that a change request was written and has to be
+// approved before the command can proceed.
+type ChangeRequestError struct {
+	Path    string
+	Request *approval.ChangeRequest
+}
+
+func (e *ChangeRequestError) Error() string {
+	return fmt.Sprintf("%s on %s %s in context '%s' requires two-person approval; change request written to %s",
+		e.Request.Operation, e.Request.Resource, strings.Join(e.Request.Targets, ", "), e.Request.Context, e.Path)
+}
+
+// writeChangeRequest signs the change request of an ApprovalRequiredError
+// with the local approval identity and writes it to the current directory.
+func writeChangeRequest(approvalErr *safety.ApprovalRequiredError) error {
+	cfg, err := LoadConfig()
+	if err != nil {
+		return err
+	}
+	key, err := approval.LoadPrivateKey(cfg.Approval.Identity)
+	if err != nil {
+		return fmt.Errorf("%w: cannot create change request: %v", approvalErr, err)
+	}
+
+	request := approvalErr.Request
+	request.Sign(cfg.Approval.Identity, key)
+	path := request.DefaultFileName()
+	if err := request.Save(path); err != nil {
+		return err
+	}
+	return &ChangeRequestError{Path: path, Request: request}
+}
+
+// approveCmd countersigns a change request
+var approveCmd = &cobra.Command{
+	Use:   "approve <change-request-file>",
+	Short: "Approve a change request for a dangerous operation",
+	Long: `Approve a change request created by another engineer.
+
+Contexts can require two-person approval for dangerous operations with
+'require-approval' (e.g. [delete-bucket]). Running such an operation writes a
+change request file signed by the requester instead of executing it. A second
+engineer reviews the request and countersigns it with 'dtctl approve'; the
+requester then re-runs the original command with --approval <file>.
+
+Both signatures are verified locally against the approvers in the config
+('dtctl config add-approver'). Change requests expire after 24 hours.`,
+	Example: `  # Review and approve a change request
+  dtctl approve dtctl-change-3f9a1c2b4d5e6f70.yaml
+
+  # The requester then runs the original command with the approval
+  dtctl delete bucket old-logs --approval dtctl-change-3f9a1c2b4d5e6f70.yaml`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		path := args[0]
+
+		cfg, err := LoadConfig()
+		if err != nil {
+			return err
+		}
+		request, err := approval.Load(path)
+		if err != nil {
+			return err
+		}
+		keys, err := approval.TrustedKeys(cfg.Approval.Approvers)
+		if err != nil {
+			return err
+		}
+		if err := request.VerifyRequest(keys, time.Now()); err != nil {
+			return err
+		}
+		if request.ApproverSignature != "" {
+			return fmt.Errorf("change request %s is already approved by %q", request.ID, request.Approver)
+		}
+		if request.Requester == cfg.Approval.Identity {
+			return fmt.Errorf("change request %s was created by you (%q) and must be approved by someone else", request.ID, request.Requester)
+		}
+		key, err := approval.LoadPrivateKey(cfg.Approval.Identity)
+		if err != nil {
+			return err
+		}
+
+		printChangeRequest(request)
+
+		if !approveYes && !plainMode {
+			if !prompt.Confirm(fmt.Sprintf("Approve %s on %s %s?", request.Operation, request.Resource, strings.Join(request.Targets, ", "))) {
+				fmt.Println("Approval cancelled")
+				return nil
+			}
+		}
+
+		if err := request.Approve(cfg.Approval.Identity, key); err != nil {
+			return err
+		}
+		if err := request.Save(path); err != nil {
+			return err
+		}
+
+		output.PrintSuccess("Change request %s approved by %s", request.ID, cfg.Approval.Identity)
+		output.PrintHint("Send %s back to %s to run the command with --approval %s", path, request.Requester, path)
+		return nil
+	},
+}
+
+func printChangeRequest(r *approval.ChangeRequest) {
+	const w = 13
+	output.DescribeKV("ID:", w, "%s", r.ID)
+	output.DescribeKV("Requester:", w, "%s", r.Requester)
+	output.DescribeKV("Context:", w, "%s (%s)", r.Context, r.Environment)
+	output.DescribeKV("Operation:", w, "%s", r.Operation)
+	output.DescribeKV("Resource:", w, "%s", r.Resource)
+	output.DescribeKV("Targets:", w, "%s", strings.Join(r.Targets, ", "))
+	output.DescribeKV("Created:", w, "%s", r.CreatedAt.Local().Format(time.RFC3339))
+	output.DescribeKV("Expires:", w, "%s", r.ExpiresAt.Local().Format(time.RFC3339))
+	if r.Diff != "" {
+		fmt.Println()
+		fmt.Println(strings.TrimRight(r.Diff, "\n"))
+	}
+	fmt.Println()
+}
+
+// configGenerateApprovalKeyCmd creates the local signing key for approvals
+var configGenerateApprovalKeyCmd = &cobra.Command{
+	Use:   "generate-approval-key <name>",
+	Short: "Generate a signing key for two-person approval",
+	Long: `Generate an ed25519 signing key for change requests and approvals.
+
+The private key is stored in the dtctl config directory and <name> becomes the
+local approval identity. The public key is added to the trusted approvers and
+printed; share it with your team so they can add it with
+'dtctl config add-approver'.`,
+	Example: `  dtctl config generate-approval-key alice`,
+	Args:    cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		name := args[0]
+
+		cfg, err := loadRawConfig()
+		if err != nil {
+			cfg = config.NewConfig()
+		}
+		publicKey, err := approval.GenerateKey(name)
+		if err != nil {
+			return err
+		}
+		cfg.Approval.Identity = name
+		setApprover(cfg, name, publicKey)
+		if err := saveConfig(cfg); err != nil {
+			return err
+		}
+
+		fmt.Println(publicKey)
+		output.PrintSuccess("Signing key %q stored at %s", name, approval.KeyPath(name))
+		output.PrintHint("Share your public key: dtctl config add-approver %s %s", name, publicKey)
+		return nil
+	},
+}
+
+// configAddApproverCmd trusts the public key of an approver
+var configAddApproverCmd = &cobra.Command{
+	Use:   "add-approver <name> <public-key>",
+	Short: "Trust the public key of a requester or approver",
+	Example: `  # Key printed by 'dtctl config generate-approval-key bob' on the approver's machine
+  dtctl config add-approver bob 3q2+7wAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAA=`,
+	Args: cobra.ExactArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		name, publicKey := args[0], args[1]
+		if _, err := approval.ParsePublicKey(publicKey); err != nil {
+			return err
+		}
+
+		cfg, err := loadRawConfig()
+		if err != nil {
+			cfg = config.NewConfig()
+		}
+		setApprover(cfg, name, publicKey)
+		if err := saveConfig(cfg); err != nil {
+			return err
+		}
+
+		output.PrintSuccess("Approver %q added", name)
+		return nil
+	},
+}
+
+// setApprover adds or replaces the public key of an approver
+func setApprover(cfg *config.Config, name, publicKey string) {
+	for i := range cfg.Approval.Approvers {
+		if cfg.Approval.Approvers[i].Name == name {
+			cfg.Approval.Approvers[i].PublicKey = publicKey
+			return
+		}
+	}
+	cfg.Approval.Approvers = append(cfg.Approval.Approvers, config.Approver{Name: name, PublicKey: publicKey})
+}
+
+func init() {
+	rootCmd.AddCommand(approveCmd)
+	approveCmd.Flags().BoolVarP(&approveYes, "yes", "y", false, "Skip confirmation prompt")
+
+	configCmd.AddCommand(configGenerateApprovalKeyCmd)
+	configCmd.AddCommand(configAddApproverCmd)
+}
@@ -0,0 +1,184 @@
+package cmd
+
+import (
+	"os"
+	"os/user"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/dynatrace-oss/dtctl/pkg/audit"
+	"github.com/dynatrace-oss/dtctl/pkg/client"
+	"github.com/dynatrace-oss/dtctl/pkg/commands"
+	"github.com/dynatrace-oss/dtctl/pkg/output"
+)
+
+// auditSession collects the API changes of the running command for the audit log
+var auditSession struct {
+	mu      sync.Mutex
+	changes []audit.Change
+	client  *client.Client
+}
+
+// auditLogFunc returns the audit log; tests override it.
+var auditLogFunc = audit.DefaultLog
+
+// trackAuditMutations records the state-changing requests of c in the audit session
+func trackAuditMutations(c *client.Client) {
+	auditSession.mu.Lock()
+	auditSession.client = c
+	auditSession.mu.Unlock()
+
+	c.OnMutation(func(m client.Mutation) {
+		auditSession.mu.Lock()
+		defer auditSession.mu.Unlock()
+		auditSession.changes = append(auditSession.changes, audit.Change{
+			Method:        m.Method,
+			Path:          m.Path,
+			Status:        m.StatusCode,
+			VersionBefore: m.VersionBefore,
+			VersionAfter:  m.VersionAfter,
+		})
+	})
+}
+
+// mutatingVerb returns the verb of the running command and whether it
+// changes state (see commands.IsMutating).
+func mutatingVerb() (string, bool) {
+	fields := strings.Fields(activeCommandPath)
+	if len(fields) < 2 {
+		return "", false
+	}
+	subcommand := ""
+	if len(fields) > 2 {
+		subcommand = fields[2]
+	}
+	return fields[1], commands.IsMutating(fields[1], subcommand)
+}
+
+// writeAuditRecord appends the executed command to the audit log if it is a
+// mutating command. args are the command-line
+// arguments after alias expansion; cmdErr is the result of the command.
+func writeAuditRecord(args []string, cmdErr error) {
+	verb, mutating := mutatingVerb()
+	if !mutating || dryRun || !audit.Enabled() {
+		return
+	}
+
+	record := audit.Record{
+		Time:     time.Now().UTC(),
+		Verb:     verb,
+		Resource: resourceFromCommandPath(activeCommandPath),
+		Command:  strings.Join(append([]string{"dtctl"}, audit.RedactArgs(args)...), " "),
+		Success:  cmdErr == nil,
+	}
+	if cmdErr != nil {
+		record.Error = cmdErr.Error()
+	}
+	if c, _, err := rootCmd.Find(args); err == nil && len(c.Flags().Args()) > 0 {
+		record.ResourceID = c.Flags().Args()[0]
+	}
+	if cfg, err := LoadConfig(); err == nil {
+		record.Context = cfg.CurrentContext
+		if ctx, err := cfg.CurrentContextObj(); err == nil {
+			record.Environment = ctx.Environment
+		}
+	}
+	record.Host, _ = os.Hostname()
+	if u, err := user.Current(); err == nil {
+		record.OSUser = u.Username
+	}
+
+	auditSession.mu.Lock()
+	record.Changes = auditSession.changes
+	c := auditSession.client
+	auditSession.mu.Unlock()
+	if c != nil {
+		record.User, _ = c.CurrentUserID()
+	}
+
+	if err := auditLogFunc().Append(record); err != nil {
+		output.PrintWarning("Could not write audit log: %v", err)
+	}
+}
+
+// auditCmd groups audit log commands
+var auditCmd = &cobra.Command{
+	Use:   "audit",
+	Short: "Inspect the local audit log of mutating commands",
+	Long: `Inspect the local audit log of mutating commands.
+
+Every create, update, delete, apply, edit, restore, share, unshare, enable,
+exec workflow and exec function command appends a record to a JSONL audit log in the dtctl data
+directory: time, context, environment, Dynatrace user ID, workstation, the
+command line with secrets redacted, the resource and the API changes with
+their before/after versions. Failed and blocked commands are recorded too.
+
+The log is rotated at 10 MB and the last 5 files are kept. Set DTCTL_AUDIT=off
+to disable it.`,
+	RunE: requireSubcommand,
+}
+
+// auditLogCmd queries the audit log
+var auditLogCmd = &cobra.Command{
+	Use:   "log",
+	Short: "Show audit log records",
+	Long: `Show records of the local audit log, oldest first.
+
+Use --for-context to show only the records of one context. The global
+--context flag selects the context to run with and does not filter.`,
+	Example: `  # Recent changes
+  dtctl audit log
+
+  # Deletions in production during the last day
+  dtctl audit log --for-context prod --verb delete --since 24h
+
+  # Failed commands with full details
+  dtctl audit log --failed -o json`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		since, _ := cmd.Flags().GetDuration("since")
+		filter := audit.Filter{
+			Failed: mustGetBool(cmd, "failed"),
+		}
+		filter.Context, _ = cmd.Flags().GetString("for-context")
+		filter.Verb, _ = cmd.Flags().GetString("verb")
+		filter.Resource, _ = cmd.Flags().GetString("resource")
+		filter.User, _ = cmd.Flags().GetString("user")
+		filter.Limit, _ = cmd.Flags().GetInt("limit")
+		if since > 0 {
+			filter.Since = time.Now().Add(-since)
+		}
+
+		records, err := auditLogFunc().Read(filter)
+		if err != nil {
+			return err
+		}
+
+		printer := NewPrinter()
+		if ap := enrichAgent(printer, "get", "audit-log"); ap != nil {
+			ap.SetTotal(len(records))
+		}
+		return printer.PrintList(records)
+	},
+}
+
+func mustGetBool(cmd *cobra.Command, name string) bool {
+	v, _ := cmd.Flags().GetBool(name)
+	return v
+}
+
+func init() {
+	rootCmd.AddCommand(auditCmd)
+	auditCmd.AddCommand(auditLogCmd)
+
+	auditLogCmd.Flags().String("for-context", "", "only show records of this context")
+	auditLogCmd.Flags().Duration("since", 0, "only show records newer than this duration (e.g. 24h)")
+	auditLogCmd.Flags().String("verb", "", "only show records of this verb (e.g. delete)")
+	auditLogCmd.Flags().String("resource", "", "only show records of this resource type (e.g. workflow)")
+	auditLogCmd.Flags().String("user", "", "only show records of this Dynatrace user ID or OS user")
+	auditLogCmd.Flags().Bool("failed", false, "only show failed or blocked commands")
+	auditLogCmd.Flags().Int("limit", 50, "show at most this many of the newest records (0 = all)")
+}
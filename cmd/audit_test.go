@@ -0,0 +1,114 @@
+package cmd
+
+import (
+	"errors"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/dynatrace-oss/dtctl/pkg/audit"
+)
+
+func withTestAuditLog(t *testing.T, commandPath string) *audit.Log {
+	t.Helper()
+	log := &audit.Log{Path: filepath.Join(t.TempDir(), "audit.jsonl"), MaxBackups: 1}
+	oldFunc, oldPath, oldCfgFile := auditLogFunc, activeCommandPath, cfgFile
+	auditLogFunc = func() *audit.Log { return log }
+	activeCommandPath = commandPath
+	cfgFile = filepath.Join(t.TempDir(), "missing.yaml")
+	t.Cleanup(func() {
+		auditLogFunc, activeCommandPath, cfgFile = oldFunc, oldPath, oldCfgFile
+		auditSession.changes, auditSession.client = nil, nil
+	})
+	return log
+}
+
+func TestWriteAuditRecord_Mutating(t *testing.T) {
+	log := withTestAuditLog(t, "dtctl delete workflow")
+	auditSession.changes = []audit.Change{{Method: "DELETE", Path: "/platform/automation/v1/workflows/wf-1", Status: 204}}
+
+	writeAuditRecord([]string{"delete", "workflow", "wf-1", "--token", "dt0c01.ABC.DEF"}, errors.New("boom"))
+
+	records, err := log.Read(audit.Filter{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(records) != 1 {
+		t.Fatalf("got %d records, want 1", len(records))
+	}
+	r := records[0]
+	if r.Verb != "delete" || r.Resource != "workflow" {
+		t.Errorf("verb/resource = %q/%q, want delete/workflow", r.Verb, r.Resource)
+	}
+	if strings.Contains(r.Command, "dt0c01") || !strings.Contains(r.Command, audit.Redacted) {
+		t.Errorf("command not redacted: %q", r.Command)
+	}
+	if r.Success || r.Error != "boom" {
+		t.Errorf("success/error = %v/%q, want false/boom", r.Success, r.Error)
+	}
+	if len(r.Changes) != 1 || r.Changes[0].Status != 204 {
+		t.Errorf("changes = %+v", r.Changes)
+	}
+}
+
+func TestWriteAuditRecord_Skipped(t *testing.T) {
+	tests := []struct {
+		name        string
+		commandPath string
+		dryRun      bool
+		env         string
+	}{
+		{name: "read-only command", commandPath: "dtctl get workflows"},
+		{name: "read-only exec subcommand", commandPath: "dtctl exec dashboard"},
+		{name: "dry run", commandPath: "dtctl delete workflow", dryRun: true},
+		{name: "disabled", commandPath: "dtctl delete workflow", env: "off"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			log := withTestAuditLog(t, tt.commandPath)
+			t.Setenv(audit.EnvAudit, tt.env)
+			oldDryRun := dryRun
+			dryRun = tt.dryRun
+			defer func() { dryRun = oldDryRun }()
+
+			writeAuditRecord(strings.Fields(tt.commandPath)[1:], nil)
+
+			records, err := log.Read(audit.Filter{})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(records) != 0 {
+				t.Errorf("got %d records, want none", len(records))
+			}
+		})
+	}
+}
+
+func TestAuditLogCmd_ForContext(t *testing.T) {
+	log := withTestAuditLog(t, "dtctl audit log")
+	for _, ctx := range []string{"prod", "dev"} {
+		if err := log.Append(audit.Record{Time: time.Now().UTC(), Context: ctx, Verb: "delete", Resource: "workflow", ResourceID: "wf-" + ctx, Success: true}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	oldContext := contextName
+	contextName = "dev" // the global --context must not filter
+	t.Cleanup(func() {
+		contextName = oldContext
+		_ = auditLogCmd.Flags().Set("for-context", "")
+	})
+	if err := auditLogCmd.Flags().Set("for-context", "prod"); err != nil {
+		t.Fatal(err)
+	}
+
+	out := captureStdout(t, func() {
+		if err := auditLogCmd.RunE(auditLogCmd, nil); err != nil {
+			t.Errorf("audit log failed: %v", err)
+		}
+	})
+	if !strings.Contains(out, "wf-prod") || strings.Contains(out, "wf-dev") {
+		t.Errorf("expected only the prod record, got:\n%s", out)
+	}
+}
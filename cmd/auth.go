@@ -371,7 +371,7 @@ func finalizeLoginConfig(cfg *config.Config, contextName, environment, tokenName
 	cfg.PruneEmptyEnvironments(contextName, placeholderNames)
 }
 
-// authLoginCmd initiates browser-based OAuth login
+// authLoginCmd initiates browser-based (or device authorization) OAuth login
 var authLoginCmd = &cobra.Command{
 	Use:   "login",
 	Short: "Authenticate using browser-based OAuth login",
@@ -385,6 +385,12 @@ This command will:
 
 After successful login, you can use dtctl commands without needing to manage API tokens manually.
 
+Headless machines:
+  On SSH sessions, containers and jump hosts without a browser, use --device.
+  dtctl prints a verification URL and a short code; open the URL on any other
+  device, enter the code and sign in. dtctl polls until the login completes and
+  then stores the tokens exactly like the browser flow.
+
 If --context and --environment are omitted, the current context is used. This is useful
 for re-authenticating when both the access token and refresh token have expired.
 
@@ -406,7 +412,10 @@ instead (dtctl config set-credentials).`,
   dtctl auth login --context my-env --environment https://abc12345.apps.dynatrace.com --token-name my-oauth-token
 
   # Login with custom timeout
-  dtctl auth login --context my-env --environment https://abc12345.apps.dynatrace.com --timeout 5m`,
+  dtctl auth login --context my-env --environment https://abc12345.apps.dynatrace.com --timeout 5m
+
+  # Login from a machine without a browser (SSH session, container)
+  dtctl auth login --context my-env --environment https://abc12345.apps.dynatrace.com --device`,
 	RunE: func(cmd *cobra.Command, args []string) error {
 		// Get flags
 		contextName, _ := cmd.Flags().GetString("context")
@@ -414,6 +423,7 @@ instead (dtctl config set-credentials).`,
 		tokenName, _ := cmd.Flags().GetString("token-name")
 		timeoutStr, _ := cmd.Flags().GetString("timeout")
 		safetyLevelStr, _ := cmd.Flags().GetString("safety-level")
+		device, _ := cmd.Flags().GetBool("device")
 
 		// Resolve contextName, environment and tokenName from the config when not
 		// supplied as explicit flags.
@@ -535,18 +545,27 @@ instead (dtctl config set-credentials).`,
 		output.PrintInfo("Safety level: %s", oauthConfig.SafetyLevel)
 		output.PrintInfo("Requesting OAuth scopes for safety level %s...", oauthConfig.SafetyLevel)
 
-		// Create OAuth flow
-		flow, err := auth.NewOAuthFlow(oauthConfig)
-		if err != nil {
-			return fmt.Errorf("failed to initialize OAuth: %w", err)
-		}
-
 		// Start OAuth flow with timeout
 		ctx, cancel := context.WithTimeout(context.Background(), timeout)
 		defer cancel()
 
-		output.PrintInfo("Starting OAuth authentication flow...")
-		tokens, err := flow.Start(ctx)
+		var tokens *auth.TokenSet
+		var getUserInfo func(string) (*auth.UserInfo, error)
+		if device {
+			// Device authorization flow (RFC 8628): no browser or callback server needed
+			flow := auth.NewDeviceFlow(oauthConfig)
+			output.PrintInfo("Starting OAuth device authorization flow...")
+			tokens, err = flow.Start(ctx)
+			getUserInfo = flow.GetUserInfo
+		} else {
+			flow, flowErr := auth.NewOAuthFlow(oauthConfig)
+			if flowErr != nil {
+				return fmt.Errorf("failed to initialize OAuth: %w", flowErr)
+			}
+			output.PrintInfo("Starting OAuth authentication flow...")
+			tokens, err = flow.Start(ctx)
+			getUserInfo = flow.GetUserInfo
+		}
 		if err != nil {
 			return fmt.Errorf("authentication failed: %w", err)
 		}
@@ -554,7 +573,7 @@ instead (dtctl config set-credentials).`,
 		output.PrintSuccess("Authentication successful!")
 
 		// Get user info
-		userInfo, err := flow.GetUserInfo(tokens.AccessToken)
+		userInfo, err := getUserInfo(tokens.AccessToken)
 		if err != nil {
 			output.PrintWarning("Failed to retrieve user info: %v", err)
 		} else {
@@ -773,6 +792,7 @@ func init() {
 	authLoginCmd.Flags().String("token-name", "", "name for storing the OAuth token (defaults to existing token name or <context>-oauth)")
 	authLoginCmd.Flags().String("timeout", "5m", "timeout for the authentication flow")
 	authLoginCmd.Flags().String("safety-level", string(config.DefaultSafetyLevel), "safety level for the context (readonly, readwrite-mine, readwrite-all, dangerously-unrestricted)")
+	authLoginCmd.Flags().Bool("device", false, "use the device authorization flow (print a URL and code instead of opening a browser)")
 
 	// Flags for logout
 	authLogoutCmd.Flags().Bool("remove-context", false, "also remove the context configuration")
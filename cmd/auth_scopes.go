@@ -0,0 +1,147 @@
+package cmd
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/dynatrace-oss/dtctl/pkg/auth"
+	"github.com/dynatrace-oss/dtctl/pkg/output"
+)
+
+// CommandScopes is a command with the token scopes it needs.
+type CommandScopes struct {
+	Command   string   `json:"command" yaml:"command" table:"COMMAND"`
+	Scopes    []string `json:"scopes" yaml:"scopes" table:"-"`
+	ScopeList string   `json:"-" yaml:"-" table:"SCOPES"`
+	Note      string   `json:"note,omitempty" yaml:"note,omitempty" table:"NOTE,wide"`
+}
+
+// ScopeCalculation is the minimal scope set for a list of commands.
+type ScopeCalculation struct {
+	Commands []string `json:"commands" yaml:"commands"`
+	Scopes   []string `json:"scopes" yaml:"scopes"`
+	Notes    []string `json:"notes,omitempty" yaml:"notes,omitempty"`
+	Unknown  []string `json:"unknown,omitempty" yaml:"unknown,omitempty"`
+}
+
+// authScopesCmd lists the token scopes needed by commands
+var authScopesCmd = &cobra.Command{
+	Use:   "scopes",
+	Short: "Show the token scopes required by commands",
+	Long: `Show the token scopes required by dtctl commands.
+
+With --for, prints the minimal set of scopes for creating a token that can run
+the given commands, e.g. a least-privilege token for a CI pipeline. Commands
+are separated by commas and may use resource aliases. Without --for, lists
+every command with its scopes.
+
+Some commands need additional scopes that depend on their input, e.g. 'query'
+needs a storage:<type>:read scope for each data type it reads and 'apply'
+needs the scopes of the resource types in the applied files. These are
+reported as notes.
+
+Before a command calls the API, dtctl checks the scopes of OAuth access tokens
+against this list and fails with the missing scopes instead of a 403 response.
+Platform tokens (dt0s16.*) do not carry their scopes and are not checked; with
+-v a warning lists the scopes the command needs.
+Set DTCTL_SKIP_SCOPE_PREFLIGHT=1 to disable the check.`,
+	Example: `  # Minimal scopes for a CI token
+  dtctl auth scopes --for "get workflows,query,apply"
+
+  # All commands and their scopes
+  dtctl auth scopes -o wide`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		forCommands, _ := cmd.Flags().GetString("for")
+
+		if forCommands == "" {
+			var rows []CommandScopes
+			for _, path := range auth.CommandScopePaths() {
+				scopes, _ := auth.ScopesForCommand(path)
+				rows = append(rows, CommandScopes{
+					Command:   path,
+					Scopes:    scopes,
+					ScopeList: strings.Join(scopes, ", "),
+					Note:      auth.ScopeNoteForCommand(path),
+				})
+			}
+			printer := NewPrinter()
+			if ap := enrichAgent(printer, "get", "scopes"); ap != nil {
+				ap.SetTotal(len(rows))
+			}
+			return printer.PrintList(rows)
+		}
+
+		calc := calculateCommandScopes(cmd.Root(), strings.Split(forCommands, ","))
+		if len(calc.Commands) == 0 {
+			return fmt.Errorf("no known commands in --for %q", forCommands)
+		}
+
+		if agentMode || (outputFormat != "" && outputFormat != "table" && outputFormat != "wide") {
+			printer := NewPrinter()
+			if ap := enrichAgent(printer, "get", "scopes"); ap != nil {
+				ap.SetTotal(len(calc.Scopes))
+				var warnings []string
+				for _, unknown := range calc.Unknown {
+					warnings = append(warnings, fmt.Sprintf("no scope information for %q", unknown))
+				}
+				ap.SetWarnings(warnings)
+			}
+			return printer.Print(calc)
+		}
+
+		for _, scope := range calc.Scopes {
+			fmt.Println(scope)
+		}
+		for _, note := range calc.Notes {
+			output.PrintHint("%s", note)
+		}
+		for _, unknown := range calc.Unknown {
+			output.PrintWarning("No scope information for %q", unknown)
+		}
+		return nil
+	},
+}
+
+// calculateCommandScopes resolves command names (including aliases) against
+// the command tree and returns the union of their scopes.
+func calculateCommandScopes(root *cobra.Command, commands []string) ScopeCalculation {
+	var calc ScopeCalculation
+	for _, entry := range commands {
+		fields := strings.Fields(entry)
+		if len(fields) == 0 {
+			continue
+		}
+		if fields[0] == root.Name() {
+			fields = fields[1:]
+		}
+
+		path := strings.Join(fields, " ")
+		if found, _, err := root.Find(fields); err == nil && found != root {
+			path = strings.TrimPrefix(found.CommandPath(), root.Name()+" ")
+		}
+		if _, ok := auth.ScopesForCommand(path); !ok {
+			calc.Unknown = append(calc.Unknown, strings.Join(fields, " "))
+			continue
+		}
+
+		if slices.Contains(calc.Commands, path) {
+			continue
+		}
+		calc.Commands = append(calc.Commands, path)
+		if note := auth.ScopeNoteForCommand(path); note != "" {
+			calc.Notes = append(calc.Notes, fmt.Sprintf("%s: %s", path, note))
+		}
+	}
+	calc.Scopes, _ = auth.ScopesForCommands(calc.Commands)
+	return calc
+}
+
+func init() {
+	authCmd.AddCommand(authScopesCmd)
+
+	authScopesCmd.Flags().String("for", "", "comma-separated commands to calculate the minimal scope set for (e.g. \"get workflows,query\")")
+}
@@ -0,0 +1,41 @@
+package cmd
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/dynatrace-oss/dtctl/pkg/auth"
+)
+
+// TestCommandScopePaths_MatchCommandTree guards against the scope table
+// drifting from the command tree when commands are renamed or removed.
+func TestCommandScopePaths_MatchCommandTree(t *testing.T) {
+	for _, path := range auth.CommandScopePaths() {
+		found, _, err := rootCmd.Find(strings.Fields(path))
+		if err != nil || found.CommandPath() != "dtctl "+path {
+			t.Errorf("scope table entry %q does not match a command", path)
+		}
+	}
+}
+
+func TestCalculateCommandScopes(t *testing.T) {
+	calc := calculateCommandScopes(rootCmd, []string{"get workflows", " dtctl get wf ", "exec workflow", "frobnicate", ""})
+
+	if want := []string{"get workflows", "exec workflow"}; !reflect.DeepEqual(calc.Commands, want) {
+		t.Errorf("Commands = %v, want %v", calc.Commands, want)
+	}
+	if want := []string{"automation:workflows:read", "automation:workflows:run"}; !reflect.DeepEqual(calc.Scopes, want) {
+		t.Errorf("Scopes = %v, want %v", calc.Scopes, want)
+	}
+	if want := []string{"frobnicate"}; !reflect.DeepEqual(calc.Unknown, want) {
+		t.Errorf("Unknown = %v, want %v", calc.Unknown, want)
+	}
+}
+
+func TestCalculateCommandScopes_Notes(t *testing.T) {
+	calc := calculateCommandScopes(rootCmd, []string{"query"})
+	if len(calc.Notes) != 1 || !strings.HasPrefix(calc.Notes[0], "query: ") {
+		t.Errorf("Notes = %v, want one note for query", calc.Notes)
+	}
+}
@@ -3,8 +3,6 @@ package cmd
 import (
 	"encoding/json"
 	"fmt"
-	"os"
-	"path/filepath"
 	"sort"
 	"strconv"
 	"strings"
@@ -184,15 +182,7 @@ func breakpointRowFromRule(rule livedebugger.BreakpointRule) (breakpointRow, boo
 }
 
 func currentProjectPath() string {
-	cwd, err := os.Getwd()
-	if err != nil {
-		return "no-project"
-	}
-	project := filepath.Base(cwd)
-	if project == "" || project == "." || project == string(filepath.Separator) {
-		return "no-project"
-	}
-	return project
+	return livedebugger.DefaultProjectPath()
 }
 
 func parseFilters(input string) (map[string][]string, error) {
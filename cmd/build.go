@@ -0,0 +1,29 @@
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+)
+
+// buildCmd represents the build command
+var buildCmd = &cobra.Command{
+	Use:   "build",
+	Short: "Compile resource specs into resource definitions",
+	Long: `Compile compact resource specs into the definitions the API expects.
+
+The output can be applied with 'dtctl apply -f', which also accepts the specs
+directly.
+
+Supported resources:
+  dashboard
+  notebook`,
+	Example: `  # Compile a dashboard spec into a dashboard document
+  dtctl build dashboard spec.yaml > dashboard.json`,
+	RunE: requireSubcommand,
+}
+
+func init() {
+	rootCmd.AddCommand(buildCmd)
+
+	buildCmd.AddCommand(buildDashboardCmd)
+	buildCmd.AddCommand(buildNotebookCmd)
+}
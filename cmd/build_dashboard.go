@@ -0,0 +1,105 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/spf13/cobra"
+
+	"github.com/dynatrace-oss/dtctl/pkg/output"
+	"github.com/dynatrace-oss/dtctl/pkg/resources/dashboard"
+)
+
+// buildDashboardCmd compiles a dashboard spec
+var buildDashboardCmd = &cobra.Command{
+	Use:     "dashboard <spec-file>",
+	Aliases: []string{"dash", "db"},
+	Short:   "Compile a dashboard spec into a dashboard document",
+	Long: `Compile a dashboard spec into a dashboard document (JSON by default, or
+-o yaml). The spec describes a dashboard compactly, with its queries in
+separate .dql files so that they can be reviewed as text:
+
+  kind: DashboardSpec
+  id: <dashboard-id>           # optional, apply updates this dashboard
+  title: Service Health
+  description: Errors and latency of the checkout services
+  timeframe: {from: now()-24h, to: now()}
+  variables:
+    - key: env
+      type: csv                # csv, query or text
+      values: [prod, stage]
+      default: prod
+    - key: service
+      type: query
+      file: queries/services.dql
+      multiple: true
+  rows:                        # rows of tiles on a 24 column grid
+    - height: 4                # grid rows, default 6
+      tiles:
+        - title: Error rate
+          file: queries/error-rate.dql
+          visualization: lineChart
+          width: 16            # tiles without width share the free columns
+        - markdown: "## Runbook\nSee the wiki."
+    - tiles:
+        - title: Requests
+          query: timeseries sum(dt.service.request.count)
+          visualization: areaChart
+          settings: {}         # visualizationSettings of the tile
+
+File paths are relative to the spec file. 'dtctl apply -f spec.yaml' builds
+and applies the spec in one step.
+
+Examples:
+  # Compile a spec
+  dtctl build dashboard spec.yaml > dashboard.json
+
+  # Compile and check the result
+  dtctl build dashboard spec.yaml | dtctl lint dashboard -f -
+
+  # Build and apply
+  dtctl apply -f spec.yaml
+`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		data, err := os.ReadFile(args[0])
+		if err != nil {
+			return fmt.Errorf("failed to read spec: %w", err)
+		}
+		doc, err := loadDashboardSpec(args[0], data)
+		if err != nil {
+			return err
+		}
+
+		if agentMode {
+			printer := NewPrinter()
+			enrichAgent(printer, "build", "dashboard")
+			return printer.Print(doc)
+		}
+		format := outputFormat
+		if format != "yaml" && format != "yml" {
+			format = "json"
+		}
+		return output.NewPrinterWithOptions(format, os.Stdout, plainMode).Print(doc)
+	},
+}
+
+// loadDashboardSpec builds the dashboard document of a spec file
+func loadDashboardSpec(path string, data []byte) (map[string]any, error) {
+	spec, err := dashboard.LoadSpec(data)
+	if err != nil {
+		return nil, err
+	}
+	return spec.Build(filepath.Dir(path))
+}
+
+// buildDashboardSpec builds a spec file into dashboard document JSON
+func buildDashboardSpec(path string, data []byte) ([]byte, error) {
+	doc, err := loadDashboardSpec(path, data)
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(doc)
+}
@@ -0,0 +1,42 @@
+package cmd
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestBuildDashboardSpec(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "errors.dql"), []byte("fetch logs | filter loglevel == \"ERROR\" | limit 10\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	spec := filepath.Join(dir, "spec.yaml")
+	data := []byte("kind: DashboardSpec\ntitle: Errors\nrows:\n  - tiles:\n      - title: Errors\n        file: errors.dql\n")
+
+	out, err := buildDashboardSpec(spec, data)
+	if err != nil {
+		t.Fatalf("buildDashboardSpec() error = %v", err)
+	}
+
+	// The result is a dashboard document that the dashboard parser understands
+	d, err := parseDashboardFile(out)
+	if err != nil {
+		t.Fatalf("parseDashboardFile() error = %v", err)
+	}
+	if len(d.Tiles) != 1 || d.Tiles[0].Query != `fetch logs | filter loglevel == "ERROR" | limit 10` {
+		t.Errorf("tiles = %+v", d.Tiles)
+	}
+	var doc map[string]any
+	if err := json.Unmarshal(out, &doc); err != nil {
+		t.Fatal(err)
+	}
+	if doc["name"] != "Errors" || doc["type"] != "dashboard" {
+		t.Errorf("document = %v", doc)
+	}
+
+	if _, err := buildDashboardSpec(filepath.Join(t.TempDir(), "spec.yaml"), data); err == nil {
+		t.Error("expected error for a missing query file")
+	}
+}
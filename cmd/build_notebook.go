@@ -0,0 +1,87 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/spf13/cobra"
+
+	"github.com/dynatrace-oss/dtctl/pkg/output"
+	"github.com/dynatrace-oss/dtctl/pkg/resources/notebook"
+)
+
+// buildNotebookCmd compiles a notebook spec
+var buildNotebookCmd = &cobra.Command{
+	Use:     "notebook <spec-file>",
+	Aliases: []string{"nb"},
+	Short:   "Compile a notebook spec into a notebook document",
+	Long: `Compile a notebook spec into a notebook document (JSON by default, or
+-o yaml). The spec lists the sections of a notebook, with their queries and
+markdown in separate files:
+
+  kind: NotebookSpec
+  id: <notebook-id>            # optional, apply updates this notebook
+  title: Error Analysis
+  timeframe: {from: now()-2h}
+  sections:
+    - markdownFile: sections/01-intro.md
+    - title: Errors by namespace
+      file: sections/02-errors.dql
+      visualization: table
+    - query: fetch logs | limit 10
+    - markdown: "## Findings"
+
+File paths are relative to the spec file. 'dtctl export notebook --explode'
+writes a spec of an existing notebook, and 'dtctl apply -f spec.yaml' builds
+and applies a spec in one step.
+
+Examples:
+  # Compile a spec
+  dtctl build notebook notebook.yaml > notebook.json
+
+  # Build and apply
+  dtctl apply -f notebook.yaml
+`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		data, err := os.ReadFile(args[0])
+		if err != nil {
+			return fmt.Errorf("failed to read spec: %w", err)
+		}
+		doc, err := loadNotebookSpec(args[0], data)
+		if err != nil {
+			return err
+		}
+
+		if agentMode {
+			printer := NewPrinter()
+			enrichAgent(printer, "build", "notebook")
+			return printer.Print(doc)
+		}
+		format := outputFormat
+		if format != "yaml" && format != "yml" {
+			format = "json"
+		}
+		return output.NewPrinterWithOptions(format, os.Stdout, plainMode).Print(doc)
+	},
+}
+
+// loadNotebookSpec builds the notebook document of a spec file
+func loadNotebookSpec(path string, data []byte) (map[string]any, error) {
+	spec, err := notebook.LoadSpec(data)
+	if err != nil {
+		return nil, err
+	}
+	return spec.Build(filepath.Dir(path))
+}
+
+// buildNotebookSpec builds a spec file into notebook document JSON
+func buildNotebookSpec(path string, data []byte) ([]byte, error) {
+	doc, err := loadNotebookSpec(path, data)
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(doc)
+}
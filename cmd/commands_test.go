@@ -291,6 +291,10 @@ func TestMutatingVerbsMatchSafetyCheckerUsage(t *testing.T) {
 		// Extract the verb from the filename pattern: <verb>_<resource>.go or <verb>.go
 		name := strings.TrimSuffix(entry.Name(), ".go")
 		verb := strings.SplitN(name, "_", 2)[0]
+		// Hyphenated verbs live in <verb_with_underscores>.go (transfer_ownership.go)
+		if hyphenated := strings.ReplaceAll(name, "_", "-"); commands.MutatingVerbs[hyphenated] != "" {
+			verb = hyphenated
+		}
 
 		// Special cases: root.go defines both NewSafetyChecker and SetupWithSafety,
 		// not a verb itself.
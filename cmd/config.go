@@ -1,8 +1,11 @@
 package cmd
 
 import (
+	"bufio"
 	"fmt"
+	"io"
 	"os"
+	"strings"
 
 	"github.com/spf13/cobra"
 	"gopkg.in/yaml.v3"
@@ -185,7 +188,7 @@ var configCurrentContextCmd = &cobra.Command{
 		if err != nil {
 			return err
 		}
-		fmt.Println(cfg.CurrentContext)
+		printCurrentContext(cfg)
 		return nil
 	},
 }
@@ -244,13 +247,51 @@ Examples:
 var configSetCredentialsCmd = &cobra.Command{
 	Use:   "set-credentials <name>",
 	Short: "Set credentials in the config",
-	Args:  cobra.ExactArgs(1),
+	Long: `Set credentials in the config.
+
+Credentials are either an API or platform token (--token), or an OAuth client
+(--client-id and --client-secret) such as the client of a service user. For an
+OAuth client, dtctl requests short-lived access tokens with the
+client-credentials grant and renews them automatically; the requested scopes
+follow the safety level of the context that references the credentials.
+
+Secrets are stored in the OS keyring when available. Pass '-' as the value of
+--token or --client-secret to read it from stdin instead of the command line.
+
+Examples:
+  # API or platform token
+  dtctl config set-credentials my-token --token dt0s16.XXXX
+
+  # OAuth client of a service user (e.g. for CI)
+  echo "$DT_CLIENT_SECRET" | dtctl config set-credentials ci-client \
+    --client-id dt0s02.XXXX --client-secret -
+  dtctl config set-context ci --environment https://abc12345.apps.dynatrace.com \
+    --token-ref ci-client --safety-level readwrite-mine
+`,
+	Args: cobra.ExactArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
 		name := args[0]
 		token, _ := cmd.Flags().GetString("token")
+		clientID, _ := cmd.Flags().GetString("client-id")
+		clientSecret, _ := cmd.Flags().GetString("client-secret")
+
+		switch {
+		case token != "" && (clientID != "" || clientSecret != ""):
+			return fmt.Errorf("--token cannot be combined with --client-id or --client-secret")
+		case token == "" && clientID == "" && clientSecret == "":
+			return fmt.Errorf("either --token or --client-id and --client-secret is required")
+		case token == "" && (clientID == "" || clientSecret == ""):
+			return fmt.Errorf("--client-id and --client-secret must be set together")
+		}
 
-		if token == "" {
-			return fmt.Errorf("--token is required")
+		var err error
+		if token == "-" {
+			token, err = readSecretFromStdin()
+		} else if clientSecret == "-" {
+			clientSecret, err = readSecretFromStdin()
+		}
+		if err != nil {
+			return err
 		}
 
 		cfg, err := loadConfigRaw()
@@ -258,7 +299,12 @@ var configSetCredentialsCmd = &cobra.Command{
 			cfg = config.NewConfig()
 		}
 
-		if err := cfg.SetToken(name, token); err != nil {
+		if token != "" {
+			err = cfg.SetToken(name, token)
+		} else {
+			err = cfg.SetOAuthClientCredentials(name, clientID, clientSecret)
+		}
+		if err != nil {
 			return err
 		}
 
@@ -275,6 +321,19 @@ var configSetCredentialsCmd = &cobra.Command{
 	},
 }
 
+// readSecretFromStdin reads a secret from the first line of stdin.
+func readSecretFromStdin() (string, error) {
+	line, err := bufio.NewReader(os.Stdin).ReadString('\n')
+	if err != nil && err != io.EOF {
+		return "", fmt.Errorf("failed to read secret from stdin: %w", err)
+	}
+	secret := strings.TrimSpace(line)
+	if secret == "" {
+		return "", fmt.Errorf("no secret provided on stdin")
+	}
+	return secret, nil
+}
+
 // configSetCmd sets a configuration value
 var configSetCmd = &cobra.Command{
 	Use:   "set <key> <value>",
@@ -423,5 +482,7 @@ func init() {
 	configSetContextCmd.Flags().String("description", "", "human-readable description for this context")
 
 	// Flags for set-credentials
-	configSetCredentialsCmd.Flags().String("token", "", "API token")
+	configSetCredentialsCmd.Flags().String("token", "", "API token ('-' reads it from stdin)")
+	configSetCredentialsCmd.Flags().String("client-id", "", "OAuth client ID (for service users)")
+	configSetCredentialsCmd.Flags().String("client-secret", "", "OAuth client secret ('-' reads it from stdin)")
 }
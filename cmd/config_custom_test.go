@@ -299,3 +299,46 @@ func TestConfigSetCredentialsWithCustomPath(t *testing.T) {
 		t.Error("token reference not found in custom config")
 	}
 }
+
+// TestConfigSetCredentialsOAuthClient tests set-credentials with an OAuth client
+func TestConfigSetCredentialsOAuthClient(t *testing.T) {
+	t.Setenv(config.EnvDisableKeyring, "1")
+
+	originalCfgFile := cfgFile
+	defer func() { cfgFile = originalCfgFile }()
+	cfgFile = filepath.Join(t.TempDir(), "custom-config.yaml")
+
+	setFlags := func(token, clientID, clientSecret string) {
+		_ = configSetCredentialsCmd.Flags().Set("token", token)
+		_ = configSetCredentialsCmd.Flags().Set("client-id", clientID)
+		_ = configSetCredentialsCmd.Flags().Set("client-secret", clientSecret)
+	}
+	defer setFlags("", "", "")
+
+	setFlags("dt0s16.token", "dt0s02.client", "")
+	if err := configSetCredentialsCmd.RunE(configSetCredentialsCmd, []string{"ci"}); err == nil {
+		t.Error("expected error when combining --token and --client-id")
+	}
+
+	setFlags("", "dt0s02.client", "")
+	if err := configSetCredentialsCmd.RunE(configSetCredentialsCmd, []string{"ci"}); err == nil {
+		t.Error("expected error when --client-secret is missing")
+	}
+
+	setFlags("", "dt0s02.client", "client-secret")
+	if err := configSetCredentialsCmd.RunE(configSetCredentialsCmd, []string{"ci"}); err != nil {
+		t.Fatalf("failed to set credentials: %v", err)
+	}
+
+	cfg, err := config.LoadFrom(cfgFile)
+	if err != nil {
+		t.Fatalf("failed to load config: %v", err)
+	}
+	cred := cfg.GetCredential("ci")
+	if cred == nil {
+		t.Fatal("credentials not found in config")
+	}
+	if cred.Type != config.CredentialTypeOAuthClient || cred.ClientID != "dt0s02.client" {
+		t.Errorf("credential = %+v, want type %q with client ID", cred, config.CredentialTypeOAuthClient)
+	}
+}
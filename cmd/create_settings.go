@@ -20,6 +20,11 @@ var createSettingsCmd = &cobra.Command{
 	Short: "Create a settings object from a file",
 	Long: `Create a new settings object from a YAML or JSON file.
 
+With --scaffold, no object is created. Instead, a commented YAML skeleton of a
+settings object for the schema is printed: defaults are filled in, enum values
+are listed, required properties are marked and nested types are expanded. Fill
+in the values and create the object with 'dtctl apply -f <file>'.
+
 Examples:
   # Create a settings object
   dtctl create settings -f pipeline.yaml --schema builtin:openpipeline.logs.pipelines --scope environment
@@ -29,6 +34,9 @@ Examples:
 
   # Dry run to preview
   dtctl create settings -f settings.yaml --schema builtin:openpipeline.logs.pipelines --scope environment --dry-run
+
+  # Generate a YAML skeleton to fill in
+  dtctl create settings --schema builtin:alerting.profile --scaffold > profile.yaml
 `,
 	Aliases: []string{"setting"},
 	RunE: func(cmd *cobra.Command, args []string) error {
@@ -36,13 +44,17 @@ Examples:
 		schemaID, _ := cmd.Flags().GetString("schema")
 		scope, _ := cmd.Flags().GetString("scope")
 		setFlags, _ := cmd.Flags().GetStringArray("set")
+		scaffold, _ := cmd.Flags().GetBool("scaffold")
 
-		if file == "" {
-			return fmt.Errorf("--file is required")
-		}
 		if schemaID == "" {
 			return fmt.Errorf("--schema is required")
 		}
+		if scaffold {
+			return scaffoldSettings(schemaID, scope)
+		}
+		if file == "" {
+			return fmt.Errorf("--file is required")
+		}
 		if scope == "" {
 			return fmt.Errorf("--scope is required")
 		}
@@ -110,13 +122,39 @@ Examples:
 	},
 }
 
+// scaffoldSettings prints a commented YAML skeleton of a settings object.
+// The schema is fetched from the current context; if that fails, a cached
+// version is used.
+func scaffoldSettings(schemaID, scope string) error {
+	cache := settingsSchemaCache()
+	var schema map[string]any
+	_, c, err := SetupClient()
+	if err == nil {
+		schema, err = settings.NewHandler(c).WithSchemaCache(cache).GetSchema(schemaID)
+	}
+	if err != nil {
+		cached, cacheErr := cache.Load(schemaID, "")
+		if cacheErr != nil {
+			return err
+		}
+		output.PrintWarning("Could not fetch schema (%v); using cached version %v", err, cached["version"])
+		schema = cached
+	}
+
+	data, err := settings.Scaffold(schema, scope)
+	if err != nil {
+		return err
+	}
+	_, err = os.Stdout.Write(data)
+	return err
+}
+
 func init() {
 	// Settings flags
-	createSettingsCmd.Flags().StringP("file", "f", "", "file containing settings value (required)")
+	createSettingsCmd.Flags().StringP("file", "f", "", "file containing settings value (required unless --scaffold)")
 	createSettingsCmd.Flags().String("schema", "", "schema ID (required)")
-	createSettingsCmd.Flags().String("scope", "", "scope for the settings object (required)")
+	createSettingsCmd.Flags().String("scope", "", "scope for the settings object (required unless --scaffold)")
 	createSettingsCmd.Flags().StringArray("set", []string{}, "set template variable (key=value)")
-	_ = createSettingsCmd.MarkFlagRequired("file")
+	createSettingsCmd.Flags().Bool("scaffold", false, "print a commented YAML skeleton for the schema instead of creating an object")
 	_ = createSettingsCmd.MarkFlagRequired("schema")
-	_ = createSettingsCmd.MarkFlagRequired("scope")
 }
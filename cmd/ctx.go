@@ -3,6 +3,7 @@ package cmd
 import (
 	"fmt"
 	"os"
+	"strings"
 
 	"github.com/spf13/cobra"
 
@@ -76,11 +77,21 @@ var ctxCurrentCmd = &cobra.Command{
 		if err != nil {
 			return err
 		}
-		fmt.Println(cfg.CurrentContext)
+		printCurrentContext(cfg)
 		return nil
 	},
 }
 
+// printCurrentContext prints the name of the current context. A context
+// synthesized from environment variables is flagged on stderr so that scripts
+// reading stdout still get only the name.
+func printCurrentContext(cfg *config.Config) {
+	fmt.Println(cfg.CurrentContext)
+	if cfg.FromEnvironment() {
+		output.PrintInfo("(from environment variable %s)", config.EnvEnvironment)
+	}
+}
+
 // ctxDescribeCmd shows detailed context information
 var ctxDescribeCmd = &cobra.Command{
 	Use:   "describe <context-name>",
@@ -241,6 +252,9 @@ func describeContext(name string) error {
 	output.DescribeKV("Name:", w, "%s%s", found.Name, currentMark)
 	output.DescribeKV("Environment:", w, "%s", found.Context.Environment)
 	output.DescribeKV("Token-Ref:", w, "%s", found.Context.TokenRef)
+	if found.Context.TokenExec != nil {
+		output.DescribeKV("Token-Exec:", w, "%s", strings.Join(append([]string{found.Context.TokenExec.Command}, found.Context.TokenExec.Args...), " "))
+	}
 	output.DescribeKV("Safety Level:", w, "%s", found.Context.GetEffectiveSafetyLevel())
 
 	switch found.Context.GetEffectiveSafetyLevel() {
@@ -254,6 +268,14 @@ func describeContext(name string) error {
 		fmt.Printf("%*s(All operations including bucket deletion)\n", w, "")
 	}
 
+	for i, rule := range found.Context.SafetyRules {
+		label := ""
+		if i == 0 {
+			label = "Safety Rules:"
+		}
+		output.DescribeKV(label, w, "%s", describeSafetyRule(rule))
+	}
+
 	if found.Context.Description != "" {
 		output.DescribeKV("Description:", w, "%s", found.Context.Description)
 	}
@@ -261,6 +283,29 @@ func describeContext(name string) error {
 	return nil
 }
 
+// describeSafetyRule summarizes a safety rule on one line,
+// e.g. "no-wf-delete: deny delete on workflow".
+func describeSafetyRule(rule config.SafetyRule) string {
+	ops, resources := "all operations", "all resources"
+	if len(rule.Operations) > 0 {
+		ops = strings.Join(rule.Operations, ",")
+	}
+	if len(rule.Resources) > 0 {
+		resources = strings.Join(rule.Resources, ",")
+	}
+	s := fmt.Sprintf("%s: %s %s on %s", rule.Name, rule.Action, ops, resources)
+	if len(rule.Names) > 0 {
+		s += fmt.Sprintf(" named %s", strings.Join(rule.Names, ","))
+	}
+	if rule.Owner != "" {
+		s += fmt.Sprintf(" (owner: %s)", rule.Owner)
+	}
+	if len(rule.Windows) > 0 {
+		s += fmt.Sprintf(" [%d time window(s)]", len(rule.Windows))
+	}
+	return s
+}
+
 // setContext creates or updates a named context (shared logic)
 func setContext(name, environment, tokenRef, safetyLevel, description string) error {
 	cfg, err := loadConfigRaw()
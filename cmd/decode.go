@@ -0,0 +1,29 @@
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+)
+
+// decodeCmd represents the decode command
+var decodeCmd = &cobra.Command{
+	Use:   "decode",
+	Short: "Decode exported payloads locally",
+	Long: `Decode exported payloads locally, without contacting a Dynatrace environment.
+
+No context or credentials are required: the input is read from a file or stdin
+and decoded on your machine.
+
+Examples:
+  # Decode Live Debugger snapshot records exported as JSON
+  dtctl decode snapshot -f records.json
+
+  # Show captured variables as a tree and print source around each frame
+  dtctl decode snapshot -f records.json --tree --source-root ./src
+
+Use "dtctl decode <command> --help" for more information about a command.
+`,
+}
+
+func init() {
+	rootCmd.AddCommand(decodeCmd)
+}
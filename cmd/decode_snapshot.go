@@ -0,0 +1,335 @@
+package cmd
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/dynatrace-oss/dtctl/pkg/output"
+)
+
+var decodeSnapshotCmd = &cobra.Command{
+	Use:     "snapshot -f <file>",
+	Aliases: []string{"snapshots"},
+	Short:   "Decode exported Live Debugger snapshot records offline (experimental)",
+	Long: `Decode Live Debugger snapshot records from a JSON export, entirely offline.
+
+The input can be any of:
+  - a JSON array of records (e.g. 'dtctl query "fetch application.snapshots" -o json')
+  - a DQL response object with a "records" or "result.records" field
+  - a single record object
+  - newline-delimited JSON, one record per line (e.g. 'dtctl logs breakpoint -o json')
+
+Records must contain the raw "snapshot.data" (and usually "snapshot.string_map")
+fields. They are decoded with the same decoder as 'query --decode-snapshots'.
+
+By default, each snapshot is printed with its hit location, captured locals and
+stack frames. Use --tree to expand nested variables, and --source-root to print
+the surrounding source lines of every frame found under a local source tree.
+Use -o json or -o yaml to emit the decoded records instead.
+
+Note: Live Debugger support is experimental. The snapshot format may change in
+future releases.
+
+Examples:
+  # Decode an export
+  dtctl decode snapshot -f records.json
+
+  # Keep type annotations in the decoded tree
+  dtctl decode snapshot -f records.json --decode full -o yaml
+
+  # Expand captured variables as a tree
+  dtctl decode snapshot -f records.json --tree
+
+  # Show 5 lines of source around each frame
+  dtctl decode snapshot -f records.json --source-root ./src --source-lines 5
+
+  # Read from stdin
+  cat records.json | dtctl decode snapshot -f -
+`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		file, _ := cmd.Flags().GetString("file")
+		decode, _ := cmd.Flags().GetString("decode")
+		tree, _ := cmd.Flags().GetBool("tree")
+		sourceRoot, _ := cmd.Flags().GetString("source-root")
+		sourceLines, _ := cmd.Flags().GetInt("source-lines")
+
+		simplify, err := parseSnapshotDecodeMode(decode)
+		if err != nil {
+			return err
+		}
+		if sourceLines < 0 {
+			return fmt.Errorf("--source-lines must not be negative")
+		}
+
+		var data []byte
+		if file == "-" {
+			data, err = io.ReadAll(os.Stdin)
+		} else {
+			data, err = os.ReadFile(file)
+		}
+		if err != nil {
+			return fmt.Errorf("failed to read snapshot records: %w", err)
+		}
+
+		records, err := parseSnapshotExport(data)
+		if err != nil {
+			return err
+		}
+
+		decoded := output.DecodeSnapshotRecords(records, simplify)
+		warnings := snapshotDecodeWarnings(decoded)
+
+		if agentMode || plainMode || (outputFormat != "table" && outputFormat != "wide") {
+			printer := NewPrinter()
+			if ap := enrichAgent(printer, "decode", "snapshot"); ap != nil {
+				ap.SetTotal(len(decoded))
+				if len(warnings) > 0 {
+					ap.SetWarnings(warnings)
+				}
+			} else {
+				for _, w := range warnings {
+					output.PrintWarning("%s", w)
+				}
+			}
+			return printer.PrintList(decoded)
+		}
+
+		opts := output.SnapshotDetailOptions{Tree: tree}
+		if sourceRoot != "" {
+			index, err := newSourceIndex(sourceRoot)
+			if err != nil {
+				return err
+			}
+			opts.Source = func(frame output.SnapshotFrame) []output.SnapshotSourceLine {
+				return index.lines(frame.Filename, frame.LineNumber(), sourceLines)
+			}
+		}
+
+		for _, w := range warnings {
+			output.PrintWarning("%s", w)
+		}
+		for _, rec := range decoded {
+			if err := output.PrintSnapshotDetailWithOptions(os.Stdout, rec, opts); err != nil {
+				return err
+			}
+		}
+		return nil
+	},
+}
+
+// parseSnapshotExport extracts snapshot records from the supported export
+// shapes: an array, a DQL response object, a single record or NDJSON.
+func parseSnapshotExport(data []byte) ([]map[string]interface{}, error) {
+	trimmed := bytes.TrimSpace(data)
+	if len(trimmed) == 0 {
+		return nil, fmt.Errorf("no snapshot records found: input is empty")
+	}
+
+	if trimmed[0] == '[' {
+		var records []map[string]interface{}
+		if err := json.Unmarshal(trimmed, &records); err != nil {
+			return nil, fmt.Errorf("failed to parse snapshot records: %w", err)
+		}
+		return records, nil
+	}
+
+	var obj map[string]interface{}
+	if err := json.Unmarshal(trimmed, &obj); err != nil {
+		// Not a single JSON document — try one record per line.
+		return parseSnapshotNDJSON(trimmed)
+	}
+
+	if records, ok := snapshotRecordsField(obj); ok {
+		return records, nil
+	}
+	if result, ok := obj["result"].(map[string]interface{}); ok {
+		if records, ok := snapshotRecordsField(result); ok {
+			return records, nil
+		}
+	}
+	return []map[string]interface{}{obj}, nil
+}
+
+func snapshotRecordsField(obj map[string]interface{}) ([]map[string]interface{}, bool) {
+	raw, ok := obj["records"].([]interface{})
+	if !ok {
+		return nil, false
+	}
+	records := make([]map[string]interface{}, 0, len(raw))
+	for _, item := range raw {
+		if rec, ok := item.(map[string]interface{}); ok {
+			records = append(records, rec)
+		}
+	}
+	return records, true
+}
+
+func parseSnapshotNDJSON(data []byte) ([]map[string]interface{}, error) {
+	var records []map[string]interface{}
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	scanner.Buffer(make([]byte, 0, 64*1024), 64*1024*1024)
+	lineNo := 0
+	for scanner.Scan() {
+		lineNo++
+		line := bytes.TrimSpace(scanner.Bytes())
+		if len(line) == 0 {
+			continue
+		}
+		var rec map[string]interface{}
+		if err := json.Unmarshal(line, &rec); err != nil {
+			return nil, fmt.Errorf("failed to parse snapshot records: line %d: %w", lineNo, err)
+		}
+		records = append(records, rec)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read snapshot records: %w", err)
+	}
+	return records, nil
+}
+
+// snapshotDecodeWarnings reports records that could not be decoded.
+func snapshotDecodeWarnings(records []map[string]interface{}) []string {
+	var warnings []string
+	missing := 0
+	for i, rec := range records {
+		if msg, ok := rec["snapshot.decode_error"].(string); ok {
+			warnings = append(warnings, fmt.Sprintf("record %d: failed to decode snapshot: %s", i+1, msg))
+			continue
+		}
+		if _, ok := rec["parsed_snapshot"]; !ok {
+			missing++
+		}
+	}
+	if missing > 0 {
+		warnings = append(warnings, fmt.Sprintf("%d of %d records contain no snapshot.data field", missing, len(records)))
+	}
+	return warnings
+}
+
+// sourceIndex maps file names under a local source root to their paths so
+// that stack frames (which usually carry only a file name or a package-relative
+// path) can be matched to source files.
+type sourceIndex struct {
+	root    string
+	byName  map[string][]string
+	content map[string][]string
+}
+
+func newSourceIndex(root string) (*sourceIndex, error) {
+	info, err := os.Stat(root)
+	if err != nil {
+		return nil, fmt.Errorf("invalid --source-root: %w", err)
+	}
+	if !info.IsDir() {
+		return nil, fmt.Errorf("invalid --source-root: %s is not a directory", root)
+	}
+
+	idx := &sourceIndex{root: root, byName: map[string][]string{}, content: map[string][]string{}}
+	err = filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return nil // skip unreadable entries
+		}
+		if d.IsDir() {
+			if path != root && strings.HasPrefix(d.Name(), ".") {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		idx.byName[d.Name()] = append(idx.byName[d.Name()], path)
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to scan --source-root: %w", err)
+	}
+	for name := range idx.byName {
+		sort.Strings(idx.byName[name])
+	}
+	return idx, nil
+}
+
+// resolve returns the local path for a frame's file name. When several files
+// share the base name, the one whose path ends with the frame's path wins.
+func (idx *sourceIndex) resolve(filename string) (string, bool) {
+	if filename == "" {
+		return "", false
+	}
+	normalized := filepath.FromSlash(strings.ReplaceAll(filename, "\\", "/"))
+	candidates := idx.byName[filepath.Base(normalized)]
+	if len(candidates) == 0 {
+		return "", false
+	}
+	best, bestScore := candidates[0], -1
+	for _, candidate := range candidates {
+		score := commonSuffixParts(candidate, normalized)
+		if score > bestScore {
+			best, bestScore = candidate, score
+		}
+	}
+	return best, true
+}
+
+// commonSuffixParts counts the trailing path elements two paths share.
+func commonSuffixParts(a, b string) int {
+	pa := strings.Split(filepath.ToSlash(a), "/")
+	pb := strings.Split(filepath.ToSlash(b), "/")
+	n := 0
+	for n < len(pa) && n < len(pb) && pa[len(pa)-1-n] == pb[len(pb)-1-n] {
+		n++
+	}
+	return n
+}
+
+// lines returns up to context lines before and after line in filename.
+func (idx *sourceIndex) lines(filename string, line, context int) []output.SnapshotSourceLine {
+	if line <= 0 {
+		return nil
+	}
+	path, ok := idx.resolve(filename)
+	if !ok {
+		return nil
+	}
+	content, ok := idx.content[path]
+	if !ok {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil
+		}
+		content = strings.Split(strings.ReplaceAll(string(data), "\r\n", "\n"), "\n")
+		idx.content[path] = content
+	}
+	if line > len(content) {
+		return nil
+	}
+
+	start := max(line-context, 1)
+	end := min(line+context, len(content))
+	result := make([]output.SnapshotSourceLine, 0, end-start+1)
+	for n := start; n <= end; n++ {
+		result = append(result, output.SnapshotSourceLine{Number: n, Text: content[n-1]})
+	}
+	return result
+}
+
+func init() {
+	decodeCmd.AddCommand(decodeSnapshotCmd)
+	decodeSnapshotCmd.Flags().StringP("file", "f", "", "file containing exported snapshot records (use '-' for stdin)")
+	decodeSnapshotCmd.Flags().String("decode", "simplified", "Snapshot decoding: simplified|full")
+	decodeSnapshotCmd.Flags().Bool("tree", false, "Show captured variables as a tree")
+	decodeSnapshotCmd.Flags().String("source-root", "", "Local source directory used to print source lines around each stack frame")
+	decodeSnapshotCmd.Flags().Int("source-lines", 3, "Number of source lines to show before and after each frame line")
+	_ = decodeSnapshotCmd.MarkFlagRequired("file")
+	_ = decodeSnapshotCmd.RegisterFlagCompletionFunc("decode", func(_ *cobra.Command, _ []string, _ string) ([]string, cobra.ShellCompDirective) {
+		return []string{"simplified", "full"}, cobra.ShellCompDirectiveNoFileComp
+	})
+}
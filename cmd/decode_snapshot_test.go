@@ -0,0 +1,106 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestParseSnapshotExport(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  []string
+	}{
+		{"array", `[{"snapshot.id":"a"},{"snapshot.id":"b"}]`, []string{"a", "b"}},
+		{"records object", `{"records":[{"snapshot.id":"a"}]}`, []string{"a"}},
+		{"query response", `{"state":"SUCCEEDED","result":{"records":[{"snapshot.id":"a"},{"snapshot.id":"b"}]}}`, []string{"a", "b"}},
+		{"single record", `{"snapshot.id":"a"}`, []string{"a"}},
+		{"ndjson", "{\"snapshot.id\":\"a\"}\n\n{\"snapshot.id\":\"b\"}\n", []string{"a", "b"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			records, err := parseSnapshotExport([]byte(tt.input))
+			if err != nil {
+				t.Fatalf("parseSnapshotExport() error = %v", err)
+			}
+			var got []string
+			for _, rec := range records {
+				got = append(got, rec["snapshot.id"].(string))
+			}
+			if strings.Join(got, ",") != strings.Join(tt.want, ",") {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseSnapshotExport_Errors(t *testing.T) {
+	if _, err := parseSnapshotExport([]byte("  \n")); err == nil || !strings.Contains(err.Error(), "empty") {
+		t.Errorf("expected empty input error, got %v", err)
+	}
+	if _, err := parseSnapshotExport([]byte("{\"a\":1}\nnot json\n")); err == nil || !strings.Contains(err.Error(), "line 2") {
+		t.Errorf("expected line number in error, got %v", err)
+	}
+}
+
+func TestSnapshotDecodeWarnings(t *testing.T) {
+	warnings := snapshotDecodeWarnings([]map[string]interface{}{
+		{"parsed_snapshot": map[string]interface{}{}},
+		{"snapshot.decode_error": "bad base64"},
+		{"snapshot.id": "x"},
+	})
+	if len(warnings) != 2 {
+		t.Fatalf("expected 2 warnings, got %v", warnings)
+	}
+	if !strings.Contains(warnings[0], "record 2") || !strings.Contains(warnings[1], "1 of 3 records") {
+		t.Errorf("unexpected warnings: %v", warnings)
+	}
+}
+
+func TestSourceIndex(t *testing.T) {
+	root := t.TempDir()
+	write := func(rel, content string) {
+		path := filepath.Join(root, rel)
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	write("orders/src/com/example/Order.java", "l1\nl2\nl3\nl4\nl5\n")
+	write("billing/src/com/other/Order.java", "other\n")
+	write(".git/Order.java", "ignored\n")
+
+	idx, err := newSourceIndex(root)
+	if err != nil {
+		t.Fatalf("newSourceIndex() error = %v", err)
+	}
+
+	path, ok := idx.resolve("com/example/Order.java")
+	if !ok || !strings.Contains(path, filepath.Join("orders", "src")) {
+		t.Errorf("expected path-suffix match, got %q", path)
+	}
+
+	lines := idx.lines("com/example/Order.java", 2, 1)
+	if len(lines) != 3 || lines[0].Number != 1 || lines[2].Text != "l3" {
+		t.Errorf("unexpected lines: %+v", lines)
+	}
+	if lines := idx.lines("com/example/Order.java", 99, 1); lines != nil {
+		t.Errorf("expected no lines past end of file, got %+v", lines)
+	}
+	if lines := idx.lines("Missing.java", 1, 1); lines != nil {
+		t.Errorf("expected no lines for unknown file, got %+v", lines)
+	}
+	if len(idx.byName["Order.java"]) != 2 {
+		t.Errorf("expected hidden directories to be skipped, got %v", idx.byName["Order.java"])
+	}
+}
+
+func TestNewSourceIndex_Invalid(t *testing.T) {
+	if _, err := newSourceIndex(filepath.Join(t.TempDir(), "missing")); err == nil {
+		t.Error("expected error for missing source root")
+	}
+}
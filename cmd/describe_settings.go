@@ -118,7 +118,7 @@ Examples:
 			return err
 		}
 
-		handler := settings.NewHandler(c)
+		handler := settings.NewHandler(c).WithSchemaCache(settingsSchemaCache())
 
 		schema, err := handler.GetSchema(schemaID)
 		if err != nil {
@@ -10,6 +10,7 @@ import (
 	"github.com/dynatrace-oss/dtctl/pkg/client"
 	"github.com/dynatrace-oss/dtctl/pkg/diff"
 	"github.com/dynatrace-oss/dtctl/pkg/resources/document"
+	"github.com/dynatrace-oss/dtctl/pkg/resources/resolver"
 	"github.com/dynatrace-oss/dtctl/pkg/resources/workflow"
 	"github.com/dynatrace-oss/dtctl/pkg/util/format"
 )
@@ -35,6 +36,12 @@ Examples:
   
   # Compare two local files (dtctl extension)
   dtctl diff -f file1.yaml -f file2.yaml
+
+  # Compare two versions of a dashboard (see 'dtctl history dashboard')
+  dtctl diff dashboard my-dashboard --from-version 3 --to-version 7
+
+  # Compare a workflow version with the current workflow
+  dtctl diff workflow my-workflow --from-version 2
   
   # Show side-by-side comparison
   dtctl diff -f workflow.yaml --side-by-side
@@ -48,6 +55,10 @@ Examples:
   # Quiet mode (exit code only)
   dtctl diff -f workflow.yaml --quiet
 
+Versions are snapshot versions for dashboards and notebooks and history
+versions for workflows; without --to-version the current state is compared.
+Dashboard diffs end with a summary of added, removed and modified tiles.
+
 Exit Codes:
   0 - No differences found
   1 - Differences found
@@ -69,6 +80,8 @@ func init() {
 	diffCmd.Flags().Int("context", 3, "Number of context lines")
 	diffCmd.Flags().Bool("color", true, "Colorize output")
 	diffCmd.Flags().StringP("output", "o", "", "Output format (overrides --format): json-patch, semantic")
+	diffCmd.Flags().Int("from-version", 0, "Compare this version of the resource (dashboard, notebook, workflow)")
+	diffCmd.Flags().Int("to-version", 0, "Compare with this version instead of the current state (requires --from-version)")
 }
 
 const (
@@ -88,6 +101,8 @@ func runDiff(cmd *cobra.Command, args []string) error {
 	contextLines, _ := cmd.Flags().GetInt("context")
 	colorize, _ := cmd.Flags().GetBool("color")
 	outputFormat, _ := cmd.Flags().GetString("output")
+	fromVersion, _ := cmd.Flags().GetInt("from-version")
+	toVersion, _ := cmd.Flags().GetInt("to-version")
 
 	if outputFormat != "" {
 		format = outputFormat
@@ -118,6 +133,11 @@ func runDiff(cmd *cobra.Command, args []string) error {
 	var err error
 
 	switch {
+	case fromVersion != 0 || toVersion != 0:
+		if len(files) > 0 || len(args) != 2 {
+			return fmt.Errorf("--from-version compares versions of one resource: use RESOURCE_TYPE NAME --from-version N [--to-version M]")
+		}
+		result, err = handleVersions(differ, args[0], args[1], fromVersion, toVersion)
 	case len(files) == 2:
 		result, err = handleTwoFiles(differ, files[0], files[1])
 	case len(files) == 1 && len(args) == 0:
@@ -213,6 +233,121 @@ func handleTwoRemoteResources(differ *diff.Differ, resourceType, id1, id2 string
 	return differ.Compare(resource1, resource2, fmt.Sprintf("%s/%s", resourceType, id1), fmt.Sprintf("%s/%s", resourceType, id2))
 }
 
+func handleVersions(differ *diff.Differ, resourceType, identifier string, fromVersion, toVersion int) (*diff.DiffResult, error) {
+	if fromVersion <= 0 {
+		return nil, fmt.Errorf("--from-version is required and must be positive")
+	}
+	if toVersion < 0 {
+		return nil, fmt.Errorf("--to-version must be positive")
+	}
+
+	_, c, err := SetupClient()
+	if err != nil {
+		return nil, err
+	}
+
+	normalizedType := normalizeResourceType(resourceType)
+	var left, right interface{}
+	var id string
+	switch normalizedType {
+	case "workflow":
+		id, err = resolver.NewResolver(c).ResolveID(resolver.TypeWorkflow, identifier)
+		if err != nil {
+			return nil, err
+		}
+		handler := workflow.NewHandler(c)
+		from, err := handler.GetHistoryRecord(id, fromVersion)
+		if err != nil {
+			return nil, err
+		}
+		var to *workflow.Workflow
+		if toVersion > 0 {
+			to, err = handler.GetHistoryRecord(id, toVersion)
+		} else {
+			to, err = handler.Get(id)
+		}
+		if err != nil {
+			return nil, err
+		}
+		left, right = workflowDiffData(from), workflowDiffData(to)
+	case "dashboard", "notebook":
+		id, err = resolver.NewResolver(c).ResolveID(resolver.ResourceType(normalizedType), identifier)
+		if err != nil {
+			return nil, err
+		}
+		handler := document.NewHandler(c)
+		from, err := handler.GetAtVersion(id, fromVersion)
+		if err != nil {
+			return nil, err
+		}
+		var to *document.Document
+		if toVersion > 0 {
+			to, err = handler.GetAtVersion(id, toVersion)
+		} else {
+			to, err = handler.Get(id)
+		}
+		if err != nil {
+			return nil, err
+		}
+		left, right = documentDiffData(from), documentDiffData(to)
+	default:
+		return nil, fmt.Errorf("version diffs are supported for dashboards, notebooks and workflows, not %s", resourceType)
+	}
+
+	toLabel := "current"
+	if toVersion > 0 {
+		toLabel = fmt.Sprintf("version %d", toVersion)
+	}
+	return differ.Compare(left, right,
+		fmt.Sprintf("%s/%s (version %d)", normalizedType, id, fromVersion),
+		fmt.Sprintf("%s/%s (%s)", normalizedType, id, toLabel))
+}
+
+// documentDiffData returns the fields of a document that make up its state:
+// name, description and the parsed content. Versions and modification info
+// are left out, as they differ between any two versions.
+func documentDiffData(doc *document.Document) map[string]interface{} {
+	data := map[string]interface{}{
+		"id":   doc.ID,
+		"name": doc.Name,
+		"type": doc.Type,
+	}
+	if doc.Description != "" {
+		data["description"] = doc.Description
+	}
+	var content interface{}
+	if err := json.Unmarshal(doc.Content, &content); err == nil {
+		data["content"] = content
+	} else if len(doc.Content) > 0 {
+		data["content"] = string(doc.Content)
+	}
+	return data
+}
+
+// workflowDiffData returns the fields of a workflow that make up its
+// definition. Owner and deployment state are left out, as history records do
+// not carry them the way the current workflow does.
+func workflowDiffData(wf *workflow.Workflow) map[string]interface{} {
+	data := map[string]interface{}{
+		"id":        wf.ID,
+		"title":     wf.Title,
+		"isPrivate": wf.Private,
+	}
+	if wf.Description != "" {
+		data["description"] = wf.Description
+	}
+	if wf.Actor != "" {
+		data["actor"] = wf.Actor
+	}
+	if wf.Tasks != nil {
+		data["tasks"] = wf.Tasks
+	}
+	if wf.Trigger != nil {
+		data["trigger"] = wf.Trigger
+	}
+	return data
+}
+
 func parseYAMLFile(path string) (interface{}, error) {
 	data, err := os.ReadFile(path)
 	if err != nil {
@@ -0,0 +1,62 @@
+package cmd
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/dynatrace-oss/dtctl/pkg/resources/document"
+	"github.com/dynatrace-oss/dtctl/pkg/resources/workflow"
+)
+
+func TestDocumentDiffData(t *testing.T) {
+	doc := &document.Document{
+		ID:      "dash-1",
+		Name:    "Ops",
+		Type:    "dashboard",
+		Version: 7,
+		Content: []byte(`{"tiles": {"0": {"type": "markdown"}}}`),
+	}
+	want := map[string]interface{}{
+		"id":      "dash-1",
+		"name":    "Ops",
+		"type":    "dashboard",
+		"content": map[string]interface{}{"tiles": map[string]interface{}{"0": map[string]interface{}{"type": "markdown"}}},
+	}
+	if got := documentDiffData(doc); !reflect.DeepEqual(got, want) {
+		t.Errorf("documentDiffData() = %v, want %v", got, want)
+	}
+
+	doc.Content = []byte("not json")
+	doc.Description = "notes"
+	got := documentDiffData(doc)
+	if got["content"] != "not json" || got["description"] != "notes" {
+		t.Errorf("documentDiffData() = %v", got)
+	}
+}
+
+func TestWorkflowDiffData(t *testing.T) {
+	tasks := map[string]interface{}{"run": map[string]interface{}{"action": "dynatrace.automations:run-javascript"}}
+	record := &workflow.Workflow{ID: "wf-1", Title: "Deploy", Tasks: tasks}
+	current := &workflow.Workflow{ID: "wf-1", Title: "Deploy", Tasks: tasks, Owner: "user-1", OwnerType: "USER", IsDeployed: true}
+
+	want := map[string]interface{}{
+		"id":        "wf-1",
+		"title":     "Deploy",
+		"isPrivate": false,
+		"tasks":     tasks,
+	}
+	if got := workflowDiffData(current); !reflect.DeepEqual(got, want) {
+		t.Errorf("workflowDiffData() = %v, want %v", got, want)
+	}
+	if !reflect.DeepEqual(workflowDiffData(record), workflowDiffData(current)) {
+		t.Error("owner and deployment state must not show up as differences")
+	}
+}
+
+func TestHandleVersionsValidation(t *testing.T) {
+	for _, tc := range []struct{ from, to int }{{0, 3}, {-1, 0}, {2, -1}} {
+		if _, err := handleVersions(nil, "dashboard", "dash-1", tc.from, tc.to); err == nil {
+			t.Errorf("handleVersions(from=%d, to=%d): expected error", tc.from, tc.to)
+		}
+	}
+}
@@ -3,6 +3,7 @@ package cmd
 import (
 	"fmt"
 	"net/http"
+	"os"
 	"strings"
 	"time"
 
@@ -86,14 +87,25 @@ func runDoctorChecksWithClient(httpClient *http.Client) []checkResult {
 		return results // Cannot continue without config
 	}
 
-	configPath := config.FindLocalConfig()
+	configPath := cfgFile
+	if configPath == "" {
+		configPath = config.FindLocalConfig()
+	}
 	if configPath == "" {
 		configPath = config.DefaultConfigPath()
 	}
+	configDetail := configPath
+	if cfg.FromEnvironment() {
+		if _, err := os.Stat(configPath); os.IsNotExist(err) {
+			configDetail = fmt.Sprintf("no config file; using %s", config.EnvEnvironment)
+		} else {
+			configDetail = fmt.Sprintf("%s (current context from %s)", configPath, config.EnvEnvironment)
+		}
+	}
 	results = append(results, checkResult{
 		Name:   "Configuration",
 		Status: "ok",
-		Detail: configPath,
+		Detail: configDetail,
 	})
 
 	// 3. Current context
@@ -122,6 +134,9 @@ func runDoctorChecksWithClient(httpClient *http.Client) []checkResult {
 		Status: "ok",
 		Detail: fmt.Sprintf("%s (environment: %s, safety: %s)", cfg.CurrentContext, ctx.Environment, safetyLevel),
 	})
+	if cfg.FromEnvironment() {
+		results[len(results)-1].Detail += fmt.Sprintf(" [from %s]", config.EnvEnvironment)
+	}
 
 	// 4. Environment URL validation
 	if urlProblems := diagnostic.CheckEnvironmentURL(ctx.Environment); len(urlProblems) > 0 {
@@ -188,7 +203,14 @@ func runDoctorChecksWithClient(httpClient *http.Client) []checkResult {
 	}
 
 	tokenSource := "config file"
-	if config.IsKeyringAvailable() {
+	if cfg.IsEnvToken(ctx.TokenRef) {
+		tokenSource = config.EnvToken
+		if os.Getenv(config.EnvTokenFile) != "" {
+			tokenSource = fmt.Sprintf("file %s", os.Getenv(config.EnvTokenFile))
+		}
+	} else if execCred := cfg.TokenExecFor(ctx.TokenRef); execCred != nil {
+		tokenSource = fmt.Sprintf("credential plugin (%s)", execCred.Command)
+	} else if config.IsKeyringAvailable() {
 		tokenSource = fmt.Sprintf("keyring (%s)", config.KeyringBackend())
 	} else if config.IsFileTokenStorage() {
 		tokenSource = fmt.Sprintf("file store (%s)", config.OAuthStorageBackend())
@@ -77,7 +77,7 @@ Examples:
 		if err != nil {
 			return err
 		}
-		if err := checker.CheckError(safety.OperationUpdate, ownership); err != nil {
+		if err := checker.ForResource("dashboard", metadata.Name).CheckError(safety.OperationUpdate, ownership); err != nil {
 			return err
 		}
 
@@ -244,7 +244,7 @@ Examples:
 		if err != nil {
 			return err
 		}
-		if err := checker.CheckError(safety.OperationUpdate, ownership); err != nil {
+		if err := checker.ForResource("notebook", metadata.Name).CheckError(safety.OperationUpdate, ownership); err != nil {
 			return err
 		}
 
@@ -408,7 +408,7 @@ Examples:
 		if err != nil {
 			return err
 		}
-		if err := checker.CheckError(safety.OperationUpdate, ownership); err != nil {
+		if err := checker.ForResource(metadata.Type, metadata.Name).CheckError(safety.OperationUpdate, ownership); err != nil {
 			return err
 		}
 
@@ -61,7 +61,7 @@ Examples:
 		if err != nil {
 			return err
 		}
-		if err := checker.CheckError(safety.OperationUpdate, ownership); err != nil {
+		if err := checker.ForResource("segment", seg.Name).CheckError(safety.OperationUpdate, ownership); err != nil {
 			return err
 		}
 
@@ -70,7 +70,7 @@ Examples:
 		if err != nil {
 			return err
 		}
-		if err := checker.CheckError(safety.OperationUpdate, ownership); err != nil {
+		if err := checker.ForResource("workflow", wf.Title).CheckError(safety.OperationUpdate, ownership); err != nil {
 			return err
 		}
 
@@ -18,6 +18,7 @@ Available operations:
   function (fn, func)     Invoke an app function or run ad-hoc JavaScript
   analyzer (az)           Run a Davis AI analyzer
   slo                     Evaluate a service-level objective
+  dashboard (dash, db)    Run the queries of a dashboard's tiles
   copilot (cp, chat)      Chat with Davis CoPilot interactively`,
 	Example: `  # Execute a workflow and wait for completion
   dtctl exec workflow <workflow-id>
@@ -31,6 +32,9 @@ Available operations:
   # Evaluate an SLO
   dtctl exec slo <slo-id>
 
+  # Run the tiles of a dashboard with a variable
+  dtctl exec dashboard <dashboard-id> --var env=prod
+
   # Chat with Davis CoPilot
   dtctl exec copilot "What happened in the last hour?"`,
 	RunE: requireSubcommand,
@@ -45,4 +49,5 @@ func init() {
 	execCmd.AddCommand(execAnalyzerCmd)
 	execCmd.AddCommand(execCopilotCmd)
 	execCmd.AddCommand(execSLOCmd)
+	execCmd.AddCommand(execDashboardCmd)
 }
@@ -0,0 +1,329 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"sort"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/dynatrace-oss/dtctl/pkg/exec"
+	"github.com/dynatrace-oss/dtctl/pkg/output"
+	"github.com/dynatrace-oss/dtctl/pkg/resources/dashboard"
+	"github.com/dynatrace-oss/dtctl/pkg/resources/document"
+	"github.com/dynatrace-oss/dtctl/pkg/resources/resolver"
+)
+
+// dashboardTileResult is the result of one tile in structured output
+type dashboardTileResult struct {
+	Tile          string                   `json:"tile"`
+	Title         string                   `json:"title,omitempty"`
+	Visualization string                   `json:"visualization,omitempty"`
+	Query         string                   `json:"query"`
+	Records       []map[string]interface{} `json:"records"`
+	Error         string                   `json:"error,omitempty"`
+}
+
+// execDashboardCmd runs the tiles of a dashboard
+var execDashboardCmd = &cobra.Command{
+	Use:     "dashboard <id-or-name>",
+	Aliases: []string{"dashboards", "dash", "db"},
+	Short:   "Run the queries of a dashboard's tiles",
+	Long: `Run the DQL queries of a dashboard's data tiles and render the results in
+the terminal, without opening the Dashboards app.
+
+Variables are resolved like in the app: --var overrides a variable, otherwise
+its default value is used, then the first result of its query (query
+variables) or the first of its values (csv variables). Multiple values are
+separated by commas (--var services=a,b).
+
+Each tile is rendered with the output matching its visualization: timeseries
+line and area charts as 'chart', timeseries bar charts as 'barchart',
+everything else as a table. Use -o to render all tiles in the same format;
+-o json|yaml returns one result per tile.
+
+The timeframe is the dashboard's default timeframe unless --from/--to are
+given (RFC 3339 or relative, e.g. now()-24h). Tiles with their own timeframe
+in the query are not changed.
+
+Examples:
+  # Run all tiles of a dashboard
+  dtctl exec dashboard "Service Health"
+
+  # Run selected tiles (by title or ID) with a variable
+  dtctl exec dashboard <id> --tile "Error rate" --tile 3 --var env=prod
+
+  # Last 24 hours, results as JSON
+  dtctl exec dashboard <id> --from now()-24h -o json
+`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		tileFlags, _ := cmd.Flags().GetStringArray("tile")
+		varFlags, _ := cmd.Flags().GetStringArray("var")
+		from, _ := cmd.Flags().GetString("from")
+		to, _ := cmd.Flags().GetString("to")
+		width, _ := cmd.Flags().GetInt("width")
+		height, _ := cmd.Flags().GetInt("height")
+
+		if !isSupportedQueryOutputFormat(outputFormat) {
+			return fmt.Errorf("unsupported output format %q for exec dashboard", outputFormat)
+		}
+		overrides, err := parseDashboardVarFlags(varFlags)
+		if err != nil {
+			return err
+		}
+
+		cfg, c, err := SetupClient()
+		if err != nil {
+			return err
+		}
+
+		res := resolver.NewResolver(c)
+		dashboardID, err := res.ResolveID(resolver.TypeDashboard, args[0])
+		if err != nil {
+			return err
+		}
+		doc, err := document.NewHandler(c).Get(dashboardID)
+		if err != nil {
+			return err
+		}
+		if doc.Type != "dashboard" {
+			return fmt.Errorf("document %q is a %s, not a dashboard", dashboardID, doc.Type)
+		}
+		d, err := dashboard.Parse(doc.Content)
+		if err != nil {
+			return err
+		}
+
+		tiles, err := selectDashboardTiles(d, tileFlags)
+		if err != nil {
+			return err
+		}
+
+		// Cancel running Grail queries on Ctrl+C / SIGTERM
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		sigCh := make(chan os.Signal, 1)
+		signal.Notify(sigCh, os.Interrupt, syscall.SIGTERM)
+		defer signal.Stop(sigCh)
+		go func() {
+			<-sigCh
+			cancel()
+		}()
+
+		opts := exec.DQLExecuteOptions{Width: width, Height: height, ClientContext: "exec-dashboard"}
+		if from == "" && to == "" {
+			from, to, _ = d.DefaultTimeframe()
+		}
+		if from != "" || to != "" {
+			now := time.Now().UTC()
+			start, err := dashboard.ResolveTime(from, now)
+			if err != nil {
+				return fmt.Errorf("invalid --from: %w", err)
+			}
+			if from == "" {
+				start = now.Add(-2 * time.Hour)
+			}
+			end, err := dashboard.ResolveTime(to, now)
+			if err != nil {
+				return fmt.Errorf("invalid --to: %w", err)
+			}
+			opts.DefaultTimeframeStart = start.Format(time.RFC3339)
+			opts.DefaultTimeframeEnd = end.Format(time.RFC3339)
+		}
+
+		executor := NewDQLExecutorFromConfig(cfg, c)
+		values, err := d.ResolveVariables(overrides, func(query string) ([]string, error) {
+			result, err := executor.ExecuteQueryWithContext(ctx, query, opts)
+			if err != nil {
+				return nil, err
+			}
+			return variableValues(queryRecords(result)), nil
+		})
+		if err != nil {
+			return err
+		}
+
+		structured := agentMode || isStructuredQueryFormat(outputFormat)
+		outputFlag := rootCmd.PersistentFlags().Lookup("output")
+		explicitFormat := outputFlag != nil && outputFlag.Changed
+
+		var results []dashboardTileResult
+		failed := 0
+		for _, t := range tiles {
+			query := dashboard.SubstituteVariables(t.Query, values)
+			if structured {
+				r := dashboardTileResult{Tile: t.ID, Title: t.Title, Visualization: t.Visualization, Query: query}
+				result, err := executor.ExecuteQueryWithContext(ctx, query, opts)
+				if err != nil {
+					r.Error = err.Error()
+					failed++
+				} else {
+					r.Records = queryRecords(result)
+				}
+				results = append(results, r)
+				continue
+			}
+
+			tileOpts := opts
+			tileOpts.OutputFormat = outputFormat
+			if !explicitFormat {
+				tileOpts.OutputFormat = tileOutputFormat(t)
+			}
+			fmt.Printf("\n--- %s ---\n", t.Label())
+			if err := executor.ExecuteWithContext(ctx, query, tileOpts); err != nil {
+				output.PrintHumanError("%s: %v", t.Label(), err)
+				failed++
+			}
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+		}
+
+		if structured {
+			printer := NewPrinter()
+			if ap := enrichAgent(printer, "exec", "dashboard"); ap != nil {
+				ap.SetTotal(len(results))
+				if failed > 0 {
+					ap.SetWarnings([]string{fmt.Sprintf("%d of %d tile(s) failed", failed, len(results))})
+				}
+			}
+			if err := printer.Print(results); err != nil {
+				return err
+			}
+		}
+		if failed > 0 {
+			return fmt.Errorf("%d of %d tile(s) failed", failed, len(tiles))
+		}
+		return nil
+	},
+}
+
+// parseDashboardVarFlags parses --var key=value flags. Values are split at
+// commas; repeating a key adds values.
+func parseDashboardVarFlags(flags []string) (map[string][]string, error) {
+	vars := map[string][]string{}
+	for _, f := range flags {
+		key, value, ok := strings.Cut(f, "=")
+		key = strings.TrimPrefix(strings.TrimSpace(key), "$")
+		if !ok || key == "" {
+			return nil, fmt.Errorf("invalid --var %q: expected key=value", f)
+		}
+		for _, v := range strings.Split(value, ",") {
+			vars[key] = append(vars[key], strings.TrimSpace(v))
+		}
+	}
+	return vars, nil
+}
+
+// selectDashboardTiles returns the data tiles matching the --tile selectors
+// (tile ID or title, case-insensitive), or all data tiles
+func selectDashboardTiles(d *dashboard.Dashboard, selectors []string) ([]dashboard.Tile, error) {
+	var data []dashboard.Tile
+	for _, t := range d.Tiles {
+		if t.IsData() && strings.TrimSpace(t.Query) != "" {
+			data = append(data, t)
+		}
+	}
+	if len(selectors) == 0 {
+		if len(data) == 0 {
+			return nil, fmt.Errorf("dashboard has no data tiles")
+		}
+		return data, nil
+	}
+
+	var selected []dashboard.Tile
+	for _, sel := range selectors {
+		found := false
+		for _, t := range data {
+			if t.ID == sel || strings.EqualFold(t.Title, sel) {
+				selected = append(selected, t)
+				found = true
+			}
+		}
+		if !found {
+			var names []string
+			for _, t := range data {
+				names = append(names, t.Label())
+			}
+			return nil, fmt.Errorf("no data tile matches %q (available: %s)", sel, strings.Join(names, ", "))
+		}
+	}
+	return selected, nil
+}
+
+// tileOutputFormat maps a tile to the matching query output format. The chart
+// printers render timeseries only, so charts of other queries (e.g.
+// categorical bar charts) are rendered as tables.
+func tileOutputFormat(t dashboard.Tile) string {
+	q, err := dashboard.ParseQuery(t.Query)
+	if err != nil || (q.Commands[0].Name != "timeseries" && !q.Has("makeTimeseries")) {
+		return "table"
+	}
+	switch t.Visualization {
+	case "lineChart", "areaChart", "bandChart":
+		return "chart"
+	case "barChart":
+		return "barchart"
+	default:
+		return "table"
+	}
+}
+
+// isStructuredQueryFormat reports whether the format returns data rather than
+// a rendering (tables, charts)
+func isStructuredQueryFormat(format string) bool {
+	switch format {
+	case "json", "yaml", "yml", "toon":
+		return true
+	}
+	return false
+}
+
+// queryRecords extracts the records of a DQL response
+func queryRecords(result *exec.DQLQueryResponse) []map[string]interface{} {
+	if result == nil {
+		return nil
+	}
+	if result.Result != nil && len(result.Result.Records) > 0 {
+		return result.Result.Records
+	}
+	return result.Records
+}
+
+// variableValues returns the distinct values of the first field of query
+// variable records (alphabetically first when a record has several fields)
+func variableValues(records []map[string]interface{}) []string {
+	var values []string
+	seen := map[string]bool{}
+	for _, r := range records {
+		if len(r) == 0 {
+			continue
+		}
+		keys := make([]string, 0, len(r))
+		for k := range r {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		v := fmt.Sprint(r[keys[0]])
+		if !seen[v] {
+			seen[v] = true
+			values = append(values, v)
+		}
+	}
+	return values
+}
+
+func init() {
+	execDashboardCmd.Flags().StringArray("tile", nil, "run only this tile, by ID or title (repeatable)")
+	execDashboardCmd.Flags().StringArray("var", nil, "set a dashboard variable (key=value, comma-separated for multiple values; repeatable)")
+	execDashboardCmd.Flags().String("from", "", "timeframe start (RFC 3339 or relative, e.g. now()-24h; default: dashboard timeframe)")
+	execDashboardCmd.Flags().String("to", "", "timeframe end (RFC 3339 or relative; default: now)")
+	execDashboardCmd.Flags().Int("width", 0, "chart width in characters (0 = default)")
+	execDashboardCmd.Flags().Int("height", 0, "chart height in lines (0 = default)")
+}
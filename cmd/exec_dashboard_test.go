@@ -0,0 +1,88 @@
+package cmd
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/dynatrace-oss/dtctl/pkg/resources/dashboard"
+)
+
+func TestParseDashboardVarFlags(t *testing.T) {
+	got, err := parseDashboardVarFlags([]string{"env=prod", "services=a, b", "$services=c", "empty="})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := map[string][]string{"env": {"prod"}, "services": {"a", "b", "c"}, "empty": {""}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("parseDashboardVarFlags() = %v, want %v", got, want)
+	}
+
+	for _, bad := range []string{"noequals", "=value"} {
+		if _, err := parseDashboardVarFlags([]string{bad}); err == nil {
+			t.Errorf("expected error for %q", bad)
+		}
+	}
+}
+
+func TestSelectDashboardTiles(t *testing.T) {
+	d := dashboard.FromContent(map[string]any{"tiles": map[string]any{
+		"0": map[string]any{"type": "markdown", "content": "# Title"},
+		"1": map[string]any{"type": "data", "title": "Error rate", "query": "fetch logs | limit 1"},
+		"2": map[string]any{"type": "data", "title": "Hosts", "query": "fetch dt.entity.host"},
+		"3": map[string]any{"type": "data", "title": "Empty"},
+	}})
+
+	ids := func(tiles []dashboard.Tile) []string {
+		var out []string
+		for _, t := range tiles {
+			out = append(out, t.ID)
+		}
+		return out
+	}
+
+	all, err := selectDashboardTiles(d, nil)
+	if err != nil || !reflect.DeepEqual(ids(all), []string{"1", "2"}) {
+		t.Errorf("selectDashboardTiles(nil) = %v, %v", ids(all), err)
+	}
+	sel, err := selectDashboardTiles(d, []string{"error RATE", "2"})
+	if err != nil || !reflect.DeepEqual(ids(sel), []string{"1", "2"}) {
+		t.Errorf("selectDashboardTiles(title, id) = %v, %v", ids(sel), err)
+	}
+	if _, err := selectDashboardTiles(d, []string{"0"}); err == nil {
+		t.Error("expected error when selecting a markdown tile")
+	}
+}
+
+func TestTileOutputFormat(t *testing.T) {
+	tests := []struct {
+		query         string
+		visualization string
+		want          string
+	}{
+		{"timeseries avg(dt.host.cpu.usage)", "lineChart", "chart"},
+		{"fetch logs | makeTimeseries count()", "areaChart", "chart"},
+		{"timeseries avg(dt.host.cpu.usage)", "barChart", "barchart"},
+		{"fetch logs | summarize count(), by:{status}", "categoricalBarChart", "table"},
+		{"fetch logs | summarize count(), by:{status}", "lineChart", "table"},
+		{"timeseries avg(dt.host.cpu.usage)", "singleValue", "table"},
+		{"fetch logs | filter (", "lineChart", "table"},
+	}
+	for _, tt := range tests {
+		tile := dashboard.Tile{Query: tt.query, Visualization: tt.visualization}
+		if got := tileOutputFormat(tile); got != tt.want {
+			t.Errorf("tileOutputFormat(%q, %q) = %q, want %q", tt.query, tt.visualization, got, tt.want)
+		}
+	}
+}
+
+func TestVariableValues(t *testing.T) {
+	records := []map[string]interface{}{
+		{"host": "web-1"},
+		{"host": "web-2", "zone": "a"},
+		{"host": "web-1"},
+		{},
+	}
+	if got, want := variableValues(records), []string{"web-1", "web-2"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("variableValues() = %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,33 @@
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+)
+
+// exportCmd represents the export command
+var exportCmd = &cobra.Command{
+	Use:   "export",
+	Short: "Export resources as source trees",
+	Long: `Export resources in a form that is easy to keep in version control.
+
+With --explode a document is split into a spec file plus one file per query
+and markdown text, so that changes show up as readable diffs. 'dtctl apply -f'
+on the spec file reassembles the document without losing content.
+
+Supported resources:
+  dashboard
+  notebook`,
+	Example: `  # Split a dashboard into a source tree
+  dtctl export dashboard <id> --explode -d service-health/
+
+  # Apply the (edited) source tree again
+  dtctl apply -f service-health/dashboard.yaml`,
+	RunE: requireSubcommand,
+}
+
+func init() {
+	rootCmd.AddCommand(exportCmd)
+
+	exportCmd.AddCommand(exportDashboardCmd)
+	exportCmd.AddCommand(exportNotebookCmd)
+}
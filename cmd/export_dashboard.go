@@ -0,0 +1,216 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/dynatrace-oss/dtctl/pkg/output"
+	"github.com/dynatrace-oss/dtctl/pkg/resources/dashboard"
+	"github.com/dynatrace-oss/dtctl/pkg/resources/document"
+	"github.com/dynatrace-oss/dtctl/pkg/resources/notebook"
+	"github.com/dynatrace-oss/dtctl/pkg/resources/resolver"
+	"github.com/dynatrace-oss/dtctl/pkg/util/format"
+)
+
+// exportResult describes an exploded source tree in structured output
+type exportResult struct {
+	ID        string   `json:"id"`
+	Name      string   `json:"name"`
+	Type      string   `json:"type"`
+	Directory string   `json:"directory"`
+	Files     []string `json:"files"`
+}
+
+// explodeFunc splits a document into the files of a source tree
+type explodeFunc func(id, title, description string, content map[string]any) (map[string][]byte, error)
+
+// exportDashboardCmd exports a dashboard
+var exportDashboardCmd = &cobra.Command{
+	Use:     "dashboard <id-or-name>",
+	Aliases: []string{"dashboards", "dash", "db"},
+	Short:   "Export a dashboard, optionally as a source tree",
+	Long: `Export a dashboard as a document that 'dtctl apply -f' accepts (YAML by
+default, or -o json).
+
+With --explode the dashboard is written to a directory instead:
+
+  dashboard.yaml               dashboard spec (see 'dtctl build dashboard')
+  tiles/<id>-<title>.dql       query of each data tile
+  tiles/<id>-<title>.md        text of each markdown tile
+  variables/<key>.dql          query of each query variable
+
+Tiles keep their IDs and layout; settings the spec has no field for are kept
+under 'extra', so 'dtctl apply -f dir/dashboard.yaml' restores the dashboard
+exactly. Exporting again into the same directory replaces the .dql and .md
+files in tiles/ and variables/.
+
+Examples:
+  # Split a dashboard into a source tree
+  dtctl export dashboard "Service Health" --explode -d service-health/
+
+  # Edit a query, review the diff and apply
+  vi service-health/tiles/1-error-rate.dql
+  git diff service-health/
+  dtctl apply -f service-health/dashboard.yaml
+
+  # Export the document
+  dtctl export dashboard <id> > dashboard.yaml
+`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return exportDocument(cmd, args[0], resolver.TypeDashboard, dashboard.Explode, []string{"tiles", "variables"})
+	},
+}
+
+// exportNotebookCmd exports a notebook
+var exportNotebookCmd = &cobra.Command{
+	Use:     "notebook <id-or-name>",
+	Aliases: []string{"notebooks", "nb"},
+	Short:   "Export a notebook, optionally as a source tree",
+	Long: `Export a notebook as a document that 'dtctl apply -f' accepts (YAML by
+default, or -o json).
+
+With --explode the notebook is written to a directory instead:
+
+  notebook.yaml                notebook spec
+  sections/<nn>-<title>.dql    query of each DQL section
+  sections/<nn>-<title>.md     text of each markdown section
+
+Sections are numbered in notebook order; settings the spec has no field for
+(including stored query results) are kept under 'extra', so
+'dtctl apply -f dir/notebook.yaml' restores the notebook exactly. Exporting
+again into the same directory replaces the .dql and .md files in sections/.
+
+Examples:
+  # Split a notebook into a source tree
+  dtctl export notebook "Error Analysis" --explode -d error-analysis/
+
+  # Apply the (edited) source tree
+  dtctl apply -f error-analysis/notebook.yaml
+`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return exportDocument(cmd, args[0], resolver.TypeNotebook, notebook.Explode, []string{"sections"})
+	},
+}
+
+// exportDocument fetches a dashboard or notebook and prints it, or explodes
+// it into a directory. The .dql and .md files of the owned subdirectories
+// are removed first, so that deleted tiles and sections do not linger.
+func exportDocument(cmd *cobra.Command, ref string, docType resolver.ResourceType, explode explodeFunc, owned []string) error {
+	doExplode, _ := cmd.Flags().GetBool("explode")
+	dir, _ := cmd.Flags().GetString("dir")
+	if dir != "" && !doExplode {
+		return fmt.Errorf("--dir requires --explode")
+	}
+
+	_, c, err := SetupClient()
+	if err != nil {
+		return err
+	}
+	id, err := resolver.NewResolver(c).ResolveID(docType, ref)
+	if err != nil {
+		return err
+	}
+	doc, err := document.NewHandler(c).Get(id)
+	if err != nil {
+		return err
+	}
+	if doc.Type != string(docType) {
+		return fmt.Errorf("document %q is a %s, not a %s", id, doc.Type, docType)
+	}
+	var content map[string]any
+	if err := json.Unmarshal(doc.Content, &content); err != nil {
+		return fmt.Errorf("failed to parse %s content: %w", docType, err)
+	}
+
+	if !doExplode {
+		out := map[string]any{"id": doc.ID, "name": doc.Name, "type": doc.Type, "content": content}
+		if doc.Description != "" {
+			out["description"] = doc.Description
+		}
+		if agentMode {
+			printer := NewPrinter()
+			enrichAgent(printer, "export", string(docType))
+			return printer.Print(out)
+		}
+		docFormat := outputFormat
+		if docFormat != "json" {
+			docFormat = "yaml"
+		}
+		return output.NewPrinterWithOptions(docFormat, os.Stdout, plainMode).Print(out)
+	}
+
+	files, err := explode(doc.ID, doc.Name, doc.Description, content)
+	if err != nil {
+		return err
+	}
+	if dir == "" {
+		dir = format.Slug(doc.Name)
+		if dir == "" {
+			dir = doc.ID
+		}
+	}
+	if err := writeSourceTree(dir, files, owned); err != nil {
+		return err
+	}
+
+	names := make([]string, 0, len(files))
+	for name := range files {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	if agentMode || outputFormat == "json" || outputFormat == "yaml" {
+		printer := NewPrinter()
+		if ap := enrichAgent(printer, "export", string(docType)); ap != nil {
+			ap.SetTotal(len(names))
+		}
+		return printer.Print(exportResult{ID: doc.ID, Name: doc.Name, Type: doc.Type, Directory: dir, Files: names})
+	}
+	output.PrintSuccess("Exported %s %q to %s (%d files)", docType, doc.Name, dir, len(names))
+	return nil
+}
+
+// writeSourceTree writes the files of an exploded document to dir. The .dql
+// and .md files in the owned subdirectories are removed first.
+func writeSourceTree(dir string, files map[string][]byte, owned []string) error {
+	for _, sub := range owned {
+		entries, err := os.ReadDir(filepath.Join(dir, sub))
+		if err != nil && !os.IsNotExist(err) {
+			return fmt.Errorf("failed to read %s: %w", filepath.Join(dir, sub), err)
+		}
+		for _, e := range entries {
+			if e.IsDir() || (!strings.HasSuffix(e.Name(), ".dql") && !strings.HasSuffix(e.Name(), ".md")) {
+				continue
+			}
+			if err := os.Remove(filepath.Join(dir, sub, e.Name())); err != nil {
+				return fmt.Errorf("failed to remove stale file: %w", err)
+			}
+		}
+	}
+
+	for name, data := range files {
+		path := filepath.Join(dir, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			return fmt.Errorf("failed to create directory: %w", err)
+		}
+		if err := os.WriteFile(path, data, 0o644); err != nil {
+			return fmt.Errorf("failed to write %s: %w", path, err)
+		}
+	}
+	return nil
+}
+
+func init() {
+	for _, c := range []*cobra.Command{exportDashboardCmd, exportNotebookCmd} {
+		c.Flags().Bool("explode", false, "split the document into a spec file plus one file per query and markdown text")
+		c.Flags().StringP("dir", "d", "", "directory for --explode (default: derived from the name)")
+	}
+}
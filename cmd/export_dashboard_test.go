@@ -0,0 +1,116 @@
+package cmd
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/dynatrace-oss/dtctl/pkg/resources/dashboard"
+	"github.com/dynatrace-oss/dtctl/pkg/resources/notebook"
+	"github.com/dynatrace-oss/dtctl/pkg/util/format"
+)
+
+func TestWriteSourceTree(t *testing.T) {
+	dir := t.TempDir()
+	stale := filepath.Join(dir, "tiles", "9-old.dql")
+	kept := filepath.Join(dir, "tiles", "README.txt")
+	for _, path := range []string{stale, kept} {
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte("x"), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	files := map[string][]byte{
+		"dashboard.yaml":    []byte("kind: DashboardSpec\n"),
+		"tiles/1-a.dql":     []byte("fetch logs\n"),
+		"variables/env.dql": []byte("fetch dt.entity.host\n"),
+	}
+	if err := writeSourceTree(dir, files, []string{"tiles", "variables"}); err != nil {
+		t.Fatalf("writeSourceTree() error = %v", err)
+	}
+
+	for name, want := range files {
+		got, err := os.ReadFile(filepath.Join(dir, name))
+		if err != nil || string(got) != string(want) {
+			t.Errorf("%s = %q, %v; want %q", name, got, err, want)
+		}
+	}
+	if _, err := os.Stat(stale); !os.IsNotExist(err) {
+		t.Error("stale query file was not removed")
+	}
+	if _, err := os.Stat(kept); err != nil {
+		t.Error("other files in the subdirectory were removed")
+	}
+}
+
+func TestExplodedDashboardApplies(t *testing.T) {
+	content := map[string]any{
+		"version": 19.0,
+		"tiles": map[string]any{
+			"3": map[string]any{"type": "data", "title": "Errors", "query": "fetch logs | filter loglevel == \"ERROR\"", "visualization": "table"},
+			"7": map[string]any{"type": "markdown", "title": "", "content": "## Notes"},
+		},
+		"layouts": map[string]any{
+			"3": map[string]any{"x": 0.0, "y": 0.0, "w": 12.0, "h": 6.0},
+			"7": map[string]any{"x": 12.0, "y": 0.0, "w": 12.0, "h": 6.0},
+		},
+	}
+	files, err := dashboard.Explode("dash-1", "Errors", "", content)
+	if err != nil {
+		t.Fatalf("Explode() error = %v", err)
+	}
+	dir := t.TempDir()
+	if err := writeSourceTree(dir, files, []string{"tiles", "variables"}); err != nil {
+		t.Fatal(err)
+	}
+
+	spec := filepath.Join(dir, dashboard.ExplodedSpecFile)
+	data, err := os.ReadFile(spec)
+	if err != nil {
+		t.Fatal(err)
+	}
+	out, err := buildDashboardSpec(spec, data)
+	if err != nil {
+		t.Fatalf("buildDashboardSpec() error = %v", err)
+	}
+	var doc map[string]any
+	if err := json.Unmarshal(out, &doc); err != nil {
+		t.Fatal(err)
+	}
+	if doc["id"] != "dash-1" || !format.JSONEqual(doc["content"], content) {
+		t.Errorf("rebuilt document = %s", out)
+	}
+}
+
+func TestBuildNotebookSpec(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "errors.dql"), []byte("fetch logs | limit 10\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	spec := filepath.Join(dir, "notebook.yaml")
+	data := []byte("kind: NotebookSpec\ntitle: Errors\nsections:\n  - file: errors.dql\n")
+	if !notebook.IsSpec(data) {
+		t.Fatal("IsSpec() = false")
+	}
+
+	out, err := buildNotebookSpec(spec, data)
+	if err != nil {
+		t.Fatalf("buildNotebookSpec() error = %v", err)
+	}
+	var doc map[string]any
+	if err := json.Unmarshal(out, &doc); err != nil {
+		t.Fatal(err)
+	}
+	sections := doc["content"].(map[string]any)["sections"].([]any)
+	if doc["type"] != "notebook" || len(sections) != 1 {
+		t.Errorf("document = %s", out)
+	}
+
+	if _, err := buildNotebookSpec(filepath.Join(t.TempDir(), "notebook.yaml"), data); err == nil {
+		t.Error("expected error for a missing query file")
+	}
+}
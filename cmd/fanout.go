@@ -0,0 +1,496 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	osexec "os/exec"
+	"slices"
+	"strings"
+	"sync"
+	"unicode/utf8"
+
+	"github.com/spf13/cobra"
+
+	"github.com/dynatrace-oss/dtctl/pkg/output"
+)
+
+var (
+	fanOutContexts []string // --contexts flag: run the command against several contexts
+	fanOutAll      bool     // --all-contexts flag: run the command against every context
+)
+
+// fanOutVerbs are the read-only verbs that support --contexts/--all-contexts.
+// Mutating verbs are deliberately excluded: fanning out writes across
+// environments would bypass the per-context confirmation flow.
+var fanOutVerbs = map[string]bool{
+	"get":      true,
+	"describe": true,
+	"query":    true,
+}
+
+// fanOutConcurrency caps how many contexts are queried at the same time.
+const fanOutConcurrency = 8
+
+// fanOutContextKey is the field added to every JSON/YAML item so merged
+// results can be traced back to the context they came from. The leading
+// underscore avoids collisions with real resource fields.
+const fanOutContextKey = "_context"
+
+// fanOutResult holds the captured output of a single per-context invocation.
+type fanOutResult struct {
+	Context string
+	Stdout  []byte
+	Stderr  []byte
+	Err     error
+}
+
+// fanOutRequested reports whether --contexts or --all-contexts was given.
+func fanOutRequested() bool {
+	return fanOutAll || len(fanOutContexts) > 0
+}
+
+// setupFanOut wires multi-context support into the command tree. Leaf commands
+// below the verbs in fanOutVerbs run once per selected context; every other
+// runnable command rejects the flags so they are never silently ignored.
+// Must be called after all subcommands are registered.
+func setupFanOut(root *cobra.Command) {
+	for _, sub := range root.Commands() {
+		attachFanOut(sub, fanOutVerbs[sub.Name()])
+	}
+}
+
+func attachFanOut(cmd *cobra.Command, supported bool) {
+	if cmd.RunE != nil {
+		run := cmd.RunE
+		leaf := !cmd.HasSubCommands()
+		cmd.RunE = func(c *cobra.Command, args []string) error {
+			if !fanOutRequested() {
+				return run(c, args)
+			}
+			if !supported || !leaf {
+				return fmt.Errorf("--contexts/--all-contexts are only supported by 'get', 'describe' and 'query'")
+			}
+			if c.Flags().Changed("context") {
+				return fmt.Errorf("--context cannot be combined with --contexts/--all-contexts")
+			}
+			// Re-run the arguments after alias expansion, not the raw os.Args
+			return runFanOut(c, activeCommandArgs)
+		}
+	}
+	for _, sub := range cmd.Commands() {
+		attachFanOut(sub, supported)
+	}
+}
+
+// fanOutTargets returns the context names selected by --contexts/--all-contexts,
+// validating that each one exists in the config.
+func fanOutTargets() ([]string, error) {
+	cfg, err := LoadConfig()
+	if err != nil {
+		return nil, err
+	}
+
+	if fanOutAll {
+		if len(fanOutContexts) > 0 {
+			return nil, fmt.Errorf("--contexts and --all-contexts are mutually exclusive")
+		}
+		names := make([]string, 0, len(cfg.Contexts))
+		for _, nc := range cfg.Contexts {
+			names = append(names, nc.Name)
+		}
+		if len(names) == 0 {
+			return nil, fmt.Errorf("no contexts configured")
+		}
+		return names, nil
+	}
+
+	seen := make(map[string]bool)
+	var names []string
+	for _, name := range fanOutContexts {
+		name = strings.TrimSpace(name)
+		if name == "" || seen[name] {
+			continue
+		}
+		if _, err := cfg.GetContext(name); err != nil {
+			return nil, err
+		}
+		seen[name] = true
+		names = append(names, name)
+	}
+	if len(names) == 0 {
+		return nil, fmt.Errorf("--contexts requires at least one context name")
+	}
+	return names, nil
+}
+
+// runFanOut re-invokes dtctl once per selected context and merges the output.
+//
+// Each context runs in its own child process rather than in-process: command
+// handlers read the active context from package-level state (LoadConfig,
+// NewClientFromConfig), so concurrent in-process runs would race.
+func runFanOut(cmd *cobra.Command, args []string) error {
+	if watch, _ := cmd.Flags().GetBool("watch"); watch {
+		return fmt.Errorf("--watch cannot be combined with --contexts/--all-contexts")
+	}
+
+	targets, err := fanOutTargets()
+	if err != nil {
+		return err
+	}
+
+	self, err := os.Executable()
+	if err != nil {
+		return fmt.Errorf("failed to locate dtctl executable: %w", err)
+	}
+
+	structured := fanOutStructured()
+	childFormat := outputFormat
+	if structured {
+		childFormat = "json"
+	}
+
+	// Commands reading from stdin (e.g. 'query -f -') get a copy of the
+	// parent's stdin, since the children cannot share a single stream.
+	var stdin []byte
+	if readsStdin(args) {
+		stdin, err = io.ReadAll(os.Stdin)
+		if err != nil {
+			return fmt.Errorf("failed to read stdin: %w", err)
+		}
+	}
+
+	results := make([]fanOutResult, len(targets))
+	sem := make(chan struct{}, fanOutConcurrency)
+	var wg sync.WaitGroup
+	for i, name := range targets {
+		wg.Add(1)
+		go func(i int, name string) {
+			defer wg.Done()
+			sem <- struct{}{}
+			defer func() { <-sem }()
+
+			child := osexec.Command(self, fanOutChildArgs(args, name, childFormat)...)
+			child.Env = append(os.Environ(), "NO_COLOR=1")
+			if stdin != nil {
+				child.Stdin = bytes.NewReader(stdin)
+			}
+			var stdout, stderr bytes.Buffer
+			child.Stdout = &stdout
+			child.Stderr = &stderr
+			err := child.Run()
+			results[i] = fanOutResult{Context: name, Stdout: stdout.Bytes(), Stderr: stderr.Bytes(), Err: err}
+		}(i, name)
+	}
+	wg.Wait()
+
+	var failed, warnings []string
+	for _, r := range results {
+		if r.Err != nil {
+			failed = append(failed, r.Context)
+			warnings = append(warnings, fmt.Sprintf("context %q: %s", r.Context, fanOutErrorMessage(r)))
+		}
+	}
+	if len(failed) == len(targets) {
+		return fmt.Errorf("all contexts failed:\n  %s", strings.Join(warnings, "\n  "))
+	}
+
+	if structured {
+		merged, err := mergeFanOutJSON(results)
+		if err != nil {
+			return err
+		}
+		printer := NewPrinter()
+		if ap := enrichAgent(printer, cmd.Parent().Name(), cmd.Name()); ap != nil {
+			// Agent mode: partial failures travel inside the envelope so
+			// the response stays a single JSON document.
+			ap.SetTotal(len(merged))
+			ap.SetWarnings(warnings)
+			return ap.PrintList(merged)
+		}
+		if err := printer.PrintList(merged); err != nil {
+			return err
+		}
+	} else {
+		rowMerge := cmd.Parent().Name() != "describe" &&
+			(outputFormat == "table" || outputFormat == "wide" || outputFormat == "csv")
+		writeFanOutText(os.Stdout, results, rowMerge, outputFormat == "csv")
+	}
+
+	for _, w := range warnings {
+		output.PrintHumanError("%s", w)
+	}
+	if len(failed) > 0 {
+		return fmt.Errorf("%d of %d contexts failed: %s", len(failed), len(targets), strings.Join(failed, ", "))
+	}
+	return nil
+}
+
+// fanOutStructured reports whether the merged output should be built from
+// JSON (json/yaml/toon output or agent/plain mode) instead of text.
+func fanOutStructured() bool {
+	if agentMode || plainMode {
+		return true
+	}
+	switch outputFormat {
+	case "json", "yaml", "yml", "toon":
+		return true
+	}
+	return false
+}
+
+// fanOutErrorMessage extracts the most useful error text from a failed child.
+func fanOutErrorMessage(r fanOutResult) string {
+	msg := strings.TrimSpace(string(r.Stderr))
+	msg = strings.TrimPrefix(msg, "Error: ")
+	if msg == "" {
+		return r.Err.Error()
+	}
+	return msg
+}
+
+// fanOutChildArgs rewrites the invocation arguments for a single context:
+// multi-context, context, output and agent flags are stripped and replaced by
+// an explicit --context, -o and --no-agent.
+func fanOutChildArgs(args []string, contextName, format string) []string {
+	stripWithValue := map[string]bool{
+		"--contexts": true,
+		"--context":  true,
+		"--output":   true,
+		"-o":         true,
+	}
+	stripBool := map[string]bool{
+		"--all-contexts": true,
+		"--agent":        true,
+		"-A":             true,
+		"--no-agent":     true,
+	}
+
+	out := make([]string, 0, len(args)+5)
+	for i := 0; i < len(args); i++ {
+		arg := args[i]
+		if arg == "--" {
+			out = append(out, args[i:]...)
+			break
+		}
+		name := arg
+		if eq := strings.Index(arg, "="); eq >= 0 {
+			name = arg[:eq]
+		}
+		switch {
+		case stripBool[name]:
+			continue
+		case stripWithValue[name]:
+			if name == arg {
+				i++ // skip the separate value token
+			}
+			continue
+		case strings.HasPrefix(arg, "-o") && !strings.HasPrefix(arg, "--") && len(arg) > 2:
+			continue // -ojson
+		}
+		out = append(out, arg)
+	}
+	return append(out, "--context", contextName, "-o", format, "--no-agent")
+}
+
+// readsStdin reports whether the arguments request reading input from stdin
+// (-f - / --file -).
+func readsStdin(args []string) bool {
+	for i, arg := range args {
+		if arg == "-f=-" || arg == "--file=-" {
+			return true
+		}
+		if (arg == "-f" || arg == "--file") && i+1 < len(args) && args[i+1] == "-" {
+			return true
+		}
+	}
+	return false
+}
+
+// mergeFanOutJSON combines the JSON output of successful per-context runs into
+// one list. Lists are flattened; every object is annotated with the context
+// name under fanOutContextKey. Non-object values are wrapped.
+func mergeFanOutJSON(results []fanOutResult) ([]interface{}, error) {
+	merged := []interface{}{}
+	for _, r := range results {
+		if r.Err != nil {
+			continue
+		}
+		data := bytes.TrimSpace(r.Stdout)
+		if len(data) == 0 {
+			continue
+		}
+
+		var v interface{}
+		if err := json.Unmarshal(data, &v); err != nil {
+			return nil, fmt.Errorf("context %q: failed to parse output as JSON: %w", r.Context, err)
+		}
+
+		items, ok := v.([]interface{})
+		if !ok {
+			items = []interface{}{v}
+		}
+		for _, item := range items {
+			merged = append(merged, annotateFanOutItem(item, r.Context))
+		}
+	}
+	return merged, nil
+}
+
+func annotateFanOutItem(item interface{}, contextName string) interface{} {
+	if obj, ok := item.(map[string]interface{}); ok {
+		obj[fanOutContextKey] = contextName
+		return obj
+	}
+	return map[string]interface{}{
+		fanOutContextKey: contextName,
+		"value":          item,
+	}
+}
+
+// writeFanOutText writes the text output of each context. With rowMerge, the
+// table header is printed once with a leading CONTEXT column and every row is
+// prefixed with its context name; tables are re-aligned since each context pads
+// its columns to its own widths. When headers differ between contexts (or
+// rowMerge is off) each context is printed as its own section instead.
+func writeFanOutText(w io.Writer, results []fanOutResult, rowMerge, csv bool) {
+	var ok []fanOutResult
+	for _, r := range results {
+		if r.Err == nil {
+			ok = append(ok, r)
+		}
+	}
+
+	if rowMerge && sameFanOutHeaders(ok) {
+		var rows [][]string
+		for _, r := range ok {
+			lines := fanOutLines(r.Stdout)
+			if len(lines) == 0 {
+				continue
+			}
+			split := func(line string) []string { return []string{line} }
+			if !csv {
+				starts := fanOutColumnStarts(lines[0])
+				split = func(line string) []string { return splitFanOutRow(line, starts) }
+			}
+			if rows == nil {
+				rows = append(rows, append([]string{"CONTEXT"}, split(lines[0])...))
+			}
+			for _, line := range lines[1:] {
+				rows = append(rows, append([]string{r.Context}, split(line)...))
+			}
+		}
+		if csv {
+			for _, row := range rows {
+				fmt.Fprintln(w, strings.Join(row, ","))
+			}
+			return
+		}
+		writeFanOutTable(w, rows)
+		return
+	}
+
+	for i, r := range ok {
+		if i > 0 {
+			fmt.Fprintln(w)
+		}
+		fmt.Fprintf(w, "%s\n", output.Colorize(output.Bold, "==> context: "+r.Context+" <=="))
+		_, _ = w.Write(r.Stdout)
+	}
+}
+
+// sameFanOutHeaders reports whether all non-empty outputs share the same
+// header columns. The padding between columns is ignored, as it depends on
+// the widths of each context's rows.
+func sameFanOutHeaders(results []fanOutResult) bool {
+	var header []string
+	for _, r := range results {
+		lines := fanOutLines(r.Stdout)
+		if len(lines) == 0 {
+			continue
+		}
+		if header == nil {
+			header = strings.Fields(lines[0])
+			continue
+		}
+		if !slices.Equal(strings.Fields(lines[0]), header) {
+			return false
+		}
+	}
+	return true
+}
+
+// fanOutColumnStarts returns the offsets (in runes) at which the columns of a
+// table header start. Columns are separated by at least two spaces, so header
+// titles with a single space stay in one column.
+func fanOutColumnStarts(header string) []int {
+	var starts []int
+	spaces := 0
+	for i, c := range []rune(header) {
+		if c == ' ' {
+			spaces++
+			continue
+		}
+		if i == 0 || spaces >= 2 {
+			starts = append(starts, i)
+		}
+		spaces = 0
+	}
+	return starts
+}
+
+// splitFanOutRow splits a table row into cells at the given column starts.
+func splitFanOutRow(line string, starts []int) []string {
+	runes := []rune(line)
+	cells := make([]string, len(starts))
+	for i, start := range starts {
+		if start >= len(runes) {
+			break
+		}
+		end := len(runes)
+		if i+1 < len(starts) && starts[i+1] < end {
+			end = starts[i+1]
+		}
+		cells[i] = strings.TrimSpace(string(runes[start:end]))
+	}
+	return cells
+}
+
+// writeFanOutTable writes rows as a table aligned like the table printer's
+// output, with three spaces between columns.
+func writeFanOutTable(w io.Writer, rows [][]string) {
+	var widths []int
+	for _, row := range rows {
+		for i, cell := range row {
+			if i >= len(widths) {
+				widths = append(widths, 0)
+			}
+			widths[i] = max(widths[i], utf8.RuneCountInString(cell))
+		}
+	}
+	for _, row := range rows {
+		var b strings.Builder
+		for i, cell := range row {
+			if i > 0 {
+				b.WriteString("   ")
+			}
+			b.WriteString(cell)
+			b.WriteString(strings.Repeat(" ", widths[i]-utf8.RuneCountInString(cell)))
+		}
+		fmt.Fprintln(w, strings.TrimRight(b.String(), " "))
+	}
+}
+
+// fanOutLines splits output into lines, dropping blank lines.
+func fanOutLines(data []byte) []string {
+	var lines []string
+	for _, line := range strings.Split(string(data), "\n") {
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+		lines = append(lines, strings.TrimRight(line, "\r"))
+	}
+	return lines
+}
@@ -0,0 +1,225 @@
+package cmd
+
+import (
+	"bytes"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/spf13/cobra"
+)
+
+func TestFanOutChildArgs(t *testing.T) {
+	tests := []struct {
+		name string
+		args []string
+		want []string
+	}{
+		{
+			name: "strips contexts and output flags",
+			args: []string{"get", "workflows", "--contexts", "dev,prod", "-o", "json"},
+			want: []string{"get", "workflows", "--context", "dev", "-o", "table", "--no-agent"},
+		},
+		{
+			name: "strips inline values and bool flags",
+			args: []string{"--all-contexts", "--context=x", "get", "wf", "--output=yaml", "-A", "--mine"},
+			want: []string{"get", "wf", "--mine", "--context", "dev", "-o", "table", "--no-agent"},
+		},
+		{
+			name: "strips attached short output",
+			args: []string{"query", "fetch logs", "-ojson"},
+			want: []string{"query", "fetch logs", "--context", "dev", "-o", "table", "--no-agent"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := fanOutChildArgs(tt.args, "dev", "table")
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("fanOutChildArgs() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestReadsStdin(t *testing.T) {
+	if !readsStdin([]string{"query", "-f", "-"}) {
+		t.Error("expected -f - to read stdin")
+	}
+	if !readsStdin([]string{"query", "--file=-"}) {
+		t.Error("expected --file=- to read stdin")
+	}
+	if readsStdin([]string{"query", "-f", "q.dql"}) {
+		t.Error("expected -f q.dql not to read stdin")
+	}
+}
+
+func TestMergeFanOutJSON(t *testing.T) {
+	results := []fanOutResult{
+		{Context: "dev", Stdout: []byte(`[{"id":"a"},{"id":"b"}]`)},
+		{Context: "stage", Err: errors.New("exit status 1"), Stderr: []byte("Error: boom")},
+		{Context: "prod", Stdout: []byte(`{"id":"c"}`)},
+		{Context: "test", Stdout: []byte(`[42]`)},
+	}
+
+	merged, err := mergeFanOutJSON(results)
+	if err != nil {
+		t.Fatalf("mergeFanOutJSON() error = %v", err)
+	}
+	if len(merged) != 4 {
+		t.Fatalf("expected 4 merged items, got %d", len(merged))
+	}
+
+	first := merged[0].(map[string]interface{})
+	if first["id"] != "a" || first[fanOutContextKey] != "dev" {
+		t.Errorf("unexpected first item: %v", first)
+	}
+	third := merged[2].(map[string]interface{})
+	if third["id"] != "c" || third[fanOutContextKey] != "prod" {
+		t.Errorf("unexpected third item: %v", third)
+	}
+	wrapped := merged[3].(map[string]interface{})
+	if wrapped["value"] != float64(42) || wrapped[fanOutContextKey] != "test" {
+		t.Errorf("unexpected wrapped item: %v", wrapped)
+	}
+}
+
+func TestMergeFanOutJSON_InvalidOutput(t *testing.T) {
+	_, err := mergeFanOutJSON([]fanOutResult{{Context: "dev", Stdout: []byte("not json")}})
+	if err == nil || !strings.Contains(err.Error(), `context "dev"`) {
+		t.Errorf("expected parse error naming the context, got %v", err)
+	}
+}
+
+func TestWriteFanOutText_RowMerge(t *testing.T) {
+	results := []fanOutResult{
+		{Context: "dev", Stdout: []byte("ID   TITLE\n1    one\n")},
+		{Context: "production", Stdout: []byte("ID   TITLE\n2    two\n3    three\n")},
+		{Context: "broken", Err: errors.New("exit status 1")},
+	}
+
+	var buf bytes.Buffer
+	writeFanOutText(&buf, results, true, false)
+
+	want := "CONTEXT      ID   TITLE\n" +
+		"dev          1    one\n" +
+		"production   2    two\n" +
+		"production   3    three\n"
+	if buf.String() != want {
+		t.Errorf("unexpected output:\n%s\nwant:\n%s", buf.String(), want)
+	}
+}
+
+func TestWriteFanOutText_RowMergeRealignsColumns(t *testing.T) {
+	results := []fanOutResult{
+		{Context: "dev", Stdout: []byte("ID   NAME       LAST MODIFIED\n1    short      today\n42   a longer   -\n")},
+		{Context: "prod", Stdout: []byte("ID        NAME   LAST MODIFIED\n1234567   x      yesterday\n")},
+	}
+
+	var buf bytes.Buffer
+	writeFanOutText(&buf, results, true, false)
+
+	want := "CONTEXT   ID        NAME       LAST MODIFIED\n" +
+		"dev       1         short      today\n" +
+		"dev       42        a longer   -\n" +
+		"prod      1234567   x          yesterday\n"
+	if buf.String() != want {
+		t.Errorf("unexpected output:\n%s\nwant:\n%s", buf.String(), want)
+	}
+}
+
+func TestWriteFanOutText_CSV(t *testing.T) {
+	results := []fanOutResult{
+		{Context: "dev", Stdout: []byte("id,title\n1,one\n")},
+		{Context: "prod", Stdout: []byte("id,title\n2,two\n")},
+	}
+
+	var buf bytes.Buffer
+	writeFanOutText(&buf, results, true, true)
+
+	want := "CONTEXT,id,title\ndev,1,one\nprod,2,two\n"
+	if buf.String() != want {
+		t.Errorf("unexpected output:\n%s\nwant:\n%s", buf.String(), want)
+	}
+}
+
+func TestWriteFanOutText_SectionsWhenHeadersDiffer(t *testing.T) {
+	results := []fanOutResult{
+		{Context: "dev", Stdout: []byte("ID  TITLE\n1   one\n")},
+		{Context: "prod", Stdout: []byte("Name: two\n")},
+	}
+
+	var buf bytes.Buffer
+	writeFanOutText(&buf, results, true, false)
+
+	out := buf.String()
+	if !strings.Contains(out, "==> context: dev <==") || !strings.Contains(out, "==> context: prod <==") {
+		t.Errorf("expected per-context sections, got:\n%s", out)
+	}
+}
+
+func TestFanOutGuardRejectsMutatingVerbs(t *testing.T) {
+	defer func() { fanOutContexts = nil }()
+	fanOutContexts = []string{"dev"}
+
+	called := false
+	cmd := newFanOutTestCommand("delete", &called)
+	attachFanOut(cmd, false)
+
+	err := cmd.Commands()[0].RunE(cmd.Commands()[0], nil)
+	if err == nil || !strings.Contains(err.Error(), "only supported by") {
+		t.Errorf("expected unsupported error, got %v", err)
+	}
+	if called {
+		t.Error("original RunE must not run when fan-out is rejected")
+	}
+}
+
+func TestFanOutRejectsExplicitContext(t *testing.T) {
+	defer func() { fanOutContexts = nil }()
+	fanOutContexts = []string{"dev"}
+
+	called := false
+	cmd := newFanOutTestCommand("get", &called)
+	cmd.PersistentFlags().String("context", "", "")
+	attachFanOut(cmd, true)
+
+	leaf := cmd.Commands()[0]
+	if err := leaf.ParseFlags([]string{"--context", "prod"}); err != nil {
+		t.Fatalf("failed to parse flags: %v", err)
+	}
+	err := leaf.RunE(leaf, nil)
+	if err == nil || !strings.Contains(err.Error(), "--context cannot be combined") {
+		t.Errorf("expected --context conflict error, got %v", err)
+	}
+	if called {
+		t.Error("original RunE must not run when fan-out is rejected")
+	}
+}
+
+func TestFanOutPassThroughWithoutFlags(t *testing.T) {
+	called := false
+	cmd := newFanOutTestCommand("get", &called)
+	attachFanOut(cmd, true)
+
+	if err := cmd.Commands()[0].RunE(cmd.Commands()[0], nil); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !called {
+		t.Error("expected original RunE to run when no fan-out flags are set")
+	}
+}
+
+// newFanOutTestCommand builds a verb with a single runnable leaf that records
+// whether its original RunE was invoked.
+func newFanOutTestCommand(verb string, called *bool) *cobra.Command {
+	parent := &cobra.Command{Use: verb}
+	parent.AddCommand(&cobra.Command{
+		Use: "things",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			*called = true
+			return nil
+		},
+	})
+	return parent
+}
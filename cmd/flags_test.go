@@ -262,6 +262,7 @@ func TestCreateFlags(t *testing.T) {
 		{"settings", "file"},
 		{"settings", "schema"},
 		{"settings", "scope"},
+		{"settings", "scaffold"},
 	}
 
 	for _, tt := range tests {
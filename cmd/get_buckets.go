@@ -73,12 +73,28 @@ Examples:
 
   # Delete without confirmation (use with caution)
   dtctl delete bucket <bucket-name> -y
+
+  # Delete in a context that requires two-person approval
+  dtctl delete bucket <bucket-name> --approval dtctl-change-<id>.yaml
 `,
 	Args: cobra.ExactArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
 		bucketName := args[0]
 
-		_, c, err := SetupWithSafety(safety.OperationDeleteBucket)
+		cfg, err := LoadConfig()
+		if err != nil {
+			return err
+		}
+		checker, err := NewSafetyChecker(cfg)
+		if err != nil {
+			return err
+		}
+		checker = checker.ForResource("bucket", bucketName).
+			WithChangeDiff(fmt.Sprintf("- bucket %s (all data in the bucket is deleted)", bucketName))
+		if err := checker.CheckError(safety.OperationDeleteBucket, safety.OwnershipUnknown); err != nil {
+			return err
+		}
+		c, err := NewClientFromConfig(cfg)
 		if err != nil {
 			return err
 		}
@@ -1,6 +1,7 @@
 package cmd
 
 import (
+	"errors"
 	"fmt"
 	"os"
 	"time"
@@ -11,6 +12,7 @@ import (
 	"github.com/dynatrace-oss/dtctl/pkg/output"
 	"github.com/dynatrace-oss/dtctl/pkg/prompt"
 	"github.com/dynatrace-oss/dtctl/pkg/resources/document"
+	"github.com/dynatrace-oss/dtctl/pkg/resources/iam"
 	"github.com/dynatrace-oss/dtctl/pkg/resources/resolver"
 	"github.com/dynatrace-oss/dtctl/pkg/safety"
 )
@@ -38,6 +40,12 @@ Examples:
 
   # List only my dashboards
   dtctl get dashboards --mine
+
+  # List the dashboards of a user, with owner names
+  dtctl get dashboards --owner jane.doe@example.com
+
+  # List dashboards whose owner no longer exists
+  dtctl get dashboards --orphaned
 `,
 	RunE: func(cmd *cobra.Command, args []string) error {
 		_, c, printer, err := Setup()
@@ -61,6 +69,7 @@ Examples:
 		if err != nil {
 			return err
 		}
+		owners := documentOwnerCache(cmd, c)
 
 		// Check if watch mode is enabled
 		watchMode, _ := cmd.Flags().GetBool("watch")
@@ -70,7 +79,7 @@ Examples:
 				if err != nil {
 					return nil, err
 				}
-				return document.ConvertToDocuments(list), nil
+				return documentListOutput(cmd, owners, list)
 			}
 			return executeWithWatch(cmd, fetcher, printer)
 		}
@@ -80,7 +89,11 @@ Examples:
 			return err
 		}
 
-		return printer.PrintList(document.ConvertToDocuments(list))
+		rows, err := documentListOutput(cmd, owners, list)
+		if err != nil {
+			return err
+		}
+		return printer.PrintList(rows)
 	},
 }
 
@@ -107,6 +120,9 @@ Examples:
 
   # List only my notebooks
   dtctl get notebooks --mine
+
+  # List the notebooks of a user, with owner names
+  dtctl get notebooks --owner jane.doe@example.com
 `,
 	RunE: func(cmd *cobra.Command, args []string) error {
 		_, c, printer, err := Setup()
@@ -130,6 +146,7 @@ Examples:
 		if err != nil {
 			return err
 		}
+		owners := documentOwnerCache(cmd, c)
 
 		// Check if watch mode is enabled
 		watchMode, _ := cmd.Flags().GetBool("watch")
@@ -139,7 +156,7 @@ Examples:
 				if err != nil {
 					return nil, err
 				}
-				return document.ConvertToDocuments(list), nil
+				return documentListOutput(cmd, owners, list)
 			}
 			return executeWithWatch(cmd, fetcher, printer)
 		}
@@ -149,7 +166,11 @@ Examples:
 			return err
 		}
 
-		return printer.PrintList(document.ConvertToDocuments(list))
+		rows, err := documentListOutput(cmd, owners, list)
+		if err != nil {
+			return err
+		}
+		return printer.PrintList(rows)
 	},
 }
 
@@ -285,7 +306,7 @@ Examples:
 		}
 		currentUserID, _ := c.CurrentUserID()
 		ownership := safety.DetermineOwnership(metadata.Owner, currentUserID)
-		if err := checker.CheckError(safety.OperationDelete, ownership); err != nil {
+		if err := checker.ForResource("dashboard", metadata.Name).CheckError(safety.OperationDelete, ownership); err != nil {
 			return err
 		}
 
@@ -354,7 +375,7 @@ Examples:
 		}
 		currentUserID, _ := c.CurrentUserID()
 		ownership := safety.DetermineOwnership(metadata.Owner, currentUserID)
-		if err := checker.CheckError(safety.OperationDelete, ownership); err != nil {
+		if err := checker.ForResource("notebook", metadata.Name).CheckError(safety.OperationDelete, ownership); err != nil {
 			return err
 		}
 
@@ -491,6 +512,12 @@ Examples:
   # List only my documents
   dtctl get documents --mine
 
+  # List the documents of a user (UUID, email or unique partial name)
+  dtctl get documents --owner jane.doe@example.com
+
+  # Report documents whose owner no longer exists in IAM
+  dtctl get documents --orphaned --admin-access
+
   # Discover what document types exist in the environment
   dtctl get documents --types
 
@@ -522,6 +549,7 @@ Examples:
 		if err != nil {
 			return err
 		}
+		owners := documentOwnerCache(cmd, c)
 
 		if typesMode {
 			// Fetch all documents (no type filter) and count by type
@@ -552,7 +580,7 @@ Examples:
 				if err != nil {
 					return nil, err
 				}
-				return document.ConvertToDocuments(list), nil
+				return documentListOutput(cmd, owners, list)
 			}
 			return executeWithWatch(cmd, fetcher, printer)
 		}
@@ -562,7 +590,11 @@ Examples:
 			return err
 		}
 
-		return printer.PrintList(document.ConvertToDocuments(list))
+		rows, err := documentListOutput(cmd, owners, list)
+		if err != nil {
+			return err
+		}
+		return printer.PrintList(rows)
 	},
 }
 
@@ -616,7 +648,7 @@ Examples:
 		}
 		currentUserID, _ := c.CurrentUserID()
 		ownership := safety.DetermineOwnership(metadata.Owner, currentUserID)
-		if err := checker.CheckError(safety.OperationDelete, ownership); err != nil {
+		if err := checker.ForResource(metadata.Type, metadata.Name).CheckError(safety.OperationDelete, ownership); err != nil {
 			return err
 		}
 
@@ -643,6 +675,7 @@ func buildDocumentFilters(cmd *cobra.Command, c *client.Client, implicitType str
 	rawFilter, _ := cmd.Flags().GetString("filter")
 	nameFilter, _ := cmd.Flags().GetString("name")
 	mineOnly, _ := cmd.Flags().GetBool("mine")
+	owner, _ := cmd.Flags().GetString("owner")
 	sortOrder, _ := cmd.Flags().GetString("sort")
 	addFields, _ := cmd.Flags().GetStringSlice("add-fields")
 	adminAccess, _ := cmd.Flags().GetBool("admin-access")
@@ -655,8 +688,8 @@ func buildDocumentFilters(cmd *cobra.Command, c *client.Client, implicitType str
 	}
 
 	if rawFilter != "" {
-		if nameFilter != "" || mineOnly {
-			fmt.Fprintln(os.Stderr, "warning: --filter overrides --name/--mine; the raw filter is sent verbatim to the API")
+		if nameFilter != "" || mineOnly || owner != "" {
+			fmt.Fprintln(os.Stderr, "warning: --filter overrides --name/--mine/--owner; the raw filter is sent verbatim to the API")
 		}
 		// For type-scoped commands (dashboards, notebooks), enforce the implicit
 		// type even when --filter is provided, so dtctl get dashboards --filter "..."
@@ -671,6 +704,16 @@ func buildDocumentFilters(cmd *cobra.Command, c *client.Client, implicitType str
 
 	filters.Type = implicitType
 	filters.Name = nameFilter
+	if mineOnly && owner != "" {
+		return filters, fmt.Errorf("--mine and --owner cannot be combined")
+	}
+	if owner != "" {
+		userID, err := resolveUserID(iam.NewHandler(c), owner, true)
+		if err != nil {
+			return filters, fmt.Errorf("failed to resolve --owner: %w", err)
+		}
+		filters.Owner = userID
+	}
 	if mineOnly {
 		userID, err := c.CurrentUserID()
 		if err != nil {
@@ -681,6 +724,69 @@ func buildDocumentFilters(cmd *cobra.Command, c *client.Client, implicitType str
 	return filters, nil
 }
 
+// ownedDocument is a listed document with the name of its owner
+type ownedDocument struct {
+	ID        string    `table:"ID" json:"id" yaml:"id"`
+	Name      string    `table:"NAME" json:"name" yaml:"name"`
+	Type      string    `table:"TYPE" json:"type" yaml:"type"`
+	Owner     string    `table:"OWNER" json:"owner" yaml:"owner"`
+	OwnerName string    `table:"OWNER_NAME" json:"ownerName" yaml:"ownerName"`
+	IsPrivate bool      `table:"PRIVATE,wide" json:"isPrivate" yaml:"isPrivate"`
+	Version   int       `table:"VERSION,wide" json:"version" yaml:"version"`
+	Modified  time.Time `table:"MODIFIED" json:"lastModified" yaml:"lastModified"`
+}
+
+// ownerNotInIAM is shown as the owner name of orphaned documents
+const ownerNotInIAM = "(not in IAM)"
+
+// documentOwnerCache returns a user cache for resolving owner names if
+// --owner or --orphaned is set, nil otherwise
+func documentOwnerCache(cmd *cobra.Command, c *client.Client) *iam.UserCache {
+	owner, _ := cmd.Flags().GetString("owner")
+	orphaned, _ := cmd.Flags().GetBool("orphaned")
+	if owner == "" && !orphaned {
+		return nil
+	}
+	return iam.NewUserCache(iam.NewHandler(c))
+}
+
+// documentListOutput returns the listed documents for printing. With an owner
+// cache, the owners are looked up in IAM and their names are shown; with
+// --orphaned only documents whose owner no longer exists are kept.
+func documentListOutput(cmd *cobra.Command, owners *iam.UserCache, list *document.DocumentList) (interface{}, error) {
+	docs := document.ConvertToDocuments(list)
+	if owners == nil {
+		return docs, nil
+	}
+	orphanedOnly, _ := cmd.Flags().GetBool("orphaned")
+
+	rows := make([]ownedDocument, 0, len(docs))
+	for _, d := range docs {
+		row := ownedDocument{
+			ID:        d.ID,
+			Name:      d.Name,
+			Type:      d.Type,
+			Owner:     d.Owner,
+			IsPrivate: d.IsPrivate,
+			Version:   d.Version,
+			Modified:  d.Modified,
+		}
+		user, err := owners.Get(d.Owner)
+		switch {
+		case errors.Is(err, iam.ErrUserNotFound):
+			row.OwnerName = ownerNotInIAM
+		case err != nil:
+			return nil, fmt.Errorf("failed to look up owner of %q: %w", d.Name, err)
+		case orphanedOnly:
+			continue
+		default:
+			row.OwnerName = user.DisplayName()
+		}
+		rows = append(rows, row)
+	}
+	return rows, nil
+}
+
 // addDocumentListFlags registers the flags shared by dashboards/notebooks/documents listing commands.
 func addDocumentListFlags(cmd *cobra.Command, includeType bool) {
 	if includeType {
@@ -688,7 +794,9 @@ func addDocumentListFlags(cmd *cobra.Command, includeType bool) {
 	}
 	cmd.Flags().String("name", "", "Filter by name (partial match, case-insensitive)")
 	cmd.Flags().Bool("mine", false, "Show only documents owned by current user")
-	cmd.Flags().String("filter", "", "Raw Document API filter expression, ANDed with the type scope (overrides --name/--mine)")
+	cmd.Flags().String("owner", "", "Show only documents owned by this user (UUID, email or unique partial name), with owner names")
+	cmd.Flags().Bool("orphaned", false, "Show only documents whose owner no longer exists in IAM")
+	cmd.Flags().String("filter", "", "Raw Document API filter expression, ANDed with the type scope (overrides --name/--mine/--owner)")
 	cmd.Flags().String("sort", "", "Sort fields, comma-separated, prefix with '-' for descending (e.g. \"name,-modificationInfo.lastModifiedTime\")")
 	cmd.Flags().StringSlice("add-fields", nil, "Request fields the API omits by default (e.g. originExtensionId,labels,shareInfo.isShared)")
 	cmd.Flags().Bool("admin-access", false, "List documents as effective owner; requires document:documents:admin permission")
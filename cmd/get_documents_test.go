@@ -1,9 +1,17 @@
 package cmd
 
 import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
 	"testing"
 
 	"github.com/spf13/cobra"
+
+	"github.com/dynatrace-oss/dtctl/pkg/client"
+	"github.com/dynatrace-oss/dtctl/pkg/resources/document"
+	"github.com/dynatrace-oss/dtctl/pkg/resources/iam"
 )
 
 func newDocFlagsCmd(includeType bool) *cobra.Command {
@@ -19,6 +27,11 @@ func TestAddDocumentListFlags_RegistersAll(t *testing.T) {
 			t.Errorf("expected flag %q registered with includeType=true", name)
 		}
 	}
+	for _, name := range []string{"owner", "orphaned"} {
+		if cmd.Flags().Lookup(name) == nil {
+			t.Errorf("expected owner filter flag %q registered", name)
+		}
+	}
 }
 
 func TestAddDocumentListFlags_SkipsTypeForImplicitTypeCommands(t *testing.T) {
@@ -106,3 +119,118 @@ func TestBuildDocumentFilters_WiresSortAddFieldsAdminAccess(t *testing.T) {
 		t.Errorf("expected AdminAccess=true, got false")
 	}
 }
+
+// newIAMTestClient returns a client whose IAM knows the given users
+func newIAMTestClient(t *testing.T, users ...iam.User) *client.Client {
+	t.Helper()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if !strings.HasSuffix(r.URL.Path, "/users") {
+			for _, u := range users {
+				if strings.HasSuffix(r.URL.Path, "/users/"+u.UID) {
+					_ = json.NewEncoder(w).Encode(u)
+					return
+				}
+			}
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		var matches []iam.User
+		for _, u := range users {
+			if strings.Contains(u.Email, r.URL.Query().Get("partialString")) {
+				matches = append(matches, u)
+			}
+		}
+		_ = json.NewEncoder(w).Encode(iam.UserListResponse{Results: matches, TotalCount: int64(len(matches))})
+	}))
+	t.Cleanup(srv.Close)
+	c, err := client.NewForTesting(srv.URL, "test-token")
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+	return c
+}
+
+const (
+	testUserID    = "11111111-2222-3333-4444-555555555555"
+	removedUserID = "99999999-8888-7777-6666-555555555555"
+)
+
+var testUser = iam.User{UID: testUserID, Email: "jane.doe@example.com", Name: "Jane", Surname: "Doe"}
+
+func TestBuildDocumentFilters_ResolvesOwner(t *testing.T) {
+	c := newIAMTestClient(t, testUser)
+	tests := []struct {
+		owner   string
+		want    string
+		wantErr string
+	}{
+		{owner: "jane.doe@example.com", want: testUserID},
+		{owner: testUserID, want: testUserID},
+		{owner: removedUserID, want: removedUserID},
+		{owner: "gone@example.com", wantErr: "only be given by UUID"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.owner, func(t *testing.T) {
+			cmd := newDocFlagsCmd(true)
+			_ = cmd.Flags().Set("owner", tt.owner)
+			filters, err := buildDocumentFilters(cmd, c, "")
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("expected error containing %q, got %v", tt.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if filters.Owner != tt.want {
+				t.Errorf("expected Owner %q, got %q", tt.want, filters.Owner)
+			}
+		})
+	}
+}
+
+func TestBuildDocumentFilters_MineAndOwnerConflict(t *testing.T) {
+	cmd := newDocFlagsCmd(true)
+	_ = cmd.Flags().Set("mine", "true")
+	_ = cmd.Flags().Set("owner", testUserID)
+	if _, err := buildDocumentFilters(cmd, nil, ""); err == nil {
+		t.Fatal("expected error for --mine with --owner")
+	}
+}
+
+func TestDocumentListOutput(t *testing.T) {
+	c := newIAMTestClient(t, testUser)
+	list := &document.DocumentList{Documents: []document.DocumentMetadata{
+		{ID: "d1", Name: "Kept", Type: "dashboard", Owner: testUserID},
+		{ID: "d2", Name: "Orphan", Type: "notebook", Owner: removedUserID},
+	}}
+
+	cmd := newDocFlagsCmd(true)
+	if out, err := documentListOutput(cmd, documentOwnerCache(cmd, c), list); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	} else if _, ok := out.([]document.Document); !ok {
+		t.Errorf("expected plain documents without --owner/--orphaned, got %T", out)
+	}
+
+	_ = cmd.Flags().Set("owner", testUserID)
+	out, err := documentListOutput(cmd, documentOwnerCache(cmd, c), list)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	rows := out.([]ownedDocument)
+	if len(rows) != 2 || rows[0].OwnerName != "Jane Doe <jane.doe@example.com>" || rows[1].OwnerName != ownerNotInIAM {
+		t.Errorf("unexpected owner names: %+v", rows)
+	}
+
+	_ = cmd.Flags().Set("orphaned", "true")
+	out, err = documentListOutput(cmd, documentOwnerCache(cmd, c), list)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	rows = out.([]ownedDocument)
+	if len(rows) != 1 || rows[0].ID != "d2" {
+		t.Errorf("expected only the orphaned document, got %+v", rows)
+	}
+}
@@ -99,7 +99,7 @@ Examples:
 		}
 		currentUserID, _ := c.CurrentUserID()
 		ownership := safety.DetermineOwnership(seg.Owner, currentUserID)
-		if err := checker.CheckError(safety.OperationDelete, ownership); err != nil {
+		if err := checker.ForResource("segment", seg.Name).CheckError(safety.OperationDelete, ownership); err != nil {
 			return err
 		}
 
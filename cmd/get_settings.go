@@ -34,7 +34,7 @@ Examples:
 			return err
 		}
 
-		handler := settings.NewHandler(c)
+		handler := settings.NewHandler(c).WithSchemaCache(settingsSchemaCache())
 
 		// Get specific schema if ID provided
 		if len(args) > 0 {
@@ -69,6 +69,9 @@ Examples:
   # List settings with a specific scope
   dtctl get settings --schema builtin:openpipeline.logs.pipelines --scope environment
 
+  # List the settings objects of all schemas in a scope
+  dtctl get settings --all-schemas --scope HOST-1234567890ABCDEF
+
   # Get a specific settings object by objectId
   dtctl get settings vu9U3hXa3q0AAAABABRidWlsdGluOnJ1bS53ZWIubmFtZQ...
 
@@ -95,9 +98,23 @@ Examples:
 			return printer.Print(obj)
 		}
 
+		allSchemas, _ := cmd.Flags().GetBool("all-schemas")
+		if allSchemas {
+			if schemaID != "" {
+				return fmt.Errorf("--schema and --all-schemas cannot be combined")
+			}
+			concurrency, _ := cmd.Flags().GetInt("concurrency")
+			objects, failed, err := handler.ListAllObjects(scope, GetChunkSize(), concurrency)
+			if err != nil {
+				return err
+			}
+			warnSchemaListErrors(failed)
+			return printer.PrintList(objects)
+		}
+
 		// List objects for schema
 		if schemaID == "" {
-			return fmt.Errorf("--schema is required when listing settings objects")
+			return fmt.Errorf("--schema or --all-schemas is required when listing settings objects")
 		}
 
 		list, err := handler.ListObjects(schemaID, scope, GetChunkSize())
@@ -109,6 +126,22 @@ Examples:
 	},
 }
 
+// warnSchemaListErrors reports schemas whose objects could not be listed
+func warnSchemaListErrors(failed []settings.SchemaListError) {
+	if len(failed) == 0 {
+		return
+	}
+	const shown = 3
+	output.PrintWarning("Objects of %d schema(s) could not be listed", len(failed))
+	for i, f := range failed {
+		if i == shown {
+			output.PrintWarning("  ... and %d more", len(failed)-shown)
+			break
+		}
+		output.PrintWarning("  %s", f.Error())
+	}
+}
+
 // deleteSettingsCmd deletes a settings object
 var deleteSettingsCmd = &cobra.Command{
 	Use:   "settings <object-id>",
@@ -163,8 +196,10 @@ Examples:
 
 func init() {
 	// Settings flags
-	getSettingsCmd.Flags().String("schema", "", "Schema ID (required when listing settings objects)")
+	getSettingsCmd.Flags().String("schema", "", "Schema ID (required when listing settings objects, unless --all-schemas)")
 	getSettingsCmd.Flags().String("scope", "", "Scope to filter settings (e.g., 'environment')")
+	getSettingsCmd.Flags().Bool("all-schemas", false, "List the settings objects of all schemas")
+	getSettingsCmd.Flags().Int("concurrency", settings.DefaultConcurrency, "Number of schemas listed in parallel with --all-schemas")
 
 	// Delete settings flags
 	deleteSettingsCmd.Flags().BoolVarP(&forceDelete, "yes", "y", false, "Skip confirmation prompt")
@@ -225,7 +225,7 @@ Examples:
 		}
 		currentUserID, _ := c.CurrentUserID()
 		ownership := safety.DetermineOwnership(wf.Owner, currentUserID)
-		if err := checker.CheckError(safety.OperationDelete, ownership); err != nil {
+		if err := checker.ForResource("workflow", wf.Title).CheckError(safety.OperationDelete, ownership); err != nil {
 			return err
 		}
 
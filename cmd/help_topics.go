@@ -28,6 +28,9 @@ Quick Reference:
   readwrite-all               Team environments, administration       Standard token
   dangerously-unrestricted    Dev environments, bucket management     Full access token
 
+For the minimal scopes of specific commands, run:
+  dtctl auth scopes --for "get workflows,query"
+
 For the full list of scopes per safety level, see:
   https://dynatrace-oss.github.io/dtctl/docs/token-scopes/
 
@@ -0,0 +1,175 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/dynatrace-oss/dtctl/pkg/audit"
+	"github.com/dynatrace-oss/dtctl/pkg/hook"
+	"github.com/dynatrace-oss/dtctl/pkg/output"
+	"github.com/dynatrace-oss/dtctl/pkg/util/format"
+)
+
+// lifecycleHookVerbs are the verbs whose commands run pre-<verb> and
+// post-<verb> hooks. 'apply' runs its own hooks with the processed resource;
+// 'query' runs its hooks itself because the query text is only known inside
+// the command.
+var lifecycleHookVerbs = []string{"create", "edit", "delete"}
+
+// hookEvent is the JSON document passed to lifecycle hooks on stdin
+type hookEvent struct {
+	Event        string   `json:"event"`
+	Context      string   `json:"context"`
+	Environment  string   `json:"environment"`
+	Command      string   `json:"command"`
+	ResourceType string   `json:"resourceType,omitempty"`
+	Args         []string `json:"args"`
+	// File and Content are the --file argument and its content as JSON
+	File    string          `json:"file,omitempty"`
+	Content json.RawMessage `json:"content,omitempty"`
+	// Query is the DQL query of pre-query and post-query hooks
+	Query  string `json:"query,omitempty"`
+	DryRun bool   `json:"dryRun,omitempty"`
+}
+
+// setupLifecycleHooks wraps the commands below the verbs in
+// lifecycleHookVerbs so that their pre- and post- hooks run around them, and
+// adds a --no-hooks flag to each. Must be called after all subcommands are
+// registered.
+func setupLifecycleHooks(root *cobra.Command) {
+	for _, verb := range lifecycleHookVerbs {
+		if cmd, _, err := root.Find([]string{verb}); err == nil && cmd != root {
+			attachLifecycleHooks(cmd, verb)
+		}
+	}
+}
+
+func attachLifecycleHooks(cmd *cobra.Command, verb string) {
+	for _, sub := range cmd.Commands() {
+		attachLifecycleHooks(sub, verb)
+	}
+	if cmd.RunE == nil || cmd.HasSubCommands() {
+		return
+	}
+	if cmd.Flags().Lookup("no-hooks") == nil {
+		cmd.Flags().Bool("no-hooks", false, fmt.Sprintf("skip pre-%s and post-%s hooks", verb, verb))
+	}
+
+	run := cmd.RunE
+	cmd.RunE = func(c *cobra.Command, args []string) error {
+		if skip, _ := c.Flags().GetBool("no-hooks"); skip {
+			return run(c, args)
+		}
+		event := newHookEvent(c, args)
+		if err := runPreHook("pre-"+verb, event); err != nil {
+			return err
+		}
+		if err := run(c, args); err != nil {
+			return err
+		}
+		if !dryRun {
+			runPostHook("post-"+verb, event)
+		}
+		return nil
+	}
+}
+
+// newHookEvent describes the running command for a lifecycle hook
+func newHookEvent(cmd *cobra.Command, args []string) hookEvent {
+	event := hookEvent{
+		Command:      strings.Join(append([]string{"dtctl"}, audit.RedactArgs(activeCommandArgs)...), " "),
+		ResourceType: resourceFromCommandPath(cmd.CommandPath()),
+		Args:         append([]string{}, args...),
+		DryRun:       dryRun,
+	}
+	if f := cmd.Flags().Lookup("file"); f != nil && f.Value.Type() == "string" && f.Value.String() != "" {
+		event.File = f.Value.String()
+		if event.File != "-" {
+			if data, err := os.ReadFile(event.File); err == nil {
+				if jsonData, err := format.YAMLToJSON(data); err == nil && json.Valid(jsonData) {
+					event.Content = jsonData
+				}
+			}
+		}
+	}
+	return event
+}
+
+// runPreHook runs the hook of a pre-* event. A non-zero exit code vetoes the
+// operation with a hook.RejectedError.
+func runPreHook(event string, ev hookEvent) error {
+	command, result, err := runLifecycleHook(event, ev)
+	if err != nil || result == nil {
+		return err
+	}
+	if result.ExitCode != 0 {
+		return &hook.RejectedError{
+			Event:    event,
+			Command:  command,
+			ExitCode: result.ExitCode,
+			Stdout:   result.Stdout,
+			Stderr:   result.Stderr,
+		}
+	}
+	return nil
+}
+
+// runPostHook runs the hook of a post-* event. The operation already
+// succeeded, so failures are reported as warnings.
+func runPostHook(event string, ev hookEvent) {
+	_, result, err := runLifecycleHook(event, ev)
+	if err != nil {
+		output.PrintWarning("%v", err)
+		return
+	}
+	if result != nil && result.ExitCode != 0 {
+		output.PrintWarning("%s hook exited with code %d", event, result.ExitCode)
+	}
+}
+
+// runLifecycleHook runs the configured hook of an event with the event JSON
+// on stdin and <resource-type> <first-arg> as arguments. Hook output goes to
+// stderr so that stdout carries only the command's result. Returns a nil
+// result if no hook is configured. A config that cannot be loaded is an
+// error, so that a configured pre-hook is never skipped silently.
+func runLifecycleHook(event string, ev hookEvent) (string, *hook.Result, error) {
+	cfg, err := LoadConfig()
+	if err != nil {
+		return "", nil, fmt.Errorf("cannot determine the %s hook: %w", event, err)
+	}
+	command := cfg.GetHook(event)
+	if command == "" {
+		return "", nil, nil
+	}
+
+	ev.Event = event
+	ev.Context = cfg.CurrentContext
+	if ctx, err := cfg.CurrentContextObj(); err == nil {
+		ev.Environment = ctx.Environment
+	}
+	stdin, err := json.Marshal(ev)
+	if err != nil {
+		return command, nil, fmt.Errorf("failed to marshal %s hook input: %w", event, err)
+	}
+
+	target := ev.File
+	if len(ev.Args) > 0 {
+		target = ev.Args[0]
+	}
+	result, err := hook.Run(context.Background(), event, command, []string{ev.ResourceType, target}, stdin)
+	if err != nil {
+		return command, nil, err
+	}
+	if result.Stdout != "" {
+		fmt.Fprint(os.Stderr, result.Stdout)
+	}
+	if result.Stderr != "" {
+		fmt.Fprint(os.Stderr, result.Stderr)
+	}
+	return command, result, nil
+}
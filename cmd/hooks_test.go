@@ -0,0 +1,145 @@
+package cmd
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/spf13/cobra"
+
+	"github.com/dynatrace-oss/dtctl/pkg/config"
+	"github.com/dynatrace-oss/dtctl/pkg/hook"
+)
+
+// withTestHooks writes a config with the given context hooks and returns a
+// command tree 'dtctl delete widget' wrapped with lifecycle hooks, and a
+// pointer to whether the command ran.
+func withTestHooks(t *testing.T, hooks config.Hooks) (*cobra.Command, *bool) {
+	t.Helper()
+	cfg := config.NewConfig()
+	cfg.SetContext("test", "https://test.example.invalid", "test-token")
+	cfg.CurrentContext = "test"
+	cfg.Contexts[0].Context.Hooks = hooks
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	if err := cfg.SaveTo(path); err != nil {
+		t.Fatal(err)
+	}
+	oldCfgFile, oldArgs := cfgFile, activeCommandArgs
+	cfgFile = path
+	activeCommandArgs = []string{"delete", "widget", "w-1"}
+	t.Cleanup(func() { cfgFile, activeCommandArgs = oldCfgFile, oldArgs })
+
+	ran := false
+	root := &cobra.Command{Use: "dtctl"}
+	deleteCmd := &cobra.Command{Use: "delete"}
+	deleteCmd.AddCommand(&cobra.Command{
+		Use: "widget",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ran = true
+			return nil
+		},
+	})
+	root.AddCommand(deleteCmd)
+	setupLifecycleHooks(root)
+	return root, &ran
+}
+
+// hookScript writes a bash hook that copies its stdin to out and exits with code
+func hookScript(t *testing.T, out string, code string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "hook.sh")
+	body := "#!/usr/bin/env bash\ncat > " + filepath.ToSlash(out) + "\nexit " + code + "\n"
+	if err := os.WriteFile(path, []byte(body), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	return "bash " + filepath.ToSlash(path)
+}
+
+func TestLifecycleHooks_PreDeleteVeto(t *testing.T) {
+	out := filepath.Join(t.TempDir(), "event.json")
+	root, ran := withTestHooks(t, config.Hooks{PreDelete: hookScript(t, out, "2")})
+	root.SetArgs([]string{"delete", "widget", "w-1"})
+
+	err := root.Execute()
+	var rejected *hook.RejectedError
+	if !errors.As(err, &rejected) || rejected.Event != "pre-delete" || rejected.ExitCode != 2 {
+		t.Fatalf("err = %v, want pre-delete RejectedError with exit code 2", err)
+	}
+	if *ran {
+		t.Error("command ran although the pre-delete hook rejected it")
+	}
+
+	data, err := os.ReadFile(out)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var ev hookEvent
+	if err := json.Unmarshal(data, &ev); err != nil {
+		t.Fatal(err)
+	}
+	if ev.Event != "pre-delete" || ev.Context != "test" || ev.ResourceType != "widget" || len(ev.Args) != 1 || ev.Args[0] != "w-1" {
+		t.Errorf("event = %+v", ev)
+	}
+	if ev.Command != "dtctl delete widget w-1" {
+		t.Errorf("command = %q", ev.Command)
+	}
+}
+
+func TestLifecycleHooks_PostDeleteRunsAfterSuccess(t *testing.T) {
+	out := filepath.Join(t.TempDir(), "event.json")
+	root, ran := withTestHooks(t, config.Hooks{PostDelete: hookScript(t, out, "1")})
+	root.SetArgs([]string{"delete", "widget", "w-1"})
+
+	// A failing post-delete hook is only a warning.
+	if err := root.Execute(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !*ran {
+		t.Error("command did not run")
+	}
+	if _, err := os.Stat(out); err != nil {
+		t.Errorf("post-delete hook did not run: %v", err)
+	}
+}
+
+func TestLifecycleHooks_UnreadableConfig(t *testing.T) {
+	root, ran := withTestHooks(t, config.Hooks{})
+	if err := os.WriteFile(cfgFile, []byte("contexts: [unclosed\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	root.SetArgs([]string{"delete", "widget", "w-1"})
+
+	// The pre-delete hook cannot be determined, so the command must not run.
+	if err := root.Execute(); err == nil || !strings.Contains(err.Error(), "pre-delete hook") {
+		t.Fatalf("err = %v, want pre-delete hook error", err)
+	}
+	if *ran {
+		t.Error("command ran although its pre-delete hook could not be determined")
+	}
+}
+
+func TestLifecycleHooks_NoHooksFlag(t *testing.T) {
+	out := filepath.Join(t.TempDir(), "event.json")
+	root, ran := withTestHooks(t, config.Hooks{PreDelete: hookScript(t, out, "1")})
+	root.SetArgs([]string{"delete", "widget", "w-1", "--no-hooks"})
+
+	if err := root.Execute(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !*ran {
+		t.Error("command did not run")
+	}
+	if _, err := os.Stat(out); !os.IsNotExist(err) {
+		t.Error("pre-delete hook ran despite --no-hooks")
+	}
+}
+
+func TestErrorToDetail_LifecycleHookRejected(t *testing.T) {
+	detail := errorToDetail(&hook.RejectedError{Event: "pre-delete", Command: "policy.sh", ExitCode: 1})
+	if detail.Code != "hook_rejected" || detail.Message != "pre-delete hook rejected the operation" {
+		t.Errorf("detail = %+v", detail)
+	}
+}
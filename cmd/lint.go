@@ -0,0 +1,32 @@
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+)
+
+// lintCmd represents the lint command
+var lintCmd = &cobra.Command{
+	Use:   "lint",
+	Short: "Check resource definitions for problems and bad practices",
+	Long: `Check resource definitions for problems and bad practices.
+
+Unlike verify, which checks that a resource is valid, lint also reports
+constructs that are valid but likely wrong, slow or outdated. Findings have a
+severity (error, warning or info); --fail-on sets the severity that fails the
+command.
+
+Supported resources:
+  dashboard`,
+	Example: `  # Lint a dashboard file
+  dtctl lint dashboard -f dashboard.yaml
+
+  # Also verify every query with the DQL verify API, fail on warnings
+  dtctl lint dashboard -f dashboard.yaml --verify --fail-on warning`,
+	RunE: requireSubcommand,
+}
+
+func init() {
+	rootCmd.AddCommand(lintCmd)
+
+	lintCmd.AddCommand(lintDashboardCmd)
+}
@@ -0,0 +1,293 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/dynatrace-oss/dtctl/pkg/exec"
+	"github.com/dynatrace-oss/dtctl/pkg/resources/dashboard"
+	"github.com/dynatrace-oss/dtctl/pkg/util/format"
+)
+
+// lintDashboardCmd represents the lint dashboard subcommand
+var lintDashboardCmd = &cobra.Command{
+	Use:     "dashboard -f <file>",
+	Aliases: []string{"dashboards", "dash", "db"},
+	Short:   "Check the tiles and variables of a dashboard",
+	Long: `Check the tiles and variables of a dashboard file for problems.
+
+The file may be a dashboard as returned by 'get dashboard -o yaml' (with
+'content') or the dashboard content itself (with 'tiles'), in YAML or JSON.
+
+Rules:
+  dql-syntax          error    query cannot be parsed (unbalanced brackets,
+                               unterminated strings, empty pipeline stages,
+                               no data source command)
+  empty-query         error    data tile without a query
+  undefined-variable  error    query references a variable that is not defined
+  dql-verify          (varies) problem reported by the DQL verify API (--verify)
+  duplicate-tile      warning  tile repeats the query and visualization of
+                               another tile
+  unused-variable     warning  variable is not used by any tile or variable
+  timeframe-override  warning  query sets from:, to: or timeframe:, so the
+                               dashboard timeframe selector has no effect
+  unbounded-query     warning  fetch without summarize, makeTimeseries or limit
+  large-scan          warning  fetch disables or raises the scan limit above
+                               500 GB, or reads more than 35 days
+  legacy-dql          warning  classic metric keys (builtin:...) or
+                               classicEntitySelector() in DQL
+  legacy-tile         warning  tile in the Dashboards Classic format
+  unknown-command     info     command not known to dtctl (may be new)
+
+Queries are checked locally. With --verify, every query is also sent to the
+DQL verify API, with variables replaced by their default values; this needs a
+context but runs no queries.
+
+The lint command returns different exit codes based on the result:
+  0 - No findings at or above the --fail-on severity
+  1 - At least one finding at or above the --fail-on severity
+
+Examples:
+  # Lint a dashboard file
+  dtctl lint dashboard -f dashboard.yaml
+
+  # Lint a dashboard of the environment
+  dtctl get dashboard <id> -o yaml | dtctl lint dashboard -f -
+
+  # Verify all queries with the API and fail on warnings (for CI)
+  dtctl lint dashboard -f dashboard.yaml --verify --fail-on warning -o json
+
+  # Ignore rules
+  dtctl lint dashboard -f dashboard.yaml --ignore unbounded-query,unknown-command
+`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		file, _ := cmd.Flags().GetString("file")
+		verify, _ := cmd.Flags().GetBool("verify")
+		failOn, _ := cmd.Flags().GetString("fail-on")
+		ignore, _ := cmd.Flags().GetStringSlice("ignore")
+
+		threshold := dashboard.SeverityRank(failOn)
+		if threshold == 0 && failOn != "none" {
+			return fmt.Errorf("invalid --fail-on %q: must be error, warning, info or none", failOn)
+		}
+
+		var (
+			data []byte
+			err  error
+		)
+		if file == "-" {
+			data, err = io.ReadAll(os.Stdin)
+		} else {
+			data, err = os.ReadFile(file)
+		}
+		if err != nil {
+			return fmt.Errorf("failed to read dashboard file: %w", err)
+		}
+
+		d, err := parseDashboardFile(data)
+		if err != nil {
+			return err
+		}
+
+		findings := dashboard.Lint(d)
+		if verify {
+			_, c, err := SetupClient()
+			if err != nil {
+				return err
+			}
+			verified, err := verifyDashboardQueries(exec.NewDQLExecutor(c), d)
+			if err != nil {
+				return err
+			}
+			findings = append(findings, verified...)
+		}
+		findings = filterFindings(findings, ignore)
+
+		failing := 0
+		for _, f := range findings {
+			if threshold > 0 && dashboard.SeverityRank(f.Severity) >= threshold {
+				failing++
+			}
+		}
+
+		if agentMode || (outputFormat != "" && outputFormat != "table" && outputFormat != "wide") {
+			printer := NewPrinter()
+			if ap := enrichAgent(printer, "lint", "dashboard"); ap != nil {
+				ap.SetTotal(len(findings))
+				if failing > 0 {
+					ap.SetWarnings([]string{fmt.Sprintf("%d finding(s) at or above severity %s", failing, failOn)})
+				}
+			}
+			if err := printer.PrintList(findings); err != nil {
+				return err
+			}
+		} else {
+			printLintFindingsHuman(findings, len(d.Tiles))
+		}
+
+		if failing > 0 {
+			os.Exit(1)
+		}
+		return nil
+	},
+}
+
+// parseDashboardFile reads a dashboard document or dashboard content from
+// YAML or JSON
+func parseDashboardFile(data []byte) (*dashboard.Dashboard, error) {
+	jsonData, err := format.ValidateAndConvert(data)
+	if err != nil {
+		return nil, fmt.Errorf("invalid file format: %w", err)
+	}
+	var doc map[string]any
+	if err := json.Unmarshal(jsonData, &doc); err != nil {
+		return nil, fmt.Errorf("failed to parse dashboard: %w", err)
+	}
+	content, _, _, warnings := extractDocumentContent(doc, "dashboard")
+	d, err := dashboard.Parse(content)
+	if err != nil {
+		return nil, err
+	}
+	if len(d.Tiles) == 0 {
+		msg := "no tiles found in the dashboard"
+		if len(warnings) > 0 {
+			msg += ": " + strings.Join(warnings, "; ")
+		}
+		return nil, fmt.Errorf("%s", msg)
+	}
+	return d, nil
+}
+
+// verifyDashboardQueries sends the queries of all data tiles and query
+// variables to the DQL verify API. Variables are replaced by their default
+// values (or an empty string).
+func verifyDashboardQueries(executor *exec.DQLExecutor, d *dashboard.Dashboard) ([]dashboard.Finding, error) {
+	values, err := d.ResolveVariables(nil, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var findings []dashboard.Finding
+	check := func(base dashboard.Finding, label, query string) error {
+		if strings.TrimSpace(query) == "" {
+			return nil
+		}
+		if _, err := dashboard.ParseQuery(query); err != nil {
+			return nil // already reported as dql-syntax
+		}
+		query = dashboard.SubstituteVariables(query, values)
+		result, err := executor.VerifyQuery(query, exec.DQLVerifyOptions{ClientContext: "lint-dashboard"})
+		if err != nil {
+			return fmt.Errorf("%s: %w", label, err)
+		}
+		reported := false
+		for _, n := range result.Notifications {
+			f := base
+			f.Rule, f.Query = dashboard.RuleDQLVerify, query
+			f.Severity = dashboard.SeverityInfo
+			switch strings.ToUpper(n.Severity) {
+			case "ERROR":
+				f.Severity = dashboard.SeverityError
+				reported = true
+			case "WARN", "WARNING":
+				f.Severity = dashboard.SeverityWarning
+			}
+			f.Message = fmt.Sprintf("%s: %s", label, n.Message)
+			if n.SyntaxPosition != nil && n.SyntaxPosition.Start != nil {
+				f.Line, f.Column = n.SyntaxPosition.Start.Line, n.SyntaxPosition.Start.Column
+			}
+			findings = append(findings, f)
+		}
+		if !result.Valid && !reported {
+			f := base
+			f.Severity, f.Rule, f.Query = dashboard.SeverityError, dashboard.RuleDQLVerify, query
+			f.Message = label + ": query is not valid"
+			findings = append(findings, f)
+		}
+		return nil
+	}
+
+	for _, v := range d.Variables {
+		if v.Type == "query" {
+			if err := check(dashboard.Finding{Variable: v.Key}, "variable "+v.Key, v.Input); err != nil {
+				return nil, err
+			}
+		}
+	}
+	for _, t := range d.Tiles {
+		if t.IsData() {
+			if err := check(dashboard.Finding{Tile: t.ID}, t.Label(), t.Query); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return findings, nil
+}
+
+// filterFindings drops findings of ignored rules
+func filterFindings(findings []dashboard.Finding, ignore []string) []dashboard.Finding {
+	if len(ignore) == 0 {
+		return findings
+	}
+	ignored := map[string]bool{}
+	for _, rule := range ignore {
+		ignored[strings.TrimSpace(rule)] = true
+	}
+	kept := findings[:0]
+	for _, f := range findings {
+		if !ignored[f.Rule] {
+			kept = append(kept, f)
+		}
+	}
+	return kept
+}
+
+// printLintFindingsHuman prints lint findings in human-readable format
+func printLintFindingsHuman(findings []dashboard.Finding, tiles int) {
+	useColor := isStderrTerminal()
+	counts := map[string]int{}
+	for _, f := range findings {
+		counts[f.Severity]++
+		severity := strings.ToUpper(f.Severity)
+		if useColor {
+			color := colorCyan
+			switch f.Severity {
+			case dashboard.SeverityError:
+				color = colorRed
+			case dashboard.SeverityWarning:
+				color = colorYellow
+			}
+			severity = color + severity + colorReset
+		}
+		fmt.Fprintf(os.Stderr, "%s [%s] %s\n", severity, f.Rule, f.Message)
+		if f.Query != "" && f.Line > 0 {
+			pos := &exec.SyntaxPosition{Start: &exec.Position{Line: f.Line, Column: f.Column}}
+			_ = printSyntaxError(f.Query, pos, useColor)
+		}
+	}
+
+	if len(findings) == 0 {
+		mark := "✔"
+		if useColor {
+			mark = colorGreen + mark + colorReset
+		}
+		fmt.Fprintf(os.Stderr, "%s %d tile(s) checked, no findings\n", mark, tiles)
+		return
+	}
+	fmt.Fprintf(os.Stderr, "\n%d tile(s) checked: %d error(s), %d warning(s), %d info\n",
+		tiles, counts[dashboard.SeverityError], counts[dashboard.SeverityWarning], counts[dashboard.SeverityInfo])
+}
+
+func init() {
+	lintDashboardCmd.Flags().StringP("file", "f", "", "dashboard file (use '-' for stdin)")
+	lintDashboardCmd.Flags().Bool("verify", false, "also verify every query with the DQL verify API")
+	lintDashboardCmd.Flags().String("fail-on", dashboard.SeverityError, "exit with code 1 on findings of this severity or higher: error, warning, info or none")
+	lintDashboardCmd.Flags().StringSlice("ignore", nil, "rules to skip, e.g. unbounded-query,unknown-command")
+	_ = lintDashboardCmd.MarkFlagRequired("file")
+}
@@ -0,0 +1,72 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/dynatrace-oss/dtctl/pkg/resources/dashboard"
+)
+
+func TestParseDashboardFile(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		tiles   int
+		wantErr bool
+	}{
+		{
+			name: "document with content",
+			input: `name: Ops
+type: dashboard
+content:
+  tiles:
+    "0": {type: data, query: "fetch logs | limit 10"}
+    "1": {type: markdown, content: "# Ops"}
+`,
+			tiles: 2,
+		},
+		{
+			name:  "bare content as JSON",
+			input: `{"tiles": {"0": {"type": "data", "query": "fetch logs | limit 10"}}}`,
+			tiles: 1,
+		},
+		{
+			name:    "no tiles",
+			input:   "name: Ops\ntype: dashboard\n",
+			wantErr: true,
+		},
+		{
+			name:    "invalid format",
+			input:   "tiles: [",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d, err := parseDashboardFile([]byte(tt.input))
+			if tt.wantErr {
+				if err == nil {
+					t.Fatal("expected error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if len(d.Tiles) != tt.tiles {
+				t.Errorf("tiles = %d, want %d", len(d.Tiles), tt.tiles)
+			}
+		})
+	}
+}
+
+func TestFilterFindings(t *testing.T) {
+	findings := []dashboard.Finding{
+		{Rule: dashboard.RuleUnboundedQuery},
+		{Rule: dashboard.RuleDQLSyntax},
+		{Rule: dashboard.RuleUnknownCommand},
+	}
+	got := filterFindings(findings, []string{"unbounded-query", " unknown-command"})
+	if len(got) != 1 || got[0].Rule != dashboard.RuleDQLSyntax {
+		t.Errorf("filterFindings() = %+v, want only dql-syntax", got)
+	}
+}
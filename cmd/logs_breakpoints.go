@@ -0,0 +1,420 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+
+	"github.com/dynatrace-oss/dtctl/pkg/exec"
+	"github.com/dynatrace-oss/dtctl/pkg/output"
+)
+
+// snapshotQueryOverlap is subtracted from the newest seen timestamp when
+// building the next poll window, so snapshots that are ingested slightly out
+// of order are still picked up. Duplicates are dropped by snapshot.id.
+const snapshotQueryOverlap = 30 * time.Second
+
+// snapshotQueryLimit caps the number of records fetched per poll.
+const snapshotQueryLimit = 100
+
+// logsBreakpointCmd prints (and optionally follows) Live Debugger snapshots
+var logsBreakpointCmd = &cobra.Command{
+	Use:     "breakpoint [id|filename:line]",
+	Aliases: []string{"breakpoints", "bp"},
+	Short:   "Print decoded Live Debugger snapshots for a breakpoint (experimental)",
+	Long: `Print decoded Live Debugger snapshots captured by a breakpoint.
+
+Without an argument, snapshots for all breakpoints in the current workspace are
+shown. A breakpoint can be identified by its ID or by its source location
+(filename:line). Snapshots are decoded locally and printed with the hit
+location, captured local variables and the stack frames.
+
+With --follow, dtctl keeps polling for new snapshots and prints them as they
+arrive, until --max-hits snapshots were printed, --timeout elapses, or the
+command is interrupted.
+
+Examples:
+  # Show snapshots captured in the last hour for a breakpoint
+  dtctl logs breakpoint OrderController.java:306
+
+  # Tail new snapshots for all breakpoints in the workspace
+  dtctl logs breakpoint --follow
+
+  # Stop after 5 hits or 10 minutes, whichever comes first
+  dtctl logs breakpoint dtctl-rule-123 -f --max-hits 5 --timeout 10m
+
+  # Stream full decoded snapshots as JSON lines
+  dtctl logs breakpoint OrderController.java:306 -f --decode full -o json
+`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		identifier := ""
+		if len(args) > 0 {
+			identifier = args[0]
+		}
+
+		opts, err := snapshotLogOptionsFromFlags(cmd)
+		if err != nil {
+			return err
+		}
+
+		return runLogsBreakpointWithDeps(identifier, opts, defaultLiveDebuggerDeps())
+	},
+}
+
+// snapshotTarget is a source location whose snapshots should be shown.
+type snapshotTarget struct {
+	Filename string
+	Line     int
+}
+
+// snapshotLogOptions configures a logs breakpoint run.
+type snapshotLogOptions struct {
+	Follow   bool
+	Since    time.Duration
+	Interval time.Duration
+	Timeout  time.Duration
+	MaxHits  int
+	Simplify bool
+}
+
+func snapshotLogOptionsFromFlags(cmd *cobra.Command) (snapshotLogOptions, error) {
+	follow, _ := cmd.Flags().GetBool("follow")
+	since, _ := cmd.Flags().GetDuration("since")
+	interval, _ := cmd.Flags().GetDuration("interval")
+	timeout, _ := cmd.Flags().GetDuration("timeout")
+	maxHits, _ := cmd.Flags().GetInt("max-hits")
+	decode, _ := cmd.Flags().GetString("decode")
+
+	opts := snapshotLogOptions{
+		Follow:   follow,
+		Since:    since,
+		Interval: interval,
+		Timeout:  timeout,
+		MaxHits:  maxHits,
+	}
+
+	simplify, err := parseSnapshotDecodeMode(decode)
+	if err != nil {
+		return opts, err
+	}
+	opts.Simplify = simplify
+	if maxHits < 0 {
+		return opts, fmt.Errorf("--max-hits must not be negative")
+	}
+	if since <= 0 {
+		return opts, fmt.Errorf("--since must be a positive duration")
+	}
+	if opts.Interval < time.Second {
+		opts.Interval = time.Second
+	}
+	return opts, nil
+}
+
+// parseSnapshotDecodeMode maps a --decode value to the simplify argument of
+// output.DecodeSnapshotRecords.
+func parseSnapshotDecodeMode(decode string) (bool, error) {
+	switch decode {
+	case "", "simplified":
+		return true, nil
+	case "full":
+		return false, nil
+	default:
+		return false, fmt.Errorf("unsupported --decode value %q (use \"simplified\" or \"full\")", decode)
+	}
+}
+
+func runLogsBreakpointWithDeps(identifier string, opts snapshotLogOptions, deps liveDebuggerDeps) error {
+	cfg, err := deps.loadConfig()
+	if err != nil {
+		return err
+	}
+
+	ctxObj, err := cfg.CurrentContextObj()
+	if err != nil {
+		return err
+	}
+
+	c, err := deps.newClient(cfg)
+	if err != nil {
+		return err
+	}
+
+	handler, err := deps.newHandler(c, ctxObj.Environment)
+	if err != nil {
+		return err
+	}
+
+	_, workspaceID, err := deps.getOrCreateWorkspace(handler, currentProjectPath())
+	if err != nil {
+		return err
+	}
+
+	workspaceRulesResp, err := deps.getWorkspaceRules(handler, workspaceID)
+	if err != nil {
+		return err
+	}
+
+	rows, err := extractBreakpointRows(workspaceRulesResp)
+	if err != nil {
+		return err
+	}
+
+	targets, err := resolveSnapshotTargets(rows, identifier)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	if opts.Timeout > 0 {
+		ctx, cancel = context.WithTimeout(ctx, opts.Timeout)
+		defer cancel()
+	}
+
+	sigCh := make(chan os.Signal, 1)
+	signal.Notify(sigCh, os.Interrupt, syscall.SIGTERM)
+	defer signal.Stop(sigCh)
+	go func() {
+		select {
+		case <-sigCh:
+			cancel()
+		case <-ctx.Done():
+		}
+	}()
+
+	executor := NewDQLExecutorFromConfig(cfg, c)
+	fetch := func(ctx context.Context, query string) ([]map[string]interface{}, error) {
+		resp, err := executor.ExecuteQueryWithContext(ctx, query, exec.DQLExecuteOptions{})
+		if err != nil || resp == nil {
+			return nil, err
+		}
+		if resp.Result != nil {
+			return resp.Result.Records, nil
+		}
+		return resp.Records, nil
+	}
+
+	emitter := newSnapshotEmitter()
+	if err := streamSnapshots(ctx, fetch, targets, opts, time.Now(), emitter.emit); err != nil {
+		return err
+	}
+	return emitter.flush()
+}
+
+// resolveSnapshotTargets maps a breakpoint identifier to source locations.
+// An empty identifier selects every breakpoint in the workspace.
+func resolveSnapshotTargets(rows []breakpointRow, identifier string) ([]snapshotTarget, error) {
+	var selected []breakpointRow
+	switch {
+	case identifier == "":
+		selected = rows
+		if len(selected) == 0 {
+			return nil, fmt.Errorf("no breakpoints in the current workspace")
+		}
+	case strings.Contains(identifier, ":"):
+		fileName, line, err := parseBreakpoint(identifier)
+		if err != nil {
+			return nil, err
+		}
+		// Source locations are accepted even without a matching rule, so
+		// snapshots of a just-deleted breakpoint can still be inspected.
+		return []snapshotTarget{{Filename: fileName, Line: line}}, nil
+	default:
+		row, ok := findBreakpointRowByID(rows, identifier)
+		if !ok {
+			return nil, fmt.Errorf("breakpoint %q not found in the current workspace", identifier)
+		}
+		selected = []breakpointRow{row}
+	}
+
+	seen := make(map[snapshotTarget]bool)
+	targets := make([]snapshotTarget, 0, len(selected))
+	for _, row := range selected {
+		t := snapshotTarget{Filename: row.Filename, Line: row.Line}
+		if seen[t] {
+			continue
+		}
+		seen[t] = true
+		targets = append(targets, t)
+	}
+	return targets, nil
+}
+
+// buildSnapshotQuery builds the DQL that fetches snapshots for the targets,
+// oldest first, starting at from. Breakpoints are registered by file name
+// while code.filepath carries the full path, hence the endsWith match.
+func buildSnapshotQuery(targets []snapshotTarget, from time.Time, limit int) string {
+	conditions := make([]string, 0, len(targets))
+	for _, t := range targets {
+		conditions = append(conditions, fmt.Sprintf("(endsWith(code.filepath, %s) and code.line.number == %d)",
+			dqlString(t.Filename), t.Line))
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "fetch application.snapshots, from: %s", dqlString(from.UTC().Format(time.RFC3339Nano)))
+	if len(conditions) > 0 {
+		fmt.Fprintf(&b, "\n| filter %s", strings.Join(conditions, " or "))
+	}
+	b.WriteString("\n| sort timestamp asc")
+	if limit > 0 {
+		fmt.Fprintf(&b, "\n| limit %d", limit)
+	}
+	return b.String()
+}
+
+// dqlString quotes s as a DQL string literal.
+func dqlString(s string) string {
+	s = strings.ReplaceAll(s, `\`, `\\`)
+	s = strings.ReplaceAll(s, `"`, `\"`)
+	return `"` + s + `"`
+}
+
+// snapshotFetcher runs a DQL query and returns the raw records.
+type snapshotFetcher func(ctx context.Context, query string) ([]map[string]interface{}, error)
+
+// streamSnapshots polls for snapshots and passes every new decoded record to
+// emit. Without opts.Follow it performs a single fetch. It returns nil when
+// the context is cancelled (timeout or interrupt) or opts.MaxHits is reached.
+func streamSnapshots(ctx context.Context, fetch snapshotFetcher, targets []snapshotTarget, opts snapshotLogOptions, now time.Time, emit func(map[string]interface{}) error) error {
+	seen := make(map[string]bool)
+	from := now.Add(-opts.Since)
+	hits := 0
+
+	for {
+		records, err := fetch(ctx, buildSnapshotQuery(targets, from, snapshotQueryLimit))
+		if ctx.Err() != nil {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		var fresh []map[string]interface{}
+		for _, rec := range records {
+			key := snapshotRecordKey(rec)
+			if seen[key] {
+				continue
+			}
+			seen[key] = true
+			fresh = append(fresh, rec)
+
+			if ts, ok := snapshotRecordTime(rec); ok && ts.Add(-snapshotQueryOverlap).After(from) {
+				from = ts.Add(-snapshotQueryOverlap)
+			}
+		}
+
+		for _, rec := range output.DecodeSnapshotRecords(fresh, opts.Simplify) {
+			if err := emit(rec); err != nil {
+				return err
+			}
+			hits++
+			if opts.MaxHits > 0 && hits >= opts.MaxHits {
+				return nil
+			}
+		}
+
+		if !opts.Follow {
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-time.After(opts.Interval):
+		}
+	}
+}
+
+// snapshotRecordKey identifies a snapshot record for de-duplication across polls.
+func snapshotRecordKey(rec map[string]interface{}) string {
+	if id, ok := rec["snapshot.id"].(string); ok && id != "" {
+		return id
+	}
+	data, _ := json.Marshal(rec)
+	return string(data)
+}
+
+func snapshotRecordTime(rec map[string]interface{}) (time.Time, bool) {
+	raw, ok := rec["timestamp"].(string)
+	if !ok {
+		return time.Time{}, false
+	}
+	ts, err := time.Parse(time.RFC3339Nano, raw)
+	if err != nil {
+		return time.Time{}, false
+	}
+	return ts, true
+}
+
+// snapshotEmitter prints decoded snapshots in the selected output format.
+// Human-readable and line-oriented formats are written as each snapshot
+// arrives; agent mode buffers everything into a single envelope.
+type snapshotEmitter struct {
+	buffered []map[string]interface{}
+}
+
+func newSnapshotEmitter() *snapshotEmitter {
+	return &snapshotEmitter{}
+}
+
+func (e *snapshotEmitter) emit(rec map[string]interface{}) error {
+	if agentMode {
+		e.buffered = append(e.buffered, rec)
+		return nil
+	}
+
+	switch outputFormat {
+	case "json":
+		data, err := json.Marshal(rec)
+		if err != nil {
+			return err
+		}
+		_, err = fmt.Fprintln(os.Stdout, string(data))
+		return err
+	case "yaml", "yml":
+		data, err := yaml.Marshal(rec)
+		if err != nil {
+			return err
+		}
+		_, err = fmt.Fprintf(os.Stdout, "---\n%s", data)
+		return err
+	default:
+		return output.PrintSnapshotDetail(os.Stdout, rec)
+	}
+}
+
+func (e *snapshotEmitter) flush() error {
+	if !agentMode {
+		return nil
+	}
+	printer := NewPrinter()
+	if ap := enrichAgent(printer, "logs", "breakpoint"); ap != nil {
+		ap.SetTotal(len(e.buffered))
+	}
+	if e.buffered == nil {
+		e.buffered = []map[string]interface{}{}
+	}
+	return printer.PrintList(e.buffered)
+}
+
+func init() {
+	logsCmd.AddCommand(logsBreakpointCmd)
+	logsBreakpointCmd.Flags().BoolP("follow", "f", false, "Keep polling and print new snapshots as they arrive")
+	logsBreakpointCmd.Flags().Duration("since", time.Hour, "Only show snapshots newer than this duration")
+	logsBreakpointCmd.Flags().Duration("interval", 5*time.Second, "Polling interval in follow mode (minimum: 1s)")
+	logsBreakpointCmd.Flags().Duration("timeout", 0, "Stop following after this duration (0 = no timeout)")
+	logsBreakpointCmd.Flags().Int("max-hits", 0, "Stop after printing this many snapshots (0 = unlimited)")
+	logsBreakpointCmd.Flags().String("decode", "simplified", "Snapshot decoding: simplified|full")
+	_ = logsBreakpointCmd.RegisterFlagCompletionFunc("decode", func(_ *cobra.Command, _ []string, _ string) ([]string, cobra.ShellCompDirective) {
+		return []string{"simplified", "full"}, cobra.ShellCompDirectiveNoFileComp
+	})
+}
@@ -0,0 +1,143 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestBuildSnapshotQuery(t *testing.T) {
+	from := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	targets := []snapshotTarget{
+		{Filename: "OrderController.java", Line: 306},
+		{Filename: `Weird"Name.java`, Line: 7},
+	}
+
+	got := buildSnapshotQuery(targets, from, 50)
+
+	for _, want := range []string{
+		`fetch application.snapshots, from: "2026-01-02T03:04:05Z"`,
+		`(endsWith(code.filepath, "OrderController.java") and code.line.number == 306) or (endsWith(code.filepath, "Weird\"Name.java") and code.line.number == 7)`,
+		"| sort timestamp asc",
+		"| limit 50",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("query missing %q:\n%s", want, got)
+		}
+	}
+}
+
+func TestResolveSnapshotTargets(t *testing.T) {
+	rows := []breakpointRow{
+		{ID: "rule-1", Filename: "A.java", Line: 10},
+		{ID: "rule-2", Filename: "A.java", Line: 10},
+		{ID: "rule-3", Filename: "B.java", Line: 20},
+	}
+
+	all, err := resolveSnapshotTargets(rows, "")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(all) != 2 {
+		t.Errorf("expected duplicate locations to collapse to 2 targets, got %v", all)
+	}
+
+	byID, err := resolveSnapshotTargets(rows, "rule-3")
+	if err != nil || len(byID) != 1 || byID[0].Filename != "B.java" || byID[0].Line != 20 {
+		t.Errorf("unexpected ID resolution: %v, %v", byID, err)
+	}
+
+	byLocation, err := resolveSnapshotTargets(nil, "Gone.java:5")
+	if err != nil || len(byLocation) != 1 || byLocation[0].Line != 5 {
+		t.Errorf("unexpected location resolution: %v, %v", byLocation, err)
+	}
+
+	if _, err := resolveSnapshotTargets(rows, "missing"); err == nil {
+		t.Error("expected error for unknown breakpoint ID")
+	}
+	if _, err := resolveSnapshotTargets(nil, ""); err == nil {
+		t.Error("expected error for empty workspace")
+	}
+}
+
+func TestStreamSnapshots_SingleFetch(t *testing.T) {
+	var queries []string
+	fetch := func(_ context.Context, query string) ([]map[string]interface{}, error) {
+		queries = append(queries, query)
+		return []map[string]interface{}{
+			{"snapshot.id": "s1", "timestamp": "2026-01-02T03:04:05Z"},
+			{"snapshot.id": "s1", "timestamp": "2026-01-02T03:04:05Z"},
+			{"snapshot.id": "s2", "timestamp": "2026-01-02T03:04:06Z"},
+		}, nil
+	}
+
+	var got []string
+	emit := func(rec map[string]interface{}) error {
+		got = append(got, rec["snapshot.id"].(string))
+		return nil
+	}
+
+	opts := snapshotLogOptions{Since: time.Hour, Interval: time.Second, Simplify: true}
+	if err := streamSnapshots(context.Background(), fetch, nil, opts, time.Now(), emit); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(queries) != 1 {
+		t.Errorf("expected a single fetch without --follow, got %d", len(queries))
+	}
+	if strings.Join(got, ",") != "s1,s2" {
+		t.Errorf("expected de-duplicated snapshots s1,s2, got %v", got)
+	}
+}
+
+func TestStreamSnapshots_FollowStopsAtMaxHits(t *testing.T) {
+	polls := 0
+	fetch := func(_ context.Context, _ string) ([]map[string]interface{}, error) {
+		polls++
+		// Each poll returns the previous snapshots again plus one new one.
+		var records []map[string]interface{}
+		for i := 1; i <= polls; i++ {
+			records = append(records, map[string]interface{}{"snapshot.id": string(rune('a' + i))})
+		}
+		return records, nil
+	}
+
+	hits := 0
+	emit := func(map[string]interface{}) error {
+		hits++
+		return nil
+	}
+
+	opts := snapshotLogOptions{Follow: true, Since: time.Hour, Interval: time.Millisecond, MaxHits: 3, Simplify: true}
+	if err := streamSnapshots(context.Background(), fetch, nil, opts, time.Now(), emit); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if hits != 3 || polls != 3 {
+		t.Errorf("expected 3 hits over 3 polls, got %d hits over %d polls", hits, polls)
+	}
+}
+
+func TestStreamSnapshots_TimeoutEndsCleanly(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	fetch := func(context.Context, string) ([]map[string]interface{}, error) {
+		return nil, nil
+	}
+	opts := snapshotLogOptions{Follow: true, Since: time.Hour, Interval: 5 * time.Millisecond}
+	if err := streamSnapshots(ctx, fetch, nil, opts, time.Now(), func(map[string]interface{}) error { return nil }); err != nil {
+		t.Errorf("expected timeout to end the stream without error, got %v", err)
+	}
+}
+
+func TestStreamSnapshots_PropagatesFetchError(t *testing.T) {
+	fetch := func(context.Context, string) ([]map[string]interface{}, error) {
+		return nil, errors.New("query failed")
+	}
+	opts := snapshotLogOptions{Since: time.Hour, Interval: time.Second}
+	err := streamSnapshots(context.Background(), fetch, nil, opts, time.Now(), func(map[string]interface{}) error { return nil })
+	if err == nil || err.Error() != "query failed" {
+		t.Errorf("expected fetch error, got %v", err)
+	}
+}
@@ -0,0 +1,32 @@
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+)
+
+// migrateCmd represents the migrate command
+var migrateCmd = &cobra.Command{
+	Use:   "migrate",
+	Short: "Migrate local resource files to the current API version",
+	Long: `Migrate local resource files to the current version of their definition.
+
+Resource files kept in version control go stale when the definition they were
+written for changes. migrate compares the files with the live definition,
+applies safe transformations automatically, rewrites the files and reports
+what has to be changed by hand. Nothing is changed in the environment.
+
+Supported resources:
+  settings`,
+	Example: `  # Migrate all settings files in a directory to the current schema versions
+  dtctl migrate settings -f settings/
+
+  # Report only, without rewriting files
+  dtctl migrate settings -f settings/ --dry-run`,
+	RunE: requireSubcommand,
+}
+
+func init() {
+	rootCmd.AddCommand(migrateCmd)
+
+	migrateCmd.AddCommand(migrateSettingsCmd)
+}
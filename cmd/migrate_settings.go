@@ -0,0 +1,493 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+
+	"github.com/dynatrace-oss/dtctl/pkg/output"
+	"github.com/dynatrace-oss/dtctl/pkg/resources/settings"
+)
+
+// settingsMigrationResult is the migration result of one settings object in a file
+type settingsMigrationResult struct {
+	File          string                     `json:"file" table:"FILE"`
+	Object        int                        `json:"object" table:"-"`
+	SchemaID      string                     `json:"schemaId" table:"SCHEMA_ID"`
+	FromVersion   string                     `json:"fromVersion,omitempty" table:"FROM"`
+	ToVersion     string                     `json:"toVersion,omitempty" table:"TO"`
+	Status        string                     `json:"status" table:"STATUS"`
+	SchemaChanges []settings.SchemaChange    `json:"schemaChanges,omitempty" table:"-"`
+	Applied       []string                   `json:"applied,omitempty" table:"-"`
+	FollowUps     []settings.ValidationError `json:"followUps,omitempty" table:"-"`
+	Note          string                     `json:"note,omitempty" table:"-"`
+	Error         string                     `json:"error,omitempty" table:"ERROR,wide"`
+}
+
+// Migration result statuses
+const (
+	migrationUpToDate       = "up-to-date"
+	migrationMigrated       = "migrated"
+	migrationNeedsAttention = "needs-attention"
+	migrationFailed         = "failed"
+)
+
+// migrateSettingsCmd migrates settings files to the current schema versions
+var migrateSettingsCmd = &cobra.Command{
+	Use:     "settings -f <file|dir>",
+	Aliases: []string{"setting"},
+	Short:   "Migrate settings files to the current schema versions",
+	Long: `Migrate settings objects stored in YAML or JSON files to the current version
+of their schema.
+
+-f takes a file or a directory, which is searched recursively for .yaml, .yml
+and .json files. Files may contain a settings object in the 'apply' format
+(schemaId, scope, schemaVersion, value) or a list of them, as written by
+'get settings -o yaml'. Other files are skipped.
+
+For every object, the schemaVersion in the file is compared with the live
+schema. Added, removed, renamed and changed properties between the two
+versions are reported (the old version is read from the schema cache or the
+API; without it renames cannot be detected and properties no longer in the
+schema are kept and reported as follow-ups). Safe transformations are applied
+automatically:
+  - values of renamed properties are moved to the new name
+  - properties no longer in the schema are dropped
+  - missing required properties with a default get the default
+
+Files with changes are rewritten with the new schemaVersion. Whatever the new
+version still rejects (validated as by 'verify settings') is listed as manual
+follow-up. Renames are detected heuristically; review the changes before
+applying the files.
+
+Examples:
+  # Migrate all settings files in a directory
+  dtctl migrate settings -f settings/
+
+  # Report only, without rewriting files
+  dtctl migrate settings -f settings/ --dry-run
+
+  # Structured report for CI
+  dtctl migrate settings -f settings/ --dry-run -o json
+`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		path, _ := cmd.Flags().GetString("file")
+
+		files, err := findSettingsFiles(path)
+		if err != nil {
+			return err
+		}
+
+		_, c, err := SetupClient()
+		if err != nil {
+			return err
+		}
+		cache := settingsSchemaCache()
+		m := &settingsMigrator{
+			handler: settings.NewHandler(c).WithSchemaCache(cache),
+			cache:   cache,
+			schemas: map[string]map[string]any{},
+			errs:    map[string]error{},
+		}
+
+		var results []settingsMigrationResult
+		var updated []string
+		single := len(files) == 1 && files[0] == path
+		for _, file := range files {
+			fileResults, changed, err := m.migrateFile(file, !single)
+			if err != nil {
+				if single {
+					return err
+				}
+				results = append(results, settingsMigrationResult{File: file, Status: migrationFailed, Error: err.Error()})
+				continue
+			}
+			results = append(results, fileResults...)
+			if changed {
+				updated = append(updated, file)
+			}
+		}
+		if len(results) == 0 {
+			return fmt.Errorf("no settings objects found in %s", path)
+		}
+
+		if agentMode || (outputFormat != "" && outputFormat != "table" && outputFormat != "wide") {
+			printer := NewPrinter()
+			if ap := enrichAgent(printer, "migrate", "settings"); ap != nil {
+				ap.SetTotal(len(results))
+				if n := countMigrations(results, migrationNeedsAttention); n > 0 {
+					ap.SetWarnings([]string{fmt.Sprintf("%d settings object(s) need manual follow-up", n)})
+				}
+			}
+			if err := printer.PrintList(results); err != nil {
+				return err
+			}
+		} else {
+			printSettingsMigrationsHuman(results)
+		}
+
+		switch {
+		case len(updated) == 0:
+			output.PrintInfo("No files to update")
+		case dryRun:
+			output.PrintInfo("Dry run: %d file(s) would be updated", len(updated))
+		default:
+			output.PrintSuccess("Updated %d file(s)", len(updated))
+		}
+
+		if n := countMigrations(results, migrationFailed); n > 0 {
+			return fmt.Errorf("%d settings object(s) or file(s) could not be migrated", n)
+		}
+		return nil
+	},
+}
+
+// settingsMigrator migrates settings files, fetching every schema version once
+type settingsMigrator struct {
+	handler *settings.Handler
+	cache   *settings.SchemaCache
+	schemas map[string]map[string]any
+	errs    map[string]error
+}
+
+// schema returns a schema version (empty for the live version) from the
+// cache or the API
+func (m *settingsMigrator) schema(schemaID, version string) (map[string]any, error) {
+	key := schemaID + "@" + version
+	if s, ok := m.schemas[key]; ok {
+		return s, nil
+	}
+	if err, ok := m.errs[key]; ok {
+		return nil, err
+	}
+
+	var (
+		s   map[string]any
+		err error
+	)
+	if version != "" {
+		s, err = m.cache.Load(schemaID, version)
+	}
+	if version == "" || err != nil {
+		s, err = m.handler.GetSchemaVersion(schemaID, version)
+	}
+	if err != nil {
+		m.errs[key] = err
+		return nil, err
+	}
+	m.schemas[key] = s
+	return s, nil
+}
+
+// migrateFile migrates the settings objects in one file and rewrites it
+// (unless in dry-run mode) if any object changed. With skipInvalid, files
+// that are not valid YAML or JSON (e.g. templates) are skipped with a warning.
+func (m *settingsMigrator) migrateFile(file string, skipInvalid bool) ([]settingsMigrationResult, bool, error) {
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to read %s: %w", file, err)
+	}
+	var doc yaml.Node
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		if skipInvalid {
+			output.PrintWarning("Skipping %s: %v", file, err)
+			return nil, false, nil
+		}
+		return nil, false, fmt.Errorf("failed to parse %s: %w", file, err)
+	}
+	objects := settingsObjectNodes(&doc)
+	if len(objects) == 0 {
+		return nil, false, nil
+	}
+
+	var results []settingsMigrationResult
+	changed := false
+	for i, obj := range objects {
+		r, objChanged := m.migrateObject(obj)
+		r.File, r.Object = file, i
+		results = append(results, r)
+		changed = changed || objChanged
+	}
+
+	if changed && !dryRun {
+		out, err := encodeSettingsFile(&doc, strings.EqualFold(filepath.Ext(file), ".json"))
+		if err != nil {
+			return nil, false, fmt.Errorf("failed to encode %s: %w", file, err)
+		}
+		if err := os.WriteFile(file, out, 0o644); err != nil {
+			return nil, false, fmt.Errorf("failed to write %s: %w", file, err)
+		}
+	}
+	return results, changed, nil
+}
+
+// migrateObject migrates one settings object node in place
+func (m *settingsMigrator) migrateObject(obj *yaml.Node) (settingsMigrationResult, bool) {
+	r := settingsMigrationResult{}
+	r.SchemaID = mappingScalar(obj, "schemaId")
+	if r.SchemaID == "" {
+		r.SchemaID = mappingScalar(obj, "schemaid")
+	}
+	r.FromVersion = mappingScalar(obj, "schemaVersion")
+
+	live, err := m.schema(r.SchemaID, "")
+	if err != nil {
+		r.Status, r.Error = migrationFailed, err.Error()
+		return r, false
+	}
+	r.ToVersion, _ = live["version"].(string)
+
+	var old map[string]any
+	switch {
+	case r.FromVersion == "":
+		r.Note = "no schemaVersion in the file; renames and removed properties cannot be detected"
+	case r.FromVersion == r.ToVersion:
+		old = live
+	default:
+		if old, err = m.schema(r.SchemaID, r.FromVersion); err != nil {
+			old = nil
+			r.Note = fmt.Sprintf("schema version %s is not available (%v); renames and removed properties cannot be detected", r.FromVersion, err)
+		} else {
+			r.SchemaChanges = settings.DiffSchemas(old, live)
+		}
+	}
+
+	valueNode := mappingValue(obj, "value")
+	var value map[string]any
+	if err := decodeJSONNode(valueNode, &value); err != nil {
+		r.Status, r.Error = migrationFailed, fmt.Sprintf("invalid value: %v", err)
+		return r, false
+	}
+
+	migration := settings.Migrate(old, live, value)
+	r.Applied = migration.Applied
+	r.FollowUps = migration.FollowUps
+
+	bumpVersion := r.FromVersion != "" && r.ToVersion != "" && r.FromVersion != r.ToVersion
+	switch {
+	case len(r.FollowUps) > 0:
+		r.Status = migrationNeedsAttention
+	case migration.Changed() || bumpVersion:
+		r.Status = migrationMigrated
+	default:
+		r.Status = migrationUpToDate
+	}
+
+	if migration.Changed() {
+		var n yaml.Node
+		if err := n.Encode(migration.Value); err != nil {
+			r.Status, r.Error = migrationFailed, fmt.Sprintf("failed to encode value: %v", err)
+			return r, false
+		}
+		n.HeadComment, n.LineComment, n.FootComment = valueNode.HeadComment, valueNode.LineComment, valueNode.FootComment
+		*valueNode = n
+	}
+	if bumpVersion {
+		v := mappingValue(obj, "schemaVersion")
+		v.Kind, v.Tag, v.Value = yaml.ScalarNode, "!!str", r.ToVersion
+	}
+	return r, migration.Changed() || bumpVersion
+}
+
+// findSettingsFiles returns path if it is a file, or the YAML and JSON files
+// below path if it is a directory
+func findSettingsFiles(path string) ([]string, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+	if !info.IsDir() {
+		return []string{path}, nil
+	}
+
+	var files []string
+	err = filepath.WalkDir(path, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			if p != path && strings.HasPrefix(d.Name(), ".") {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		switch strings.ToLower(filepath.Ext(p)) {
+		case ".yaml", ".yml", ".json":
+			files = append(files, p)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to scan %s: %w", path, err)
+	}
+	sort.Strings(files)
+	return files, nil
+}
+
+// settingsObjectNodes returns the settings objects (mappings with a schema ID
+// and a value object) of a document holding one object or a list of them
+func settingsObjectNodes(doc *yaml.Node) []*yaml.Node {
+	if doc.Kind != yaml.DocumentNode || len(doc.Content) == 0 {
+		return nil
+	}
+	candidates := []*yaml.Node{doc.Content[0]}
+	if root := doc.Content[0]; root.Kind == yaml.SequenceNode {
+		candidates = root.Content
+	}
+
+	var objects []*yaml.Node
+	for _, n := range candidates {
+		if n.Kind != yaml.MappingNode {
+			continue
+		}
+		if mappingScalar(n, "schemaId") == "" && mappingScalar(n, "schemaid") == "" {
+			continue
+		}
+		if v := mappingValue(n, "value"); v == nil || v.Kind != yaml.MappingNode {
+			continue
+		}
+		objects = append(objects, n)
+	}
+	return objects
+}
+
+// mappingValue returns the value node of a key in a mapping node
+func mappingValue(n *yaml.Node, key string) *yaml.Node {
+	for i := 0; i+1 < len(n.Content); i += 2 {
+		if n.Content[i].Value == key {
+			return n.Content[i+1]
+		}
+	}
+	return nil
+}
+
+func mappingScalar(n *yaml.Node, key string) string {
+	if v := mappingValue(n, key); v != nil && v.Kind == yaml.ScalarNode {
+		return v.Value
+	}
+	return ""
+}
+
+// decodeJSONNode decodes a YAML node into its JSON form
+func decodeJSONNode(n *yaml.Node, v any) error {
+	var raw any
+	if err := n.Decode(&raw); err != nil {
+		return err
+	}
+	data, err := json.Marshal(raw)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, v)
+}
+
+// encodeSettingsFile renders a migrated document as JSON or YAML
+func encodeSettingsFile(doc *yaml.Node, asJSON bool) ([]byte, error) {
+	if asJSON {
+		var v any
+		if err := decodeJSONNode(doc, &v); err != nil {
+			return nil, err
+		}
+		data, err := json.MarshalIndent(v, "", "  ")
+		if err != nil {
+			return nil, err
+		}
+		return append(data, '\n'), nil
+	}
+
+	var buf bytes.Buffer
+	enc := yaml.NewEncoder(&buf)
+	enc.SetIndent(2)
+	if err := enc.Encode(doc); err != nil {
+		return nil, err
+	}
+	if err := enc.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func countMigrations(results []settingsMigrationResult, status string) int {
+	n := 0
+	for _, r := range results {
+		if r.Status == status {
+			n++
+		}
+	}
+	return n
+}
+
+// printSettingsMigrationsHuman prints migration results in human-readable format
+func printSettingsMigrationsHuman(results []settingsMigrationResult) {
+	useColor := isStderrTerminal()
+	colored := func(color, s string) string {
+		if useColor {
+			return color + s + colorReset
+		}
+		return s
+	}
+
+	// Schema changes are the same for all objects of a schema version; print them once
+	printedChanges := map[string]bool{}
+	for _, r := range results {
+		label := r.File
+		if r.SchemaID != "" {
+			label = fmt.Sprintf("%s object[%d] (%s", r.File, r.Object, r.SchemaID)
+			switch {
+			case r.FromVersion != "" && r.FromVersion != r.ToVersion:
+				label += " " + r.FromVersion + " -> " + r.ToVersion
+			case r.ToVersion != "":
+				label += " " + r.ToVersion
+			}
+			label += ")"
+		}
+
+		switch r.Status {
+		case migrationFailed:
+			fmt.Fprintf(os.Stderr, "%s %s: %s\n", colored(colorRed, "✖"), label, r.Error)
+			continue
+		case migrationNeedsAttention:
+			fmt.Fprintf(os.Stderr, "%s %s: needs manual follow-up\n", colored(colorYellow, "!"), label)
+		case migrationMigrated:
+			fmt.Fprintf(os.Stderr, "%s %s: migrated\n", colored(colorGreen, "✔"), label)
+		default:
+			fmt.Fprintf(os.Stderr, "%s %s: up to date\n", colored(colorGreen, "✔"), label)
+		}
+
+		key := r.SchemaID + "@" + r.FromVersion
+		if len(r.SchemaChanges) > 0 && !printedChanges[key] {
+			printedChanges[key] = true
+			fmt.Fprintf(os.Stderr, "  schema changes %s -> %s:\n", r.FromVersion, r.ToVersion)
+			for _, c := range r.SchemaChanges {
+				fmt.Fprintf(os.Stderr, "    %s\n", c)
+			}
+		}
+		if r.Note != "" {
+			fmt.Fprintf(os.Stderr, "  %s: %s\n", colored(colorYellow, "WARN"), r.Note)
+		}
+		for _, a := range r.Applied {
+			fmt.Fprintf(os.Stderr, "  + %s\n", a)
+		}
+		for _, f := range r.FollowUps {
+			fmt.Fprintf(os.Stderr, "  - TODO %s\n", f.Error())
+		}
+	}
+
+	fmt.Fprintf(os.Stderr, "\n%d object(s): %d migrated, %d up to date, %d need manual follow-up, %d failed\n",
+		len(results), countMigrations(results, migrationMigrated), countMigrations(results, migrationUpToDate),
+		countMigrations(results, migrationNeedsAttention), countMigrations(results, migrationFailed))
+}
+
+func init() {
+	migrateSettingsCmd.Flags().StringP("file", "f", "", "settings file or directory of settings files")
+	_ = migrateSettingsCmd.MarkFlagRequired("file")
+}
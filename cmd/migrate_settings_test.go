@@ -0,0 +1,129 @@
+package cmd
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func testSettingsMigrator(t *testing.T) *settingsMigrator {
+	t.Helper()
+	schema := func(s string) map[string]any {
+		var m map[string]any
+		if err := json.Unmarshal([]byte(s), &m); err != nil {
+			t.Fatal(err)
+		}
+		return m
+	}
+	v1 := schema(`{"schemaId": "builtin:test", "version": "1.0", "properties": {
+		"name": {"type": "text", "displayName": "Name"},
+		"legacy": {"type": "text", "nullable": true}}}`)
+	v2 := schema(`{"schemaId": "builtin:test", "version": "2.0", "properties": {
+		"title": {"type": "text", "displayName": "Name"},
+		"mode": {"type": "text", "default": "auto"}}}`)
+	return &settingsMigrator{
+		schemas: map[string]map[string]any{"builtin:test@": v2, "builtin:test@1.0": v1},
+		errs:    map[string]error{},
+	}
+}
+
+func TestMigrateSettingsFile(t *testing.T) {
+	dir := t.TempDir()
+	file := filepath.Join(dir, "settings.yaml")
+	content := `# Managed by the platform team
+- schemaId: builtin:test
+  scope: environment
+  schemaVersion: "1.0"
+  value:
+    name: web
+    legacy: x
+- schemaId: builtin:test
+  scope: HOST-1
+  schemaVersion: "2.0"
+  value:
+    title: db
+    mode: manual
+`
+	if err := os.WriteFile(file, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	results, changed, err := testSettingsMigrator(t).migrateFile(file, false)
+	if err != nil {
+		t.Fatalf("migrateFile() error = %v", err)
+	}
+	if !changed || len(results) != 2 {
+		t.Fatalf("migrateFile() changed=%v results=%d", changed, len(results))
+	}
+	if results[0].Status != migrationMigrated || results[0].ToVersion != "2.0" || len(results[0].SchemaChanges) != 3 {
+		t.Errorf("unexpected first result: %+v", results[0])
+	}
+	if results[1].Status != migrationUpToDate {
+		t.Errorf("unexpected second result: %+v", results[1])
+	}
+
+	data, _ := os.ReadFile(file)
+	got := string(data)
+	for _, want := range []string{"# Managed by the platform team", `schemaVersion: "2.0"`, "title: web", "mode: auto", "scope: HOST-1"} {
+		if !strings.Contains(got, want) {
+			t.Errorf("migrated file misses %q:\n%s", want, got)
+		}
+	}
+	for _, unwanted := range []string{"legacy", "name: web", `"1.0"`} {
+		if strings.Contains(got, unwanted) {
+			t.Errorf("migrated file still contains %q:\n%s", unwanted, got)
+		}
+	}
+}
+
+func TestMigrateSettingsFile_DryRunAndJSON(t *testing.T) {
+	dir := t.TempDir()
+	file := filepath.Join(dir, "settings.json")
+	content := `{"schemaId": "builtin:test", "schemaVersion": "1.0", "value": {"name": "web"}}`
+	if err := os.WriteFile(file, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	dryRun = true
+	_, changed, err := testSettingsMigrator(t).migrateFile(file, false)
+	dryRun = false
+	if err != nil || !changed {
+		t.Fatalf("migrateFile() changed=%v err=%v", changed, err)
+	}
+	if data, _ := os.ReadFile(file); string(data) != content {
+		t.Errorf("dry run must not rewrite the file, got:\n%s", data)
+	}
+
+	if _, _, err := testSettingsMigrator(t).migrateFile(file, false); err != nil {
+		t.Fatalf("migrateFile() error = %v", err)
+	}
+	var obj map[string]any
+	data, _ := os.ReadFile(file)
+	if err := json.Unmarshal(data, &obj); err != nil {
+		t.Fatalf("migrated JSON file is invalid: %v\n%s", err, data)
+	}
+	if obj["schemaVersion"] != "2.0" || obj["value"].(map[string]any)["title"] != "web" {
+		t.Errorf("unexpected migrated object: %v", obj)
+	}
+}
+
+func TestFindSettingsFiles(t *testing.T) {
+	dir := t.TempDir()
+	for _, name := range []string{"a.yaml", "sub/b.json", "notes.txt", ".git/c.yaml"} {
+		path := filepath.Join(dir, name)
+		_ = os.MkdirAll(filepath.Dir(path), 0o755)
+		if err := os.WriteFile(path, []byte("x: 1\n"), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	files, err := findSettingsFiles(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []string{filepath.Join(dir, "a.yaml"), filepath.Join(dir, "sub", "b.json")}
+	if strings.Join(files, ",") != strings.Join(want, ",") {
+		t.Errorf("findSettingsFiles() = %v, want %v", files, want)
+	}
+}
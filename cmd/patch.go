@@ -0,0 +1,31 @@
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+)
+
+// patchCmd represents the patch command
+var patchCmd = &cobra.Command{
+	Use:   "patch",
+	Short: "Change fields of many resources at once",
+	Long: `Change individual fields of all resources that match a selector.
+
+Unlike 'edit', which changes one resource in an editor, 'patch' selects
+resources with --where expressions and sets fields with --set, previews the
+affected resources, and updates them in parallel after confirmation.
+
+Supported resources:
+  settings`,
+	Example: `  # Enable all disabled objects of a schema
+  dtctl patch settings --schema builtin:rum.web.enablement --where 'value.enabled==false' --set value.enabled=true
+
+  # Preview only
+  dtctl patch settings --schema builtin:rum.web.enablement --set value.enabled=true --dry-run`,
+	RunE: requireSubcommand,
+}
+
+func init() {
+	rootCmd.AddCommand(patchCmd)
+
+	patchCmd.AddCommand(patchSettingsCmd)
+}
@@ -0,0 +1,281 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/dynatrace-oss/dtctl/pkg/output"
+	"github.com/dynatrace-oss/dtctl/pkg/prompt"
+	"github.com/dynatrace-oss/dtctl/pkg/resources/settings"
+	"github.com/dynatrace-oss/dtctl/pkg/safety"
+)
+
+// settingsPatchChange is one property changed by 'patch settings'
+type settingsPatchChange struct {
+	Path string `json:"path"`
+	Old  any    `json:"old"`
+	New  any    `json:"new"`
+}
+
+// settingsPatchResult is the outcome of patching one settings object
+type settingsPatchResult struct {
+	ObjectID string                `json:"objectId" table:"OBJECT_ID"`
+	SchemaID string                `json:"schemaId" table:"SCHEMA_ID,wide"`
+	Scope    string                `json:"scope" table:"SCOPE"`
+	Summary  string                `json:"summary,omitempty" table:"SUMMARY"`
+	Status   string                `json:"status" table:"STATUS"`
+	Changes  []settingsPatchChange `json:"changes,omitempty" table:"-"`
+	Error    string                `json:"error,omitempty" table:"ERROR"`
+
+	value map[string]any
+}
+
+// Patch result statuses
+const (
+	patchPlanned   = "planned"
+	patchUpdated   = "updated"
+	patchUnchanged = "unchanged"
+	patchFailed    = "failed"
+)
+
+// patchSettingsCmd changes fields of all settings objects matching selectors
+var patchSettingsCmd = &cobra.Command{
+	Use:     "settings --schema <schema-id> --set <path>=<value> [--where <selector>]",
+	Aliases: []string{"setting"},
+	Short:   "Change fields of all settings objects that match selectors",
+	Long: `Change fields of all settings objects of a schema that match selectors.
+
+--where selects objects by a field, with the operators ==, !=, =~ (regular
+expression), !~, >, >=, < and <=. Fields are paths into the object as shown by
+'get settings -o yaml', e.g. value.enabled, value.rules[0].name or scope.
+Repeated --where flags must all match. Without --where, all objects of the
+schema (in --scope) are patched.
+
+--set assigns a property below 'value'. Values are parsed as YAML, so true,
+42, null and "quoted strings" keep their type.
+
+The affected objects and changes are previewed before confirmation. Objects the
+patch would not change are skipped. Updates run in parallel (--concurrency),
+and the result of every object is reported; the command fails if any update
+failed.
+
+Examples:
+  # Enable all disabled objects of a schema
+  dtctl patch settings --schema builtin:rum.web.enablement \
+    --where 'value.enabled==false' --set value.enabled=true
+
+  # Restrict to host scopes and set two fields
+  dtctl patch settings --schema builtin:logmonitoring.log-storage-settings \
+    --where 'scope=~^HOST-' --set value.enabled=true --set 'value.config-item-title="Store all"'
+
+  # Preview without changing anything
+  dtctl patch settings --schema builtin:rum.web.enablement --set value.enabled=true --dry-run
+`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		schemaID, _ := cmd.Flags().GetString("schema")
+		scope, _ := cmd.Flags().GetString("scope")
+		whereFlags, _ := cmd.Flags().GetStringArray("where")
+		setFlags, _ := cmd.Flags().GetStringArray("set")
+		concurrency, _ := cmd.Flags().GetInt("concurrency")
+
+		var selectors []*settings.Selector
+		for _, w := range whereFlags {
+			s, err := settings.ParseSelector(w)
+			if err != nil {
+				return err
+			}
+			selectors = append(selectors, s)
+		}
+		if len(setFlags) == 0 {
+			return fmt.Errorf("at least one --set is required")
+		}
+		var assignments []*settings.Assignment
+		for _, s := range setFlags {
+			a, err := settings.ParseAssignment(s)
+			if err != nil {
+				return err
+			}
+			assignments = append(assignments, a)
+		}
+
+		_, c, err := SetupWithSafety(safety.OperationUpdate)
+		if err != nil {
+			return err
+		}
+		handler := settings.NewHandler(c)
+
+		list, err := handler.ListObjects(schemaID, scope, GetChunkSize())
+		if err != nil {
+			return err
+		}
+		results := planSettingsPatch(list.Items, selectors, assignments)
+
+		var pending []int
+		for i, r := range results {
+			if r.Status == patchPlanned {
+				pending = append(pending, i)
+			}
+		}
+		if len(pending) == 0 {
+			output.PrintInfo("No settings objects to patch (%d matched, none would change)", len(results))
+			return nil
+		}
+
+		structured := agentMode || (outputFormat != "" && outputFormat != "table" && outputFormat != "wide")
+		if !structured {
+			printSettingsPatchPlan(results)
+		}
+		if dryRun {
+			if structured {
+				return printSettingsPatchResults(results)
+			}
+			output.PrintInfo("Dry run: %d settings object(s) would be patched", len(pending))
+			return nil
+		}
+		if !forceDelete && !plainMode {
+			if !prompt.Confirm(fmt.Sprintf("Patch %d settings object(s)?", len(pending))) {
+				fmt.Println("Patch cancelled")
+				return nil
+			}
+		}
+
+		settings.ForEach(len(pending), concurrency, func(i int) {
+			r := &results[pending[i]]
+			if _, err := handler.Update(r.ObjectID, r.value); err != nil {
+				r.Status, r.Error = patchFailed, err.Error()
+				return
+			}
+			r.Status = patchUpdated
+		})
+
+		if err := printSettingsPatchResults(results); err != nil {
+			return err
+		}
+		failed, attempted := 0, 0
+		for _, r := range results {
+			switch r.Status {
+			case patchFailed:
+				failed++
+				attempted++
+			case patchUpdated:
+				attempted++
+			}
+		}
+		if failed > 0 {
+			return fmt.Errorf("%d of %d settings object(s) could not be patched", failed, attempted)
+		}
+		return nil
+	},
+}
+
+// planSettingsPatch selects the objects matching all selectors and applies
+// the assignments to a copy of their values. Objects the assignments do not
+// change are marked unchanged; objects they cannot be applied to are marked
+// failed.
+func planSettingsPatch(objects []settings.SettingsObject, selectors []*settings.Selector, assignments []*settings.Assignment) []settingsPatchResult {
+	var results []settingsPatchResult
+	for _, obj := range objects {
+		m := settings.ObjectMap(obj)
+		matched := true
+		for _, s := range selectors {
+			if !s.Match(m) {
+				matched = false
+				break
+			}
+		}
+		if !matched {
+			continue
+		}
+
+		r := settingsPatchResult{
+			ObjectID: obj.ObjectID,
+			SchemaID: obj.SchemaID,
+			Scope:    obj.Scope,
+			Summary:  obj.Summary,
+			Status:   patchUnchanged,
+		}
+		// Assignment paths start with "value"; apply them to the object map
+		// and take its value as the new settings value.
+		if _, ok := m["value"].(map[string]any); !ok {
+			m["value"] = map[string]any{}
+		}
+		for _, a := range assignments {
+			old, changed, err := a.Apply(m)
+			if err != nil {
+				r.Status, r.Error = patchFailed, err.Error()
+				break
+			}
+			if changed {
+				r.Status = patchPlanned
+				r.Changes = append(r.Changes, settingsPatchChange{Path: a.String(), Old: old, New: a.Value})
+			}
+		}
+		r.value, _ = m["value"].(map[string]any)
+		results = append(results, r)
+	}
+	return results
+}
+
+// printSettingsPatchPlan previews the changes of a patch
+func printSettingsPatchPlan(results []settingsPatchResult) {
+	for _, r := range results {
+		if r.Status == patchUnchanged {
+			continue
+		}
+		label := r.ObjectID
+		if r.Summary != "" {
+			label = fmt.Sprintf("%s (%s)", r.Summary, r.ObjectID)
+		}
+		fmt.Printf("%s  [%s]\n", label, r.Scope)
+		if r.Status == patchFailed {
+			fmt.Printf("   cannot patch: %s\n", r.Error)
+		}
+		for _, c := range r.Changes {
+			fmt.Printf("   %s: %s -> %s\n", c.Path, patchValue(c.Old), patchValue(c.New))
+		}
+	}
+	fmt.Println()
+}
+
+// printSettingsPatchResults prints the per-object results of a patch
+func printSettingsPatchResults(results []settingsPatchResult) error {
+	printer := NewPrinter()
+	if ap := enrichAgent(printer, "patch", "settings"); ap != nil {
+		ap.SetTotal(len(results))
+		var warnings []string
+		for _, r := range results {
+			if r.Status == patchFailed {
+				warnings = append(warnings, fmt.Sprintf("%s: %s", r.ObjectID, r.Error))
+			}
+		}
+		if len(warnings) > 0 {
+			ap.SetWarnings(warnings)
+		}
+	}
+	return printer.PrintList(results)
+}
+
+func patchValue(v any) string {
+	if v == nil {
+		return "null"
+	}
+	data, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Sprint(v)
+	}
+	return strings.TrimSpace(string(data))
+}
+
+func init() {
+	patchSettingsCmd.Flags().String("schema", "", "schema ID (required)")
+	patchSettingsCmd.Flags().String("scope", "", "only patch objects in this scope")
+	patchSettingsCmd.Flags().StringArray("where", nil, "select objects by a field, e.g. 'value.enabled==false' (repeatable, all must match)")
+	patchSettingsCmd.Flags().StringArray("set", nil, "set a property below 'value', e.g. value.enabled=true (repeatable)")
+	patchSettingsCmd.Flags().Int("concurrency", settings.DefaultConcurrency, "number of objects updated in parallel")
+	patchSettingsCmd.Flags().BoolVarP(&forceDelete, "yes", "y", false, "Skip confirmation prompt")
+	_ = patchSettingsCmd.MarkFlagRequired("schema")
+}
@@ -0,0 +1,47 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/dynatrace-oss/dtctl/pkg/resources/settings"
+)
+
+func TestPlanSettingsPatch(t *testing.T) {
+	objects := []settings.SettingsObject{
+		{ObjectID: "off", Scope: "HOST-1", Value: map[string]any{"enabled": false}},
+		{ObjectID: "on", Scope: "HOST-2", Value: map[string]any{"enabled": true}},
+		{ObjectID: "other", Scope: "APPLICATION-1", Value: map[string]any{"enabled": false}},
+		{ObjectID: "bad", Scope: "HOST-3", Value: map[string]any{"enabled": false, "rules": []any{}}},
+	}
+	where, err := settings.ParseSelector("scope=~^HOST-")
+	if err != nil {
+		t.Fatal(err)
+	}
+	enable, _ := settings.ParseAssignment("value.enabled=true")
+	rule, _ := settings.ParseAssignment("value.rules[0]=x")
+
+	results := planSettingsPatch(objects[:3], []*settings.Selector{where}, []*settings.Assignment{enable})
+	if len(results) != 2 {
+		t.Fatalf("expected 2 matching objects, got %d", len(results))
+	}
+	if results[0].ObjectID != "off" || results[0].Status != patchPlanned {
+		t.Errorf("unexpected result for 'off': %+v", results[0])
+	}
+	if len(results[0].Changes) != 1 || results[0].Changes[0].Old != false || results[0].Changes[0].New != true {
+		t.Errorf("unexpected changes: %+v", results[0].Changes)
+	}
+	if results[0].value["enabled"] != true {
+		t.Errorf("patched value = %v", results[0].value)
+	}
+	if objects[0].Value["enabled"] != false {
+		t.Error("planning must not modify the listed object")
+	}
+	if results[1].ObjectID != "on" || results[1].Status != patchUnchanged {
+		t.Errorf("unexpected result for 'on': %+v", results[1])
+	}
+
+	results = planSettingsPatch(objects[3:], nil, []*settings.Assignment{enable, rule})
+	if len(results) != 1 || results[0].Status != patchFailed || results[0].Error == "" {
+		t.Errorf("expected failed plan, got %+v", results)
+	}
+}
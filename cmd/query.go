@@ -15,6 +15,7 @@ import (
 	"gopkg.in/yaml.v3"
 
 	"github.com/dynatrace-oss/dtctl/pkg/exec"
+	"github.com/dynatrace-oss/dtctl/pkg/hook"
 	"github.com/dynatrace-oss/dtctl/pkg/output"
 	"github.com/dynatrace-oss/dtctl/pkg/resources/resolver"
 	"github.com/dynatrace-oss/dtctl/pkg/util/template"
@@ -213,6 +214,17 @@ Examples:
 			query = rendered
 		}
 
+		// Run the pre-query hook with the final query text
+		noHooks, _ := cmd.Flags().GetBool("no-hooks")
+		var hookEv hookEvent
+		if !noHooks {
+			hookEv = newHookEvent(cmd, args)
+			hookEv.Query, hookEv.Content = query, nil
+			if err := runPreHook(hook.EventPreQuery, hookEv); err != nil {
+				return err
+			}
+		}
+
 		// Get visualization options
 		live, _ := cmd.Flags().GetBool("live")
 		interval, _ := cmd.Flags().GetDuration("interval")
@@ -420,7 +432,13 @@ Examples:
 			return livePrinter.RunLive(ctx, fetcher)
 		}
 
-		return executor.ExecuteWithContext(ctx, query, opts)
+		if err := executor.ExecuteWithContext(ctx, query, opts); err != nil {
+			return err
+		}
+		if !noHooks && !dryRun {
+			runPostHook(hook.EventPostQuery, hookEv)
+		}
+		return nil
 	},
 }
 
@@ -670,6 +688,7 @@ func init() {
 	// Flags for main query command
 	queryCmd.Flags().StringP("file", "f", "", "read query from file")
 	queryCmd.Flags().StringArray("set", []string{}, "set template variable (key=value)")
+	queryCmd.Flags().Bool("no-hooks", false, "skip pre-query and post-query hooks")
 
 	// Live mode flags
 	queryCmd.Flags().Bool("live", false, "enable live mode with periodic updates")
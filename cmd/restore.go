@@ -77,7 +77,7 @@ Examples:
 		}
 		currentUserID, _ := c.CurrentUserID()
 		ownership := safety.DetermineOwnership(wf.Owner, currentUserID)
-		if err := checker.CheckError(safety.OperationUpdate, ownership); err != nil {
+		if err := checker.ForResource("workflow", wf.Title).CheckError(safety.OperationUpdate, ownership); err != nil {
 			return err
 		}
 
@@ -158,7 +158,7 @@ Examples:
 		}
 		currentUserID, _ := c.CurrentUserID()
 		ownership := safety.DetermineOwnership(metadata.Owner, currentUserID)
-		if err := checker.CheckError(safety.OperationUpdate, ownership); err != nil {
+		if err := checker.ForResource("dashboard", metadata.Name).CheckError(safety.OperationUpdate, ownership); err != nil {
 			return err
 		}
 
@@ -239,7 +239,7 @@ Examples:
 		}
 		currentUserID, _ := c.CurrentUserID()
 		ownership := safety.DetermineOwnership(metadata.Owner, currentUserID)
-		if err := checker.CheckError(safety.OperationUpdate, ownership); err != nil {
+		if err := checker.ForResource("notebook", metadata.Name).CheckError(safety.OperationUpdate, ownership); err != nil {
 			return err
 		}
 
@@ -322,7 +322,7 @@ Examples:
 		}
 		currentUserID, _ := c.CurrentUserID()
 		ownership := safety.DetermineOwnership(metadata.Owner, currentUserID)
-		if err := checker.CheckError(safety.OperationUpdate, ownership); err != nil {
+		if err := checker.ForResource(metadata.Type, metadata.Name).CheckError(safety.OperationUpdate, ownership); err != nil {
 			return err
 		}
 
@@ -17,10 +17,13 @@ import (
 
 	"github.com/dynatrace-oss/dtctl/pkg/aidetect"
 	"github.com/dynatrace-oss/dtctl/pkg/apply"
+	"github.com/dynatrace-oss/dtctl/pkg/approval"
+	"github.com/dynatrace-oss/dtctl/pkg/auth"
 	"github.com/dynatrace-oss/dtctl/pkg/client"
 	"github.com/dynatrace-oss/dtctl/pkg/config"
 	"github.com/dynatrace-oss/dtctl/pkg/diagnostic"
 	"github.com/dynatrace-oss/dtctl/pkg/exec"
+	"github.com/dynatrace-oss/dtctl/pkg/hook"
 	"github.com/dynatrace-oss/dtctl/pkg/output"
 	"github.com/dynatrace-oss/dtctl/pkg/safety"
 	"github.com/dynatrace-oss/dtctl/pkg/suggest"
@@ -74,6 +77,8 @@ func Execute() {
 func execute() int {
 	// Setup enhanced error handling after all subcommands are registered
 	setupErrorHandlers(rootCmd)
+	setupLifecycleHooks(rootCmd)
+	setupFanOut(rootCmd)
 
 	// --- Alias resolution (before Cobra parses args AND before tracing init) ---
 	// Resolving aliases first ensures the span name reflects the real command,
@@ -102,6 +107,11 @@ func execute() int {
 	}
 	// --- End alias resolution ---
 
+	if c, _, err := rootCmd.Find(spanArgs); err == nil {
+		activeCommandPath = c.CommandPath()
+	}
+	activeCommandArgs = spanArgs
+
 	// Initialise OpenTelemetry tracing. Done after alias resolution so that
 	// the span name reflects the actual command (not a pre-alias invocation).
 	// The root span covers the entire invocation; shutdown flushes buffered
@@ -124,7 +134,9 @@ func execute() int {
 		fmt.Fprintf(os.Stderr, "dtctl: tracing: %v (check OTEL_EXPORTER_OTLP_ENDPOINT or unset it to disable export)\n", tracingErr)
 	}
 
-	if err := rootCmd.Execute(); err != nil {
+	err := rootCmd.Execute()
+	writeAuditRecord(spanArgs, err)
+	if err != nil {
 		errStr := err.Error()
 
 		// Enhance unknown command errors with suggestions
@@ -137,15 +149,24 @@ func execute() int {
 			err = enhanceFlagError(rootCmd, err)
 		}
 
+		// Write a change request for operations that require two-person approval
+		var approvalErr *safety.ApprovalRequiredError
+		if errors.As(err, &approvalErr) {
+			err = writeChangeRequest(approvalErr)
+		}
+
 		// Check for URL-related hints (e.g., wrong domain like live.dynatrace.com)
 		urlHints := getURLHintsForError(err)
 
 		// Check for auth-related hints (e.g., expired OAuth session)
 		authHints := getAuthHintsForError(err)
 
-		allHints := make([]string, 0, len(urlHints)+len(authHints))
+		approvalHints := getApprovalHintsForError(err)
+
+		allHints := make([]string, 0, len(urlHints)+len(authHints)+len(approvalHints))
 		allHints = append(allHints, urlHints...)
 		allHints = append(allHints, authHints...)
+		allHints = append(allHints, approvalHints...)
 
 		// Record the error on the root span so it appears in traces.
 		rootSpan.SetStatus(codes.Error, err.Error())
@@ -279,7 +300,7 @@ func errorToDetail(err error) *output.ErrorDetail {
 		}
 	}
 
-	// safety.SafetyError — operation blocked by safety level
+	// safety.SafetyError — operation blocked by safety level or rule
 	var safetyErr *safety.SafetyError
 	if errors.As(err, &safetyErr) {
 		return &output.ErrorDetail{
@@ -289,6 +310,16 @@ func errorToDetail(err error) *output.ErrorDetail {
 		}
 	}
 
+	// ChangeRequestError — operation awaits two-person approval
+	var changeErr *ChangeRequestError
+	var approvalErr *safety.ApprovalRequiredError
+	if errors.As(err, &changeErr) || errors.As(err, &approvalErr) {
+		return &output.ErrorDetail{
+			Code:    "approval_required",
+			Message: err.Error(),
+		}
+	}
+
 	// apply.HookRejectedError — pre-apply hook rejected the resource
 	var hookErr *apply.HookRejectedError
 	if errors.As(err, &hookErr) {
@@ -302,6 +333,28 @@ func errorToDetail(err error) *output.ErrorDetail {
 		}
 	}
 
+	// hook.RejectedError — a pre-create/edit/delete/query hook vetoed the operation
+	var lifecycleHookErr *hook.RejectedError
+	if errors.As(err, &lifecycleHookErr) {
+		return &output.ErrorDetail{
+			Code:    "hook_rejected",
+			Message: fmt.Sprintf("%s hook rejected the operation", lifecycleHookErr.Event),
+			Suggestions: []string{
+				"check hook stderr output for details",
+				fmt.Sprintf("use --no-hooks to skip %s hooks", lifecycleHookErr.Event),
+			},
+		}
+	}
+
+	// auth.MissingScopesError — scope preflight failed before any API call
+	var scopesErr *auth.MissingScopesError
+	if errors.As(err, &scopesErr) {
+		return &output.ErrorDetail{
+			Code:    "missing_scopes",
+			Message: scopesErr.Error(),
+		}
+	}
+
 	// suggest.CommandError — unknown command with "did you mean?" suggestions
 	var cmdErr *suggest.CommandError
 	if errors.As(err, &cmdErr) {
@@ -383,6 +436,14 @@ func getURLHintsForError(err error) []string {
 // getAuthHintsForError returns actionable hints when the error looks like an
 // OAuth token refresh failure (e.g., expired session, revoked refresh token).
 func getAuthHintsForError(err error) []string {
+	var scopesErr *auth.MissingScopesError
+	if errors.As(err, &scopesErr) {
+		return []string{
+			fmt.Sprintf("List the scopes for a new token: dtctl auth scopes --for %q", scopesErr.Command),
+			"With 'dtctl auth login', the scopes follow the context safety level; log in again after changing it",
+			fmt.Sprintf("Set %s=1 to skip this check", auth.EnvSkipScopePreflight),
+		}
+	}
 	if !isTokenRefreshError(err) {
 		return nil
 	}
@@ -391,6 +452,23 @@ func getAuthHintsForError(err error) []string {
 	}
 }
 
+// getApprovalHintsForError returns the next steps when an operation requires
+// two-person approval.
+func getApprovalHintsForError(err error) []string {
+	var changeErr *ChangeRequestError
+	if errors.As(err, &changeErr) {
+		return []string{
+			fmt.Sprintf("Ask another approver to run: dtctl approve %s", changeErr.Path),
+			fmt.Sprintf("Then re-run this command with --approval %s", changeErr.Path),
+		}
+	}
+	var approvalErr *safety.ApprovalRequiredError
+	if errors.As(err, &approvalErr) {
+		return []string{"Create your signing key with 'dtctl config generate-approval-key <name>'"}
+	}
+	return nil
+}
+
 // isTokenRefreshError returns true if the error looks like an OAuth token
 // refresh failure (expired session, invalid grant, etc.).
 func isTokenRefreshError(err error) bool {
@@ -442,6 +520,17 @@ func exitCodeForError(err error) int {
 		return apiErr.ExitCode()
 	}
 
+	var scopesErr *auth.MissingScopesError
+	if errors.As(err, &scopesErr) {
+		return client.ExitPermissionError
+	}
+
+	var changeErr *ChangeRequestError
+	var approvalErr *safety.ApprovalRequiredError
+	if errors.As(err, &changeErr) || errors.As(err, &approvalErr) {
+		return client.ExitPermissionError
+	}
+
 	var cmdErr *suggest.CommandError
 	if errors.As(err, &cmdErr) {
 		return client.ExitUsageError
@@ -553,7 +642,31 @@ func NewSafetyChecker(cfg *config.Config) (*safety.Checker, error) {
 		return nil, err
 	}
 
-	return safety.NewChecker(cfg.CurrentContext, ctx), nil
+	if err := ctx.ValidateSafetyRules(); err != nil {
+		return nil, fmt.Errorf("context %q: %w", cfg.CurrentContext, err)
+	}
+
+	var request *approval.ChangeRequest
+	if approvalFile != "" {
+		if request, err = approval.Load(approvalFile); err != nil {
+			return nil, err
+		}
+	}
+
+	return safety.NewChecker(cfg.CurrentContext, ctx).
+		ForResource(resourceFromCommandPath(activeCommandPath), "").
+		WithApprovals(cfg.Approval.Approvers, request).
+		WithApprovalLedger(approval.DefaultLedger()), nil
+}
+
+// resourceFromCommandPath returns the resource type of a "dtctl <verb> <resource>"
+// command path (e.g. "workflow" for "dtctl delete workflow") for safety rule matching.
+func resourceFromCommandPath(path string) string {
+	fields := strings.Fields(path)
+	if len(fields) < 3 {
+		return ""
+	}
+	return fields[len(fields)-1]
 }
 
 // NewPrinter creates a new printer respecting agent and plain mode settings
@@ -615,12 +728,31 @@ func LoadConfig() (*config.Config, error) {
 	return cfg, nil
 }
 
+// activeCommandPath is the path of the command being executed (e.g.
+// "dtctl get workflows"), used for the token scope preflight check and
+// safety rule matching.
+var activeCommandPath string
+
+// activeCommandArgs are the arguments of the command being executed, after
+// alias expansion.
+var activeCommandArgs []string
+
 // NewClientFromConfig creates a new client from config with verbose mode configured
 func NewClientFromConfig(cfg *config.Config) (*client.Client, error) {
 	c, err := client.NewFromConfig(cfg)
 	if err != nil {
 		return nil, err
 	}
+	// Fail fast with the exact missing scopes instead of a bare 403.
+	if activeCommandPath != "" && os.Getenv(auth.EnvSkipScopePreflight) == "" {
+		if err := auth.CheckCommandScopes(activeCommandPath, c.Token()); errors.Is(err, auth.ErrScopesUnknown) {
+			if isDebugVerbose() {
+				output.PrintWarning("%v", err)
+			}
+		} else if err != nil {
+			return nil, err
+		}
+	}
 	// If --debug flag is set, force verbosity to 2 (full debug mode)
 	if debugMode {
 		c.SetVerbosity(2)
@@ -631,6 +763,8 @@ func NewClientFromConfig(cfg *config.Config) (*client.Client, error) {
 	if tracingRootCtx != nil {
 		c.InjectTraceContext(tracingRootCtx)
 	}
+	trackAuditMutations(c)
+	trackUndoSnapshots(c, cfg)
 	return c, nil
 }
 
@@ -644,8 +778,10 @@ func NewClientFromConfig(cfg *config.Config) (*client.Client, error) {
 var flagsTakingValues = map[string]bool{
 	"--config":     true,
 	"--context":    true,
+	"--contexts":   true,
 	"--output":     true,
 	"--chunk-size": true,
+	"--approval":   true,
 }
 
 // shortFlagsTakingValues maps short flag letters to true when they consume the
@@ -781,6 +917,8 @@ Use "{{.CommandPath}} [command] --help" for more information about a command.{{e
 	// Global flags
 	rootCmd.PersistentFlags().StringVar(&cfgFile, "config", "", "config file (searches .dtctl.yaml upward, then $XDG_CONFIG_HOME/dtctl/config)")
 	rootCmd.PersistentFlags().StringVar(&contextName, "context", "", "use a specific context")
+	rootCmd.PersistentFlags().StringSliceVar(&fanOutContexts, "contexts", nil, "run get/describe/query against several contexts concurrently (comma-separated)")
+	rootCmd.PersistentFlags().BoolVar(&fanOutAll, "all-contexts", false, "run get/describe/query against every configured context")
 	rootCmd.PersistentFlags().StringVarP(&outputFormat, "output", "o", "table", "output format: json|yaml|csv|toon|table|wide")
 	rootCmd.PersistentFlags().CountVarP(&verbosity, "verbose", "v", "verbose output (-v for details, -vv for full debug including auth headers)")
 	rootCmd.PersistentFlags().BoolVar(&debugMode, "debug", false, "enable debug mode (full HTTP request/response logging, equivalent to -vv)")
@@ -789,6 +927,7 @@ Use "{{.CommandPath}} [command] --help" for more information about a command.{{e
 	rootCmd.PersistentFlags().BoolVarP(&agentMode, "agent", "A", false, "agent output mode: wrap output in a structured JSON envelope with metadata")
 	rootCmd.PersistentFlags().BoolVar(&noAgent, "no-agent", false, "disable auto-detected agent mode")
 	rootCmd.PersistentFlags().Int64Var(&chunkSize, "chunk-size", 500, "Paginate through all results in chunks of this size. 0 returns only the first page.")
+	rootCmd.PersistentFlags().StringVar(&approvalFile, "approval", "", "approved change request for an operation that requires two-person approval")
 
 	// Bind flags to viper
 	_ = viper.BindPFlag("context", rootCmd.PersistentFlags().Lookup("context"))
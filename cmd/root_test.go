@@ -1215,3 +1215,18 @@ func TestFlagsTakingValues_SyncGuard(t *testing.T) {
 		}
 	}
 }
+
+func TestResourceFromCommandPath(t *testing.T) {
+	tests := map[string]string{
+		"dtctl delete workflow":    "workflow",
+		"dtctl update settings":    "settings",
+		"dtctl apply":              "",
+		"":                         "",
+		"dtctl config set-context": "set-context",
+	}
+	for path, want := range tests {
+		if got := resourceFromCommandPath(path); got != want {
+			t.Errorf("resourceFromCommandPath(%q) = %q, want %q", path, got, want)
+		}
+	}
+}
@@ -95,7 +95,7 @@ Examples:
 		}
 		currentUserID, _ := c.CurrentUserID()
 		ownership := safety.DetermineOwnership(metadata.Owner, currentUserID)
-		if err := checker.CheckError(safety.OperationUpdate, ownership); err != nil {
+		if err := checker.ForResource(metadata.Type, metadata.Name).CheckError(safety.OperationUpdate, ownership); err != nil {
 			return err
 		}
 
@@ -208,7 +208,7 @@ Examples:
 		}
 		currentUserID, _ := c.CurrentUserID()
 		ownership := safety.DetermineOwnership(metadata.Owner, currentUserID)
-		if err := checker.CheckError(safety.OperationUpdate, ownership); err != nil {
+		if err := checker.ForResource(metadata.Type, metadata.Name).CheckError(safety.OperationUpdate, ownership); err != nil {
 			return err
 		}
 
@@ -0,0 +1,204 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/dynatrace-oss/dtctl/pkg/client"
+	"github.com/dynatrace-oss/dtctl/pkg/output"
+	"github.com/dynatrace-oss/dtctl/pkg/prompt"
+	"github.com/dynatrace-oss/dtctl/pkg/resources/document"
+	"github.com/dynatrace-oss/dtctl/pkg/resources/iam"
+	"github.com/dynatrace-oss/dtctl/pkg/resources/workflow"
+	"github.com/dynatrace-oss/dtctl/pkg/safety"
+)
+
+// resolveUserID resolves a user given as UUID, email or unique partial name to
+// a UUID. With allowRemoved, the UUID of a user that no longer exists in IAM
+// is accepted as well, so that what removed users owned can still be found.
+func resolveUserID(users *iam.Handler, ref string, allowRemoved bool) (string, error) {
+	user, err := users.FindUser(ref)
+	switch {
+	case err == nil:
+		return user.UID, nil
+	case allowRemoved && errors.Is(err, iam.ErrUserNotFound):
+		if iam.IsUUID(ref) {
+			return ref, nil
+		}
+		return "", fmt.Errorf("%w (users removed from IAM can only be given by UUID)", err)
+	}
+	return "", err
+}
+
+// ownershipTransfer is a document or workflow whose owner is to be changed
+type ownershipTransfer struct {
+	Type    string `table:"TYPE" json:"type" yaml:"type"`
+	ID      string `table:"ID" json:"id" yaml:"id"`
+	Name    string `table:"NAME" json:"name" yaml:"name"`
+	Version int    `table:"VERSION,wide" json:"version,omitempty" yaml:"version,omitempty"`
+	// Actor is the user a workflow runs as
+	Actor string `table:"-" json:"actor,omitempty" yaml:"actor,omitempty"`
+}
+
+// transferOwnershipCmd moves the documents and workflows of one user to another
+var transferOwnershipCmd = &cobra.Command{
+	Use:   "transfer-ownership --from <user> --to <user>",
+	Short: "Transfer documents and workflows from one user to another",
+	Long: `Transfer the ownership of all documents (dashboards, notebooks, ...) and
+workflows owned by one user to another, e.g. when someone leaves the company.
+
+Users are given by UUID, email or a unique partial name. --from also accepts
+the UUID of a user that no longer exists in IAM; use
+'dtctl get documents --orphaned' to find the documents of removed users.
+
+Every resource passes the safety checks of the context before anything is
+changed. Documents and workflows of other users are only visible and
+transferable with admin permissions; use --admin-access for documents.
+Workflows keep the actor they run as.`,
+	Example: `  # Preview what would be transferred
+  dtctl transfer-ownership --from jane.doe@example.com --to john.roe@example.com --dry-run
+
+  # Transfer only dashboards, without confirmation
+  dtctl transfer-ownership --from jane.doe@example.com --to john.roe@example.com --type dashboard -y
+
+  # Transfer everything of a user removed from IAM
+  dtctl transfer-ownership --from 7f3c1e2a-5b4d-4c3e-9a8b-1d2e3f4a5b6c --to john.roe@example.com --admin-access`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		from, _ := cmd.Flags().GetString("from")
+		to, _ := cmd.Flags().GetString("to")
+		resourceType, _ := cmd.Flags().GetString("type")
+		adminAccess, _ := cmd.Flags().GetBool("admin-access")
+
+		cfg, c, err := SetupClient()
+		if err != nil {
+			return err
+		}
+
+		users := iam.NewHandler(c)
+		fromID, err := resolveUserID(users, from, true)
+		if err != nil {
+			return fmt.Errorf("failed to resolve --from: %w", err)
+		}
+		newOwner, err := users.FindUser(to)
+		if err != nil {
+			return fmt.Errorf("failed to resolve --to: %w", err)
+		}
+		if newOwner.UID == fromID {
+			return fmt.Errorf("--from and --to are the same user")
+		}
+		fromName := fromID
+		if u, err := users.GetUser(fromID); err == nil {
+			fromName = u.DisplayName()
+		}
+
+		transfers, err := findOwnershipTransfers(c, fromID, resourceType, adminAccess)
+		if err != nil {
+			return err
+		}
+		if len(transfers) == 0 {
+			output.PrintInfo("Nothing owned by %s", fromName)
+			return nil
+		}
+
+		// Check every transfer before changing anything.
+		checker, err := NewSafetyChecker(cfg)
+		if err != nil {
+			return err
+		}
+		currentUserID, _ := c.CurrentUserID()
+		ownership := safety.DetermineOwnership(fromID, currentUserID)
+		for _, t := range transfers {
+			if err := checker.ForResource(t.Type, t.Name).CheckError(safety.OperationUpdate, ownership); err != nil {
+				return err
+			}
+		}
+
+		printer := NewPrinter()
+		if ap := enrichAgent(printer, "transfer-ownership", "documents"); ap != nil {
+			ap.SetTotal(len(transfers))
+		}
+		if err := printer.PrintList(transfers); err != nil {
+			return err
+		}
+
+		if dryRun {
+			output.PrintInfo("Dry run: %d resource(s) would be transferred from %s to %s", len(transfers), fromName, newOwner.DisplayName())
+			return nil
+		}
+		if !forceDelete && !plainMode {
+			if !prompt.Confirm(fmt.Sprintf("Transfer %d resource(s) from %s to %s in context '%s'?", len(transfers), fromName, newOwner.DisplayName(), cfg.CurrentContext)) {
+				fmt.Println("Transfer cancelled")
+				return nil
+			}
+		}
+
+		docs := document.NewHandler(c)
+		workflows := workflow.NewHandler(c)
+		failed := 0
+		for _, t := range transfers {
+			if t.Type == "workflow" {
+				_, err = workflows.TransferOwner(t.ID, newOwner.UID)
+			} else {
+				err = docs.TransferOwner(t.ID, t.Version, newOwner.UID, adminAccess)
+			}
+			if err != nil {
+				failed++
+				output.PrintWarning("Could not transfer %s %q: %v", t.Type, t.Name, err)
+				continue
+			}
+			if t.Type == "workflow" && t.Actor == fromID {
+				output.PrintWarning("Workflow %q still runs as %s; change its actor with 'dtctl edit workflow %s'", t.Name, fromName, t.ID)
+			}
+		}
+		if failed > 0 {
+			return fmt.Errorf("%d of %d transfer(s) failed", failed, len(transfers))
+		}
+		output.PrintSuccess("Transferred %d resource(s) from %s to %s", len(transfers), fromName, newOwner.DisplayName())
+		return nil
+	},
+}
+
+// findOwnershipTransfers lists the documents and workflows owned by owner.
+// resourceType "workflow" selects workflows, any other type selects documents
+// of that type; empty selects all documents and workflows.
+func findOwnershipTransfers(c *client.Client, owner, resourceType string, adminAccess bool) ([]ownershipTransfer, error) {
+	var transfers []ownershipTransfer
+	if resourceType != "workflow" {
+		list, err := document.NewHandler(c).List(document.DocumentFilters{
+			Type:        resourceType,
+			Owner:       owner,
+			ChunkSize:   GetChunkSize(),
+			AdminAccess: adminAccess,
+		})
+		if err != nil {
+			return nil, err
+		}
+		for _, d := range list.Documents {
+			transfers = append(transfers, ownershipTransfer{Type: d.Type, ID: d.ID, Name: d.Name, Version: d.Version})
+		}
+	}
+	if resourceType == "" || resourceType == "workflow" {
+		list, err := workflow.NewHandler(c).List(workflow.WorkflowFilters{Owner: owner})
+		if err != nil {
+			return nil, err
+		}
+		for _, wf := range list.Results {
+			transfers = append(transfers, ownershipTransfer{Type: "workflow", ID: wf.ID, Name: wf.Title, Actor: wf.Actor})
+		}
+	}
+	return transfers, nil
+}
+
+func init() {
+	rootCmd.AddCommand(transferOwnershipCmd)
+	transferOwnershipCmd.Flags().String("from", "", "Current owner (UUID, email or unique partial name)")
+	transferOwnershipCmd.Flags().String("to", "", "New owner (UUID, email or unique partial name)")
+	transferOwnershipCmd.Flags().String("type", "", "Transfer only this type: workflow or a document type (e.g. dashboard, notebook)")
+	transferOwnershipCmd.Flags().Bool("admin-access", false, "List and transfer documents as effective owner; requires document:documents:admin permission")
+	transferOwnershipCmd.Flags().BoolVarP(&forceDelete, "yes", "y", false, "Skip confirmation prompt")
+	_ = transferOwnershipCmd.MarkFlagRequired("from")
+	_ = transferOwnershipCmd.MarkFlagRequired("to")
+}
@@ -0,0 +1,104 @@
+package cmd
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/dynatrace-oss/dtctl/pkg/client"
+	"github.com/dynatrace-oss/dtctl/pkg/resources/document"
+	"github.com/dynatrace-oss/dtctl/pkg/resources/iam"
+	"github.com/dynatrace-oss/dtctl/pkg/resources/workflow"
+)
+
+func TestResolveUserID(t *testing.T) {
+	c := newIAMTestClient(t, testUser)
+	users := iam.NewHandler(c)
+
+	if id, err := resolveUserID(users, "jane.doe@example.com", false); err != nil || id != testUserID {
+		t.Errorf("resolveUserID(email) = %q, %v", id, err)
+	}
+	if id, err := resolveUserID(users, removedUserID, true); err != nil || id != removedUserID {
+		t.Errorf("resolveUserID(removed UUID, allowRemoved) = %q, %v", id, err)
+	}
+	if _, err := resolveUserID(users, removedUserID, false); err == nil {
+		t.Error("expected error for removed UUID without allowRemoved")
+	}
+	if _, err := resolveUserID(users, "gone@example.com", true); err == nil || !strings.Contains(err.Error(), "only be given by UUID") {
+		t.Errorf("expected hint to use the UUID, got %v", err)
+	}
+}
+
+func TestFindOwnershipTransfers(t *testing.T) {
+	var docFilters []string
+	mux := http.NewServeMux()
+	mux.HandleFunc("/platform/document/v1/documents", func(w http.ResponseWriter, r *http.Request) {
+		docFilters = append(docFilters, r.URL.Query().Get("filter"))
+		if r.URL.Query().Get("admin-access") != "true" {
+			t.Error("expected admin-access=true")
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(document.DocumentList{
+			Documents:  []document.DocumentMetadata{{ID: "d1", Name: "Prod", Type: "dashboard", Owner: removedUserID, Version: 7}},
+			TotalCount: 1,
+		})
+	})
+	mux.HandleFunc("/platform/automation/v1/workflows", func(w http.ResponseWriter, r *http.Request) {
+		if got := r.URL.Query().Get("owner"); got != removedUserID {
+			t.Errorf("expected owner filter %q, got %q", removedUserID, got)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(workflow.WorkflowList{
+			Count:   1,
+			Results: []workflow.Workflow{{ID: "wf1", Title: "Nightly", Owner: removedUserID, Actor: removedUserID}},
+		})
+	})
+	srv := httptest.NewServer(mux)
+	defer srv.Close()
+	c, err := client.NewForTesting(srv.URL, "test-token")
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+
+	tests := []struct {
+		resourceType string
+		wantTypes    []string
+		wantFilter   string
+	}{
+		{resourceType: "", wantTypes: []string{"dashboard", "workflow"}, wantFilter: "owner=='" + removedUserID + "'"},
+		{resourceType: "dashboard", wantTypes: []string{"dashboard"}, wantFilter: "type=='dashboard' and owner=='" + removedUserID + "'"},
+		{resourceType: "workflow", wantTypes: []string{"workflow"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.resourceType, func(t *testing.T) {
+			docFilters = nil
+			transfers, err := findOwnershipTransfers(c, removedUserID, tt.resourceType, true)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			var types []string
+			for _, tr := range transfers {
+				types = append(types, tr.Type)
+			}
+			if strings.Join(types, ",") != strings.Join(tt.wantTypes, ",") {
+				t.Errorf("expected types %v, got %v", tt.wantTypes, types)
+			}
+			if tt.wantFilter == "" && docFilters != nil {
+				t.Errorf("expected no document listing, got filters %v", docFilters)
+			}
+			if tt.wantFilter != "" && (len(docFilters) != 1 || docFilters[0] != tt.wantFilter) {
+				t.Errorf("expected document filter %q, got %v", tt.wantFilter, docFilters)
+			}
+			for _, tr := range transfers {
+				if tr.Type == "dashboard" && tr.Version != 7 {
+					t.Errorf("expected document version 7 for optimistic locking, got %d", tr.Version)
+				}
+				if tr.Type == "workflow" && tr.Actor != removedUserID {
+					t.Errorf("expected workflow actor to be kept, got %q", tr.Actor)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,187 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/dynatrace-oss/dtctl/pkg/audit"
+	"github.com/dynatrace-oss/dtctl/pkg/client"
+	"github.com/dynatrace-oss/dtctl/pkg/config"
+	"github.com/dynatrace-oss/dtctl/pkg/output"
+	"github.com/dynatrace-oss/dtctl/pkg/prompt"
+	"github.com/dynatrace-oss/dtctl/pkg/safety"
+	"github.com/dynatrace-oss/dtctl/pkg/undo"
+)
+
+// undoJournalFunc returns the undo journal; tests override it.
+var undoJournalFunc = undo.DefaultJournal
+
+// trackUndoSnapshots records pre-change snapshots of workflows and documents
+// changed by the running command, so that 'dtctl undo' can revert them.
+func trackUndoSnapshots(c *client.Client, cfg *config.Config) {
+	if _, mutating := mutatingVerb(); !mutating || activeCommandPath == "dtctl undo" || dryRun || !undo.Enabled() {
+		return
+	}
+	ctx, err := cfg.CurrentContextObj()
+	if err != nil {
+		return
+	}
+	command := strings.Join(append([]string{"dtctl"}, audit.RedactArgs(activeCommandArgs)...), " ")
+	recorder := undo.NewRecorder(c, undoJournalFunc(), cfg.CurrentContext, ctx.Environment, command)
+	recorder.OnError = func(err error) {
+		output.PrintWarning("Could not record undo snapshot: %v", err)
+	}
+	recorder.Attach()
+}
+
+var (
+	undoList  bool
+	undoCount int
+)
+
+// undoCmd reverts the last recorded operations
+var undoCmd = &cobra.Command{
+	Use:   "undo",
+	Short: "Revert the last create, update or delete of a workflow or document",
+	Long: `Revert the last mutating operations on workflows and documents (dashboards,
+notebooks, ...) in the current context.
+
+Before a workflow or document is changed, dtctl records its previous state in a
+local undo journal. 'dtctl undo' reverts the newest operations:
+
+  created resources   are deleted (documents are moved to the trash)
+  updated resources   are restored to their previous version, using the
+                      workflow history or document snapshots when available
+  deleted resources   are re-created (documents are restored from the trash;
+                      workflows get a new ID)
+
+A preview of every revert is shown before confirmation, and each revert passes
+the safety checks of the context. The journal keeps the last 50 operations;
+set DTCTL_UNDO=off to disable recording.`,
+	Example: `  # Show the operations that can be undone
+  dtctl undo --list
+
+  # Revert the last operation
+  dtctl undo
+
+  # Revert the last 3 operations without confirmation
+  dtctl undo -n 3 -y
+
+  # Preview only
+  dtctl undo -n 2 --dry-run`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cfg, err := LoadConfig()
+		if err != nil {
+			return err
+		}
+		ctx, err := cfg.CurrentContextObj()
+		if err != nil {
+			return err
+		}
+
+		journal := undoJournalFunc()
+		pending, err := journal.Pending(cfg.CurrentContext, ctx.Environment)
+		if err != nil {
+			return err
+		}
+
+		if undoList {
+			printer := NewPrinter()
+			if ap := enrichAgent(printer, "list", "undo"); ap != nil {
+				ap.SetTotal(len(pending))
+			}
+			return printer.PrintList(pending)
+		}
+
+		if len(pending) == 0 {
+			output.PrintInfo("Nothing to undo in context '%s'", cfg.CurrentContext)
+			return nil
+		}
+		if undoCount < 1 {
+			return fmt.Errorf("--count must be at least 1")
+		}
+		if undoCount > len(pending) {
+			return fmt.Errorf("only %d operation(s) can be undone in context '%s'", len(pending), cfg.CurrentContext)
+		}
+
+		c, err := NewClientFromConfig(cfg)
+		if err != nil {
+			return err
+		}
+		checker, err := NewSafetyChecker(cfg)
+		if err != nil {
+			return err
+		}
+		currentUserID, _ := c.CurrentUserID()
+
+		// Plan and check every revert before changing anything.
+		reverter := undo.NewReverter(c)
+		plans := make([]*undo.Plan, 0, undoCount)
+		for _, e := range pending[:undoCount] {
+			plan, err := reverter.Plan(e)
+			if err != nil {
+				return fmt.Errorf("cannot undo %q: %w", e.Command, err)
+			}
+			ownership := safety.DetermineOwnership(plan.Owner, currentUserID)
+			if err := checker.ForResource(e.Resource, e.Name).WithChangeDiff(plan.Diff).CheckError(plan.Operation, ownership); err != nil {
+				return err
+			}
+			plans = append(plans, plan)
+		}
+
+		for i, plan := range plans {
+			printUndoPlan(i+1, plan)
+		}
+
+		if dryRun {
+			output.PrintInfo("Dry run: %d operation(s) would be undone", len(plans))
+			return nil
+		}
+		if !forceDelete && !plainMode {
+			if !prompt.Confirm(fmt.Sprintf("Undo %d operation(s) in context '%s'?", len(plans), cfg.CurrentContext)) {
+				fmt.Println("Undo cancelled")
+				return nil
+			}
+		}
+
+		for _, plan := range plans {
+			if err := reverter.Revert(plan); err != nil {
+				return fmt.Errorf("failed to %s: %w", plan.Summary, err)
+			}
+			if err := journal.MarkUndone(plan.Entry.ID, time.Now().UTC()); err != nil {
+				output.PrintWarning("Could not update undo journal: %v", err)
+			}
+			if plan.NewID != "" {
+				output.PrintSuccess("Undone: %s (new ID %s)", plan.Summary, plan.NewID)
+			} else {
+				output.PrintSuccess("Undone: %s", plan.Summary)
+			}
+		}
+		return nil
+	},
+}
+
+func printUndoPlan(n int, plan *undo.Plan) {
+	e := plan.Entry
+	fmt.Printf("%d. %s  (%s, %s)\n", n, plan.Summary, e.Command, e.Time.Local().Format("2006-01-02 15:04:05"))
+	for _, line := range strings.Split(strings.TrimRight(plan.Diff, "\n"), "\n") {
+		if line != "" {
+			fmt.Printf("   %s\n", line)
+		}
+	}
+	for _, w := range plan.Warnings {
+		output.PrintWarning("%s", w)
+	}
+	fmt.Println()
+}
+
+func init() {
+	rootCmd.AddCommand(undoCmd)
+	undoCmd.Flags().BoolVar(&undoList, "list", false, "List the operations that can be undone, newest first")
+	undoCmd.Flags().IntVarP(&undoCount, "count", "n", 1, "Number of operations to undo")
+	undoCmd.Flags().BoolVarP(&forceDelete, "yes", "y", false, "Skip confirmation prompt")
+}
@@ -2,7 +2,6 @@ package cmd
 
 import (
 	"fmt"
-	"sort"
 	"strconv"
 	"strings"
 
@@ -12,13 +11,6 @@ import (
 	"github.com/dynatrace-oss/dtctl/pkg/safety"
 )
 
-const (
-	breakpointDefaultOutputMessage    = "Hit on {store.rookout.frame.filename}:{store.rookout.frame.line}"
-	breakpointRookoutTargetID         = "Rookout"
-	breakpointRookoutTargetName       = "send_rookout"
-	breakpointRookoutOnPremTargetName = "send_rookout_data_on_prem"
-)
-
 var updateBreakpointCmd = &cobra.Command{
 	Use:     "breakpoint [<id|filename:line>]",
 	Aliases: []string{"breakpoints", "bp"},
@@ -223,7 +215,7 @@ Examples:
 				return fmt.Errorf("breakpoint %q not found in the current workspace", identifier)
 			}
 			for _, rule := range targetRules {
-				ruleSettings, err := buildEditBreakpointSettings(rule, condition, true)
+				ruleSettings, err := livedebugger.BuildEditRuleSettings(rule, condition, true)
 				if err != nil {
 					return err
 				}
@@ -289,169 +281,6 @@ func resolveBreakpointRulesForEdit(rules []livedebugger.BreakpointRule, identifi
 	return nil, identifier, true, nil
 }
 
-func buildEditBreakpointSettings(rule livedebugger.BreakpointRule, condition string, conditionChanged bool) (map[string]interface{}, error) {
-	row, ok := breakpointRowFromRule(rule)
-	if !ok || row.ID == "" {
-		return nil, fmt.Errorf("rule missing mutable rule id")
-	}
-
-	aug := rule.AugJSON
-	if aug == nil {
-		return nil, fmt.Errorf("rule %s missing aug_json", row.ID)
-	}
-
-	if _, hasLocation := aug["location"].(map[string]interface{}); !hasLocation {
-		return nil, fmt.Errorf("rule %s missing aug_json", row.ID)
-	}
-
-	paths := extractBreakpointOperationPaths(aug)
-	currentCondition := stringValue(aug["conditional"])
-	if conditionChanged {
-		currentCondition = condition
-	}
-
-	settings := map[string]interface{}{
-		"mutableRuleId":               row.ID,
-		"collectLocalsMethod":         stringValue(paths["store.rookout.frame"]),
-		"stackTraceCollection":        stringValue(paths["store.rookout.traceback"]) == "stack.traceback()",
-		"ttlHitLimit":                 intValue(aug["globalHitLimit"], 100),
-		"ttlTimeLimit":                stringValue(aug["globalDisableAfterTime"]),
-		"ttlTimeLimitInterval":        0,
-		"collectedVariables":          extractCollectedVariables(paths),
-		"targetConfiguration":         map[string]interface{}{"targetId": extractBreakpointTargetID(rule)},
-		"outputMessage":               extractBreakpointOutputMessage(rule),
-		"condition":                   currentCondition,
-		"rateLimit":                   stringValue(aug["rateLimit"]),
-		"tracingCollection":           stringValue(paths["store.rookout.tracing"]) == "trace.dump()",
-		"processMonitoringCollection": stringValue(paths["store.rookout.processMonitoring"]) == "state.dump()",
-	}
-
-	return settings, nil
-}
-
-func extractBreakpointOperationPaths(aug map[string]interface{}) map[string]interface{} {
-	paths := map[string]interface{}{}
-	action, ok := aug["action"].(map[string]interface{})
-	if !ok {
-		return paths
-	}
-	operations, ok := action["operations"].([]interface{})
-	if !ok {
-		return paths
-	}
-	for _, operationIfc := range operations {
-		operation, ok := operationIfc.(map[string]interface{})
-		if !ok {
-			continue
-		}
-		opPaths, ok := operation["paths"].(map[string]interface{})
-		if !ok {
-			continue
-		}
-		for key, value := range opPaths {
-			paths[key] = value
-		}
-	}
-	return paths
-}
-
-func extractCollectedVariables(paths map[string]interface{}) []string {
-	variables := make([]string, 0)
-	for key, value := range paths {
-		if !strings.HasPrefix(key, "store.rookout.variables.") {
-			continue
-		}
-		pathValue := stringValue(value)
-		if pathValue == "" {
-			continue
-		}
-		variables = append(variables, formatCollectedVariable(pathValue))
-	}
-	sort.Strings(variables)
-	return variables
-}
-
-func formatCollectedVariable(path string) string {
-	trimmed := strings.TrimPrefix(path, "frame.")
-	if className, member, ok := parseThreadLocalPath(trimmed); ok {
-		return className + "." + member
-	}
-	return trimmed
-}
-
-func parseThreadLocalPath(path string) (string, string, bool) {
-	if !strings.HasPrefix(path, "utils.class(\"") {
-		return "", "", false
-	}
-	rest := strings.TrimPrefix(path, "utils.class(\"")
-	className, remainder, found := strings.Cut(rest, "\")")
-	if !found || className == "" {
-		return "", "", false
-	}
-	member := strings.TrimPrefix(remainder, ".")
-	if member == "" {
-		return "", "", false
-	}
-	return className, member, true
-}
-
-func extractBreakpointOutputMessage(rule livedebugger.BreakpointRule) string {
-	processing := rule.Processing
-	if processing == nil {
-		return breakpointDefaultOutputMessage
-	}
-
-	_, ok := processing["operations"].([]interface{})
-	if !ok {
-		return breakpointDefaultOutputMessage
-	}
-	operations, ok := processing["operations"].([]interface{})
-	if !ok {
-		return breakpointDefaultOutputMessage
-	}
-	for _, operationIfc := range operations {
-		operation, ok := operationIfc.(map[string]interface{})
-		if !ok {
-			continue
-		}
-		if stringValue(operation["path"]) == "temp.message.rookout.message" {
-			format := stringValue(operation["format"])
-			if format != "" {
-				return format
-			}
-		}
-	}
-	return breakpointDefaultOutputMessage
-}
-
-func extractBreakpointTargetID(rule livedebugger.BreakpointRule) string {
-	processing := rule.Processing
-	if processing == nil {
-		return breakpointRookoutTargetID
-	}
-
-	_, ok := processing["operations"].([]interface{})
-	if !ok {
-		return breakpointRookoutTargetID
-	}
-	operations, ok := processing["operations"].([]interface{})
-	if !ok || len(operations) == 0 {
-		return breakpointRookoutTargetID
-	}
-	lastOperation, ok := operations[len(operations)-1].(map[string]interface{})
-	if !ok {
-		return breakpointRookoutTargetID
-	}
-	name := stringValue(lastOperation["name"])
-	if name == breakpointRookoutTargetName || name == breakpointRookoutOnPremTargetName {
-		return breakpointRookoutTargetID
-	}
-	if targetID := stringValue(lastOperation["target_id"]); targetID != "" {
-		return targetID
-	}
-	return breakpointRookoutTargetID
-}
-
 func findBreakpointRulesByLocation(rules []livedebugger.BreakpointRule, fileName string, lineNumber int) []livedebugger.BreakpointRule {
 	matches := make([]livedebugger.BreakpointRule, 0)
 	for _, rule := range rules {
@@ -480,21 +309,6 @@ func stringValue(value interface{}) string {
 	return stringVal
 }
 
-func intValue(value interface{}, defaultValue int) int {
-	switch typed := value.(type) {
-	case int:
-		return typed
-	case int32:
-		return int(typed)
-	case int64:
-		return int(typed)
-	case float64:
-		return int(typed)
-	default:
-		return defaultValue
-	}
-}
-
 func describeBreakpointEdits(conditionChanged bool, condition string, enabledChanged bool, enabled bool) string {
 	changes := make([]string, 0, 2)
 	if conditionChanged {
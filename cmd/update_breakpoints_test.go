@@ -56,90 +56,6 @@ func TestUpdateBreakpointFilters_NoIdentifier_NoPanic(t *testing.T) {
 	}
 }
 
-func TestBuildEditBreakpointSettings(t *testing.T) {
-	rule := livedebugger.BreakpointRule{
-		ID: "dtctl-rule-1",
-		AugJSON: map[string]interface{}{
-			"action": map[string]interface{}{
-				"operations": []interface{}{
-					map[string]interface{}{
-						"name": "set",
-						"paths": map[string]interface{}{
-							"store.rookout.frame":                    "frame.dump()",
-							"store.rookout.traceback":                "stack.traceback()",
-							"store.rookout.tracing":                  "trace.dump()",
-							"store.rookout.processMonitoring":        "state.dump()",
-							"store.rookout.variables.customerId":     "frame.customerId",
-							"store.rookout.variables.MyClass.thread": "utils.class(\"com.example.MyClass\").thread",
-						},
-					},
-				},
-			},
-			"conditional":            nil,
-			"globalDisableAfterTime": "2026-03-17T08:25:11Z",
-			"globalHitLimit":         float64(100),
-			"location": map[string]interface{}{
-				"filename": "OrderController.java",
-				"lineno":   float64(306),
-			},
-			"rateLimit": "150/20000",
-		},
-		Processing: map[string]interface{}{
-			"operations": []interface{}{
-				map[string]interface{}{"name": "set", "paths": map[string]interface{}{"temp.message.rookout": "store.rookout"}},
-				map[string]interface{}{"name": "format", "path": "temp.message.rookout.message", "format": "Hit on {store.rookout.frame.filename}:{store.rookout.frame.line}"},
-				map[string]interface{}{"name": "send_rookout", "path": "temp.message"},
-			},
-		},
-	}
-
-	settings, err := buildEditBreakpointSettings(rule, "value>othervalue", true)
-	if err != nil {
-		t.Fatalf("buildEditBreakpointSettings returned error: %v", err)
-	}
-
-	if settings["mutableRuleId"] != "dtctl-rule-1" {
-		t.Fatalf("unexpected mutableRuleId: %#v", settings["mutableRuleId"])
-	}
-	if settings["condition"] != "value>othervalue" {
-		t.Fatalf("unexpected condition: %#v", settings["condition"])
-	}
-	if settings["outputMessage"] != breakpointDefaultOutputMessage {
-		t.Fatalf("unexpected outputMessage: %#v", settings["outputMessage"])
-	}
-	if settings["collectLocalsMethod"] != "frame.dump()" {
-		t.Fatalf("unexpected collectLocalsMethod: %#v", settings["collectLocalsMethod"])
-	}
-	if settings["stackTraceCollection"] != true {
-		t.Fatalf("unexpected stackTraceCollection: %#v", settings["stackTraceCollection"])
-	}
-	if settings["tracingCollection"] != true {
-		t.Fatalf("unexpected tracingCollection: %#v", settings["tracingCollection"])
-	}
-	if settings["processMonitoringCollection"] != true {
-		t.Fatalf("unexpected processMonitoringCollection: %#v", settings["processMonitoringCollection"])
-	}
-
-	collectedVariables, ok := settings["collectedVariables"].([]string)
-	if !ok {
-		t.Fatalf("unexpected collectedVariables type: %#v", settings["collectedVariables"])
-	}
-	if len(collectedVariables) != 2 {
-		t.Fatalf("unexpected collectedVariables length: %#v", collectedVariables)
-	}
-	if collectedVariables[0] != "com.example.MyClass.thread" || collectedVariables[1] != "customerId" {
-		t.Fatalf("unexpected collectedVariables: %#v", collectedVariables)
-	}
-
-	targetConfiguration, ok := settings["targetConfiguration"].(map[string]interface{})
-	if !ok {
-		t.Fatalf("unexpected targetConfiguration: %#v", settings["targetConfiguration"])
-	}
-	if targetConfiguration["targetId"] != breakpointRookoutTargetID {
-		t.Fatalf("unexpected targetId: %#v", targetConfiguration["targetId"])
-	}
-}
-
 func TestResolveBreakpointRulesForEdit(t *testing.T) {
 	rules := []livedebugger.BreakpointRule{
 		{
@@ -282,60 +198,6 @@ func TestGetOptionalBoolFlag_ErrorCases(t *testing.T) {
 	})
 }
 
-func TestExtractBreakpointOperationPaths(t *testing.T) {
-	t.Run("missing action", func(t *testing.T) {
-		paths := extractBreakpointOperationPaths(map[string]interface{}{})
-		if len(paths) != 0 {
-			t.Fatalf("expected empty map, got %#v", paths)
-		}
-	})
-
-	t.Run("invalid operations type", func(t *testing.T) {
-		paths := extractBreakpointOperationPaths(map[string]interface{}{"action": map[string]interface{}{"operations": "invalid"}})
-		if len(paths) != 0 {
-			t.Fatalf("expected empty map, got %#v", paths)
-		}
-	})
-
-	t.Run("merges valid operation paths", func(t *testing.T) {
-		paths := extractBreakpointOperationPaths(map[string]interface{}{
-			"action": map[string]interface{}{
-				"operations": []interface{}{
-					"skip",
-					map[string]interface{}{"name": "set"},
-					map[string]interface{}{"name": "set", "paths": map[string]interface{}{"a": "1", "b": "2"}},
-					map[string]interface{}{"name": "set", "paths": map[string]interface{}{"b": "3"}},
-				},
-			},
-		})
-
-		if got := len(paths); got != 2 {
-			t.Fatalf("expected 2 paths, got %d (%#v)", got, paths)
-		}
-		if paths["a"] != "1" || paths["b"] != "3" {
-			t.Fatalf("unexpected merged paths: %#v", paths)
-		}
-	})
-}
-
-func TestIntValue(t *testing.T) {
-	if got := intValue(10, 99); got != 10 {
-		t.Fatalf("unexpected int conversion: %d", got)
-	}
-	if got := intValue(int32(11), 99); got != 11 {
-		t.Fatalf("unexpected int32 conversion: %d", got)
-	}
-	if got := intValue(int64(12), 99); got != 12 {
-		t.Fatalf("unexpected int64 conversion: %d", got)
-	}
-	if got := intValue(float64(13.9), 99); got != 13 {
-		t.Fatalf("unexpected float64 conversion: %d", got)
-	}
-	if got := intValue("invalid", 99); got != 99 {
-		t.Fatalf("unexpected default conversion: %d", got)
-	}
-}
-
 func TestDescribeBreakpointEdits(t *testing.T) {
 	if got := describeBreakpointEdits(false, "", false, false); got != "" {
 		t.Fatalf("expected empty changes, got %q", got)
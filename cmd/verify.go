@@ -30,6 +30,12 @@ Examples:
   # Verify and fail on warnings (strict mode for CI/CD)
   dtctl verify query -f query.dql --fail-on-warn
 
+  # Verify the include filters of a segment definition
+  dtctl verify segment -f segment.yaml --check-fields
+
+  # Validate settings objects offline against cached schemas
+  dtctl verify settings -f pipeline.yaml
+
 Exit Codes:
   0 - Verification successful
   1 - Verification failed (errors found)
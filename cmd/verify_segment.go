@@ -0,0 +1,324 @@
+package cmd
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/dynatrace-oss/dtctl/pkg/exec"
+	"github.com/dynatrace-oss/dtctl/pkg/resources/segment"
+	"github.com/dynatrace-oss/dtctl/pkg/util/format"
+)
+
+// segmentIncludeCheck is the verification result of a single include filter.
+type segmentIncludeCheck struct {
+	Segment    string                  `json:"segment,omitempty"`
+	Include    int                     `json:"include"`
+	DataObject string                  `json:"dataObject"`
+	Filter     string                  `json:"filter"`
+	Valid      bool                    `json:"valid"`
+	Error      string                  `json:"error,omitempty"`
+	Line       int                     `json:"line,omitempty"`
+	Column     int                     `json:"column,omitempty"`
+	Normalized string                  `json:"normalized,omitempty"`
+	Query      string                  `json:"query,omitempty"`
+	Fields     *exec.DQLVerifyResponse `json:"fields,omitempty"`
+	Skipped    string                  `json:"skipped,omitempty"`
+}
+
+// verifySegmentCmd represents the verify segment subcommand
+var verifySegmentCmd = &cobra.Command{
+	Use:     "segment -f <file>",
+	Aliases: []string{"segments", "seg"},
+	Short:   "Verify the include filters of a segment definition",
+	Long: `Verify the include filters of a segment definition file.
+
+Every include filter is parsed locally with the same parser used by
+'create segment' and 'apply'. Parse errors are reported with their line and
+column, and valid filters are printed in their normalized form. Filters stored
+as JSON AST (as returned by 'get segment -o yaml') are rendered back to DQL.
+
+With --check-fields, each filter is additionally wrapped in a query against
+its data object ("fetch <dataObject> | filter ...") and sent to the DQL verify
+API, so that the server reports problems such as unknown fields or type
+mismatches. Includes for all data objects (_all_data_object) and metrics have
+no single fetch source and are skipped. --check-fields requires a context.
+
+The file may contain a single segment or a list of segments.
+
+The verify command returns different exit codes based on the result:
+  0 - All include filters are valid
+  1 - At least one include filter is invalid (or has warnings with --fail-on-warn)
+  2 - Authentication/permission error (--check-fields)
+  3 - Network/server error (--check-fields)
+
+Examples:
+  # Check filter syntax offline
+  dtctl verify segment -f segment.yaml
+
+  # Also ask the server to verify each filter against its data object
+  dtctl verify segment -f segment.yaml --check-fields
+
+  # Verify an existing segment
+  dtctl get segment <uid> -o yaml | dtctl verify segment -f -
+
+  # Structured output for CI
+  dtctl verify segment -f segment.yaml --check-fields --fail-on-warn -o json
+`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		file, _ := cmd.Flags().GetString("file")
+		checkFields, _ := cmd.Flags().GetBool("check-fields")
+		failOnWarn, _ := cmd.Flags().GetBool("fail-on-warn")
+
+		var (
+			data []byte
+			err  error
+		)
+		if file == "-" {
+			data, err = io.ReadAll(os.Stdin)
+		} else {
+			data, err = os.ReadFile(file)
+		}
+		if err != nil {
+			return fmt.Errorf("failed to read segment definition: %w", err)
+		}
+
+		segments, err := parseSegmentDefinitions(data)
+		if err != nil {
+			return err
+		}
+
+		checks := checkSegmentIncludes(segments)
+
+		if checkFields {
+			_, c, err := SetupClient()
+			if err != nil {
+				return err
+			}
+			executor := exec.NewDQLExecutor(c)
+			for i := range checks {
+				if err := verifySegmentIncludeFields(executor, &checks[i], failOnWarn); err != nil {
+					if code := getVerifyExitCode(nil, err, failOnWarn); code != 1 {
+						fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+						os.Exit(code)
+					}
+					return err
+				}
+			}
+		}
+
+		invalid := 0
+		for _, check := range checks {
+			if !check.Valid {
+				invalid++
+			}
+		}
+
+		if agentMode || (outputFormat != "" && outputFormat != "table" && outputFormat != "wide") {
+			printer := NewPrinter()
+			if ap := enrichAgent(printer, "verify", "segment"); ap != nil {
+				ap.SetTotal(len(checks))
+				if invalid > 0 {
+					ap.SetWarnings([]string{fmt.Sprintf("%d of %d include filters failed verification", invalid, len(checks))})
+				}
+			}
+			if err := printer.PrintList(checks); err != nil {
+				return err
+			}
+		} else {
+			printSegmentChecksHuman(checks)
+		}
+
+		if invalid > 0 {
+			os.Exit(1)
+		}
+		return nil
+	},
+}
+
+// parseSegmentDefinitions reads one segment or a list of segments from YAML or JSON.
+func parseSegmentDefinitions(data []byte) ([]segment.FilterSegment, error) {
+	jsonData, err := format.ValidateAndConvert(data)
+	if err != nil {
+		return nil, fmt.Errorf("invalid file format: %w", err)
+	}
+
+	var segments []segment.FilterSegment
+	if trimmed := strings.TrimSpace(string(jsonData)); strings.HasPrefix(trimmed, "[") {
+		if err := json.Unmarshal(jsonData, &segments); err != nil {
+			return nil, fmt.Errorf("failed to parse segment definitions: %w", err)
+		}
+	} else {
+		var seg segment.FilterSegment
+		if err := json.Unmarshal(jsonData, &seg); err != nil {
+			return nil, fmt.Errorf("failed to parse segment definition: %w", err)
+		}
+		segments = append(segments, seg)
+	}
+
+	total := 0
+	for _, seg := range segments {
+		total += len(seg.Includes)
+	}
+	if total == 0 {
+		return nil, fmt.Errorf("no include filters found in segment definition")
+	}
+	return segments, nil
+}
+
+// checkSegmentIncludes parses every include filter and records parse errors
+// and the normalized rendering.
+func checkSegmentIncludes(segments []segment.FilterSegment) []segmentIncludeCheck {
+	var checks []segmentIncludeCheck
+	for _, seg := range segments {
+		for i, inc := range seg.Includes {
+			check := segmentIncludeCheck{
+				Segment:    seg.Name,
+				Include:    i,
+				DataObject: inc.DataObject,
+				Filter:     strings.TrimSpace(inc.Filter),
+			}
+
+			ast, err := segment.FilterToAST(inc.Filter)
+			if err == nil {
+				check.Normalized, err = segment.FilterFromAST(ast)
+			}
+			if err != nil {
+				check.Error = err.Error()
+				var fe *segment.FilterError
+				if errors.As(err, &fe) {
+					check.Line, check.Column = fe.Line, fe.Column
+				}
+			} else {
+				check.Valid = true
+			}
+			checks = append(checks, check)
+		}
+	}
+	return checks
+}
+
+// verifySegmentIncludeFields verifies a parsed include filter against its data
+// object with the DQL verify API. Only API failures are returned as errors.
+func verifySegmentIncludeFields(executor *exec.DQLExecutor, check *segmentIncludeCheck, failOnWarn bool) error {
+	if !check.Valid {
+		return nil
+	}
+	query, ok, err := segment.FetchQueryForInclude(check.DataObject, check.Filter)
+	if err != nil {
+		check.Valid = false
+		check.Error = err.Error()
+		return nil
+	}
+	if !ok {
+		check.Skipped = fmt.Sprintf("data object %q cannot be verified with a fetch query", check.DataObject)
+		return nil
+	}
+
+	check.Query = query
+	result, err := executor.VerifyQuery(query, exec.DQLVerifyOptions{})
+	if err != nil {
+		return fmt.Errorf("include[%d] (%s): %w", check.Include, check.DataObject, err)
+	}
+	check.Fields = result
+	if getVerifyExitCode(result, nil, failOnWarn) != 0 {
+		check.Valid = false
+	}
+	return nil
+}
+
+// printSegmentChecksHuman prints verification results in human-readable format
+func printSegmentChecksHuman(checks []segmentIncludeCheck) {
+	useColor := isStderrTerminal()
+	mark := func(ok bool) string {
+		switch {
+		case ok && useColor:
+			return colorGreen + "✔" + colorReset
+		case ok:
+			return "✔"
+		case useColor:
+			return colorRed + "✖" + colorReset
+		default:
+			return "✖"
+		}
+	}
+
+	lastSegment := ""
+	invalid := 0
+	for i, check := range checks {
+		if check.Segment != "" && (i == 0 || check.Segment != lastSegment) {
+			fmt.Fprintf(os.Stderr, "Segment %q\n", check.Segment)
+			lastSegment = check.Segment
+		}
+		if !check.Valid {
+			invalid++
+		}
+
+		label := fmt.Sprintf("include[%d] (%s)", check.Include, check.DataObject)
+		if check.Error != "" {
+			if check.Line > 0 {
+				fmt.Fprintf(os.Stderr, "%s %s: %d:%d: %s\n", mark(false), label, check.Line, check.Column, check.Error)
+				pos := &exec.SyntaxPosition{Start: &exec.Position{Line: check.Line, Column: check.Column}}
+				_ = printSyntaxError(check.Filter, pos, useColor)
+			} else {
+				fmt.Fprintf(os.Stderr, "%s %s: %s\n", mark(false), label, check.Error)
+			}
+			continue
+		}
+
+		fmt.Fprintf(os.Stderr, "%s %s\n", mark(check.Valid), label)
+		fmt.Fprintf(os.Stderr, "  normalized: %s\n", check.Normalized)
+		if check.Skipped != "" {
+			fmt.Fprintf(os.Stderr, "  fields: skipped (%s)\n", check.Skipped)
+		}
+		if check.Fields == nil {
+			continue
+		}
+		if len(check.Fields.Notifications) == 0 {
+			fmt.Fprintf(os.Stderr, "  fields: verified against %s\n", check.DataObject)
+			continue
+		}
+		for _, n := range check.Fields.Notifications {
+			severity := n.Severity
+			if severity == "" {
+				severity = "INFO"
+			}
+			if useColor {
+				color := colorCyan
+				switch severity {
+				case "ERROR":
+					color = colorRed
+				case "WARN", "WARNING":
+					color = colorYellow
+				}
+				severity = color + severity + colorReset
+			}
+			fmt.Fprintf(os.Stderr, "  %s: %s\n", severity, n.Message)
+		}
+		fmt.Fprintf(os.Stderr, "  query:\n")
+		for _, line := range strings.Split(check.Query, "\n") {
+			fmt.Fprintf(os.Stderr, "    %s\n", line)
+		}
+	}
+
+	if invalid == 0 {
+		fmt.Fprintf(os.Stderr, "%s %d include filter(s) valid\n", mark(true), len(checks))
+	} else {
+		fmt.Fprintf(os.Stderr, "%s %d of %d include filter(s) failed verification\n", mark(false), invalid, len(checks))
+	}
+}
+
+func init() {
+	verifyCmd.AddCommand(verifySegmentCmd)
+
+	verifySegmentCmd.Flags().StringP("file", "f", "", "file containing the segment definition (use '-' for stdin)")
+	verifySegmentCmd.Flags().Bool("check-fields", false, "verify each filter against its data object with the DQL verify API")
+	verifySegmentCmd.Flags().Bool("fail-on-warn", false, "treat warnings from --check-fields as failures")
+	_ = verifySegmentCmd.MarkFlagRequired("file")
+}
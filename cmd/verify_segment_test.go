@@ -0,0 +1,54 @@
+package cmd
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/dynatrace-oss/dtctl/pkg/resources/segment"
+)
+
+func TestParseSegmentDefinitions(t *testing.T) {
+	single := "name: a\nincludes:\n  - dataObject: logs\n    filter: status = ERROR\n"
+	segments, err := parseSegmentDefinitions([]byte(single))
+	if err != nil || len(segments) != 1 || segments[0].Name != "a" {
+		t.Fatalf("unexpected result: %+v, %v", segments, err)
+	}
+
+	list := `[{"name":"a","includes":[{"dataObject":"logs","filter":"a = 1"}]},{"name":"b","includes":[{"dataObject":"spans","filter":"b = 2"}]}]`
+	segments, err = parseSegmentDefinitions([]byte(list))
+	if err != nil || len(segments) != 2 {
+		t.Fatalf("unexpected result: %+v, %v", segments, err)
+	}
+
+	if _, err := parseSegmentDefinitions([]byte("name: empty\n")); err == nil || !strings.Contains(err.Error(), "no include filters") {
+		t.Errorf("expected missing includes error, got %v", err)
+	}
+}
+
+func TestCheckSegmentIncludes(t *testing.T) {
+	ast, err := segment.FilterToAST(`status = ERROR`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	checks := checkSegmentIncludes([]segment.FilterSegment{{
+		Name: "seg",
+		Includes: []segment.Include{
+			{DataObject: "logs", Filter: `a = x b != "y"`},
+			{DataObject: "spans", Filter: "a = 1 AND\n  b == 2"},
+			{DataObject: "events", Filter: ast},
+		},
+	}})
+	if len(checks) != 3 {
+		t.Fatalf("expected 3 checks, got %d", len(checks))
+	}
+
+	if !checks[0].Valid || checks[0].Normalized != `a = "x" b != "y"` {
+		t.Errorf("unexpected first check: %+v", checks[0])
+	}
+	if checks[1].Valid || checks[1].Line != 2 || checks[1].Column != 5 {
+		t.Errorf("expected error at 2:5, got %+v", checks[1])
+	}
+	if !checks[2].Valid || checks[2].Normalized != `status = "ERROR"` {
+		t.Errorf("expected AST filter to be rendered, got %+v", checks[2])
+	}
+}
@@ -0,0 +1,296 @@
+package cmd
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/dynatrace-oss/dtctl/pkg/config"
+	"github.com/dynatrace-oss/dtctl/pkg/resources/settings"
+	"github.com/dynatrace-oss/dtctl/pkg/util/format"
+)
+
+// settingsSchemaCache returns the local cache of settings schema definitions
+func settingsSchemaCache() *settings.SchemaCache {
+	return settings.NewSchemaCache(filepath.Join(config.CacheDir(), "settings-schemas"))
+}
+
+// settingsCheck is the offline validation result of one settings object.
+type settingsCheck struct {
+	Object        int                        `json:"object"`
+	SchemaID      string                     `json:"schemaId"`
+	SchemaVersion string                     `json:"schemaVersion,omitempty"`
+	Scope         string                     `json:"scope,omitempty"`
+	Valid         bool                       `json:"valid"`
+	Error         string                     `json:"error,omitempty"`
+	Warning       string                     `json:"warning,omitempty"`
+	Errors        []settings.ValidationError `json:"errors,omitempty"`
+}
+
+// verifySettingsCmd represents the verify settings subcommand
+var verifySettingsCmd = &cobra.Command{
+	Use:     "settings -f <file>",
+	Aliases: []string{"setting"},
+	Short:   "Validate settings objects offline against cached schemas",
+	Long: `Validate settings objects against their schema without calling the API.
+
+Schemas are read from a local cache (under the dtctl cache directory), which is
+filled whenever dtctl fetches a schema: by 'get settings-schema',
+'describe settings-schema' or 'verify settings --fetch'. Each schema version is
+cached separately; objects with a schemaVersion are validated against that
+version, other objects against the newest cached version.
+
+The file may contain a settings object as used by 'apply' (schemaId, scope,
+value), a list of such objects, or, with --schema, a bare settings value.
+
+Checks performed locally:
+  - property types, enum values and nested types
+  - required (non-nullable) and unknown properties
+  - preconditions: properties whose precondition is not met are ignored
+  - list sizes and LENGTH, RANGE, PATTERN, NOT_BLANK, TRIMMED, NO_WHITESPACE
+    and UNIQUE constraints
+
+Constraints evaluated by server-side code are not checked; 'create settings'
+and 'apply' still validate with the API.
+
+The verify command returns different exit codes based on the result:
+  0 - All settings objects are valid
+  1 - At least one object is invalid or its schema is not cached
+
+Examples:
+  # Validate a settings file offline (e.g. in a pre-commit hook)
+  dtctl verify settings -f pipeline.yaml
+
+  # Fetch the schemas from the current context into the cache first
+  dtctl verify settings -f pipeline.yaml --fetch
+
+  # Validate a bare value against a schema
+  dtctl verify settings -f value.yaml --schema builtin:openpipeline.logs.pipelines
+
+  # Structured output for CI
+  dtctl verify settings -f settings.yaml -o json
+`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		file, _ := cmd.Flags().GetString("file")
+		schemaID, _ := cmd.Flags().GetString("schema")
+		schemaVersion, _ := cmd.Flags().GetString("schema-version")
+		fetch, _ := cmd.Flags().GetBool("fetch")
+
+		var (
+			data []byte
+			err  error
+		)
+		if file == "-" {
+			data, err = io.ReadAll(os.Stdin)
+		} else {
+			data, err = os.ReadFile(file)
+		}
+		if err != nil {
+			return fmt.Errorf("failed to read settings file: %w", err)
+		}
+
+		objects, err := parseSettingsObjects(data, schemaID, schemaVersion)
+		if err != nil {
+			return err
+		}
+
+		cache := settingsSchemaCache()
+		if fetch {
+			_, c, err := SetupClient()
+			if err != nil {
+				return err
+			}
+			handler := settings.NewHandler(c).WithSchemaCache(cache)
+			fetched := map[string]bool{}
+			for _, obj := range objects {
+				if fetched[obj.SchemaID] {
+					continue
+				}
+				if _, err := handler.GetSchema(obj.SchemaID); err != nil {
+					return err
+				}
+				fetched[obj.SchemaID] = true
+			}
+		}
+
+		checks := make([]settingsCheck, 0, len(objects))
+		invalid := 0
+		for i, obj := range objects {
+			check := checkSettingsObject(cache, obj)
+			check.Object = i
+			if !check.Valid {
+				invalid++
+			}
+			checks = append(checks, check)
+		}
+
+		if agentMode || (outputFormat != "" && outputFormat != "table" && outputFormat != "wide") {
+			printer := NewPrinter()
+			if ap := enrichAgent(printer, "verify", "settings"); ap != nil {
+				ap.SetTotal(len(checks))
+				if invalid > 0 {
+					ap.SetWarnings([]string{fmt.Sprintf("%d of %d settings objects failed validation", invalid, len(checks))})
+				}
+			}
+			if err := printer.PrintList(checks); err != nil {
+				return err
+			}
+		} else {
+			printSettingsChecksHuman(checks)
+		}
+
+		if invalid > 0 {
+			os.Exit(1)
+		}
+		return nil
+	},
+}
+
+// parseSettingsObjects reads one settings object, a list of settings objects,
+// or (with schemaID) a bare settings value from YAML or JSON.
+func parseSettingsObjects(data []byte, schemaID, schemaVersion string) ([]settings.SettingsObjectCreate, error) {
+	jsonData, err := format.ValidateAndConvert(data)
+	if err != nil {
+		return nil, fmt.Errorf("invalid file format: %w", err)
+	}
+
+	var raw []map[string]any
+	if trimmed := strings.TrimSpace(string(jsonData)); strings.HasPrefix(trimmed, "[") {
+		if err := json.Unmarshal(jsonData, &raw); err != nil {
+			return nil, fmt.Errorf("failed to parse settings objects: %w", err)
+		}
+	} else {
+		var obj map[string]any
+		if err := json.Unmarshal(jsonData, &obj); err != nil {
+			return nil, fmt.Errorf("failed to parse settings object: %w", err)
+		}
+		raw = append(raw, obj)
+	}
+	if len(raw) == 0 {
+		return nil, fmt.Errorf("no settings objects found")
+	}
+
+	objects := make([]settings.SettingsObjectCreate, 0, len(raw))
+	for i, m := range raw {
+		obj := settings.SettingsObjectCreate{SchemaVersion: schemaVersion}
+		value, isObject := m["value"].(map[string]any)
+		if isObject {
+			// Settings object (apply format); handle both camelCase and lowercase keys
+			obj.SchemaID, _ = m["schemaId"].(string)
+			if obj.SchemaID == "" {
+				obj.SchemaID, _ = m["schemaid"].(string)
+			}
+			obj.Scope, _ = m["scope"].(string)
+			if obj.SchemaVersion == "" {
+				obj.SchemaVersion, _ = m["schemaVersion"].(string)
+			}
+			obj.Value = value
+		} else if schemaID != "" {
+			obj.Value = m
+		} else {
+			return nil, fmt.Errorf("object %d has no 'value'; use --schema to validate a bare settings value", i)
+		}
+		if schemaID != "" {
+			obj.SchemaID = schemaID
+		}
+		if obj.SchemaID == "" {
+			return nil, fmt.Errorf("object %d has no schemaId; use --schema to set it", i)
+		}
+		objects = append(objects, obj)
+	}
+	return objects, nil
+}
+
+// checkSettingsObject validates one settings object against its cached schema
+func checkSettingsObject(cache *settings.SchemaCache, obj settings.SettingsObjectCreate) settingsCheck {
+	check := settingsCheck{SchemaID: obj.SchemaID, SchemaVersion: obj.SchemaVersion, Scope: obj.Scope}
+
+	schema, err := cache.Load(obj.SchemaID, obj.SchemaVersion)
+	var notCached *settings.SchemaNotCachedError
+	if errors.As(err, &notCached) && obj.SchemaVersion != "" {
+		// Fall back to the newest cached version
+		if schema, err = cache.Load(obj.SchemaID, ""); err == nil {
+			check.Warning = fmt.Sprintf("schema version %s is not cached; validated against version %v", obj.SchemaVersion, schema["version"])
+		}
+	}
+	if err != nil {
+		check.Error = err.Error()
+		if errors.As(err, &notCached) {
+			check.Error += "; run with --fetch or 'dtctl get settings-schema " + obj.SchemaID + "' to cache it"
+		}
+		return check
+	}
+
+	check.SchemaVersion, _ = schema["version"].(string)
+	check.Errors = settings.Validate(schema, obj.Value)
+	check.Valid = len(check.Errors) == 0
+	return check
+}
+
+// printSettingsChecksHuman prints validation results in human-readable format
+func printSettingsChecksHuman(checks []settingsCheck) {
+	useColor := isStderrTerminal()
+	mark := func(ok bool) string {
+		switch {
+		case ok && useColor:
+			return colorGreen + "✔" + colorReset
+		case ok:
+			return "✔"
+		case useColor:
+			return colorRed + "✖" + colorReset
+		default:
+			return "✖"
+		}
+	}
+
+	invalid := 0
+	for _, check := range checks {
+		label := fmt.Sprintf("object[%d] (%s", check.Object, check.SchemaID)
+		if check.SchemaVersion != "" {
+			label += " " + check.SchemaVersion
+		}
+		label += ")"
+		if !check.Valid {
+			invalid++
+		}
+
+		if check.Error != "" {
+			fmt.Fprintf(os.Stderr, "%s %s: %s\n", mark(false), label, check.Error)
+			continue
+		}
+		fmt.Fprintf(os.Stderr, "%s %s\n", mark(check.Valid), label)
+		if check.Warning != "" {
+			warning := "WARN"
+			if useColor {
+				warning = colorYellow + warning + colorReset
+			}
+			fmt.Fprintf(os.Stderr, "  %s: %s\n", warning, check.Warning)
+		}
+		for _, e := range check.Errors {
+			fmt.Fprintf(os.Stderr, "  %s\n", e.Error())
+		}
+	}
+
+	if invalid == 0 {
+		fmt.Fprintf(os.Stderr, "%s %d settings object(s) valid\n", mark(true), len(checks))
+	} else {
+		fmt.Fprintf(os.Stderr, "%s %d of %d settings object(s) failed validation\n", mark(false), invalid, len(checks))
+	}
+}
+
+func init() {
+	verifyCmd.AddCommand(verifySettingsCmd)
+
+	verifySettingsCmd.Flags().StringP("file", "f", "", "file containing the settings object(s) (use '-' for stdin)")
+	verifySettingsCmd.Flags().String("schema", "", "schema ID (required for bare settings values; overrides schemaId in the file)")
+	verifySettingsCmd.Flags().String("schema-version", "", "validate against this cached schema version")
+	verifySettingsCmd.Flags().Bool("fetch", false, "fetch the schemas from the current context into the cache before validating")
+	_ = verifySettingsCmd.MarkFlagRequired("file")
+}
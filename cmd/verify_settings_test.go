@@ -0,0 +1,91 @@
+package cmd
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/dynatrace-oss/dtctl/pkg/resources/settings"
+)
+
+func TestParseSettingsObjects(t *testing.T) {
+	tests := []struct {
+		name    string
+		data    string
+		schema  string
+		want    []string
+		wantErr string
+	}{
+		{
+			name: "single object",
+			data: "schemaId: builtin:a\nscope: environment\nvalue:\n  enabled: true\n",
+			want: []string{"builtin:a"},
+		},
+		{
+			name: "list with lowercase keys",
+			data: `[{"schemaid": "builtin:a", "value": {}}, {"schemaId": "builtin:b", "value": {}}]`,
+			want: []string{"builtin:a", "builtin:b"},
+		},
+		{
+			name:   "bare value with --schema",
+			data:   "enabled: true\n",
+			schema: "builtin:c",
+			want:   []string{"builtin:c"},
+		},
+		{
+			name:    "bare value without --schema",
+			data:    "enabled: true\n",
+			wantErr: "use --schema",
+		},
+		{
+			name:    "missing schemaId",
+			data:    "value: {}\n",
+			wantErr: "has no schemaId",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			objects, err := parseSettingsObjects([]byte(tt.data), tt.schema, "")
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("err = %v, want it to contain %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			var got []string
+			for _, obj := range objects {
+				got = append(got, obj.SchemaID)
+			}
+			if strings.Join(got, ",") != strings.Join(tt.want, ",") {
+				t.Errorf("schema IDs = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCheckSettingsObject_VersionFallback(t *testing.T) {
+	cache := settings.NewSchemaCache(t.TempDir())
+	if err := cache.Store(map[string]any{
+		"schemaId":   "builtin:a",
+		"version":    "1.1",
+		"properties": map[string]any{"enabled": map[string]any{"type": "boolean"}},
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	check := checkSettingsObject(cache, settings.SettingsObjectCreate{
+		SchemaID:      "builtin:a",
+		SchemaVersion: "1.0",
+		Value:         map[string]any{"enabled": true},
+	})
+	if !check.Valid || check.SchemaVersion != "1.1" || !strings.Contains(check.Warning, "1.0 is not cached") {
+		t.Errorf("check = %+v", check)
+	}
+
+	check = checkSettingsObject(cache, settings.SettingsObjectCreate{SchemaID: "builtin:b", Value: map[string]any{}})
+	if check.Valid || !strings.Contains(check.Error, "--fetch") {
+		t.Errorf("check = %+v", check)
+	}
+}
@@ -41,6 +41,9 @@ type Applier struct {
 	client        *client.Client
 	baseURL       string
 	safetyChecker *safety.Checker
+	safetyType    string // resource type matched by safety rules
+	safetyName    string // resource name matched by safety rules
+	safetyDiff    string // applied document, recorded in change requests
 	currentUserID string
 	preApplyHook  string    // hook command (empty = no hook)
 	postApplyHook string    // post-apply hook command (empty = no hook)
@@ -123,7 +126,26 @@ func (a *Applier) checkSafety(op safety.Operation, ownership safety.ResourceOwne
 	if a.safetyChecker == nil {
 		return nil // No checker configured, allow operation
 	}
-	return a.safetyChecker.CheckError(op, ownership)
+	return a.safetyChecker.ForResource(a.safetyType, a.safetyName).WithChangeDiff(a.safetyDiff).CheckError(op, ownership)
+}
+
+// resourceDisplayName returns the name or title of a resource document, for
+// matching safety rules with name patterns.
+func resourceDisplayName(data []byte) string {
+	var doc struct {
+		Name        string `json:"name"`
+		Title       string `json:"title"`
+		DisplayName string `json:"displayName"`
+	}
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return ""
+	}
+	for _, name := range []string{doc.Name, doc.Title, doc.DisplayName} {
+		if name != "" {
+			return name
+		}
+	}
+	return ""
 }
 
 // determineOwnership determines resource ownership given an owner ID
@@ -159,6 +181,7 @@ const (
 	ResourceExtensionConfig       ResourceType = "extension_config"
 	ResourceSegment               ResourceType = "segment"
 	ResourceAnomalyDetector       ResourceType = "anomaly_detector"
+	ResourceBreakpointSet         ResourceType = "breakpoint_set"
 	ResourceUnknown               ResourceType = "unknown"
 )
 
@@ -304,6 +327,9 @@ func (a *Applier) applySingle(resourceType ResourceType, jsonData []byte, opts A
 	var result ApplyResult
 	var err error
 
+	a.safetyType, a.safetyName = string(resourceType), resourceDisplayName(jsonData)
+	a.safetyDiff = string(jsonData)
+
 	// Connection resources can return multiple results
 	switch resourceType {
 	case ResourceAzureConnection:
@@ -338,6 +364,8 @@ func (a *Applier) applySingle(resourceType ResourceType, jsonData []byte, opts A
 		result, err = a.applySegment(jsonData)
 	case ResourceAnomalyDetector:
 		result, err = a.applyAnomalyDetector(jsonData)
+	case ResourceBreakpointSet:
+		result, err = a.applyBreakpointSet(jsonData, false)
 	default:
 		return nil, fmt.Errorf("unsupported resource type: %s", resourceType)
 	}
@@ -488,6 +516,11 @@ func detectResourceType(data []byte) (ResourceType, bool, error) {
 		}
 	}
 
+	// Live Debugger breakpoint sets have a "breakpoints" list
+	if _, ok := raw["breakpoints"].([]interface{}); ok {
+		return ResourceBreakpointSet, false, nil
+	}
+
 	// Heuristic detection based on field presence
 	// Workflows have "tasks" and "trigger" fields
 	if _, hasTasks := raw["tasks"]; hasTasks {
@@ -634,6 +667,11 @@ func (a *Applier) dryRun(resourceType ResourceType, data []byte) (ApplyResult, e
 		return a.dryRunDocument(resourceType, doc)
 	}
 
+	// Breakpoint sets are diffed against the live workspace (read-only)
+	if resourceType == ResourceBreakpointSet {
+		return a.applyBreakpointSet(data, true)
+	}
+
 	// Extension monitoring configs have specific fields
 	if resourceType == ResourceExtensionConfig {
 		return a.dryRunExtensionConfig(doc)
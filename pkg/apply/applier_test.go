@@ -178,6 +178,15 @@ func TestDetectResourceType(t *testing.T) {
 			expected: ResourceUnknown,
 			wantErr:  true,
 		},
+		{
+			name: "breakpoint set",
+			input: `{
+				"filters": {"k8s.namespace.name": ["prod"]},
+				"breakpoints": [{"filename": "OrderController.java", "lineNumber": 306}]
+			}`,
+			expected: ResourceBreakpointSet,
+			wantErr:  false,
+		},
 		{
 			name:     "array of unknown objects",
 			input:    `[{"random": "field"}]`,
@@ -457,6 +466,12 @@ func TestCountDocumentItems(t *testing.T) {
 			docType:  "dashboard",
 			expected: 3,
 		},
+		{
+			name:     "dashboard with tiles keyed by ID",
+			content:  `{"tiles": {"0": {"type": "data"}, "1": {"type": "markdown"}}, "version": 15}`,
+			docType:  "dashboard",
+			expected: 2,
+		},
 		{
 			name:     "dashboard with no tiles",
 			content:  `{"version": "1"}`,
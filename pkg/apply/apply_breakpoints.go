@@ -0,0 +1,203 @@
+package apply
+
+import (
+	"fmt"
+
+	"github.com/dynatrace-oss/dtctl/pkg/resources/livedebugger"
+	"github.com/dynatrace-oss/dtctl/pkg/safety"
+)
+
+// applyBreakpointSet reconciles the Live Debugger workspace with a breakpoint
+// set: missing breakpoints are created, conditions and enabled state are
+// updated, rules not listed in the file are removed, and the workspace
+// filters are replaced when the file specifies them.
+func (a *Applier) applyBreakpointSet(data []byte, dryRun bool) (ApplyResult, error) {
+	set, err := livedebugger.ParseBreakpointSet(data)
+	if err != nil {
+		return nil, err
+	}
+
+	handler, err := livedebugger.NewHandler(a.client, a.baseURL)
+	if err != nil {
+		return nil, err
+	}
+
+	project := set.Project
+	if project == "" {
+		project = livedebugger.DefaultProjectPath()
+	}
+
+	// Plan against the existing workspace without creating it, so that a dry
+	// run or a blocked apply leaves nothing behind on the server.
+	workspaceID, err := handler.FindWorkspace(project)
+	if err != nil {
+		return nil, fmt.Errorf("failed to look up Live Debugger workspace: %w", err)
+	}
+
+	var rules []livedebugger.BreakpointRule
+	if workspaceID != "" {
+		rules, err = fetchWorkspaceRules(handler, workspaceID)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	plan := livedebugger.PlanBreakpointSet(set, rules)
+	result := newBreakpointSetResult(workspaceID, project, set, plan)
+	result.DryRun = dryRun
+	if dryRun || result.Action == ActionUnchanged {
+		return result, nil
+	}
+
+	if len(plan.Create) > 0 {
+		if err := a.checkSafety(safety.OperationCreate, safety.OwnershipUnknown); err != nil {
+			return nil, err
+		}
+	}
+	if len(plan.Update) > 0 || set.Filters != nil {
+		if err := a.checkSafety(safety.OperationUpdate, safety.OwnershipUnknown); err != nil {
+			return nil, err
+		}
+	}
+	if len(plan.Delete) > 0 {
+		if err := a.checkSafety(safety.OperationDelete, safety.OwnershipUnknown); err != nil {
+			return nil, err
+		}
+	}
+
+	if workspaceID == "" {
+		_, workspaceID, err = handler.GetOrCreateWorkspace(project)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get Live Debugger workspace: %w", err)
+		}
+		result.ID = workspaceID
+	}
+
+	if set.Filters != nil {
+		if _, err := handler.UpdateWorkspaceFilters(workspaceID, livedebugger.BuildFilterSets(set.FilterMap())); err != nil {
+			return nil, fmt.Errorf("failed to update workspace filters: %w", err)
+		}
+	}
+
+	for _, rule := range plan.Delete {
+		if _, err := handler.DeleteBreakpoint(workspaceID, rule.ID); err != nil {
+			return nil, fmt.Errorf("failed to delete breakpoint %s: %w", rule.ID, err)
+		}
+	}
+
+	// New rules are created with defaults (no condition, enabled), so any
+	// that need a condition or must start disabled are updated afterwards.
+	followUp := make([]livedebugger.BreakpointSpec, 0)
+	for _, spec := range plan.Create {
+		if _, err := handler.CreateBreakpoint(workspaceID, spec.Filename, spec.LineNumber); err != nil {
+			return nil, fmt.Errorf("failed to create breakpoint at %s: %w", spec.Location(), err)
+		}
+		if spec.Condition != "" || !spec.IsEnabled() {
+			followUp = append(followUp, spec)
+		}
+	}
+
+	updates := plan.Update
+	if len(followUp) > 0 {
+		rules, err := fetchWorkspaceRules(handler, workspaceID)
+		if err != nil {
+			return nil, err
+		}
+		created := livedebugger.PlanBreakpointSet(&livedebugger.BreakpointSet{Breakpoints: followUp}, rules)
+		updates = append(updates, created.Update...)
+	}
+
+	if err := applyBreakpointChanges(handler, workspaceID, updates); err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// applyBreakpointChanges edits conditions one rule at a time and toggles
+// enabled state in at most two batched calls.
+func applyBreakpointChanges(handler *livedebugger.Handler, workspaceID string, changes []livedebugger.BreakpointChange) error {
+	var enable, disable []string
+	for _, change := range changes {
+		if change.ConditionChanged {
+			settings, err := livedebugger.BuildEditRuleSettings(change.Rule, change.Spec.Condition, true)
+			if err != nil {
+				return err
+			}
+			if _, err := handler.EditBreakpoint(workspaceID, settings); err != nil {
+				return fmt.Errorf("failed to update condition of breakpoint at %s: %w", change.Spec.Location(), err)
+			}
+		}
+		if change.EnabledChanged {
+			if change.Spec.IsEnabled() {
+				enable = append(enable, change.Rule.ID)
+			} else {
+				disable = append(disable, change.Rule.ID)
+			}
+		}
+	}
+
+	if len(enable) > 0 {
+		if _, err := handler.EnableOrDisableBreakpoints(workspaceID, enable, false); err != nil {
+			return fmt.Errorf("failed to enable breakpoints: %w", err)
+		}
+	}
+	if len(disable) > 0 {
+		if _, err := handler.EnableOrDisableBreakpoints(workspaceID, disable, true); err != nil {
+			return fmt.Errorf("failed to disable breakpoints: %w", err)
+		}
+	}
+	return nil
+}
+
+func fetchWorkspaceRules(handler *livedebugger.Handler, workspaceID string) ([]livedebugger.BreakpointRule, error) {
+	resp, err := handler.GetWorkspaceRules(workspaceID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list breakpoints: %w", err)
+	}
+	return livedebugger.ExtractWorkspaceRules(resp)
+}
+
+// newBreakpointSetResult summarizes a reconciliation plan.
+func newBreakpointSetResult(workspaceID, project string, set *livedebugger.BreakpointSet, plan livedebugger.BreakpointSetPlan) *BreakpointSetApplyResult {
+	changes := make([]string, 0, len(plan.Create)+len(plan.Update)+len(plan.Delete)+1)
+	for _, spec := range plan.Create {
+		changes = append(changes, "create "+spec.Location())
+	}
+	for _, change := range plan.Update {
+		if change.ConditionChanged {
+			changes = append(changes, fmt.Sprintf("update %s condition=%q", change.Spec.Location(), change.Spec.Condition))
+		}
+		if change.EnabledChanged {
+			changes = append(changes, fmt.Sprintf("update %s enabled=%t", change.Spec.Location(), change.Spec.IsEnabled()))
+		}
+	}
+	for _, rule := range plan.Delete {
+		filename, line, _ := livedebugger.RuleLocation(rule)
+		changes = append(changes, fmt.Sprintf("delete %s:%d (%s)", filename, line, rule.ID))
+	}
+	if set.Filters != nil {
+		changes = append(changes, "replace workspace filters")
+	}
+
+	action := ActionUpdated
+	if len(changes) == 0 {
+		action = ActionUnchanged
+	} else if workspaceID == "" {
+		changes = append([]string{"create workspace for " + project}, changes...)
+	}
+
+	return &BreakpointSetApplyResult{
+		ApplyResultBase: ApplyResultBase{
+			Action:       action,
+			ResourceType: string(ResourceBreakpointSet),
+			ID:           workspaceID,
+			Name:         project,
+		},
+		Created:   len(plan.Create),
+		Updated:   len(plan.Update),
+		Deleted:   len(plan.Delete),
+		Unchanged: plan.Unchanged,
+		Changes:   changes,
+	}
+}
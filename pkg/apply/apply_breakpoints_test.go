@@ -0,0 +1,97 @@
+package apply
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/dynatrace-oss/dtctl/pkg/client"
+	"github.com/dynatrace-oss/dtctl/pkg/config"
+	"github.com/dynatrace-oss/dtctl/pkg/safety"
+)
+
+// newBreakpointTestApplier returns an applier backed by a Live Debugger
+// GraphQL server without a workspace for the project, and the names of the
+// operations the server received.
+func newBreakpointTestApplier(t *testing.T) (*Applier, *[]string) {
+	t.Helper()
+
+	var operations []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/platform/dob/graphql" {
+			http.NotFound(w, r)
+			return
+		}
+		var body struct {
+			Query string `json:"query"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Fatalf("decode request body failed: %v", err)
+		}
+		name := strings.Fields(body.Query)[1]
+		name = name[:strings.Index(name, "(")]
+		operations = append(operations, name)
+
+		org := map[string]interface{}{}
+		switch name {
+		case "FindUserWorkspaceV2":
+			org["userWorkspaceV2"] = nil
+		case "GetOrCreateWorkspaceV2":
+			org["getOrCreateUserWorkspaceV2"] = map[string]interface{}{"id": "ws-1"}
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{"data": map[string]interface{}{"org": org}})
+	}))
+	t.Cleanup(server.Close)
+
+	c, err := client.NewForTesting(server.URL, "dt0c01.test")
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+	return NewApplier(c), &operations
+}
+
+func TestApplyBreakpointSet_DoesNotCreateWorkspaceEarly(t *testing.T) {
+	set := []byte(`{"breakpoints":[{"filename":"OrderController.java","lineNumber":306}]}`)
+
+	t.Run("dry run", func(t *testing.T) {
+		a, operations := newBreakpointTestApplier(t)
+		result, err := a.applyBreakpointSet(set, true)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if got := strings.Join(*operations, ","); got != "FindUserWorkspaceV2" {
+			t.Errorf("dry run must only look up the workspace, got %s", got)
+		}
+		if r := result.(*BreakpointSetApplyResult); r.Created != 1 || r.ID != "" {
+			t.Errorf("expected 1 planned breakpoint in a new workspace, got %+v", r)
+		}
+	})
+
+	t.Run("blocked by safety level", func(t *testing.T) {
+		a, operations := newBreakpointTestApplier(t)
+		a.WithSafetyChecker(safety.NewChecker("ro", &config.Context{SafetyLevel: config.SafetyLevelReadOnly}))
+		if _, err := a.applyBreakpointSet(set, false); err == nil {
+			t.Fatal("expected apply to be blocked in a readonly context")
+		}
+		if got := strings.Join(*operations, ","); got != "FindUserWorkspaceV2" {
+			t.Errorf("blocked apply must not create the workspace, got %s", got)
+		}
+	})
+
+	t.Run("apply", func(t *testing.T) {
+		a, operations := newBreakpointTestApplier(t)
+		result, err := a.applyBreakpointSet(set, false)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if got := strings.Join(*operations, ","); !strings.HasPrefix(got, "FindUserWorkspaceV2,GetOrCreateWorkspaceV2,") {
+			t.Errorf("expected the workspace to be created before the breakpoint, got %s", got)
+		}
+		if r := result.(*BreakpointSetApplyResult); r.ID != "ws-1" {
+			t.Errorf("expected workspace ID ws-1 in result, got %q", r.ID)
+		}
+	})
+}
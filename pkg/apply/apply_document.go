@@ -255,7 +255,11 @@ func countDocumentItems(contentData []byte, docType string) int {
 	}
 
 	if docType == "dashboard" {
-		if tiles, ok := content["tiles"].([]interface{}); ok {
+		// The Dashboards app stores tiles as a map keyed by tile ID
+		switch tiles := content["tiles"].(type) {
+		case []interface{}:
+			return len(tiles)
+		case map[string]interface{}:
 			return len(tiles)
 		}
 	} else if docType == "notebook" {
@@ -93,6 +93,19 @@ type AnomalyDetectorApplyResult struct {
 	ApplyResultBase `yaml:",inline"`
 }
 
+// BreakpointSetApplyResult is the result of reconciling a Live Debugger
+// workspace with a breakpoint set. ID is the workspace ID, empty in a dry
+// run against a workspace that does not exist yet.
+type BreakpointSetApplyResult struct {
+	ApplyResultBase `yaml:",inline"`
+	Created         int      `json:"created"             yaml:"created"             table:"CREATED"`
+	Updated         int      `json:"updated"             yaml:"updated"             table:"UPDATED"`
+	Deleted         int      `json:"deleted"             yaml:"deleted"             table:"DELETED"`
+	Unchanged       int      `json:"unchanged"           yaml:"unchanged"           table:"UNCHANGED"`
+	Changes         []string `json:"changes,omitempty"   yaml:"changes,omitempty"   table:"-"`
+	DryRun          bool     `json:"dryRun,omitempty"    yaml:"dryRun,omitempty"    table:"-"`
+}
+
 // DryRunResult is the result of a dry-run apply operation.
 // It reports what would happen without actually modifying anything.
 type DryRunResult struct {
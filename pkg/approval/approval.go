@@ -0,0 +1,196 @@
+// Package approval implements two-person approval for dangerous operations.
+//
+// A requester creates a signed ChangeRequest describing the operation; a second
+// engineer countersigns it with 'dtctl approve'. The approved request is then
+// passed back to the original command, which verifies both signatures against
+// the trusted keys in the config before proceeding.
+package approval
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"slices"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// DefaultTTL is how long a change request stays valid after it was created
+const DefaultTTL = 24 * time.Hour
+
+// ChangeRequest describes a dangerous operation awaiting approval. DiffHash
+// is the SHA-256 of the operation payload, so that an approval only covers
+// the exact payload it was requested for.
+type ChangeRequest struct {
+	ID          string    `yaml:"id" json:"id"`
+	Context     string    `yaml:"context" json:"context"`
+	Environment string    `yaml:"environment" json:"environment"`
+	Operation   string    `yaml:"operation" json:"operation"`
+	Resource    string    `yaml:"resource" json:"resource"`
+	Targets     []string  `yaml:"targets" json:"targets"`
+	Diff        string    `yaml:"diff,omitempty" json:"diff,omitempty"`
+	DiffHash    string    `yaml:"diff-hash" json:"diffHash"`
+	Requester   string    `yaml:"requester" json:"requester"`
+	CreatedAt   time.Time `yaml:"created-at" json:"createdAt"`
+	ExpiresAt   time.Time `yaml:"expires-at" json:"expiresAt"`
+
+	RequesterSignature string     `yaml:"requester-signature,omitempty" json:"-"`
+	Approver           string     `yaml:"approver,omitempty" json:"-"`
+	ApprovedAt         *time.Time `yaml:"approved-at,omitempty" json:"-"`
+	ApproverSignature  string     `yaml:"approver-signature,omitempty" json:"-"`
+}
+
+// NewChangeRequest creates an unsigned change request valid for DefaultTTL
+func NewChangeRequest(contextName, environment, operation, resource string, targets []string) *ChangeRequest {
+	id := make([]byte, 8)
+	_, _ = rand.Read(id)
+	now := time.Now().UTC().Truncate(time.Second)
+	return &ChangeRequest{
+		ID:          hex.EncodeToString(id),
+		Context:     contextName,
+		Environment: environment,
+		Operation:   operation,
+		Resource:    resource,
+		Targets:     targets,
+		CreatedAt:   now,
+		ExpiresAt:   now.Add(DefaultTTL),
+	}
+}
+
+// SetDiff records the payload of the operation and its hash
+func (r *ChangeRequest) SetDiff(diff string) {
+	r.Diff = diff
+	r.DiffHash = HashDiff(diff)
+}
+
+// HashDiff returns the hex-encoded SHA-256 of an operation payload
+func HashDiff(diff string) string {
+	sum := sha256.Sum256([]byte(diff))
+	return hex.EncodeToString(sum[:])
+}
+
+// requestPayload is what the requester signs: the request without signatures.
+func (r *ChangeRequest) requestPayload() []byte {
+	req := *r
+	if req.Targets == nil {
+		// nil and empty marshal differently but round-trip through YAML the same
+		req.Targets = []string{}
+	}
+	data, _ := json.Marshal(req)
+	return data
+}
+
+// approvalPayload is what the approver signs: the request, the requester's
+// signature and the approval metadata.
+func (r *ChangeRequest) approvalPayload() []byte {
+	approvedAt := ""
+	if r.ApprovedAt != nil {
+		approvedAt = r.ApprovedAt.UTC().Format(time.RFC3339)
+	}
+	data, _ := json.Marshal(struct {
+		Request            json.RawMessage `json:"request"`
+		RequesterSignature string          `json:"requesterSignature"`
+		Approver           string          `json:"approver"`
+		ApprovedAt         string          `json:"approvedAt"`
+	}{r.requestPayload(), r.RequesterSignature, r.Approver, approvedAt})
+	return data
+}
+
+// Sign signs the request as the requester
+func (r *ChangeRequest) Sign(signer string, key ed25519.PrivateKey) {
+	r.Requester = signer
+	r.RequesterSignature = base64.StdEncoding.EncodeToString(ed25519.Sign(key, r.requestPayload()))
+}
+
+// Approve countersigns the request. The approver must differ from the requester.
+func (r *ChangeRequest) Approve(approver string, key ed25519.PrivateKey) error {
+	if approver == r.Requester {
+		return fmt.Errorf("change request %s was created by %q and must be approved by someone else", r.ID, approver)
+	}
+	now := time.Now().UTC().Truncate(time.Second)
+	r.Approver = approver
+	r.ApprovedAt = &now
+	r.ApproverSignature = base64.StdEncoding.EncodeToString(ed25519.Sign(key, r.approvalPayload()))
+	return nil
+}
+
+// VerifyRequest checks the requester signature and expiry
+func (r *ChangeRequest) VerifyRequest(keys map[string]ed25519.PublicKey, now time.Time) error {
+	if now.After(r.ExpiresAt) {
+		return fmt.Errorf("change request %s expired at %s", r.ID, r.ExpiresAt.Format(time.RFC3339))
+	}
+	return verifySignature(keys, r.Requester, "requester", r.requestPayload(), r.RequesterSignature)
+}
+
+// Verify checks that the request is signed by its requester, countersigned by
+// a different approver, and not expired.
+func (r *ChangeRequest) Verify(keys map[string]ed25519.PublicKey, now time.Time) error {
+	if err := r.VerifyRequest(keys, now); err != nil {
+		return err
+	}
+	if r.ApproverSignature == "" {
+		return fmt.Errorf("change request %s has not been approved yet", r.ID)
+	}
+	if r.Approver == r.Requester {
+		return fmt.Errorf("change request %s was approved by its own requester %q", r.ID, r.Requester)
+	}
+	return verifySignature(keys, r.Approver, "approver", r.approvalPayload(), r.ApproverSignature)
+}
+
+// Covers reports whether the request is for exactly this operation and payload
+func (r *ChangeRequest) Covers(contextName, environment, operation, resource string, targets []string, diff string) bool {
+	return r.Context == contextName && r.Environment == environment &&
+		r.Operation == operation && r.Resource == resource && slices.Equal(r.Targets, targets) &&
+		r.DiffHash == HashDiff(diff)
+}
+
+func verifySignature(keys map[string]ed25519.PublicKey, signer, role string, payload []byte, signature string) error {
+	if signer == "" || signature == "" {
+		return fmt.Errorf("change request is not signed by a %s", role)
+	}
+	key, ok := keys[signer]
+	if !ok {
+		return fmt.Errorf("%s %q is not a trusted approver", role, signer)
+	}
+	sig, err := base64.StdEncoding.DecodeString(signature)
+	if err != nil || !ed25519.Verify(key, payload, sig) {
+		return fmt.Errorf("invalid %s signature of %q (the change request was modified or signed with another key)", role, signer)
+	}
+	return nil
+}
+
+// Load reads a change request file
+func Load(path string) (*ChangeRequest, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read change request: %w", err)
+	}
+	var r ChangeRequest
+	if err := yaml.Unmarshal(data, &r); err != nil {
+		return nil, fmt.Errorf("failed to parse change request %s: %w", path, err)
+	}
+	return &r, nil
+}
+
+// Save writes the change request to path
+func (r *ChangeRequest) Save(path string) error {
+	data, err := yaml.Marshal(r)
+	if err != nil {
+		return fmt.Errorf("failed to marshal change request: %w", err)
+	}
+	if err := os.WriteFile(path, data, 0600); err != nil {
+		return fmt.Errorf("failed to write change request: %w", err)
+	}
+	return nil
+}
+
+// DefaultFileName is the file name used for a new change request
+func (r *ChangeRequest) DefaultFileName() string {
+	return fmt.Sprintf("dtctl-change-%s.yaml", r.ID)
+}
@@ -0,0 +1,177 @@
+package approval
+
+import (
+	"crypto/ed25519"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/dynatrace-oss/dtctl/pkg/config"
+)
+
+func testKeys(t *testing.T, names ...string) (map[string]ed25519.PrivateKey, map[string]ed25519.PublicKey) {
+	t.Helper()
+	priv := map[string]ed25519.PrivateKey{}
+	pub := map[string]ed25519.PublicKey{}
+	for _, name := range names {
+		p, k, err := ed25519.GenerateKey(nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		priv[name], pub[name] = k, p
+	}
+	return priv, pub
+}
+
+func TestChangeRequest_SignApproveVerify(t *testing.T) {
+	priv, pub := testKeys(t, "alice", "bob")
+	path := filepath.Join(t.TempDir(), "request.yaml")
+
+	request := NewChangeRequest("prod", "https://prod.example", "delete-bucket", "bucket", []string{"logs"})
+	request.SetDiff("- bucket logs\n")
+	request.Sign("alice", priv["alice"])
+	if err := request.Save(path); err != nil {
+		t.Fatal(err)
+	}
+
+	// The approver works on the file written by the requester
+	loaded, err := Load(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := loaded.VerifyRequest(pub, time.Now()); err != nil {
+		t.Fatalf("VerifyRequest() error = %v", err)
+	}
+	if err := loaded.Verify(pub, time.Now()); err == nil || !strings.Contains(err.Error(), "not been approved") {
+		t.Errorf("Verify() before approval error = %v", err)
+	}
+	if err := loaded.Approve("alice", priv["alice"]); err == nil {
+		t.Error("expected self-approval to fail")
+	}
+	if err := loaded.Approve("bob", priv["bob"]); err != nil {
+		t.Fatal(err)
+	}
+	if err := loaded.Save(path); err != nil {
+		t.Fatal(err)
+	}
+
+	approved, err := Load(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := approved.Verify(pub, time.Now()); err != nil {
+		t.Errorf("Verify() error = %v", err)
+	}
+	if !approved.Covers("prod", "https://prod.example", "delete-bucket", "bucket", []string{"logs"}, "- bucket logs\n") {
+		t.Error("expected request to cover the original operation")
+	}
+	if approved.Covers("prod", "https://prod.example", "delete-bucket", "bucket", []string{"traces"}, "- bucket logs\n") {
+		t.Error("request must not cover another target")
+	}
+	if approved.Covers("prod", "https://prod.example", "delete-bucket", "bucket", []string{"logs"}, "- bucket logs-v2\n") {
+		t.Error("request must not cover another payload")
+	}
+	if err := approved.Verify(pub, approved.ExpiresAt.Add(time.Second)); err == nil || !strings.Contains(err.Error(), "expired") {
+		t.Errorf("Verify() after expiry error = %v", err)
+	}
+}
+
+func TestChangeRequest_Tampering(t *testing.T) {
+	priv, pub := testKeys(t, "alice", "bob", "mallory")
+
+	newApproved := func() *ChangeRequest {
+		r := NewChangeRequest("prod", "https://prod.example", "delete-bucket", "bucket", []string{"logs"})
+		r.Sign("alice", priv["alice"])
+		if err := r.Approve("bob", priv["bob"]); err != nil {
+			t.Fatal(err)
+		}
+		return r
+	}
+
+	tests := []struct {
+		name    string
+		tamper  func(r *ChangeRequest)
+		wantErr string
+	}{
+		{"changed target", func(r *ChangeRequest) { r.Targets = []string{"traces"} }, "invalid requester signature"},
+		{"changed payload", func(r *ChangeRequest) { r.SetDiff("- bucket traces\n") }, "invalid requester signature"},
+		{"extended expiry", func(r *ChangeRequest) { r.ExpiresAt = r.ExpiresAt.Add(time.Hour) }, "invalid requester signature"},
+		{"swapped approver", func(r *ChangeRequest) { r.Approver = "mallory" }, "invalid approver signature"},
+		{"untrusted approver", func(r *ChangeRequest) {
+			_ = r.Approve("eve", priv["mallory"])
+		}, `approver "eve" is not a trusted approver`},
+		{"approved by requester", func(r *ChangeRequest) { r.Approver = "alice" }, "approved by its own requester"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := newApproved()
+			tt.tamper(r)
+			if err := r.Verify(pub, time.Now()); err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("Verify() error = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestGenerateKey(t *testing.T) {
+	dir := t.TempDir()
+	orig := keyDir
+	keyDir = func() string { return dir }
+	defer func() { keyDir = orig }()
+
+	publicKey, err := GenerateKey("alice")
+	if err != nil {
+		t.Fatalf("GenerateKey() error = %v", err)
+	}
+	if _, err := GenerateKey("alice"); err == nil {
+		t.Error("expected error when key already exists")
+	}
+	if _, err := GenerateKey("../alice"); err == nil {
+		t.Error("expected error for identity with path separator")
+	}
+
+	priv, err := LoadPrivateKey("alice")
+	if err != nil {
+		t.Fatalf("LoadPrivateKey() error = %v", err)
+	}
+	keys, err := TrustedKeys([]config.Approver{{Name: "alice", PublicKey: publicKey}})
+	if err != nil {
+		t.Fatalf("TrustedKeys() error = %v", err)
+	}
+	if !keys["alice"].Equal(priv.Public()) {
+		t.Error("public key does not match stored private key")
+	}
+
+	if _, err := TrustedKeys([]config.Approver{{Name: "bob", PublicKey: "not-a-key"}}); err == nil {
+		t.Error("expected error for invalid public key")
+	}
+	if _, err := LoadPrivateKey(""); err == nil {
+		t.Error("expected error without identity")
+	}
+}
+
+func TestLedger(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "approvals-used")
+	ledger := &Ledger{Path: path}
+
+	if used, err := ledger.Used("r1"); err != nil || used {
+		t.Fatalf("Used() before Consume = %v, %v", used, err)
+	}
+	if err := ledger.Consume("r1"); err != nil {
+		t.Fatal(err)
+	}
+	// The consuming process may check the approval again
+	if used, err := ledger.Used("r1"); err != nil || used {
+		t.Errorf("Used() in the consuming process = %v, %v; want false", used, err)
+	}
+
+	// Any later run sees the approval as used
+	later := &Ledger{Path: path}
+	if used, err := later.Used("r1"); err != nil || !used {
+		t.Errorf("Used() in a later run = %v, %v; want true", used, err)
+	}
+	if used, err := later.Used("r2"); err != nil || used {
+		t.Errorf("Used(r2) = %v, %v; want false", used, err)
+	}
+}
@@ -0,0 +1,86 @@
+package approval
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/base64"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/dynatrace-oss/dtctl/pkg/config"
+)
+
+// keyDir returns the directory holding private signing keys. It is a
+// variable so tests can redirect it.
+var keyDir = func() string {
+	return filepath.Join(config.ConfigDir(), "approval-keys")
+}
+
+// KeyPath returns the path of the private signing key of an identity
+func KeyPath(identity string) string {
+	return filepath.Join(keyDir(), identity+".key")
+}
+
+// GenerateKey creates a signing key for identity, stores the private key in
+// the config directory and returns the base64-encoded public key.
+func GenerateKey(identity string) (string, error) {
+	if identity == "" || strings.ContainsAny(identity, `/\`) {
+		return "", fmt.Errorf("invalid identity %q", identity)
+	}
+	path := KeyPath(identity)
+	if _, err := os.Stat(path); err == nil {
+		return "", fmt.Errorf("signing key for %q already exists at %s", identity, path)
+	}
+
+	pub, priv, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		return "", fmt.Errorf("failed to generate key: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return "", fmt.Errorf("failed to create key directory: %w", err)
+	}
+	if err := os.WriteFile(path, []byte(base64.StdEncoding.EncodeToString(priv.Seed())+"\n"), 0600); err != nil {
+		return "", fmt.Errorf("failed to write signing key: %w", err)
+	}
+	return base64.StdEncoding.EncodeToString(pub), nil
+}
+
+// LoadPrivateKey reads the private signing key of an identity
+func LoadPrivateKey(identity string) (ed25519.PrivateKey, error) {
+	if identity == "" {
+		return nil, fmt.Errorf("no approval identity configured (run 'dtctl config generate-approval-key <name>')")
+	}
+	data, err := os.ReadFile(KeyPath(identity))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read signing key of %q: %w", identity, err)
+	}
+	seed, err := base64.StdEncoding.DecodeString(strings.TrimSpace(string(data)))
+	if err != nil || len(seed) != ed25519.SeedSize {
+		return nil, fmt.Errorf("invalid signing key %s", KeyPath(identity))
+	}
+	return ed25519.NewKeyFromSeed(seed), nil
+}
+
+// ParsePublicKey decodes a base64-encoded ed25519 public key
+func ParsePublicKey(s string) (ed25519.PublicKey, error) {
+	key, err := base64.StdEncoding.DecodeString(strings.TrimSpace(s))
+	if err != nil || len(key) != ed25519.PublicKeySize {
+		return nil, fmt.Errorf("invalid public key %q (expected base64-encoded ed25519 key)", s)
+	}
+	return ed25519.PublicKey(key), nil
+}
+
+// TrustedKeys parses the approvers of the config into a key set
+func TrustedKeys(approvers []config.Approver) (map[string]ed25519.PublicKey, error) {
+	keys := make(map[string]ed25519.PublicKey, len(approvers))
+	for _, a := range approvers {
+		key, err := ParsePublicKey(a.PublicKey)
+		if err != nil {
+			return nil, fmt.Errorf("approver %q: %w", a.Name, err)
+		}
+		keys[a.Name] = key
+	}
+	return keys, nil
+}
@@ -0,0 +1,74 @@
+package approval
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/dynatrace-oss/dtctl/pkg/config"
+)
+
+// Ledger records the IDs of change requests that were used, so that an
+// approval authorizes a single run of the command it was requested for.
+type Ledger struct {
+	Path string
+	// consumed holds the IDs consumed by this process, which may check the
+	// same approval more than once.
+	consumed map[string]bool
+}
+
+// DefaultLedger returns the ledger in the dtctl data directory
+func DefaultLedger() *Ledger {
+	return &Ledger{Path: filepath.Join(config.DataDir(), "approvals-used")}
+}
+
+// Used reports whether the change request was used by an earlier run
+func (l *Ledger) Used(id string) (bool, error) {
+	if l.consumed[id] {
+		return false, nil
+	}
+	f, err := os.Open(l.Path)
+	if os.IsNotExist(err) {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("failed to open approval ledger: %w", err)
+	}
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		if strings.TrimSpace(scanner.Text()) == id {
+			return true, nil
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return false, fmt.Errorf("failed to read approval ledger: %w", err)
+	}
+	return false, nil
+}
+
+// Consume records that the change request was used
+func (l *Ledger) Consume(id string) error {
+	if l.consumed[id] {
+		return nil
+	}
+	if err := os.MkdirAll(filepath.Dir(l.Path), 0700); err != nil {
+		return fmt.Errorf("failed to create approval ledger directory: %w", err)
+	}
+	f, err := os.OpenFile(l.Path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
+	if err != nil {
+		return fmt.Errorf("failed to open approval ledger: %w", err)
+	}
+	defer f.Close()
+	if _, err := fmt.Fprintln(f, id); err != nil {
+		return fmt.Errorf("failed to write approval ledger: %w", err)
+	}
+	if l.consumed == nil {
+		l.consumed = make(map[string]bool)
+	}
+	l.consumed[id] = true
+	return nil
+}
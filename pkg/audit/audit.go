@@ -0,0 +1,183 @@
+// Package audit records mutating dtctl commands in a local, rotating JSONL log.
+package audit
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/dynatrace-oss/dtctl/pkg/config"
+)
+
+// EnvAudit disables the audit log when set to "off".
+const EnvAudit = "DTCTL_AUDIT"
+
+const (
+	// DefaultMaxBytes is the size at which the audit log is rotated
+	DefaultMaxBytes = 10 << 20
+	// DefaultMaxBackups is the number of rotated audit logs that are kept
+	DefaultMaxBackups = 5
+)
+
+// Record is one mutating command in the audit log
+type Record struct {
+	Time        time.Time `json:"time" yaml:"time" table:"TIME"`
+	Context     string    `json:"context" yaml:"context" table:"CONTEXT"`
+	Environment string    `json:"environment" yaml:"environment" table:"ENVIRONMENT,wide"`
+	User        string    `json:"user,omitempty" yaml:"user,omitempty" table:"USER"`
+	Host        string    `json:"host,omitempty" yaml:"host,omitempty" table:"HOST,wide"`
+	OSUser      string    `json:"osUser,omitempty" yaml:"osUser,omitempty" table:"OS-USER,wide"`
+	Verb        string    `json:"verb" yaml:"verb" table:"VERB"`
+	Resource    string    `json:"resource,omitempty" yaml:"resource,omitempty" table:"RESOURCE"`
+	ResourceID  string    `json:"resourceId,omitempty" yaml:"resourceId,omitempty" table:"ID"`
+	Command     string    `json:"command" yaml:"command" table:"COMMAND,wide"`
+	Changes     []Change  `json:"changes,omitempty" yaml:"changes,omitempty" table:"-"`
+	Success     bool      `json:"success" yaml:"success" table:"SUCCESS"`
+	Error       string    `json:"error,omitempty" yaml:"error,omitempty" table:"ERROR,wide"`
+}
+
+// Change is an API request that changed state during a command
+type Change struct {
+	Method        string `json:"method" yaml:"method"`
+	Path          string `json:"path" yaml:"path"`
+	Status        int    `json:"status" yaml:"status"`
+	VersionBefore string `json:"versionBefore,omitempty" yaml:"versionBefore,omitempty"`
+	VersionAfter  string `json:"versionAfter,omitempty" yaml:"versionAfter,omitempty"`
+}
+
+// Log is a rotating JSONL audit log
+type Log struct {
+	Path       string
+	MaxBytes   int64
+	MaxBackups int
+}
+
+// DefaultLog returns the audit log in the dtctl data directory
+func DefaultLog() *Log {
+	return &Log{
+		Path:       filepath.Join(config.DataDir(), "audit.jsonl"),
+		MaxBytes:   DefaultMaxBytes,
+		MaxBackups: DefaultMaxBackups,
+	}
+}
+
+// Enabled reports whether audit logging is enabled (DTCTL_AUDIT is not "off")
+func Enabled() bool {
+	return !strings.EqualFold(os.Getenv(EnvAudit), "off")
+}
+
+// Append writes a record, rotating the log when it exceeds MaxBytes
+func (l *Log) Append(r Record) error {
+	line, err := json.Marshal(r)
+	if err != nil {
+		return fmt.Errorf("failed to marshal audit record: %w", err)
+	}
+	line = append(line, '\n')
+
+	if err := os.MkdirAll(filepath.Dir(l.Path), 0700); err != nil {
+		return fmt.Errorf("failed to create audit log directory: %w", err)
+	}
+	if info, err := os.Stat(l.Path); err == nil && l.MaxBytes > 0 && info.Size()+int64(len(line)) > l.MaxBytes {
+		if err := l.rotate(); err != nil {
+			return err
+		}
+	}
+
+	f, err := os.OpenFile(l.Path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
+	if err != nil {
+		return fmt.Errorf("failed to open audit log: %w", err)
+	}
+	defer f.Close()
+	if _, err := f.Write(line); err != nil {
+		return fmt.Errorf("failed to write audit log: %w", err)
+	}
+	return nil
+}
+
+// rotate shifts audit.jsonl to audit.jsonl.1, audit.jsonl.1 to .2 and so on,
+// dropping the oldest backup.
+func (l *Log) rotate() error {
+	if l.MaxBackups <= 0 {
+		return os.Remove(l.Path)
+	}
+	_ = os.Remove(l.backupPath(l.MaxBackups))
+	for i := l.MaxBackups - 1; i >= 1; i-- {
+		if err := os.Rename(l.backupPath(i), l.backupPath(i+1)); err != nil && !os.IsNotExist(err) {
+			return fmt.Errorf("failed to rotate audit log: %w", err)
+		}
+	}
+	if err := os.Rename(l.Path, l.backupPath(1)); err != nil {
+		return fmt.Errorf("failed to rotate audit log: %w", err)
+	}
+	return nil
+}
+
+func (l *Log) backupPath(i int) string {
+	return fmt.Sprintf("%s.%d", l.Path, i)
+}
+
+// Filter selects audit records. Empty fields match everything.
+type Filter struct {
+	Since    time.Time
+	Context  string
+	Verb     string
+	Resource string
+	User     string
+	// Failed selects only failed commands
+	Failed bool
+	// Limit keeps only the newest records (0 = all)
+	Limit int
+}
+
+func (f Filter) matches(r Record) bool {
+	return (f.Since.IsZero() || !r.Time.Before(f.Since)) &&
+		(f.Context == "" || r.Context == f.Context) &&
+		(f.Verb == "" || r.Verb == f.Verb) &&
+		(f.Resource == "" || strings.TrimSuffix(r.Resource, "s") == strings.TrimSuffix(f.Resource, "s")) &&
+		(f.User == "" || r.User == f.User || r.OSUser == f.User) &&
+		(!f.Failed || !r.Success)
+}
+
+// Read returns the records matching filter, oldest first, across the
+// current log and its backups. Malformed lines are skipped.
+func (l *Log) Read(filter Filter) ([]Record, error) {
+	var records []Record
+	for i := l.MaxBackups; i >= 0; i-- {
+		path := l.Path
+		if i > 0 {
+			path = l.backupPath(i)
+		}
+		f, err := os.Open(path)
+		if os.IsNotExist(err) {
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to open audit log: %w", err)
+		}
+		scanner := bufio.NewScanner(f)
+		scanner.Buffer(make([]byte, 64*1024), 4<<20)
+		for scanner.Scan() {
+			var r Record
+			if json.Unmarshal(scanner.Bytes(), &r) != nil {
+				continue
+			}
+			if filter.matches(r) {
+				records = append(records, r)
+			}
+		}
+		err = scanner.Err()
+		f.Close()
+		if err != nil {
+			return nil, fmt.Errorf("failed to read audit log %s: %w", path, err)
+		}
+	}
+
+	if filter.Limit > 0 && len(records) > filter.Limit {
+		records = records[len(records)-filter.Limit:]
+	}
+	return records, nil
+}
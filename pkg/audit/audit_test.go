@@ -0,0 +1,126 @@
+package audit
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestLog_AppendAndRead(t *testing.T) {
+	log := &Log{Path: filepath.Join(t.TempDir(), "audit", "audit.jsonl"), MaxBytes: DefaultMaxBytes, MaxBackups: 2}
+	base := time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC)
+
+	records := []Record{
+		{Time: base, Context: "prod", Verb: "delete", Resource: "workflow", ResourceID: "wf-1", User: "alice", Success: true},
+		{Time: base.Add(time.Hour), Context: "dev", Verb: "apply", Resource: "dashboards", User: "bob", Success: true},
+		{Time: base.Add(2 * time.Hour), Context: "prod", Verb: "edit", Resource: "dashboard", User: "alice", Success: false, Error: "conflict"},
+	}
+	for _, r := range records {
+		if err := log.Append(r); err != nil {
+			t.Fatalf("Append() error = %v", err)
+		}
+	}
+
+	info, err := os.Stat(log.Path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if info.Mode().Perm() != 0600 {
+		t.Errorf("audit log permissions = %v, want 0600", info.Mode().Perm())
+	}
+
+	tests := []struct {
+		name    string
+		filter  Filter
+		wantIDs []string // verbs of expected records, oldest first
+	}{
+		{"all", Filter{}, []string{"delete", "apply", "edit"}},
+		{"context", Filter{Context: "prod"}, []string{"delete", "edit"}},
+		{"resource plural", Filter{Resource: "dashboard"}, []string{"apply", "edit"}},
+		{"since", Filter{Since: base.Add(30 * time.Minute)}, []string{"apply", "edit"}},
+		{"user", Filter{User: "bob"}, []string{"apply"}},
+		{"failed", Filter{Failed: true}, []string{"edit"}},
+		{"limit keeps newest", Filter{Limit: 2}, []string{"apply", "edit"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := log.Read(tt.filter)
+			if err != nil {
+				t.Fatalf("Read() error = %v", err)
+			}
+			var verbs []string
+			for _, r := range got {
+				verbs = append(verbs, r.Verb)
+			}
+			if !reflect.DeepEqual(verbs, tt.wantIDs) {
+				t.Errorf("Read() verbs = %v, want %v", verbs, tt.wantIDs)
+			}
+		})
+	}
+}
+
+func TestLog_Rotate(t *testing.T) {
+	dir := t.TempDir()
+	log := &Log{Path: filepath.Join(dir, "audit.jsonl"), MaxBytes: 200, MaxBackups: 2}
+
+	for i := 0; i < 20; i++ {
+		if err := log.Append(Record{Time: time.Unix(int64(i), 0).UTC(), Verb: "create", Command: strings.Repeat("x", 50)}); err != nil {
+			t.Fatalf("Append() error = %v", err)
+		}
+	}
+
+	for _, name := range []string{"audit.jsonl", "audit.jsonl.1", "audit.jsonl.2"} {
+		if _, err := os.Stat(filepath.Join(dir, name)); err != nil {
+			t.Errorf("expected %s to exist: %v", name, err)
+		}
+	}
+	if _, err := os.Stat(filepath.Join(dir, "audit.jsonl.3")); !os.IsNotExist(err) {
+		t.Error("expected at most 2 backups")
+	}
+
+	records, err := log.Read(Filter{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(records) == 0 || records[len(records)-1].Time.Unix() != 19 {
+		t.Fatalf("expected newest record last, got %d records", len(records))
+	}
+	for i := 1; i < len(records); i++ {
+		if records[i].Time.Before(records[i-1].Time) {
+			t.Fatalf("records not in chronological order at %d", i)
+		}
+	}
+}
+
+func TestRedactArgs(t *testing.T) {
+	jwt := "eyJhbGciOiJSUzI1NiJ9.eyJzdWIiOiJ1In0.c2ln"
+	tests := []struct {
+		args []string
+		want []string
+	}{
+		{
+			[]string{"config", "set-credentials", "prod", "--token", "dt0s16.ABC.DEF"},
+			[]string{"config", "set-credentials", "prod", "--token", Redacted},
+		},
+		{
+			[]string{"config", "set-credentials", "svc", "--client-id", "id", "--client-secret=s3cret"},
+			[]string{"config", "set-credentials", "svc", "--client-id", "id", "--client-secret=" + Redacted},
+		},
+		{
+			[]string{"config", "set-context", "prod", "--token-ref", "prod-token"},
+			[]string{"config", "set-context", "prod", "--token-ref", "prod-token"},
+		},
+		{
+			[]string{"apply", "-f", "wf.yaml", "--set", "apiToken=abc", "--set", "env=prod", jwt},
+			[]string{"apply", "-f", "wf.yaml", "--set", "apiToken=" + Redacted, "--set", "env=prod", Redacted},
+		},
+	}
+	for _, tt := range tests {
+		if got := RedactArgs(tt.args); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("RedactArgs(%v) = %v, want %v", tt.args, got, tt.want)
+		}
+	}
+}
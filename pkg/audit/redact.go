@@ -0,0 +1,62 @@
+package audit
+
+import (
+	"regexp"
+	"strings"
+)
+
+// Redacted replaces secret values in recorded command lines
+const Redacted = "[REDACTED]"
+
+// sensitiveWords mark flags and key=value arguments whose values are secrets.
+var sensitiveWords = []string{"token", "secret", "password", "passwd", "credential", "api-key", "apikey", "private-key"}
+
+// secretPattern matches tokens that are secrets regardless of where they
+// appear: Dynatrace tokens (dt0s16.*, dt0c01.*) and JWTs.
+var secretPattern = regexp.MustCompile(`^(dt0[a-z]\d{2}\.[A-Za-z0-9._-]+|eyJ[A-Za-z0-9_-]+\.[A-Za-z0-9_-]+\.[A-Za-z0-9_-]*)$`)
+
+// isSensitiveName reports whether a flag or key holds a secret. References to
+// secrets (e.g. --token-ref, --token-file) are not secrets themselves.
+func isSensitiveName(name string) bool {
+	name = strings.ToLower(strings.TrimLeft(name, "-"))
+	if strings.HasSuffix(name, "-ref") || strings.HasSuffix(name, "-file") || strings.HasSuffix(name, "-storage") {
+		return false
+	}
+	for _, w := range sensitiveWords {
+		if strings.Contains(name, w) {
+			return true
+		}
+	}
+	return false
+}
+
+// RedactArgs returns a copy of command-line args with secret values replaced
+func RedactArgs(args []string) []string {
+	out := make([]string, len(args))
+	redactNext := false
+	for i, arg := range args {
+		switch {
+		case redactNext:
+			out[i] = Redacted
+			redactNext = false
+		case strings.HasPrefix(arg, "-"):
+			name, _, hasValue := strings.Cut(arg, "=")
+			out[i] = arg
+			if isSensitiveName(name) {
+				if hasValue {
+					out[i] = name + "=" + Redacted
+				} else {
+					redactNext = true
+				}
+			}
+		case secretPattern.MatchString(arg):
+			out[i] = Redacted
+		default:
+			out[i] = arg
+			if key, value, ok := strings.Cut(arg, "="); ok && (isSensitiveName(key) || secretPattern.MatchString(value)) {
+				out[i] = key + "=" + Redacted
+			}
+		}
+	}
+	return out
+}
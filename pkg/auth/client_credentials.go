@@ -0,0 +1,104 @@
+package auth
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// clientCredentialsRefreshBuffer is how long before expiry a cached
+// client-credentials token is replaced. It is shorter than TokenRefreshBuffer
+// because platform access tokens are short-lived (typically 5 minutes) and a
+// new token can be requested at any time without user interaction.
+const clientCredentialsRefreshBuffer = 30 * time.Second
+
+// ClientCredentialsScopes returns the scopes requested with the
+// client-credentials grant. The grant issues neither ID nor refresh tokens,
+// so the user-session scopes are dropped.
+func ClientCredentialsScopes(scopes []string) []string {
+	result := make([]string, 0, len(scopes))
+	for _, scope := range scopes {
+		if scope == "openid" || scope == "offline_access" {
+			continue
+		}
+		result = append(result, scope)
+	}
+	return result
+}
+
+// ClientCredentialsToken requests an access token for an OAuth client (for
+// example a service user's client) with the client-credentials grant.
+func (f *OAuthFlow) ClientCredentialsToken(clientID, clientSecret string) (*TokenSet, error) {
+	data := url.Values{
+		"grant_type":    {"client_credentials"},
+		"client_id":     {clientID},
+		"client_secret": {clientSecret},
+		"scope":         {strings.Join(ClientCredentialsScopes(f.config.Scopes), " ")},
+	}
+	if f.config.EnvironmentURL != "" {
+		data.Set("resource", f.config.EnvironmentURL)
+	}
+
+	req, err := http.NewRequest("POST", f.config.TokenURL, strings.NewReader(data.Encode()))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	httpDo := f.httpDo
+	if httpDo == nil {
+		httpDo = defaultOAuthHTTPDo
+	}
+
+	resp, err := httpDo(req)
+	if err != nil {
+		return nil, fmt.Errorf("client credentials request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("client credentials token request failed: %s - %s", resp.Status, string(body))
+	}
+
+	var tokens TokenSet
+	if err := json.NewDecoder(resp.Body).Decode(&tokens); err != nil {
+		return nil, fmt.Errorf("failed to decode token response: %w", err)
+	}
+
+	tokens.ExpiresAt = time.Now().Add(time.Duration(tokens.ExpiresIn) * time.Second)
+
+	return &tokens, nil
+}
+
+// GetClientCredentialsToken returns the cached access token of an OAuth client
+// credential, requesting a new one with the client-credentials grant when no
+// token is cached or the cached one is about to expire. The secret callback is
+// only invoked when a new token is needed.
+func (tm *TokenManager) GetClientCredentialsToken(tokenName, clientID string, secret func() (string, error)) (string, error) {
+	if stored, err := tm.loadToken(tokenName); err == nil && stored.AccessToken != "" &&
+		time.Now().Add(clientCredentialsRefreshBuffer).Before(stored.ExpiresAt) {
+		return stored.AccessToken, nil
+	}
+
+	clientSecret, err := secret()
+	if err != nil {
+		return "", fmt.Errorf("client secret for %q: %w", tokenName, err)
+	}
+
+	tokens, err := tm.flow.ClientCredentialsToken(clientID, clientSecret)
+	if err != nil {
+		return "", err
+	}
+
+	// Caching is best-effort: without a token store the new token is still
+	// valid for this invocation, it is just requested again next time.
+	_ = tm.SaveToken(tokenName, tokens)
+
+	return tokens.AccessToken, nil
+}
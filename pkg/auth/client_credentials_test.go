@@ -0,0 +1,132 @@
+package auth
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/dynatrace-oss/dtctl/pkg/config"
+)
+
+func TestClientCredentialsScopes(t *testing.T) {
+	got := ClientCredentialsScopes([]string{"openid", "offline_access", "storage:logs:read"})
+	if len(got) != 1 || got[0] != "storage:logs:read" {
+		t.Fatalf("unexpected scopes: %v", got)
+	}
+}
+
+func TestOAuthFlowClientCredentialsToken(t *testing.T) {
+	cfg := OAuthConfigFromEnvironmentURLWithSafety("https://abc12345.apps.dynatrace.com", config.SafetyLevelReadOnly)
+	flow, _ := NewOAuthFlow(cfg)
+
+	t.Run("success", func(t *testing.T) {
+		flow.httpDo = func(req *http.Request) (*http.Response, error) {
+			body, _ := io.ReadAll(req.Body)
+			form, _ := url.ParseQuery(string(body))
+			if form.Get("grant_type") != "client_credentials" || form.Get("client_id") != "dt0s02.ABC" || form.Get("client_secret") != "s3cret" {
+				t.Errorf("unexpected form: %v", form)
+			}
+			if strings.Contains(form.Get("scope"), "openid") || !strings.Contains(form.Get("scope"), "storage:logs:read") {
+				t.Errorf("unexpected scope: %q", form.Get("scope"))
+			}
+			if form.Get("resource") != "https://abc12345.apps.dynatrace.com" {
+				t.Errorf("unexpected resource: %q", form.Get("resource"))
+			}
+			return &http.Response{
+				StatusCode: http.StatusOK,
+				Body:       io.NopCloser(strings.NewReader(`{"access_token":"at","expires_in":300}`)),
+				Header:     make(http.Header),
+			}, nil
+		}
+		tokens, err := flow.ClientCredentialsToken("dt0s02.ABC", "s3cret")
+		if err != nil {
+			t.Fatalf("ClientCredentialsToken() error = %v", err)
+		}
+		if tokens.AccessToken != "at" || time.Until(tokens.ExpiresAt) < 4*time.Minute {
+			t.Fatalf("unexpected tokens: %#v", tokens)
+		}
+	})
+
+	t.Run("non-200", func(t *testing.T) {
+		flow.httpDo = func(req *http.Request) (*http.Response, error) {
+			return &http.Response{
+				StatusCode: http.StatusUnauthorized,
+				Status:     "401 Unauthorized",
+				Body:       io.NopCloser(strings.NewReader(`{"error":"invalid_client"}`)),
+				Header:     make(http.Header),
+			}, nil
+		}
+		if _, err := flow.ClientCredentialsToken("dt0s02.ABC", "wrong"); err == nil || !strings.Contains(err.Error(), "invalid_client") {
+			t.Fatalf("expected invalid_client error, got %v", err)
+		}
+	})
+}
+
+func TestTokenManagerGetClientCredentialsToken(t *testing.T) {
+	newManager := func(store map[string]string, requests *int) *TokenManager {
+		tm, _ := NewTokenManager(DefaultOAuthConfig())
+		tm.deps.keyringAvailable = func() bool { return true }
+		tm.deps.getToken = func(ts *config.TokenStore, name string) (string, error) {
+			if v, ok := store[name]; ok {
+				return v, nil
+			}
+			return "", errors.New("not found")
+		}
+		tm.deps.setToken = func(ts *config.TokenStore, name, token string) error {
+			store[name] = token
+			return nil
+		}
+		tm.flow.httpDo = func(req *http.Request) (*http.Response, error) {
+			*requests++
+			return &http.Response{
+				StatusCode: http.StatusOK,
+				Body:       io.NopCloser(strings.NewReader(`{"access_token":"fresh","expires_in":300}`)),
+				Header:     make(http.Header),
+			}, nil
+		}
+		return tm
+	}
+
+	t.Run("requests and caches a token", func(t *testing.T) {
+		store := map[string]string{}
+		requests := 0
+		tm := newManager(store, &requests)
+
+		secretReads := 0
+		secret := func() (string, error) { secretReads++; return "s3cret", nil }
+		for i := 0; i < 2; i++ {
+			token, err := tm.GetClientCredentialsToken("ci", "dt0s02.ABC", secret)
+			if err != nil || token != "fresh" {
+				t.Fatalf("GetClientCredentialsToken() = %q, %v", token, err)
+			}
+		}
+		if requests != 1 || secretReads != 1 {
+			t.Fatalf("expected one token request and secret read, got %d/%d", requests, secretReads)
+		}
+	})
+
+	t.Run("refreshes an expiring token", func(t *testing.T) {
+		requests := 0
+		store := map[string]string{
+			"oauth:prod:ci": storedJSON(t, StoredToken{Name: "ci", TokenSet: TokenSet{AccessToken: "old", ExpiresAt: time.Now().Add(10 * time.Second)}}),
+		}
+		tm := newManager(store, &requests)
+		token, err := tm.GetClientCredentialsToken("ci", "dt0s02.ABC", func() (string, error) { return "s3cret", nil })
+		if err != nil || token != "fresh" || requests != 1 {
+			t.Fatalf("expected refreshed token, got %q, %v (requests=%d)", token, err, requests)
+		}
+	})
+
+	t.Run("secret error", func(t *testing.T) {
+		requests := 0
+		tm := newManager(map[string]string{}, &requests)
+		_, err := tm.GetClientCredentialsToken("ci", "dt0s02.ABC", func() (string, error) { return "", errors.New("not found in keyring") })
+		if err == nil || !strings.Contains(err.Error(), "client secret") || requests != 0 {
+			t.Fatalf("expected secret error without request, got %v (requests=%d)", err, requests)
+		}
+	})
+}
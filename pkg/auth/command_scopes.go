@@ -0,0 +1,366 @@
+package auth
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// commandScopeSpec lists the scopes a command needs.
+type commandScopeSpec struct {
+	scopes []string
+	// varies marks commands whose scopes depend on their input (e.g. the data
+	// types a query reads or the resource types a file applies). scopes then
+	// holds the scopes for typical use; they are reported by the calculator but
+	// not enforced by the preflight check.
+	varies bool
+	note   string
+}
+
+var (
+	documentsRead   = []string{"document:documents:read"}
+	documentsWrite  = []string{"document:documents:read", "document:documents:write"}
+	documentsDelete = []string{"document:documents:read", "document:documents:delete"}
+	workflowsRead   = []string{"automation:workflows:read"}
+	workflowsWrite  = []string{"automation:workflows:read", "automation:workflows:write"}
+	settingsRead    = []string{"settings:schemas:read", "settings:objects:read"}
+	settingsWrite   = []string{"settings:schemas:read", "settings:objects:read", "settings:objects:write"}
+	segmentsRead    = []string{"storage:filter-segments:read"}
+	segmentsWrite   = []string{"storage:filter-segments:read", "storage:filter-segments:write"}
+	breakpoints     = []string{"dev-obs:breakpoints:set"}
+	queryRead       = []string{"storage:buckets:read"}
+)
+
+// commandScopes maps command paths (without the leading "dtctl") to the token
+// scopes they need. It refines the per-safety-level lists of
+// GetScopesForSafetyLevel down to single commands so that missing scopes can
+// be reported before a request fails with 403, and so that least-privilege
+// tokens can be created for automation.
+var commandScopes = map[string]commandScopeSpec{
+	// Workflows
+	"get workflows":               {scopes: workflowsRead},
+	"describe workflow":           {scopes: workflowsRead},
+	"get workflow-executions":     {scopes: workflowsRead},
+	"describe workflow-execution": {scopes: workflowsRead},
+	"get wfe-task-result":         {scopes: workflowsRead},
+	"logs workflow-execution":     {scopes: workflowsRead},
+	"history workflow":            {scopes: workflowsRead},
+	"create workflow":             {scopes: workflowsWrite},
+	"edit workflow":               {scopes: workflowsWrite},
+	"restore workflow":            {scopes: workflowsWrite},
+	"delete workflow":             {scopes: workflowsWrite},
+	"exec workflow":               {scopes: []string{"automation:workflows:read", "automation:workflows:run"}},
+
+	// Documents (dashboards, notebooks)
+	"get dashboards":     {scopes: documentsRead, note: "plus iam:users:read with --owner or --orphaned"},
+	"get notebooks":      {scopes: documentsRead, note: "plus iam:users:read with --owner or --orphaned"},
+	"get documents":      {scopes: documentsRead, note: "plus iam:users:read with --owner or --orphaned"},
+	"describe dashboard": {scopes: documentsRead},
+	"describe notebook":  {scopes: documentsRead},
+	"describe document":  {scopes: documentsRead},
+	"history dashboard":  {scopes: documentsRead},
+	"history notebook":   {scopes: documentsRead},
+	"history document":   {scopes: documentsRead},
+	"export dashboard":   {scopes: documentsRead},
+	"export notebook":    {scopes: documentsRead},
+	"create dashboard":   {scopes: documentsWrite},
+	"create notebook":    {scopes: documentsWrite},
+	"create document":    {scopes: documentsWrite},
+	"edit dashboard":     {scopes: documentsWrite},
+	"edit notebook":      {scopes: documentsWrite},
+	"edit document":      {scopes: documentsWrite},
+	"restore dashboard":  {scopes: documentsWrite},
+	"restore notebook":   {scopes: documentsWrite},
+	"restore document":   {scopes: documentsWrite},
+	"delete dashboard":   {scopes: documentsDelete},
+	"delete notebook":    {scopes: documentsDelete},
+	"delete document":    {scopes: documentsDelete},
+	"get trash":          {scopes: []string{"document:trash.documents:read"}},
+	"describe trash":     {scopes: []string{"document:trash.documents:read"}},
+	"restore trash":      {scopes: []string{"document:trash.documents:read", "document:trash.documents:restore"}},
+	"delete trash":       {scopes: []string{"document:trash.documents:read", "document:trash.documents:delete"}},
+	"share dashboard":    {scopes: []string{"document:documents:read", "document:direct-shares:read", "document:direct-shares:write"}},
+	"share notebook":     {scopes: []string{"document:documents:read", "document:direct-shares:read", "document:direct-shares:write"}},
+	"share document":     {scopes: []string{"document:documents:read", "document:direct-shares:read", "document:direct-shares:write"}},
+	"unshare dashboard":  {scopes: []string{"document:documents:read", "document:direct-shares:read", "document:direct-shares:delete"}},
+	"unshare notebook":   {scopes: []string{"document:documents:read", "document:direct-shares:read", "document:direct-shares:delete"}},
+	"unshare document":   {scopes: []string{"document:documents:read", "document:direct-shares:read", "document:direct-shares:delete"}},
+	"exec dashboard":     {scopes: []string{"document:documents:read", "storage:buckets:read"}, varies: true, note: "plus storage:<type>:read for each data type the tiles read"},
+	"lint dashboard":     {scopes: queryRead, varies: true, note: "only with --verify; local checks need no token"},
+	"transfer-ownership": {scopes: []string{"document:documents:read", "document:documents:write", "automation:workflows:read", "automation:workflows:write", "iam:users:read"}, varies: true, note: "workflow scopes only without --type or with --type workflow"},
+
+	// SLOs
+	"get slos":          {scopes: []string{"slo:slos:read"}},
+	"describe slo":      {scopes: []string{"slo:slos:read"}},
+	"get slo-templates": {scopes: []string{"slo:objective-templates:read"}},
+	"create slo":        {scopes: []string{"slo:slos:read", "slo:slos:write"}},
+	"delete slo":        {scopes: []string{"slo:slos:read", "slo:slos:write"}},
+	"exec slo":          {scopes: []string{"slo:slos:read", "slo:slos:write"}},
+
+	// Settings (anomaly detectors are settings objects)
+	"get settings-schemas":      {scopes: []string{"settings:schemas:read"}},
+	"describe settings-schema":  {scopes: []string{"settings:schemas:read"}},
+	"get settings":              {scopes: settingsRead},
+	"describe settings":         {scopes: settingsRead},
+	"create settings":           {scopes: settingsWrite},
+	"edit setting":              {scopes: settingsWrite},
+	"delete settings":           {scopes: settingsWrite},
+	"migrate settings":          {scopes: []string{"settings:schemas:read"}},
+	"patch settings":            {scopes: settingsWrite},
+	"verify settings":           {scopes: []string{"settings:schemas:read"}, varies: true, note: "only with --fetch; offline validation needs no token"},
+	"get anomaly-detectors":     {scopes: settingsRead},
+	"describe anomaly-detector": {scopes: settingsRead},
+	"create anomaly-detector":   {scopes: settingsWrite},
+	"edit anomaly-detector":     {scopes: settingsWrite},
+	"delete anomaly-detector":   {scopes: settingsWrite},
+
+	// Grail: queries, segments, buckets, lookups
+	"query":               {scopes: queryRead, varies: true, note: "plus storage:<type>:read for each data type the query reads (e.g. storage:logs:read for 'fetch logs')"},
+	"verify query":        {scopes: queryRead},
+	"wait query":          {scopes: queryRead, varies: true, note: "plus storage:<type>:read for each data type the query reads"},
+	"get segments":        {scopes: segmentsRead},
+	"describe segment":    {scopes: segmentsRead},
+	"create segment":      {scopes: segmentsWrite},
+	"edit segment":        {scopes: segmentsWrite},
+	"delete segment":      {scopes: []string{"storage:filter-segments:read", "storage:filter-segments:delete"}},
+	"verify segment":      {scopes: queryRead, varies: true, note: "with --check-fields, plus storage:<type>:read for each data object"},
+	"get buckets":         {scopes: []string{"storage:bucket-definitions:read"}},
+	"describe bucket":     {scopes: []string{"storage:bucket-definitions:read"}},
+	"create bucket":       {scopes: []string{"storage:bucket-definitions:read", "storage:bucket-definitions:write"}},
+	"delete bucket":       {scopes: []string{"storage:bucket-definitions:read", "storage:bucket-definitions:delete"}},
+	"get lookups":         {scopes: []string{"storage:files:read"}},
+	"describe lookup":     {scopes: []string{"storage:files:read"}},
+	"create lookup":       {scopes: []string{"storage:files:read", "storage:files:write"}},
+	"delete lookup":       {scopes: []string{"storage:files:read", "storage:files:delete"}},
+	"get notifications":   {scopes: []string{"notification:notifications:read"}},
+	"delete notification": {scopes: []string{"notification:notifications:read", "notification:notifications:write"}},
+
+	// Extensions and Hub
+	"get extensions":             {scopes: []string{"extensions:definitions:read"}},
+	"describe extension":         {scopes: []string{"extensions:definitions:read"}},
+	"create extension":           {scopes: []string{"extensions:definitions:read", "extensions:definitions:write"}},
+	"get extension-configs":      {scopes: []string{"extensions:configurations:read"}},
+	"describe extension-config":  {scopes: []string{"extensions:configurations:read"}},
+	"apply extension-config":     {scopes: []string{"extensions:configurations:read", "extensions:configurations:write"}},
+	"get hub-extensions":         {scopes: []string{"hub:catalog:read"}},
+	"describe hub-extensions":    {scopes: []string{"hub:catalog:read"}},
+	"get hub-extension-releases": {scopes: []string{"hub:catalog:read"}},
+
+	// App Engine
+	"get apps":             {scopes: []string{"app-engine:apps:run"}},
+	"describe app":         {scopes: []string{"app-engine:apps:run"}},
+	"delete app":           {scopes: []string{"app-engine:apps:run", "app-engine:apps:delete"}},
+	"get functions":        {scopes: []string{"app-engine:apps:run"}},
+	"describe function":    {scopes: []string{"app-engine:apps:run"}},
+	"exec function":        {scopes: []string{"app-engine:apps:run", "app-engine:functions:run"}},
+	"get intents":          {scopes: []string{"app-engine:apps:run"}},
+	"describe intent":      {scopes: []string{"app-engine:apps:run"}},
+	"find intents":         {scopes: []string{"app-engine:apps:run"}},
+	"get edgeconnects":     {scopes: []string{"app-engine:edge-connects:read"}},
+	"describe edgeconnect": {scopes: []string{"app-engine:edge-connects:read"}},
+	"create edgeconnect":   {scopes: []string{"app-engine:edge-connects:read", "app-engine:edge-connects:write"}},
+	"delete edgeconnect":   {scopes: []string{"app-engine:edge-connects:read", "app-engine:edge-connects:delete"}},
+
+	// IAM
+	"get users":      {scopes: []string{"iam:users:read"}},
+	"describe user":  {scopes: []string{"iam:users:read"}},
+	"get groups":     {scopes: []string{"iam:groups:read"}},
+	"describe group": {scopes: []string{"iam:groups:read"}},
+
+	// Davis
+	"get analyzers":                {scopes: []string{"davis:analyzers:read"}},
+	"exec analyzer":                {scopes: []string{"davis:analyzers:read", "davis:analyzers:execute"}},
+	"get copilot-skills":           {scopes: []string{"davis-copilot:conversations:execute"}},
+	"exec copilot":                 {scopes: []string{"davis-copilot:conversations:execute"}},
+	"exec copilot nl2dql":          {scopes: []string{"davis-copilot:nl2dql:execute"}},
+	"exec copilot dql2nl":          {scopes: []string{"davis-copilot:dql2nl:execute"}},
+	"exec copilot document-search": {scopes: []string{"davis-copilot:document-search:execute"}},
+
+	// Live Debugger
+	"get breakpoints":     {scopes: breakpoints},
+	"describe breakpoint": {scopes: breakpoints},
+	"create breakpoint":   {scopes: breakpoints},
+	"update breakpoint":   {scopes: breakpoints},
+	"delete breakpoint":   {scopes: breakpoints},
+	"logs breakpoint":     {scopes: append([]string{"storage:application.snapshots:read"}, breakpoints...)},
+
+	// Identity
+	"auth whoami": {scopes: []string{"app-engine:apps:run"}},
+
+	// Declarative apply: the scopes depend on the resource types in the file.
+	"apply": {scopes: []string{
+		"automation:workflows:read", "automation:workflows:write",
+		"document:documents:read", "document:documents:write",
+		"slo:slos:read", "slo:slos:write",
+		"settings:schemas:read", "settings:objects:read", "settings:objects:write",
+		"storage:filter-segments:read", "storage:filter-segments:write",
+	}, varies: true, note: "only the read and write scopes of the resource types in the applied files are needed"},
+}
+
+// ScopesForCommand returns the scopes needed by a command, identified by its
+// path without the leading "dtctl" (e.g. "get workflows"). known is false for
+// commands without an entry, such as local commands that make no API calls.
+func ScopesForCommand(path string) (scopes []string, known bool) {
+	spec, ok := commandScopes[normalizeCommandPath(path)]
+	if !ok {
+		return nil, false
+	}
+	return append([]string(nil), spec.scopes...), true
+}
+
+// ScopeNoteForCommand returns a hint about additional, input-dependent scopes
+// of a command, or "" if its scopes are fixed.
+func ScopeNoteForCommand(path string) string {
+	return commandScopes[normalizeCommandPath(path)].note
+}
+
+// PreflightScopes returns the scopes a command always needs. Commands whose
+// scopes depend on their input return nil so that the preflight check never
+// rejects a token that would have worked.
+func PreflightScopes(path string) []string {
+	spec, ok := commandScopes[normalizeCommandPath(path)]
+	if !ok || spec.varies {
+		return nil
+	}
+	return append([]string(nil), spec.scopes...)
+}
+
+// ScopesForCommands returns the sorted union of the scopes needed by the
+// given commands, and the commands that have no scope entry.
+func ScopesForCommands(paths []string) (scopes []string, unknown []string) {
+	seen := make(map[string]bool)
+	for _, path := range paths {
+		commandScopes, ok := ScopesForCommand(path)
+		if !ok {
+			unknown = append(unknown, path)
+			continue
+		}
+		for _, scope := range commandScopes {
+			if !seen[scope] {
+				seen[scope] = true
+				scopes = append(scopes, scope)
+			}
+		}
+	}
+	sort.Strings(scopes)
+	return scopes, unknown
+}
+
+// MissingScopes returns the scopes in required that are not in granted.
+func MissingScopes(required, granted []string) []string {
+	have := make(map[string]bool, len(granted))
+	for _, scope := range granted {
+		have[scope] = true
+	}
+	var missing []string
+	for _, scope := range required {
+		if !have[scope] {
+			missing = append(missing, scope)
+		}
+	}
+	return missing
+}
+
+// TokenScopes returns the scopes granted to an OAuth access token, read from
+// its "scope" (space-separated) or "scp" claim. Platform tokens (dt0s16.*)
+// are opaque, so ok is false for them and for any token that is not a JWT
+// with a scope claim.
+func TokenScopes(token string) (scopes []string, ok bool) {
+	if strings.HasPrefix(token, "dt0s16.") {
+		return nil, false
+	}
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return nil, false
+	}
+	decoded, err := base64.RawURLEncoding.DecodeString(strings.TrimRight(parts[1], "="))
+	if err != nil {
+		return nil, false
+	}
+
+	var claims struct {
+		Scope string          `json:"scope"`
+		Scp   json.RawMessage `json:"scp"`
+	}
+	if err := json.Unmarshal(decoded, &claims); err != nil {
+		return nil, false
+	}
+
+	if claims.Scope != "" {
+		return strings.Fields(claims.Scope), true
+	}
+	if len(claims.Scp) > 0 {
+		var list []string
+		if err := json.Unmarshal(claims.Scp, &list); err == nil {
+			return list, true
+		}
+		var joined string
+		if err := json.Unmarshal(claims.Scp, &joined); err == nil {
+			return strings.Fields(joined), true
+		}
+	}
+	return nil, false
+}
+
+func normalizeCommandPath(path string) string {
+	fields := strings.Fields(path)
+	if len(fields) > 0 && fields[0] == "dtctl" {
+		fields = fields[1:]
+	}
+	return strings.Join(fields, " ")
+}
+
+// EnvSkipScopePreflight disables the scope preflight check when set to a
+// non-empty value, e.g. when the scope map is out of date for a new API.
+const EnvSkipScopePreflight = "DTCTL_SKIP_SCOPE_PREFLIGHT"
+
+// MissingScopesError is returned by the preflight check when the token lacks
+// scopes a command needs.
+type MissingScopesError struct {
+	Command string
+	Missing []string
+}
+
+func (e *MissingScopesError) Error() string {
+	return fmt.Sprintf("token is missing scopes required by 'dtctl %s': %s", e.Command, strings.Join(e.Missing, ", "))
+}
+
+// ErrScopesUnknown is returned by the preflight check for platform tokens
+// (dt0s16.*): their scopes are not part of the token, so a missing scope only
+// shows up as a 403 response from the API.
+var ErrScopesUnknown = errors.New("scopes of platform tokens are unknown until the API is called")
+
+// CheckCommandScopes verifies that token grants the scopes command always
+// needs. For platform tokens it returns an error wrapping ErrScopesUnknown,
+// which callers report as a warning; other tokens whose scopes cannot be read
+// and commands without fixed scopes pass.
+func CheckCommandScopes(command, token string) error {
+	required := PreflightScopes(command)
+	if len(required) == 0 {
+		return nil
+	}
+	granted, ok := TokenScopes(token)
+	if !ok {
+		if strings.HasPrefix(token, "dt0s16.") {
+			return fmt.Errorf("%w; 'dtctl %s' needs %s", ErrScopesUnknown, normalizeCommandPath(command), strings.Join(required, ", "))
+		}
+		return nil
+	}
+	if missing := MissingScopes(required, granted); len(missing) > 0 {
+		return &MissingScopesError{Command: normalizeCommandPath(command), Missing: missing}
+	}
+	return nil
+}
+
+// CommandScopePaths returns the command paths that have a scope entry, sorted.
+func CommandScopePaths() []string {
+	paths := make([]string, 0, len(commandScopes))
+	for path := range commandScopes {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+	return paths
+}
@@ -0,0 +1,83 @@
+package auth
+
+import (
+	"encoding/base64"
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func fakeJWT(claims string) string {
+	return "eyJhbGciOiJub25lIn0." + base64.RawURLEncoding.EncodeToString([]byte(claims)) + ".sig"
+}
+
+func TestTokenScopes(t *testing.T) {
+	tests := []struct {
+		name   string
+		token  string
+		want   []string
+		wantOK bool
+	}{
+		{name: "scope claim", token: fakeJWT(`{"scope":"openid automation:workflows:read"}`), want: []string{"openid", "automation:workflows:read"}, wantOK: true},
+		{name: "scp array", token: fakeJWT(`{"scp":["storage:logs:read"]}`), want: []string{"storage:logs:read"}, wantOK: true},
+		{name: "scp string", token: fakeJWT(`{"scp":"a b"}`), want: []string{"a", "b"}, wantOK: true},
+		{name: "no scope claim", token: fakeJWT(`{"sub":"user"}`)},
+		{name: "platform token", token: "dt0s16.ABC.DEF"},
+		{name: "not a JWT", token: "opaque"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := TokenScopes(tt.token)
+			if ok != tt.wantOK || !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("TokenScopes() = %v, %v; want %v, %v", got, ok, tt.want, tt.wantOK)
+			}
+		})
+	}
+}
+
+func TestScopesForCommands(t *testing.T) {
+	scopes, unknown := ScopesForCommands([]string{"get workflows", "dtctl exec workflow", "version"})
+	want := []string{"automation:workflows:read", "automation:workflows:run"}
+	if !reflect.DeepEqual(scopes, want) {
+		t.Errorf("scopes = %v, want %v", scopes, want)
+	}
+	if !reflect.DeepEqual(unknown, []string{"version"}) {
+		t.Errorf("unknown = %v, want [version]", unknown)
+	}
+}
+
+func TestCheckCommandScopes(t *testing.T) {
+	token := fakeJWT(`{"scope":"automation:workflows:read"}`)
+
+	if err := CheckCommandScopes("dtctl get workflows", token); err != nil {
+		t.Errorf("CheckCommandScopes(get workflows) error = %v", err)
+	}
+
+	err := CheckCommandScopes("dtctl create workflow", token)
+	var scopesErr *MissingScopesError
+	if !errors.As(err, &scopesErr) {
+		t.Fatalf("expected MissingScopesError, got %v", err)
+	}
+	if scopesErr.Command != "create workflow" || !reflect.DeepEqual(scopesErr.Missing, []string{"automation:workflows:write"}) {
+		t.Errorf("MissingScopesError = %+v", scopesErr)
+	}
+
+	// Platform tokens cannot be checked; the caller is told so.
+	err = CheckCommandScopes("dtctl create workflow", "dt0s16.ABC.DEF")
+	if !errors.Is(err, ErrScopesUnknown) {
+		t.Errorf("expected ErrScopesUnknown for a platform token, got %v", err)
+	}
+
+	// Input-dependent commands, unknown commands and opaque tokens pass.
+	for _, tc := range []struct{ command, token string }{
+		{"dtctl query", token},
+		{"dtctl apply", token},
+		{"dtctl version", token},
+		{"dtctl query", "dt0s16.ABC.DEF"},
+		{"dtctl create workflow", "opaque"},
+	} {
+		if err := CheckCommandScopes(tc.command, tc.token); err != nil {
+			t.Errorf("CheckCommandScopes(%q) error = %v", tc.command, err)
+		}
+	}
+}
@@ -0,0 +1,243 @@
+package auth
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+)
+
+const (
+	deviceCodeGrantType = "urn:ietf:params:oauth:grant-type:device_code"
+
+	// Defaults from RFC 8628 section 3.2 and 3.5.
+	defaultDevicePollInterval = 5 * time.Second
+	deviceSlowDownIncrement   = 5 * time.Second
+	maxDevicePollInterval     = 60 * time.Second
+)
+
+// DeviceAuthorization is the response of the device authorization endpoint
+// (RFC 8628 section 3.2).
+type DeviceAuthorization struct {
+	DeviceCode              string `json:"device_code"`
+	UserCode                string `json:"user_code"`
+	VerificationURI         string `json:"verification_uri"`
+	VerificationURIComplete string `json:"verification_uri_complete,omitempty"`
+	ExpiresIn               int    `json:"expires_in"`
+	Interval                int    `json:"interval,omitempty"`
+}
+
+// deviceTokenError is the error response of the token endpoint while polling
+// (RFC 8628 section 3.5).
+type deviceTokenError struct {
+	Error            string `json:"error"`
+	ErrorDescription string `json:"error_description"`
+}
+
+// DeviceFlow implements the OAuth 2.0 device authorization grant for machines
+// without a browser (SSH sessions, containers, jump hosts). The user opens the
+// verification URL on another device and enters the user code while dtctl
+// polls the token endpoint.
+type DeviceFlow struct {
+	config *OAuthConfig
+	out    io.Writer
+	httpDo func(*http.Request) (*http.Response, error)
+	sleep  func(context.Context, time.Duration) error
+}
+
+// NewDeviceFlow creates a device authorization flow. Instructions for the user
+// are written to stderr.
+func NewDeviceFlow(config *OAuthConfig) *DeviceFlow {
+	if config == nil {
+		config = DefaultOAuthConfig()
+	}
+	return &DeviceFlow{
+		config: config,
+		out:    os.Stderr,
+		httpDo: defaultOAuthHTTPDo,
+		sleep:  sleepContext,
+	}
+}
+
+// Start requests a device code, prints the verification URL and user code,
+// and polls the token endpoint until the user has approved the request, the
+// code expires or ctx is done.
+func (f *DeviceFlow) Start(ctx context.Context) (*TokenSet, error) {
+	authorization, err := f.RequestDeviceCode(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	fmt.Fprintln(f.out, "To authenticate, open the following URL on any device:")
+	fmt.Fprintf(f.out, "  %s\n", authorization.VerificationURI)
+	fmt.Fprintf(f.out, "and enter the code: %s\n", authorization.UserCode)
+	if authorization.VerificationURIComplete != "" {
+		fmt.Fprintln(f.out, "Or open this URL, which already contains the code:")
+		fmt.Fprintf(f.out, "  %s\n", authorization.VerificationURIComplete)
+	}
+	fmt.Fprintln(f.out, "Waiting for authorization...")
+
+	return f.PollToken(ctx, authorization)
+}
+
+// RequestDeviceCode starts the flow at the device authorization endpoint.
+func (f *DeviceFlow) RequestDeviceCode(ctx context.Context) (*DeviceAuthorization, error) {
+	if f.config.DeviceAuthURL == "" {
+		return nil, fmt.Errorf("device authorization is not configured for environment %q", f.config.Environment)
+	}
+
+	data := url.Values{
+		"client_id": {f.config.ClientID},
+		"scope":     {strings.Join(f.config.Scopes, " ")},
+	}
+	if f.config.EnvironmentURL != "" {
+		data.Set("resource", f.config.EnvironmentURL)
+	}
+
+	resp, err := f.postForm(ctx, f.config.DeviceAuthURL, data)
+	if err != nil {
+		return nil, fmt.Errorf("device authorization request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("device authorization failed: %s - %s", resp.Status, string(body))
+	}
+
+	var authorization DeviceAuthorization
+	if err := json.NewDecoder(resp.Body).Decode(&authorization); err != nil {
+		return nil, fmt.Errorf("failed to decode device authorization response: %w", err)
+	}
+	if authorization.DeviceCode == "" || authorization.UserCode == "" || authorization.VerificationURI == "" {
+		return nil, fmt.Errorf("device authorization response is missing device_code, user_code or verification_uri")
+	}
+
+	return &authorization, nil
+}
+
+// PollToken polls the token endpoint at the interval requested by the server.
+// "slow_down" responses and transient failures (network errors, 5xx) increase
+// the interval; "authorization_pending" keeps it.
+func (f *DeviceFlow) PollToken(ctx context.Context, authorization *DeviceAuthorization) (*TokenSet, error) {
+	interval := defaultDevicePollInterval
+	if authorization.Interval > 0 {
+		interval = time.Duration(authorization.Interval) * time.Second
+	}
+
+	var deadline time.Time
+	if authorization.ExpiresIn > 0 {
+		deadline = time.Now().Add(time.Duration(authorization.ExpiresIn) * time.Second)
+	}
+
+	sleep := f.sleep
+	if sleep == nil {
+		sleep = sleepContext
+	}
+
+	for {
+		if err := sleep(ctx, interval); err != nil {
+			return nil, fmt.Errorf("authentication cancelled: %w", err)
+		}
+		if !deadline.IsZero() && time.Now().After(deadline) {
+			return nil, fmt.Errorf("device code expired before authorization completed; run 'dtctl auth login --device' again")
+		}
+
+		tokens, pollErr, err := f.pollOnce(ctx, authorization.DeviceCode)
+		if err != nil {
+			// Transient failure: back off and try again.
+			interval = min(interval*2, maxDevicePollInterval)
+			continue
+		}
+		if tokens != nil {
+			return tokens, nil
+		}
+
+		switch pollErr.Error {
+		case "authorization_pending":
+		case "slow_down":
+			interval = min(interval+deviceSlowDownIncrement, maxDevicePollInterval)
+		case "access_denied":
+			return nil, fmt.Errorf("authentication failed: authorization request was denied")
+		case "expired_token":
+			return nil, fmt.Errorf("device code expired before authorization completed; run 'dtctl auth login --device' again")
+		default:
+			return nil, fmt.Errorf("authentication failed: %s - %s", pollErr.Error, pollErr.ErrorDescription)
+		}
+	}
+}
+
+// pollOnce performs a single token request. It returns the tokens on success,
+// the OAuth error for a 4xx error response, or an error for transient failures.
+func (f *DeviceFlow) pollOnce(ctx context.Context, deviceCode string) (*TokenSet, *deviceTokenError, error) {
+	data := url.Values{
+		"grant_type":  {deviceCodeGrantType},
+		"device_code": {deviceCode},
+		"client_id":   {f.config.ClientID},
+	}
+
+	resp, err := f.postForm(ctx, f.config.TokenURL, data)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if resp.StatusCode == http.StatusOK {
+		var tokens TokenSet
+		if err := json.Unmarshal(body, &tokens); err != nil {
+			return nil, &deviceTokenError{Error: "invalid_response", ErrorDescription: err.Error()}, nil
+		}
+		tokens.ExpiresAt = time.Now().Add(time.Duration(tokens.ExpiresIn) * time.Second)
+		return &tokens, nil, nil
+	}
+
+	if resp.StatusCode >= 500 || resp.StatusCode == http.StatusTooManyRequests {
+		return nil, nil, fmt.Errorf("token endpoint returned %s", resp.Status)
+	}
+
+	var tokenErr deviceTokenError
+	if err := json.Unmarshal(body, &tokenErr); err != nil || tokenErr.Error == "" {
+		return nil, &deviceTokenError{Error: "invalid_response", ErrorDescription: fmt.Sprintf("%s - %s", resp.Status, string(body))}, nil
+	}
+	return nil, &tokenErr, nil
+}
+
+// GetUserInfo fetches the user info for an access token obtained by the flow.
+func (f *DeviceFlow) GetUserInfo(accessToken string) (*UserInfo, error) {
+	return getUserInfo(f.config, f.httpDo, accessToken)
+}
+
+func (f *DeviceFlow) postForm(ctx context.Context, endpoint string, data url.Values) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, strings.NewReader(data.Encode()))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	httpDo := f.httpDo
+	if httpDo == nil {
+		httpDo = defaultOAuthHTTPDo
+	}
+	return httpDo(req)
+}
+
+func sleepContext(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
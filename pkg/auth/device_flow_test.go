@@ -0,0 +1,154 @@
+package auth
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func newTestDeviceFlow(t *testing.T, handler http.HandlerFunc) (*DeviceFlow, *[]time.Duration) {
+	t.Helper()
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+
+	cfg := DefaultOAuthConfig()
+	cfg.DeviceAuthURL = server.URL + "/device"
+	cfg.TokenURL = server.URL + "/token"
+	cfg.EnvironmentURL = "https://abc12345.apps.dynatrace.com"
+
+	var sleeps []time.Duration
+	flow := NewDeviceFlow(cfg)
+	flow.out = io.Discard
+	flow.sleep = func(ctx context.Context, d time.Duration) error {
+		sleeps = append(sleeps, d)
+		return ctx.Err()
+	}
+	return flow, &sleeps
+}
+
+func TestDeviceFlowStart(t *testing.T) {
+	polls := 0
+	flow, sleeps := newTestDeviceFlow(t, func(w http.ResponseWriter, r *http.Request) {
+		if err := r.ParseForm(); err != nil {
+			t.Fatalf("ParseForm: %v", err)
+		}
+		switch r.URL.Path {
+		case "/device":
+			if r.Form.Get("client_id") != prodClientID || r.Form.Get("resource") == "" || !strings.Contains(r.Form.Get("scope"), "openid") {
+				t.Errorf("unexpected device request: %v", r.Form)
+			}
+			w.Write([]byte(`{"device_code":"dc","user_code":"ABCD-EFGH","verification_uri":"https://sso.example.invalid/device","expires_in":600,"interval":2}`))
+		case "/token":
+			if r.Form.Get("grant_type") != deviceCodeGrantType || r.Form.Get("device_code") != "dc" {
+				t.Errorf("unexpected token request: %v", r.Form)
+			}
+			polls++
+			switch polls {
+			case 1:
+				w.WriteHeader(http.StatusBadRequest)
+				w.Write([]byte(`{"error":"authorization_pending"}`))
+			case 2:
+				w.WriteHeader(http.StatusBadRequest)
+				w.Write([]byte(`{"error":"slow_down"}`))
+			case 3:
+				w.WriteHeader(http.StatusBadGateway)
+			default:
+				w.Write([]byte(`{"access_token":"at","refresh_token":"rt","expires_in":300}`))
+			}
+		}
+	})
+
+	var out bytes.Buffer
+	flow.out = &out
+	tokens, err := flow.Start(context.Background())
+	if err != nil {
+		t.Fatalf("Start() error = %v", err)
+	}
+	if tokens.AccessToken != "at" || tokens.RefreshToken != "rt" || tokens.ExpiresAt.IsZero() {
+		t.Fatalf("unexpected tokens: %#v", tokens)
+	}
+	if !strings.Contains(out.String(), "ABCD-EFGH") || !strings.Contains(out.String(), "https://sso.example.invalid/device") {
+		t.Errorf("expected instructions in output, got %q", out.String())
+	}
+
+	want := []time.Duration{2 * time.Second, 2 * time.Second, 7 * time.Second, 14 * time.Second}
+	if len(*sleeps) != len(want) {
+		t.Fatalf("expected %d polls, got sleeps %v", len(want), *sleeps)
+	}
+	for i, d := range want {
+		if (*sleeps)[i] != d {
+			t.Errorf("sleep %d = %v, want %v", i, (*sleeps)[i], d)
+		}
+	}
+}
+
+func TestDeviceFlowPollTokenErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		body    string
+		wantErr string
+	}{
+		{"denied", `{"error":"access_denied"}`, "denied"},
+		{"expired", `{"error":"expired_token"}`, "expired"},
+		{"other", `{"error":"invalid_client","error_description":"unknown client"}`, "unknown client"},
+		{"malformed", `not json`, "invalid_response"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			flow, _ := newTestDeviceFlow(t, func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusBadRequest)
+				w.Write([]byte(tt.body))
+			})
+			_, err := flow.PollToken(context.Background(), &DeviceAuthorization{DeviceCode: "dc"})
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Fatalf("expected error containing %q, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestDeviceFlowPollTokenCancelled(t *testing.T) {
+	flow, _ := newTestDeviceFlow(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte(`{"error":"authorization_pending"}`))
+	})
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err := flow.PollToken(ctx, &DeviceAuthorization{DeviceCode: "dc"})
+	if err == nil || !strings.Contains(err.Error(), "authentication cancelled") {
+		t.Fatalf("expected cancelled error, got %v", err)
+	}
+}
+
+func TestDeviceFlowRequestDeviceCodeErrors(t *testing.T) {
+	flow, _ := newTestDeviceFlow(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"device_code":"dc"}`))
+	})
+	if _, err := flow.RequestDeviceCode(context.Background()); err == nil || !strings.Contains(err.Error(), "missing") {
+		t.Fatalf("expected missing fields error, got %v", err)
+	}
+
+	flow, _ = newTestDeviceFlow(t, func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "unauthorized_client", http.StatusBadRequest)
+	})
+	if _, err := flow.RequestDeviceCode(context.Background()); err == nil || !strings.Contains(err.Error(), "unauthorized_client") {
+		t.Fatalf("expected endpoint error, got %v", err)
+	}
+}
+
+func TestOAuthConfigDeviceAuthURL(t *testing.T) {
+	for env, want := range map[Environment]string{
+		EnvironmentProd: prodDeviceURL,
+		EnvironmentDev:  devDeviceURL,
+		EnvironmentHard: hardDeviceURL,
+	} {
+		if got := OAuthConfigForEnvironment(env, "").DeviceAuthURL; got != want {
+			t.Errorf("%s: DeviceAuthURL = %q, want %q", env, got, want)
+		}
+	}
+}
@@ -27,18 +27,21 @@ const (
 	prodAuthURL     = "https://sso.dynatrace.com/oauth2/authorize"
 	prodTokenURL    = "https://token.dynatrace.com/sso/oauth2/token"
 	prodUserInfoURL = "https://sso.dynatrace.com/sso/oauth2/userinfo"
+	prodDeviceURL   = "https://sso.dynatrace.com/oauth2/device_authorization"
 	prodClientID    = "dt0s12.dtctl-prod"
 
 	// Development environment
 	devAuthURL     = "https://sso-dev.dynatracelabs.com/oauth2/authorize"
 	devTokenURL    = "https://dev.token.dynatracelabs.com/sso/oauth2/token"
 	devUserInfoURL = "https://sso-dev.dynatracelabs.com/sso/oauth2/userinfo"
+	devDeviceURL   = "https://sso-dev.dynatracelabs.com/oauth2/device_authorization"
 	devClientID    = "dt0s12.dtctl-dev"
 
 	// Hardening/Sprint environment
 	hardAuthURL     = "https://sso-sprint.dynatracelabs.com/oauth2/authorize"
 	hardTokenURL    = "https://hard.token.dynatracelabs.com/sso/oauth2/token"
 	hardUserInfoURL = "https://sso-sprint.dynatracelabs.com/sso/oauth2/userinfo"
+	hardDeviceURL   = "https://sso-sprint.dynatracelabs.com/oauth2/device_authorization"
 	hardClientID    = "dt0s12.dtctl-sprint"
 
 	callbackPort = 3232
@@ -333,6 +336,7 @@ type OAuthConfig struct {
 	AuthURL        string
 	TokenURL       string
 	UserInfoURL    string
+	DeviceAuthURL  string
 	ClientID       string
 	Scopes         []string
 	Port           int
@@ -363,7 +367,7 @@ func DefaultOAuthConfig() *OAuthConfig {
 
 // OAuthConfigForEnvironment creates an OAuth configuration for the specified environment and safety level
 func OAuthConfigForEnvironment(env Environment, safetyLevel config.SafetyLevel) *OAuthConfig {
-	var authURL, tokenURL, userInfoURL, clientID string
+	var authURL, tokenURL, userInfoURL, deviceAuthURL, clientID string
 
 	// Normalize empty safety level to default
 	if safetyLevel == "" {
@@ -375,28 +379,32 @@ func OAuthConfigForEnvironment(env Environment, safetyLevel config.SafetyLevel)
 		authURL = devAuthURL
 		tokenURL = devTokenURL
 		userInfoURL = devUserInfoURL
+		deviceAuthURL = devDeviceURL
 		clientID = devClientID
 	case EnvironmentHard:
 		authURL = hardAuthURL
 		tokenURL = hardTokenURL
 		userInfoURL = hardUserInfoURL
+		deviceAuthURL = hardDeviceURL
 		clientID = hardClientID
 	default: // EnvironmentProd
 		authURL = prodAuthURL
 		tokenURL = prodTokenURL
 		userInfoURL = prodUserInfoURL
+		deviceAuthURL = prodDeviceURL
 		clientID = prodClientID
 	}
 
 	return &OAuthConfig{
-		AuthURL:     authURL,
-		TokenURL:    tokenURL,
-		UserInfoURL: userInfoURL,
-		ClientID:    clientID,
-		Scopes:      GetScopesForSafetyLevel(safetyLevel),
-		Port:        callbackPort,
-		Environment: env,
-		SafetyLevel: safetyLevel,
+		AuthURL:       authURL,
+		TokenURL:      tokenURL,
+		UserInfoURL:   userInfoURL,
+		DeviceAuthURL: deviceAuthURL,
+		ClientID:      clientID,
+		Scopes:        GetScopesForSafetyLevel(safetyLevel),
+		Port:          callbackPort,
+		Environment:   env,
+		SafetyLevel:   safetyLevel,
 	}
 }
 
@@ -551,14 +559,19 @@ func (f *OAuthFlow) RefreshToken(refreshToken string) (*TokenSet, error) {
 }
 
 func (f *OAuthFlow) GetUserInfo(accessToken string) (*UserInfo, error) {
-	req, err := http.NewRequest("GET", f.config.UserInfoURL, nil)
+	return getUserInfo(f.config, f.httpDo, accessToken)
+}
+
+// getUserInfo fetches the user info for an access token. It is shared by the
+// browser and device authorization flows.
+func getUserInfo(config *OAuthConfig, httpDo func(*http.Request) (*http.Response, error), accessToken string) (*UserInfo, error) {
+	req, err := http.NewRequest("GET", config.UserInfoURL, nil)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create request: %w", err)
 	}
 
 	req.Header.Set("Authorization", "Bearer "+accessToken)
 
-	httpDo := f.httpDo
 	if httpDo == nil {
 		httpDo = defaultOAuthHTTPDo
 	}
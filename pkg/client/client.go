@@ -30,6 +30,7 @@ type Client struct {
 	baseURL string
 	token   string
 	logger  *logrus.Logger
+	userID  string // cached result of CurrentUserID
 }
 
 // NewFromConfig creates a new client from config with OAuth support
@@ -45,7 +46,21 @@ func NewFromConfig(cfg *config.Config) (*Client, error) {
 		return nil, err
 	}
 
-	return New(ctx.Environment, token)
+	c, err := New(ctx.Environment, token)
+	if err != nil {
+		return nil, err
+	}
+
+	// A token from a credential plugin may be revoked or rotated before its
+	// advertised expiry; ask the plugin for a new one when the API rejects it.
+	if execCred := cfg.TokenExecFor(ctx.TokenRef); execCred != nil {
+		c.RefreshTokenOnUnauthorized(func() (string, error) {
+			execCred.Invalidate()
+			return execCred.Token()
+		})
+	}
+
+	return c, nil
 }
 
 // NewForTesting creates a client with retries disabled, suitable for unit tests
@@ -127,6 +142,11 @@ func (c *Client) HTTP() *resty.Client {
 	return c.http
 }
 
+// Token returns the bearer token used for HTTP requests.
+func (c *Client) Token() string {
+	return c.token
+}
+
 // SetToken updates the bearer token used for all subsequent HTTP requests.
 // This is used to inject a freshly refreshed OAuth token without recreating the client.
 func (c *Client) SetToken(token string) {
@@ -134,6 +154,22 @@ func (c *Client) SetToken(token string) {
 	c.http.SetAuthToken(token)
 }
 
+// RefreshTokenOnUnauthorized retries a request once with a new token when it
+// fails with 401 Unauthorized. refresh must return a freshly obtained token.
+func (c *Client) RefreshTokenOnUnauthorized(refresh func() (string, error)) {
+	c.http.AddRetryCondition(func(r *resty.Response, err error) bool {
+		if err != nil || r == nil || r.StatusCode() != http.StatusUnauthorized || r.Request.Attempt > 1 {
+			return false
+		}
+		token, refreshErr := refresh()
+		if refreshErr != nil || token == "" || token == c.token {
+			return false
+		}
+		c.SetToken(token)
+		return true
+	})
+}
+
 // sensitiveHeaders lists headers that should always be redacted in debug output
 var sensitiveHeaders = []string{"authorization", "x-api-key", "cookie", "set-cookie"}
 
@@ -298,12 +334,18 @@ func (c *Client) CurrentUser() (*UserInfo, error) {
 }
 
 // CurrentUserID returns the current user's ID.
-// First tries the metadata API, falls back to JWT token decoding.
+// First tries the metadata API, falls back to JWT token decoding. The result
+// is cached for the lifetime of the client.
 func (c *Client) CurrentUserID() (string, error) {
+	if c.userID != "" {
+		return c.userID, nil
+	}
+
 	// Try metadata API first
 	userInfo, err := c.CurrentUser()
 	if err == nil && userInfo.UserID != "" {
-		return userInfo.UserID, nil
+		c.userID = userInfo.UserID
+		return c.userID, nil
 	}
 
 	// Platform tokens are not JWTs, so the JWT fallback below would parse
@@ -318,7 +360,11 @@ func (c *Client) CurrentUserID() (string, error) {
 	}
 
 	// Fallback to JWT decoding
-	return ExtractUserIDFromToken(c.token)
+	userID, err := ExtractUserIDFromToken(c.token)
+	if err == nil {
+		c.userID = userID
+	}
+	return userID, err
 }
 
 // platformTokenPrefix identifies Dynatrace platform tokens — opaque bearer
@@ -930,3 +930,53 @@ func (c envMapCarrier) Keys() []string {
 	}
 	return keys
 }
+
+func TestClient_RefreshTokenOnUnauthorized(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer fresh-token" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	c, err := New(server.URL, "revoked-token")
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	c.HTTP().SetRetryWaitTime(time.Millisecond)
+
+	refreshes := 0
+	c.RefreshTokenOnUnauthorized(func() (string, error) {
+		refreshes++
+		return "fresh-token", nil
+	})
+
+	resp, err := c.HTTP().R().Get("/")
+	if err != nil {
+		t.Fatalf("request error = %v", err)
+	}
+	if resp.StatusCode() != http.StatusOK {
+		t.Errorf("status = %d, want 200", resp.StatusCode())
+	}
+	if refreshes != 1 {
+		t.Errorf("refreshes = %d, want 1", refreshes)
+	}
+
+	// A token that is still rejected after refreshing is not retried again.
+	refreshes = 0
+	c2, _ := New(server.URL, "revoked-token")
+	c2.HTTP().SetRetryWaitTime(time.Millisecond)
+	c2.RefreshTokenOnUnauthorized(func() (string, error) {
+		refreshes++
+		return "still-revoked", nil
+	})
+	resp, err = c2.HTTP().R().Get("/")
+	if err != nil {
+		t.Fatalf("request error = %v", err)
+	}
+	if resp.StatusCode() != http.StatusUnauthorized || refreshes != 1 {
+		t.Errorf("status = %d, refreshes = %d; want 401 after a single refresh", resp.StatusCode(), refreshes)
+	}
+}
@@ -0,0 +1,87 @@
+package client
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/go-resty/resty/v2"
+)
+
+// Mutation describes a state-changing API request (POST, PUT, PATCH or DELETE)
+type Mutation struct {
+	Method     string
+	Path       string
+	StatusCode int
+	// VersionBefore is the optimistic-locking version sent with the request
+	VersionBefore string
+	// VersionAfter is the version reported in the response body
+	VersionAfter string
+	// Response is the raw API response
+	Response *resty.Response
+}
+
+func isMutation(method string) bool {
+	switch method {
+	case http.MethodPost, http.MethodPut, http.MethodPatch, http.MethodDelete:
+		return true
+	}
+	return false
+}
+
+// OnBeforeMutation registers fn to be called with the method and URL path of
+// every state-changing request before it is sent. It is used to snapshot
+// resources for 'dtctl undo'. fn may issue read requests with the client.
+func (c *Client) OnBeforeMutation(fn func(method, path string)) {
+	c.http.OnBeforeRequest(func(_ *resty.Client, req *resty.Request) error {
+		if !isMutation(req.Method) {
+			return nil
+		}
+		path := req.URL
+		if u, err := url.Parse(req.URL); err == nil {
+			path = u.Path
+		}
+		fn(req.Method, path)
+		return nil
+	})
+}
+
+// OnMutation registers fn to be called after every state-changing request
+// that received a response. It is used to record changes in the audit log.
+func (c *Client) OnMutation(fn func(Mutation)) {
+	c.http.OnAfterResponse(func(_ *resty.Client, resp *resty.Response) error {
+		req := resp.Request
+		if req == nil || req.RawRequest == nil || !isMutation(req.Method) {
+			return nil
+		}
+
+		fn(Mutation{
+			Method:        req.Method,
+			Path:          req.RawRequest.URL.Path,
+			StatusCode:    resp.StatusCode(),
+			VersionBefore: req.RawRequest.URL.Query().Get("optimistic-locking-version"),
+			VersionAfter:  responseVersion(resp),
+			Response:      resp,
+		})
+		return nil
+	})
+}
+
+// responseVersion extracts a top-level "version" field from a JSON response
+func responseVersion(resp *resty.Response) string {
+	if !strings.Contains(resp.Header().Get("Content-Type"), "json") {
+		return ""
+	}
+	var body struct {
+		Version json.RawMessage `json:"version"`
+	}
+	if err := json.Unmarshal(resp.Body(), &body); err != nil || len(body.Version) == 0 || string(body.Version) == "null" {
+		return ""
+	}
+	var s string
+	if err := json.Unmarshal(body.Version, &s); err == nil {
+		return s
+	}
+	return string(body.Version)
+}
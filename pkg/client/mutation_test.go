@@ -0,0 +1,57 @@
+package client
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestMutationHooks(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"id":"doc-1","version":"4"}`))
+	}))
+	defer server.Close()
+
+	c, err := NewForTesting(server.URL, "test-token")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var before []string
+	var after []Mutation
+	c.OnBeforeMutation(func(method, path string) {
+		before = append(before, method+" "+path)
+		// Snapshot reads from inside the hook must not be reported.
+		if _, err := c.HTTP().R().Get("/platform/document/v1/documents/doc-1"); err != nil {
+			t.Errorf("read in hook failed: %v", err)
+		}
+	})
+	c.OnMutation(func(m Mutation) { after = append(after, m) })
+
+	if _, err := c.HTTP().R().Get("/platform/document/v1/documents"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := c.HTTP().R().
+		SetQueryParam("optimistic-locking-version", "3").
+		Patch("/platform/document/v1/documents/doc-1"); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(before) != 1 || before[0] != "PATCH /platform/document/v1/documents/doc-1" {
+		t.Errorf("before hooks = %v", before)
+	}
+	if len(after) != 1 {
+		t.Fatalf("after hooks = %d, want 1", len(after))
+	}
+	m := after[0]
+	if m.Method != http.MethodPatch || m.Path != "/platform/document/v1/documents/doc-1" || m.StatusCode != 200 {
+		t.Errorf("mutation = %s %s %d", m.Method, m.Path, m.StatusCode)
+	}
+	if m.VersionBefore != "3" || m.VersionAfter != "4" {
+		t.Errorf("versions = %q -> %q, want 3 -> 4", m.VersionBefore, m.VersionAfter)
+	}
+	if m.Response == nil || len(m.Response.Body()) == 0 {
+		t.Error("mutation response not set")
+	}
+}
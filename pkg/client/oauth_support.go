@@ -2,6 +2,7 @@ package client
 
 import (
 	"errors"
+	"fmt"
 	"strings"
 
 	"github.com/dynatrace-oss/dtctl/pkg/auth"
@@ -10,6 +11,18 @@ import (
 
 // GetTokenWithOAuthSupport retrieves a token from config with OAuth token refresh support
 func GetTokenWithOAuthSupport(cfg *config.Config, tokenRef string) (string, error) {
+	// OAuth client credentials: obtain (and cache) an access token with the
+	// client-credentials grant instead of using a stored token.
+	if cred := cfg.GetCredential(tokenRef); cred != nil && cred.Type == config.CredentialTypeOAuthClient {
+		return getClientCredentialsToken(cfg, cred)
+	}
+
+	// Credential plugins and DTCTL_TOKEN(_FILE): the token comes from an
+	// external command or the environment.
+	if cfg.TokenExecFor(tokenRef) != nil || cfg.IsEnvToken(tokenRef) {
+		return cfg.GetToken(tokenRef)
+	}
+
 	// First, try to get it as an OAuth token (via keyring or file-based storage)
 	if config.IsOAuthStorageAvailable() {
 		// Get current context to detect environment
@@ -42,6 +55,28 @@ func GetTokenWithOAuthSupport(cfg *config.Config, tokenRef string) (string, erro
 	return cfg.GetToken(tokenRef)
 }
 
+// getClientCredentialsToken returns an access token for an OAuth client
+// credential. Scopes follow the safety level of the current context.
+func getClientCredentialsToken(cfg *config.Config, cred *config.NamedToken) (string, error) {
+	ctx, err := cfg.CurrentContextObj()
+	if err != nil {
+		return "", err
+	}
+	if ctx.Environment == "" {
+		return "", fmt.Errorf("OAuth client credential %q requires a context with an environment URL", cred.Name)
+	}
+
+	oauthConfig := auth.OAuthConfigFromEnvironmentURLWithSafety(ctx.Environment, ctx.GetEffectiveSafetyLevel())
+	tokenManager, err := auth.NewTokenManager(oauthConfig)
+	if err != nil {
+		return "", err
+	}
+
+	return tokenManager.GetClientCredentialsToken(cred.Name, cred.ClientID, func() (string, error) {
+		return cfg.GetStoredToken(cred.Name)
+	})
+}
+
 // NewFromConfigWithOAuth creates a new client from config with OAuth support.
 //
 // Deprecated: Use NewFromConfig instead, which now supports OAuth tokens automatically.
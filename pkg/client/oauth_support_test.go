@@ -3,6 +3,7 @@ package client
 import (
 	"errors"
 	"fmt"
+	"strings"
 	"testing"
 
 	"github.com/dynatrace-oss/dtctl/pkg/auth"
@@ -58,3 +59,23 @@ func TestGetTokenWithOAuthSupport_FallsBackWithoutOAuthContext(t *testing.T) {
 		t.Fatalf("GetTokenWithOAuthSupport() = %q, want %q", got, "dt0c01.test")
 	}
 }
+
+func TestGetTokenWithOAuthSupport_OAuthClientCredential(t *testing.T) {
+	t.Setenv(config.EnvDisableKeyring, "1")
+
+	cfg := config.NewConfig()
+	cfg.Tokens = []config.NamedToken{{Name: "ci-client", Type: config.CredentialTypeOAuthClient, ClientID: "dt0s02.ABC"}}
+
+	// Without a context there is no environment to request a token for.
+	if _, err := GetTokenWithOAuthSupport(cfg, "ci-client"); err == nil {
+		t.Fatal("expected error without current context")
+	}
+
+	// The stored secret is never returned as a bearer token.
+	cfg.SetContext("ci", "https://abc12345.apps.dynatrace.com", "ci-client")
+	cfg.CurrentContext = "ci"
+	_, err := GetTokenWithOAuthSupport(cfg, "ci-client")
+	if err == nil || !strings.Contains(err.Error(), "client secret") {
+		t.Fatalf("expected missing client secret error, got %v", err)
+	}
+}
@@ -6,6 +6,7 @@ package commands
 import (
 	"encoding/json"
 	"io"
+	"slices"
 	"strings"
 
 	"github.com/spf13/cobra"
@@ -68,16 +69,39 @@ type TimeFormats struct {
 // TestMutatingVerbsMatchSafetyCheckerUsage test in cmd/commands_test.go
 // cross-references this map against the real command tree to detect drift.
 var MutatingVerbs = map[string]string{
-	"apply":   "OperationCreate",
-	"create":  "OperationCreate",
-	"edit":    "OperationUpdate",
-	"delete":  "OperationDelete",
-	"restore": "OperationUpdate",
-	"share":   "OperationUpdate",
-	"unshare": "OperationUpdate",
-	"update":  "OperationUpdate",
-	"exec":    "OperationCreate", // semantically mutating (runs workflows, functions)
-	"enable":  "OperationUpdate", // PUTs updated monitoring/credential config to the tenant
+	"apply":              "OperationCreate",
+	"create":             "OperationCreate",
+	"edit":               "OperationUpdate",
+	"patch":              "OperationUpdate",
+	"delete":             "OperationDelete",
+	"restore":            "OperationUpdate",
+	"share":              "OperationUpdate",
+	"unshare":            "OperationUpdate",
+	"update":             "OperationUpdate",
+	"exec":               "OperationCreate", // semantically mutating (runs workflows, functions)
+	"enable":             "OperationUpdate", // PUTs updated monitoring/credential config to the tenant
+	"undo":               "OperationUpdate", // reverts recorded creates, updates and deletes
+	"transfer-ownership": "OperationUpdate",
+}
+
+// MutatingSubcommands lists, for mutating verbs whose subcommands are mostly
+// read-only, the subcommands that change state. exec also runs queries,
+// analyzers, SLO evaluations and CoPilot, which only read.
+var MutatingSubcommands = map[string][]string{
+	"exec": {"workflow", "function"},
+}
+
+// IsMutating reports whether "<verb> <subcommand>" changes state. An empty
+// subcommand asks about the verb as a whole.
+func IsMutating(verb, subcommand string) bool {
+	if _, ok := MutatingVerbs[verb]; !ok {
+		return false
+	}
+	subcommands, restricted := MutatingSubcommands[verb]
+	if !restricted || subcommand == "" {
+		return true
+	}
+	return slices.Contains(subcommands, subcommand)
 }
 
 // ResourceAliases are the standard resource aliases built into dtctl.
@@ -214,7 +238,7 @@ func buildVerbs(root *cobra.Command) map[string]*Verb {
 					subVerb := &Verb{
 						Description: sub.Short,
 					}
-					if safetyOp, ok := MutatingVerbs[name]; ok {
+					if safetyOp, ok := MutatingVerbs[name]; ok && IsMutating(name, subName) {
 						subVerb.Mutating = true
 						subVerb.SafetyOp = safetyOp
 					}
@@ -142,6 +142,24 @@ func TestBuild_MutatingVerbs(t *testing.T) {
 	}
 }
 
+func TestIsMutating(t *testing.T) {
+	tests := []struct {
+		verb, subcommand string
+		want             bool
+	}{
+		{"delete", "workflow", true},
+		{"get", "workflows", false},
+		{"exec", "", true},
+		{"exec", "workflow", true},
+		{"exec", "function", true},
+		{"exec", "dashboard", false},
+		{"exec", "copilot", false},
+	}
+	for _, tt := range tests {
+		require.Equal(t, tt.want, IsMutating(tt.verb, tt.subcommand), "IsMutating(%q, %q)", tt.verb, tt.subcommand)
+	}
+}
+
 func TestBuild_Resources(t *testing.T) {
 	root := newTestRoot()
 	listing := Build(root)
@@ -177,6 +195,7 @@ func TestBuild_NestedSubcommands(t *testing.T) {
 	require.Contains(t, execVerb.Subcommands, "copilot")
 
 	copilot := execVerb.Subcommands["copilot"]
+	require.False(t, copilot.Mutating, "exec copilot is read-only")
 	require.NotNil(t, copilot.Subcommands)
 	require.Contains(t, copilot.Subcommands, "nl2dql")
 	require.Contains(t, copilot.Subcommands, "dql2nl")
@@ -20,6 +20,10 @@ type Config struct {
 	Tokens         []NamedToken      `yaml:"tokens"`
 	Preferences    Preferences       `yaml:"preferences"`
 	Aliases        map[string]string `yaml:"aliases,omitempty"`
+	Approval       ApprovalConfig    `yaml:"approval,omitempty"`
+
+	// env is set when a context was synthesized from environment variables.
+	env *envOverlay
 }
 
 // NamedContext holds a context with its name
@@ -80,8 +84,44 @@ func (s SafetyLevel) String() string {
 
 // Hooks holds hook commands for lifecycle events
 type Hooks struct {
-	PreApply  string `yaml:"pre-apply,omitempty"`
-	PostApply string `yaml:"post-apply,omitempty"`
+	PreApply   string `yaml:"pre-apply,omitempty"`
+	PostApply  string `yaml:"post-apply,omitempty"`
+	PreCreate  string `yaml:"pre-create,omitempty"`
+	PostCreate string `yaml:"post-create,omitempty"`
+	PreEdit    string `yaml:"pre-edit,omitempty"`
+	PostEdit   string `yaml:"post-edit,omitempty"`
+	PreDelete  string `yaml:"pre-delete,omitempty"`
+	PostDelete string `yaml:"post-delete,omitempty"`
+	PreQuery   string `yaml:"pre-query,omitempty"`
+	PostQuery  string `yaml:"post-query,omitempty"`
+}
+
+// Command returns the hook command configured for a lifecycle event
+// (e.g. "pre-delete"), or "" if none is configured.
+func (h Hooks) Command(event string) string {
+	switch event {
+	case "pre-apply":
+		return h.PreApply
+	case "post-apply":
+		return h.PostApply
+	case "pre-create":
+		return h.PreCreate
+	case "post-create":
+		return h.PostCreate
+	case "pre-edit":
+		return h.PreEdit
+	case "post-edit":
+		return h.PostEdit
+	case "pre-delete":
+		return h.PreDelete
+	case "post-delete":
+		return h.PostDelete
+	case "pre-query":
+		return h.PreQuery
+	case "post-query":
+		return h.PostQuery
+	}
+	return ""
 }
 
 // Context holds the connection information for a Dynatrace environment
@@ -91,14 +131,43 @@ type Context struct {
 	SafetyLevel SafetyLevel `yaml:"safety-level,omitempty" table:"SAFETY-LEVEL"`
 	Description string      `yaml:"description,omitempty" table:"DESCRIPTION,wide"`
 	Hooks       Hooks       `yaml:"hooks,omitempty"`
+	// SafetyRules refine the safety level with allow/deny rules (first match wins).
+	SafetyRules []SafetyRule `yaml:"safety-rules,omitempty"`
+	// RequireApproval lists operations (e.g. delete-bucket) that need a change
+	// request countersigned by a second approver.
+	RequireApproval []string `yaml:"require-approval,omitempty"`
+	// TokenExec obtains the token from a credential plugin instead of a stored
+	// token. TokenRef is optional in that case.
+	TokenExec *ExecCredential `yaml:"token-exec,omitempty"`
+}
+
+// ApprovalConfig holds the keys used for two-person approval
+type ApprovalConfig struct {
+	// Identity is the name of the local signing key (see approval.KeyPath)
+	Identity string `yaml:"identity,omitempty"`
+	// Approvers are the trusted public keys of requesters and approvers
+	Approvers []Approver `yaml:"approvers,omitempty"`
+}
+
+// Approver is a trusted public key for two-person approval
+type Approver struct {
+	Name      string `yaml:"name" table:"NAME"`
+	PublicKey string `yaml:"public-key" table:"PUBLIC-KEY"`
 }
 
 // NamedToken holds a token with its name
 type NamedToken struct {
-	Name  string `yaml:"name"`
-	Token string `yaml:"token"`
+	Name     string `yaml:"name"`
+	Token    string `yaml:"token"`
+	Type     string `yaml:"type,omitempty"`
+	ClientID string `yaml:"client-id,omitempty"`
 }
 
+// CredentialTypeOAuthClient marks a credential that holds an OAuth client ID.
+// The client secret is stored like a token (in the keyring when available) and
+// access tokens are obtained with the client-credentials grant.
+const CredentialTypeOAuthClient = "oauth-client"
+
 // Preferences holds user preferences
 type Preferences struct {
 	Output string `yaml:"output,omitempty"`
@@ -165,6 +234,8 @@ func findLocalConfigFrom(startDir string) string {
 //  2. Global config (XDG_CONFIG_HOME/dtctl/config)
 //
 // If a local config is found, it is used exclusively (not merged with global).
+// When DTCTL_ENVIRONMENT is set, an in-memory context is added and made current;
+// the config file is optional in that case (see withEnvironmentContext).
 func Load() (*Config, error) {
 	// Check for local config first
 	localConfig := FindLocalConfig()
@@ -178,7 +249,8 @@ func Load() (*Config, error) {
 
 // LoadFrom loads the configuration from a specific path
 func LoadFrom(path string) (*Config, error) {
-	return loadFrom(path, true)
+	cfg, err := loadFrom(path, true)
+	return withEnvironmentContext(path, cfg, err)
 }
 
 // LoadFromWithoutExpansion loads the configuration from a specific path without
@@ -369,7 +441,7 @@ func (c *Config) SaveTo(path string) error {
 		return fmt.Errorf("failed to create config directory: %w", err)
 	}
 
-	data, err := yaml.Marshal(c)
+	data, err := yaml.Marshal(c.withoutEnvironmentContext())
 	if err != nil {
 		return fmt.Errorf("failed to marshal config: %w", err)
 	}
@@ -409,7 +481,18 @@ func (c *Config) GetContext(name string) (*NamedContext, error) {
 // GetToken retrieves a token by reference name.
 // It first tries the OS keyring (checking both regular and OAuth tokens),
 // then file-based OAuth token storage, then falls back to the config file.
+// The context synthesized from DTCTL_ENVIRONMENT and contexts with a credential
+// plugin (token-exec) get the token from the environment or the plugin.
 func (c *Config) GetToken(tokenRef string) (string, error) {
+	// Tokens from DTCTL_TOKEN(_FILE) and plugins are never stored, so no
+	// store needs to be checked.
+	if c.IsEnvToken(tokenRef) {
+		return c.env.token, nil
+	}
+	if execCred := c.TokenExecFor(tokenRef); execCred != nil {
+		return execCred.Token()
+	}
+
 	// Try keyring first
 	if IsKeyringAvailable() {
 		ts := NewTokenStore()
@@ -519,6 +602,34 @@ func oauthEnvironmentFromURL(environmentURL string) string {
 	return ""
 }
 
+// GetCredential returns the credential entry with the given name, or nil.
+func (c *Config) GetCredential(name string) *NamedToken {
+	for i := range c.Tokens {
+		if c.Tokens[i].Name == name {
+			return &c.Tokens[i]
+		}
+	}
+	return nil
+}
+
+// GetStoredToken returns the secret stored for a credential (keyring first,
+// then the config file) without considering cached OAuth sessions. It is used
+// for OAuth client secrets, where GetToken would return the cached access token.
+func (c *Config) GetStoredToken(name string) (string, error) {
+	if IsKeyringAvailable() {
+		if token, err := NewTokenStore().GetToken(name); err == nil && token != "" {
+			return token, nil
+		}
+	}
+	if cred := c.GetCredential(name); cred != nil {
+		if cred.Token != "" {
+			return cred.Token, nil
+		}
+		return "", fmt.Errorf("token %q not found in keyring (may need to re-add credentials)", name)
+	}
+	return "", fmt.Errorf("token %q not found", name)
+}
+
 // MustGetToken retrieves a token by reference name, returning empty string on error
 func (c *Config) MustGetToken(tokenRef string) string {
 	token, _ := c.GetToken(tokenRef)
@@ -580,36 +691,31 @@ func (c *Context) GetEffectiveSafetyLevel() SafetyLevel {
 	return c.SafetyLevel
 }
 
-// GetPreApplyHook returns the effective pre-apply hook command.
+// GetHook returns the effective hook command for a lifecycle event.
 // Per-context hooks take precedence over global (preferences) hooks.
 // The special value "none" explicitly disables the global hook for a context.
-func (c *Config) GetPreApplyHook() string {
+func (c *Config) GetHook(event string) string {
 	// Per-context hook wins
 	if ctx, err := c.CurrentContextObj(); err == nil {
-		if ctx.Hooks.PreApply != "" {
-			if ctx.Hooks.PreApply == "none" {
+		if command := ctx.Hooks.Command(event); command != "" {
+			if command == "none" {
 				return "" // explicitly disabled
 			}
-			return ctx.Hooks.PreApply
+			return command
 		}
 	}
 	// Fall back to global
-	return c.Preferences.Hooks.PreApply
+	return c.Preferences.Hooks.Command(event)
+}
+
+// GetPreApplyHook returns the effective pre-apply hook command.
+func (c *Config) GetPreApplyHook() string {
+	return c.GetHook("pre-apply")
 }
 
 // GetPostApplyHook returns the effective post-apply hook command.
-// Per-context hooks take precedence over global (preferences) hooks.
-// The special value "none" explicitly disables the global hook for a context.
 func (c *Config) GetPostApplyHook() string {
-	if ctx, err := c.CurrentContextObj(); err == nil {
-		if ctx.Hooks.PostApply != "" {
-			if ctx.Hooks.PostApply == "none" {
-				return ""
-			}
-			return ctx.Hooks.PostApply
-		}
-	}
-	return c.Preferences.Hooks.PostApply
+	return c.GetHook("post-apply")
 }
 
 // DeleteContext removes a context by name.
@@ -685,6 +791,8 @@ func (c *Config) setTokenWithKeyring(name, token string, kr keyringBackend, file
 	for i, nt := range c.Tokens {
 		if nt.Name == name {
 			c.Tokens[i].Token = token
+			c.Tokens[i].Type = ""
+			c.Tokens[i].ClientID = ""
 			return nil
 		}
 	}
@@ -696,6 +804,26 @@ func (c *Config) setTokenWithKeyring(name, token string, kr keyringBackend, file
 	return nil
 }
 
+// SetOAuthClientCredentials creates or updates an OAuth client credential.
+// The secret is stored like a token (see SetToken); the client ID is kept in
+// the config file.
+func (c *Config) SetOAuthClientCredentials(name, clientID, clientSecret string) error {
+	return c.setOAuthClientCredentialsWithKeyring(name, clientID, clientSecret, nil, nil)
+}
+
+func (c *Config) setOAuthClientCredentialsWithKeyring(name, clientID, clientSecret string, kr keyringBackend, fileStore *OAuthFileStore) error {
+	if clientID == "" {
+		return fmt.Errorf("client ID is required")
+	}
+	if err := c.setTokenWithKeyring(name, clientSecret, kr, fileStore); err != nil {
+		return err
+	}
+	cred := c.GetCredential(name)
+	cred.Type = CredentialTypeOAuthClient
+	cred.ClientID = clientID
+	return nil
+}
+
 // NewConfig creates a new default configuration
 func NewConfig() *Config {
 	return &Config{
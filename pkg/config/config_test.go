@@ -1918,3 +1918,88 @@ func contextNames(contexts []NamedContext) []string {
 	}
 	return names
 }
+
+func TestConfig_SetOAuthClientCredentials(t *testing.T) {
+	t.Parallel()
+
+	kr := newMockKeyring()
+	kr.data["oauth:prod:ci-client"] = `{"access_token":"cached"}`
+	fileStore := NewOAuthFileStoreWithDir(t.TempDir())
+
+	cfg := NewConfig()
+	if err := cfg.setOAuthClientCredentialsWithKeyring("ci-client", "dt0s02.ABC", "s3cret", kr, fileStore); err != nil {
+		t.Fatalf("setOAuthClientCredentialsWithKeyring() error = %v", err)
+	}
+
+	cred := cfg.GetCredential("ci-client")
+	if cred == nil || cred.Type != CredentialTypeOAuthClient || cred.ClientID != "dt0s02.ABC" || cred.Token != "" {
+		t.Fatalf("unexpected credential: %+v", cred)
+	}
+	if kr.data["ci-client"] != "s3cret" {
+		t.Errorf("expected secret in keyring, got %q", kr.data["ci-client"])
+	}
+	if _, ok := kr.data["oauth:prod:ci-client"]; ok {
+		t.Error("expected cached access token to be invalidated")
+	}
+
+	// Replacing the credential with a plain token clears the client fields.
+	if err := cfg.setTokenWithKeyring("ci-client", "dt0c01.token", kr, fileStore); err != nil {
+		t.Fatalf("setTokenWithKeyring() error = %v", err)
+	}
+	if cred := cfg.GetCredential("ci-client"); cred.Type != "" || cred.ClientID != "" {
+		t.Errorf("expected plain token credential, got %+v", cred)
+	}
+
+	if err := cfg.setOAuthClientCredentialsWithKeyring("other", "", "s3cret", kr, fileStore); err == nil {
+		t.Error("expected error for missing client ID")
+	}
+}
+
+func TestConfig_GetStoredToken_ConfigFallback(t *testing.T) {
+	t.Setenv(EnvDisableKeyring, "1")
+
+	cfg := NewConfig()
+	cfg.Tokens = []NamedToken{
+		{Name: "ci-client", Token: "s3cret", Type: CredentialTypeOAuthClient, ClientID: "dt0s02.ABC"},
+		{Name: "migrated"},
+	}
+
+	if got, err := cfg.GetStoredToken("ci-client"); err != nil || got != "s3cret" {
+		t.Errorf("GetStoredToken() = %q, %v", got, err)
+	}
+	if _, err := cfg.GetStoredToken("migrated"); err == nil || !strings.Contains(err.Error(), "keyring") {
+		t.Errorf("expected keyring error, got %v", err)
+	}
+	if _, err := cfg.GetStoredToken("missing"); err == nil {
+		t.Error("expected error for unknown credential")
+	}
+}
+
+func TestGetHook_LifecycleEvents(t *testing.T) {
+	cfg := &Config{
+		Preferences: Preferences{
+			Hooks: Hooks{PreDelete: "global-delete", PostQuery: "log-query"},
+		},
+		CurrentContext: "prod",
+		Contexts: []NamedContext{{
+			Name: "prod",
+			Context: Context{
+				Environment: "https://prod.example.invalid",
+				TokenRef:    "prod-token",
+				Hooks:       Hooks{PreDelete: "prod-delete", PostQuery: "none"},
+			},
+		}},
+	}
+	tests := map[string]string{
+		"pre-delete":  "prod-delete",
+		"post-query":  "",
+		"pre-create":  "",
+		"unknown":     "",
+		"post-delete": "",
+	}
+	for event, want := range tests {
+		if got := cfg.GetHook(event); got != want {
+			t.Errorf("GetHook(%q) = %q, want %q", event, got, want)
+		}
+	}
+}
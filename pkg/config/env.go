@@ -0,0 +1,151 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"strings"
+)
+
+const (
+	// EnvEnvironment is the environment variable that configures dtctl without
+	// a config file. When set, Load synthesizes an in-memory context with this
+	// environment URL and makes it the current context.
+	EnvEnvironment = "DTCTL_ENVIRONMENT"
+
+	// EnvToken holds the token of the context synthesized from EnvEnvironment.
+	EnvToken = "DTCTL_TOKEN"
+
+	// EnvTokenFile is the path of a file holding the token of the context
+	// synthesized from EnvEnvironment (e.g. a mounted Kubernetes secret).
+	EnvTokenFile = "DTCTL_TOKEN_FILE"
+
+	// EnvSafetyLevel is the safety level of the context synthesized from
+	// EnvEnvironment. Defaults to DefaultSafetyLevel.
+	EnvSafetyLevel = "DTCTL_SAFETY_LEVEL"
+
+	// EnvContextName is the name of the context synthesized from EnvEnvironment.
+	// It is also the token reference of that context.
+	EnvContextName = "env"
+)
+
+// envOverlay records the context synthesized from environment variables so
+// that it is never written back to the config file.
+type envOverlay struct {
+	token              string
+	fileCurrentContext string
+	shadowed           *NamedContext
+}
+
+// FromEnvironment reports whether the current context was synthesized from
+// environment variables (DTCTL_ENVIRONMENT) rather than read from a config file.
+func (c *Config) FromEnvironment() bool {
+	return c.env != nil && c.CurrentContext == EnvContextName
+}
+
+// IsEnvToken reports whether tokenRef refers to the in-memory token of the
+// context synthesized from environment variables. That is only the case while
+// the synthesized context is current; other contexts may use a stored token
+// that happens to be named "env".
+func (c *Config) IsEnvToken(tokenRef string) bool {
+	return c.FromEnvironment() && tokenRef == EnvContextName
+}
+
+// withEnvironmentContext adds the context configured by DTCTL_ENVIRONMENT to
+// cfg, the result of loading path. A missing config file is not an error in
+// that case, so dtctl can run on ephemeral runners without one.
+func withEnvironmentContext(path string, cfg *Config, loadErr error) (*Config, error) {
+	environment := strings.TrimSpace(os.Getenv(EnvEnvironment))
+	if environment == "" {
+		return cfg, loadErr
+	}
+	if loadErr != nil {
+		if _, err := os.Stat(path); !os.IsNotExist(err) {
+			return nil, loadErr
+		}
+		cfg = NewConfig()
+	}
+
+	token, err := tokenFromEnvironment()
+	if err != nil {
+		return nil, err
+	}
+
+	safetyLevel := SafetyLevel(strings.TrimSpace(os.Getenv(EnvSafetyLevel)))
+	if safetyLevel != "" && !safetyLevel.IsValid() {
+		return nil, fmt.Errorf("invalid %s %q. Valid values: readonly, readwrite-mine, readwrite-all, dangerously-unrestricted", EnvSafetyLevel, safetyLevel)
+	}
+
+	overlay := &envOverlay{token: token, fileCurrentContext: cfg.CurrentContext}
+	contexts := make([]NamedContext, 0, len(cfg.Contexts)+1)
+	for _, nc := range cfg.Contexts {
+		if nc.Name == EnvContextName {
+			shadowed := nc
+			overlay.shadowed = &shadowed
+			continue
+		}
+		contexts = append(contexts, nc)
+	}
+	cfg.Contexts = append(contexts, NamedContext{
+		Name: EnvContextName,
+		Context: Context{
+			Environment: environment,
+			TokenRef:    EnvContextName,
+			SafetyLevel: safetyLevel,
+			Description: "from " + EnvEnvironment,
+		},
+	})
+	cfg.CurrentContext = EnvContextName
+	cfg.env = overlay
+
+	return cfg, nil
+}
+
+// tokenFromEnvironment reads the token of the environment context from
+// DTCTL_TOKEN or DTCTL_TOKEN_FILE.
+func tokenFromEnvironment() (string, error) {
+	token := strings.TrimSpace(os.Getenv(EnvToken))
+	tokenFile := strings.TrimSpace(os.Getenv(EnvTokenFile))
+
+	switch {
+	case token != "" && tokenFile != "":
+		return "", fmt.Errorf("%s and %s are mutually exclusive", EnvToken, EnvTokenFile)
+	case token != "":
+		return token, nil
+	case tokenFile != "":
+		data, err := os.ReadFile(tokenFile)
+		if err != nil {
+			return "", fmt.Errorf("failed to read %s: %w", EnvTokenFile, err)
+		}
+		token = strings.TrimSpace(string(data))
+		if token == "" {
+			return "", fmt.Errorf("%s %q is empty", EnvTokenFile, tokenFile)
+		}
+		return token, nil
+	default:
+		return "", fmt.Errorf("%s is set but neither %s nor %s is", EnvEnvironment, EnvToken, EnvTokenFile)
+	}
+}
+
+// withoutEnvironmentContext returns the config as it should be persisted:
+// without the context synthesized from environment variables.
+func (c *Config) withoutEnvironmentContext() *Config {
+	if c.env == nil {
+		return c
+	}
+
+	persisted := *c
+	persisted.env = nil
+	persisted.Contexts = make([]NamedContext, 0, len(c.Contexts))
+	for _, nc := range c.Contexts {
+		if nc.Name != EnvContextName {
+			persisted.Contexts = append(persisted.Contexts, nc)
+		}
+	}
+	if c.env.shadowed != nil {
+		persisted.Contexts = append(persisted.Contexts, *c.env.shadowed)
+	}
+	if persisted.CurrentContext == EnvContextName {
+		persisted.CurrentContext = c.env.fileCurrentContext
+	}
+	return &persisted
+}
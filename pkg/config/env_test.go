@@ -0,0 +1,195 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestLoadFrom_EnvironmentWithoutConfigFile(t *testing.T) {
+	t.Setenv(EnvDisableKeyring, "1")
+	t.Setenv(EnvEnvironment, "https://abc12345.apps.dynatrace.com")
+	t.Setenv(EnvToken, "dt0s16.env")
+	t.Setenv(EnvTokenFile, "")
+	t.Setenv(EnvSafetyLevel, "readonly")
+
+	cfg, err := LoadFrom(filepath.Join(t.TempDir(), "missing"))
+	if err != nil {
+		t.Fatalf("LoadFrom() error = %v", err)
+	}
+	if !cfg.FromEnvironment() {
+		t.Error("FromEnvironment() = false, want true")
+	}
+
+	ctx, err := cfg.CurrentContextObj()
+	if err != nil {
+		t.Fatalf("CurrentContextObj() error = %v", err)
+	}
+	if ctx.Environment != "https://abc12345.apps.dynatrace.com" || ctx.SafetyLevel != SafetyLevelReadOnly {
+		t.Errorf("context = %+v", ctx)
+	}
+
+	token, err := cfg.GetToken(ctx.TokenRef)
+	if err != nil || token != "dt0s16.env" {
+		t.Errorf("GetToken() = %q, %v; want %q", token, err, "dt0s16.env")
+	}
+}
+
+func TestLoadFrom_EnvironmentTokenFile(t *testing.T) {
+	tokenFile := filepath.Join(t.TempDir(), "token")
+	if err := os.WriteFile(tokenFile, []byte("dt0s16.file\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	t.Setenv(EnvDisableKeyring, "1")
+	t.Setenv(EnvEnvironment, "https://abc12345.apps.dynatrace.com")
+	t.Setenv(EnvToken, "")
+	t.Setenv(EnvTokenFile, tokenFile)
+	t.Setenv(EnvSafetyLevel, "")
+
+	cfg, err := LoadFrom(filepath.Join(t.TempDir(), "missing"))
+	if err != nil {
+		t.Fatalf("LoadFrom() error = %v", err)
+	}
+	if token, _ := cfg.GetToken(EnvContextName); token != "dt0s16.file" {
+		t.Errorf("GetToken() = %q, want %q", token, "dt0s16.file")
+	}
+	ctx, _ := cfg.CurrentContextObj()
+	if ctx.GetEffectiveSafetyLevel() != DefaultSafetyLevel {
+		t.Errorf("safety level = %q, want default", ctx.GetEffectiveSafetyLevel())
+	}
+}
+
+func TestLoadFrom_EnvironmentErrors(t *testing.T) {
+	tests := []struct {
+		name        string
+		token       string
+		tokenFile   string
+		safetyLevel string
+		wantErr     string
+	}{
+		{name: "no token", wantErr: "neither"},
+		{name: "both tokens", token: "a", tokenFile: "b", wantErr: "mutually exclusive"},
+		{name: "missing token file", tokenFile: "/nonexistent/token", wantErr: EnvTokenFile},
+		{name: "invalid safety level", token: "a", safetyLevel: "yolo", wantErr: EnvSafetyLevel},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv(EnvEnvironment, "https://abc12345.apps.dynatrace.com")
+			t.Setenv(EnvToken, tt.token)
+			t.Setenv(EnvTokenFile, tt.tokenFile)
+			t.Setenv(EnvSafetyLevel, tt.safetyLevel)
+
+			_, err := LoadFrom(filepath.Join(t.TempDir(), "missing"))
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("LoadFrom() error = %v, want error containing %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestLoadFrom_EnvironmentIsNotPersisted(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config")
+	fileCfg := NewConfig()
+	fileCfg.SetContext("prod", "https://prod.example.com", "prod-token")
+	fileCfg.SetContext(EnvContextName, "https://shadowed.example.com", "other-token")
+	fileCfg.CurrentContext = "prod"
+	if err := fileCfg.SaveTo(path); err != nil {
+		t.Fatal(err)
+	}
+
+	t.Setenv(EnvDisableKeyring, "1")
+	t.Setenv(EnvEnvironment, "https://abc12345.apps.dynatrace.com")
+	t.Setenv(EnvToken, "dt0s16.env")
+	t.Setenv(EnvTokenFile, "")
+	t.Setenv(EnvSafetyLevel, "")
+
+	cfg, err := LoadFrom(path)
+	if err != nil {
+		t.Fatalf("LoadFrom() error = %v", err)
+	}
+	if cfg.CurrentContext != EnvContextName {
+		t.Errorf("CurrentContext = %q, want %q", cfg.CurrentContext, EnvContextName)
+	}
+	if _, err := cfg.GetContext("prod"); err != nil {
+		t.Errorf("contexts from the config file should remain available: %v", err)
+	}
+
+	cfg.SetContext("staging", "https://staging.example.com", "staging-token")
+	if err := cfg.SaveTo(path); err != nil {
+		t.Fatalf("SaveTo() error = %v", err)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(string(data), "dt0s16.env") || strings.Contains(string(data), "abc12345") {
+		t.Errorf("environment context was persisted:\n%s", data)
+	}
+
+	t.Setenv(EnvEnvironment, "")
+	saved, err := LoadFrom(path)
+	if err != nil {
+		t.Fatalf("LoadFrom() error = %v", err)
+	}
+	if saved.CurrentContext != "prod" {
+		t.Errorf("saved CurrentContext = %q, want %q", saved.CurrentContext, "prod")
+	}
+	shadowed, err := saved.GetContext(EnvContextName)
+	if err != nil || shadowed.Context.Environment != "https://shadowed.example.com" {
+		t.Errorf("shadowed context not restored: %+v, %v", shadowed, err)
+	}
+	if _, err := saved.GetContext("staging"); err != nil {
+		t.Errorf("new context not saved: %v", err)
+	}
+}
+
+func TestIsEnvToken_RequiresEnvironmentContext(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config")
+	fileCfg := NewConfig()
+	fileCfg.SetContext("staging", "https://staging.example.com", EnvContextName)
+	fileCfg.CurrentContext = "staging"
+	if err := fileCfg.SaveTo(path); err != nil {
+		t.Fatal(err)
+	}
+
+	t.Setenv(EnvDisableKeyring, "1")
+	t.Setenv(EnvEnvironment, "https://abc12345.apps.dynatrace.com")
+	t.Setenv(EnvToken, "dt0s16.env")
+	t.Setenv(EnvTokenFile, "")
+	t.Setenv(EnvSafetyLevel, "")
+
+	cfg, err := LoadFrom(path)
+	if err != nil {
+		t.Fatalf("LoadFrom() error = %v", err)
+	}
+	if !cfg.IsEnvToken(EnvContextName) {
+		t.Error("IsEnvToken() = false for the environment context, want true")
+	}
+
+	// A file context whose token-ref is "env" must not receive the token
+	// from DTCTL_TOKEN.
+	cfg.CurrentContext = "staging"
+	if cfg.IsEnvToken(EnvContextName) {
+		t.Error("IsEnvToken() = true for a file context, want false")
+	}
+	if token, _ := cfg.GetToken(EnvContextName); token == "dt0s16.env" {
+		t.Error("GetToken() returned the environment token for a file context")
+	}
+}
+
+func TestLoadFrom_EnvironmentKeepsParseErrors(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config")
+	if err := os.WriteFile(path, []byte("contexts: [unterminated"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv(EnvEnvironment, "https://abc12345.apps.dynatrace.com")
+	t.Setenv(EnvToken, "dt0s16.env")
+	t.Setenv(EnvTokenFile, "")
+
+	if _, err := LoadFrom(path); err == nil {
+		t.Error("expected parse error for invalid config file")
+	}
+}
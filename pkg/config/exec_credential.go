@@ -0,0 +1,226 @@
+package config
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// execCredentialDir is the subdirectory under CacheDir where tokens returned
+	// by credential plugins are cached until they expire.
+	execCredentialDir = "exec-credentials"
+
+	// execCredentialTimeout bounds a single plugin invocation. It is generous
+	// because plugins may wait for interactive unlocks (e.g. biometric prompts).
+	execCredentialTimeout = 2 * time.Minute
+
+	// execCredentialExpiryBuffer is how long before expiry a cached token is
+	// considered stale and the plugin is invoked again.
+	execCredentialExpiryBuffer = 30 * time.Second
+)
+
+// ExecCredential configures a credential plugin: an external command that
+// prints a token to stdout, for example a wrapper around the Vault or
+// 1Password CLI. The token never lands in the config file or the keyring.
+//
+// The command must print a JSON object:
+//
+//	{"token": "dt0s16.XXXX", "expiresAt": "2026-01-02T15:04:05Z"}
+//
+// expiresAt (RFC 3339) is optional. Tokens with an expiry are cached in the
+// cache directory until they expire; tokens without one are cached in memory
+// for the lifetime of the process.
+type ExecCredential struct {
+	Command string       `yaml:"command"`
+	Args    []string     `yaml:"args,omitempty"`
+	Env     []ExecEnvVar `yaml:"env,omitempty"`
+}
+
+// ExecEnvVar is an environment variable set for a credential plugin in
+// addition to the environment of dtctl.
+type ExecEnvVar struct {
+	Name  string `yaml:"name"`
+	Value string `yaml:"value"`
+}
+
+// ExecTokenResponse is the JSON object printed by a credential plugin.
+type ExecTokenResponse struct {
+	Token     string     `json:"token"`
+	ExpiresAt *time.Time `json:"expiresAt,omitempty"`
+}
+
+// execCredentialCacheDir returns the on-disk cache directory. Overridden in tests.
+var execCredentialCacheDir = func() string {
+	return filepath.Join(CacheDir(), execCredentialDir)
+}
+
+var (
+	execTokenCacheMu sync.Mutex
+	execTokenCache   = map[string]ExecTokenResponse{}
+)
+
+// TokenExecFor returns the credential plugin used for tokenRef, or nil if the
+// token is not provided by a plugin. When the current context references
+// tokenRef, its plugin (or the lack of one) decides, even if other contexts
+// sharing the token-ref set a plugin; with an empty tokenRef only the current
+// context is considered.
+func (c *Config) TokenExecFor(tokenRef string) *ExecCredential {
+	if ctx, err := c.CurrentContextObj(); err == nil && ctx.TokenRef == tokenRef {
+		return ctx.TokenExec
+	}
+	if tokenRef == "" {
+		return nil
+	}
+	for _, nc := range c.Contexts {
+		if nc.Context.TokenExec != nil && nc.Context.TokenRef == tokenRef {
+			return nc.Context.TokenExec
+		}
+	}
+	return nil
+}
+
+// Token returns the token of the credential plugin, invoking the command only
+// when no unexpired token is cached.
+func (e *ExecCredential) Token() (string, error) {
+	key := e.cacheKey()
+
+	execTokenCacheMu.Lock()
+	defer execTokenCacheMu.Unlock()
+
+	if cached, ok := execTokenCache[key]; ok && cached.valid() {
+		return cached.Token, nil
+	}
+	if cached, err := readExecTokenCache(key); err == nil && cached.valid() {
+		execTokenCache[key] = *cached
+		return cached.Token, nil
+	}
+
+	resp, err := e.run()
+	if err != nil {
+		return "", err
+	}
+
+	execTokenCache[key] = *resp
+	if resp.ExpiresAt != nil {
+		// Caching on disk is best-effort: the plugin is simply invoked again
+		// by the next dtctl process.
+		_ = writeExecTokenCache(key, resp)
+	}
+	return resp.Token, nil
+}
+
+// Invalidate drops the cached token so that the next call to Token invokes
+// the plugin again, e.g. after the API rejected the token with 401.
+func (e *ExecCredential) Invalidate() {
+	key := e.cacheKey()
+
+	execTokenCacheMu.Lock()
+	defer execTokenCacheMu.Unlock()
+
+	delete(execTokenCache, key)
+	_ = os.Remove(execTokenCachePath(key))
+}
+
+// run invokes the plugin and parses its response.
+func (e *ExecCredential) run() (*ExecTokenResponse, error) {
+	if e.Command == "" {
+		return nil, fmt.Errorf("credential plugin: command is required")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), execCredentialTimeout)
+	defer cancel()
+
+	cmd := exec.CommandContext(ctx, e.Command, e.Args...)
+	cmd.Env = os.Environ()
+	for _, env := range e.Env {
+		cmd.Env = append(cmd.Env, env.Name+"="+env.Value)
+	}
+	// Plugins may prompt the user (e.g. to unlock a vault), so they share
+	// stdin with dtctl; only stdout is reserved for the response.
+	cmd.Stdin = os.Stdin
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		if ctx.Err() == context.DeadlineExceeded {
+			return nil, fmt.Errorf("credential plugin %q timed out after %s", e.Command, execCredentialTimeout)
+		}
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return nil, fmt.Errorf("credential plugin %q failed: %w: %s", e.Command, err, msg)
+		}
+		return nil, fmt.Errorf("credential plugin %q failed: %w", e.Command, err)
+	}
+
+	var resp ExecTokenResponse
+	if err := json.Unmarshal(stdout.Bytes(), &resp); err != nil {
+		return nil, fmt.Errorf("credential plugin %q returned invalid JSON: %w", e.Command, err)
+	}
+	if resp.Token == "" {
+		return nil, fmt.Errorf("credential plugin %q returned no token", e.Command)
+	}
+	if resp.ExpiresAt != nil && !resp.valid() {
+		return nil, fmt.Errorf("credential plugin %q returned a token that expires at %s", e.Command, resp.ExpiresAt.Format(time.RFC3339))
+	}
+	return &resp, nil
+}
+
+// cacheKey identifies a plugin configuration, so that changing the command,
+// its arguments or its environment never reuses a token of the old one.
+func (e *ExecCredential) cacheKey() string {
+	h := sha256.New()
+	h.Write([]byte(e.Command))
+	for _, arg := range e.Args {
+		h.Write([]byte{0})
+		h.Write([]byte(arg))
+	}
+	for _, env := range e.Env {
+		h.Write([]byte{0})
+		h.Write([]byte(env.Name + "=" + env.Value))
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+func (r ExecTokenResponse) valid() bool {
+	return r.ExpiresAt == nil || time.Now().Add(execCredentialExpiryBuffer).Before(*r.ExpiresAt)
+}
+
+func execTokenCachePath(key string) string {
+	return filepath.Join(execCredentialCacheDir(), key+".json")
+}
+
+func readExecTokenCache(key string) (*ExecTokenResponse, error) {
+	data, err := os.ReadFile(execTokenCachePath(key))
+	if err != nil {
+		return nil, err
+	}
+	var resp ExecTokenResponse
+	if err := json.Unmarshal(data, &resp); err != nil {
+		return nil, err
+	}
+	if resp.Token == "" || resp.ExpiresAt == nil {
+		return nil, fmt.Errorf("incomplete cache entry")
+	}
+	return &resp, nil
+}
+
+func writeExecTokenCache(key string, resp *ExecTokenResponse) error {
+	if err := os.MkdirAll(execCredentialCacheDir(), oauthTokenDirMode); err != nil {
+		return err
+	}
+	data, err := json.Marshal(resp)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(execTokenCachePath(key), data, oauthTokenFileMode)
+}
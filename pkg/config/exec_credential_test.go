@@ -0,0 +1,179 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+)
+
+// newTestExecCredential returns a plugin that prints response and appends a
+// line to a counter file on every invocation.
+func newTestExecCredential(t *testing.T, response string) (*ExecCredential, func() int) {
+	t.Helper()
+	if runtime.GOOS == "windows" {
+		t.Skip("credential plugin tests use sh")
+	}
+
+	cacheDir := t.TempDir()
+	orig := execCredentialCacheDir
+	execCredentialCacheDir = func() string { return cacheDir }
+	t.Cleanup(func() { execCredentialCacheDir = orig })
+
+	counter := filepath.Join(t.TempDir(), "calls")
+	cred := &ExecCredential{
+		Command: "sh",
+		Args:    []string{"-c", `echo x >> "$CALLS"; printf '%s' "$RESPONSE"`},
+		Env: []ExecEnvVar{
+			{Name: "CALLS", Value: counter},
+			{Name: "RESPONSE", Value: response},
+		},
+	}
+	t.Cleanup(cred.Invalidate)
+
+	calls := func() int {
+		data, _ := os.ReadFile(counter)
+		return strings.Count(string(data), "x")
+	}
+	return cred, calls
+}
+
+func TestExecCredential_Token(t *testing.T) {
+	expiresAt := time.Now().Add(time.Hour).UTC().Format(time.RFC3339)
+	cred, calls := newTestExecCredential(t, fmt.Sprintf(`{"token":"dt0s16.exec","expiresAt":%q}`, expiresAt))
+
+	for i := 0; i < 2; i++ {
+		token, err := cred.Token()
+		if err != nil {
+			t.Fatalf("Token() error = %v", err)
+		}
+		if token != "dt0s16.exec" {
+			t.Errorf("Token() = %q, want %q", token, "dt0s16.exec")
+		}
+	}
+	if got := calls(); got != 1 {
+		t.Errorf("plugin invoked %d times, want 1", got)
+	}
+
+	// The disk cache is shared with later processes.
+	execTokenCacheMu.Lock()
+	delete(execTokenCache, cred.cacheKey())
+	execTokenCacheMu.Unlock()
+	if _, err := cred.Token(); err != nil {
+		t.Fatalf("Token() error = %v", err)
+	}
+	if got := calls(); got != 1 {
+		t.Errorf("plugin invoked %d times after reading disk cache, want 1", got)
+	}
+
+	cred.Invalidate()
+	if _, err := cred.Token(); err != nil {
+		t.Fatalf("Token() error = %v", err)
+	}
+	if got := calls(); got != 2 {
+		t.Errorf("plugin invoked %d times after Invalidate, want 2", got)
+	}
+}
+
+func TestExecCredential_TokenWithoutExpiry(t *testing.T) {
+	cred, calls := newTestExecCredential(t, `{"token":"dt0s16.exec"}`)
+
+	if _, err := cred.Token(); err != nil {
+		t.Fatalf("Token() error = %v", err)
+	}
+	if _, err := os.Stat(execTokenCachePath(cred.cacheKey())); !os.IsNotExist(err) {
+		t.Errorf("token without expiry must not be cached on disk, stat error = %v", err)
+	}
+	if _, err := cred.Token(); err != nil {
+		t.Fatalf("Token() error = %v", err)
+	}
+	if got := calls(); got != 1 {
+		t.Errorf("plugin invoked %d times, want 1", got)
+	}
+}
+
+func TestExecCredential_Errors(t *testing.T) {
+	expired := time.Now().Add(-time.Minute).UTC().Format(time.RFC3339)
+	tests := []struct {
+		name     string
+		response string
+		wantErr  string
+	}{
+		{name: "invalid JSON", response: "dt0s16.plain", wantErr: "invalid JSON"},
+		{name: "empty token", response: `{"token":""}`, wantErr: "no token"},
+		{name: "expired", response: fmt.Sprintf(`{"token":"t","expiresAt":%q}`, expired), wantErr: "expires at"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cred, _ := newTestExecCredential(t, tt.response)
+			_, err := cred.Token()
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("Token() error = %v, want error containing %q", err, tt.wantErr)
+			}
+		})
+	}
+
+	t.Run("command fails", func(t *testing.T) {
+		cred, _ := newTestExecCredential(t, "")
+		cred.Args = []string{"-c", "echo 'vault is sealed' >&2; exit 3"}
+		_, err := cred.Token()
+		if err == nil || !strings.Contains(err.Error(), "vault is sealed") {
+			t.Errorf("Token() error = %v, want error with plugin stderr", err)
+		}
+	})
+}
+
+func TestConfig_GetToken_ExecCredential(t *testing.T) {
+	t.Setenv(EnvDisableKeyring, "1")
+	cred, _ := newTestExecCredential(t, `{"token":"dt0s16.exec"}`)
+
+	cfg := NewConfig()
+	cfg.Tokens = []NamedToken{{Name: "vault", Token: "stale-inline-token"}}
+	cfg.Contexts = []NamedContext{
+		{Name: "prod", Context: Context{Environment: "https://prod.example.com", TokenRef: "vault", TokenExec: cred}},
+		{Name: "dev", Context: Context{Environment: "https://dev.example.com", TokenExec: cred}},
+	}
+
+	// Contexts referencing a plugin never fall back to stored tokens.
+	token, err := cfg.GetToken("vault")
+	if err != nil || token != "dt0s16.exec" {
+		t.Errorf("GetToken(vault) = %q, %v; want plugin token", token, err)
+	}
+
+	// token-ref is optional for the current context.
+	cfg.CurrentContext = "dev"
+	token, err = cfg.GetToken("")
+	if err != nil || token != "dt0s16.exec" {
+		t.Errorf("GetToken(\"\") = %q, %v; want plugin token", token, err)
+	}
+
+	if cfg.TokenExecFor("other") != nil {
+		t.Error("TokenExecFor(other) should be nil")
+	}
+}
+
+func TestConfig_TokenExecFor_SharedTokenRef(t *testing.T) {
+	cred, _ := newTestExecCredential(t, `{"token":"dt0s16.exec"}`)
+
+	cfg := NewConfig()
+	cfg.Tokens = []NamedToken{{Name: "shared", Token: "dt0s16.stored"}}
+	cfg.Contexts = []NamedContext{
+		{Name: "plugin", Context: Context{Environment: "https://a.example.com", TokenRef: "shared", TokenExec: cred}},
+		{Name: "stored", Context: Context{Environment: "https://b.example.com", TokenRef: "shared"}},
+	}
+
+	// The current context has no plugin, so another context's plugin for the
+	// same token-ref must not be used.
+	cfg.CurrentContext = "stored"
+	if cfg.TokenExecFor("shared") != nil {
+		t.Error("TokenExecFor(shared) should be nil for a current context without token-exec")
+	}
+
+	cfg.CurrentContext = "plugin"
+	if cfg.TokenExecFor("shared") != cred {
+		t.Error("TokenExecFor(shared) should return the plugin of the current context")
+	}
+}
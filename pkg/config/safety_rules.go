@@ -0,0 +1,207 @@
+package config
+
+import (
+	"fmt"
+	"path"
+	"strings"
+	"time"
+)
+
+// SafetyRuleAction is the effect of a matching safety rule
+type SafetyRuleAction string
+
+const (
+	// SafetyRuleAllow permits the operation, even if the safety level would block it
+	SafetyRuleAllow SafetyRuleAction = "allow"
+	// SafetyRuleDeny blocks the operation, even if the safety level would permit it
+	SafetyRuleDeny SafetyRuleAction = "deny"
+)
+
+// Owner values of a safety rule
+const (
+	// SafetyRuleOwnerMine matches resources owned by the current user
+	SafetyRuleOwnerMine = "mine"
+	// SafetyRuleOwnerOthers matches resources owned by someone else
+	SafetyRuleOwnerOthers = "others"
+)
+
+// validSafetyRuleOperations mirrors the operations the safety checker enforces.
+var validSafetyRuleOperations = map[string]bool{
+	"create": true, "update": true, "delete": true, "delete-bucket": true,
+}
+
+var weekdays = map[string]time.Weekday{
+	"sun": time.Sunday, "mon": time.Monday, "tue": time.Tuesday, "wed": time.Wednesday,
+	"thu": time.Thursday, "fri": time.Friday, "sat": time.Saturday,
+}
+
+// SafetyRule refines the safety level of a context. Rules are evaluated in
+// order; the first rule whose conditions all match decides whether the
+// operation is allowed. Empty conditions match everything.
+type SafetyRule struct {
+	Name       string           `yaml:"name"`
+	Action     SafetyRuleAction `yaml:"action"`
+	Operations []string         `yaml:"operations,omitempty"`
+	Resources  []string         `yaml:"resources,omitempty"`
+	// Names are glob patterns (path.Match syntax) for the resource name.
+	// When the name is not known, a deny rule with names matches and an
+	// allow rule with names does not.
+	Names []string `yaml:"names,omitempty"`
+	// Owner is "mine" or "others". When ownership cannot be determined, a
+	// deny rule with an owner matches and an allow rule with an owner does not.
+	Owner string `yaml:"owner,omitempty"`
+	// Windows restrict the rule to time windows, e.g. a change freeze.
+	Windows []SafetyWindow `yaml:"windows,omitempty"`
+	// Message is shown when the rule blocks an operation.
+	Message string `yaml:"message,omitempty"`
+}
+
+// SafetyWindow is a time window in which a safety rule applies. Start/End
+// define an absolute window; Days/From/To a weekly recurring one. Both may be
+// combined, in which case both must match.
+type SafetyWindow struct {
+	Start *time.Time `yaml:"start,omitempty"`
+	End   *time.Time `yaml:"end,omitempty"`
+	// Days are weekday abbreviations (mon, tue, ...). Empty means every day.
+	Days []string `yaml:"days,omitempty"`
+	// From and To are HH:MM times of day. A window with From after To spans
+	// midnight and belongs to the day it starts on.
+	From string `yaml:"from,omitempty"`
+	To   string `yaml:"to,omitempty"`
+	// Timezone is an IANA time zone name for Days/From/To. Defaults to local time.
+	Timezone string `yaml:"timezone,omitempty"`
+}
+
+// Validate checks a safety rule for unknown values
+func (r SafetyRule) Validate() error {
+	if r.Name == "" {
+		return fmt.Errorf("safety rule without name")
+	}
+	if r.Action != SafetyRuleAllow && r.Action != SafetyRuleDeny {
+		return fmt.Errorf("safety rule %q: invalid action %q (must be allow or deny)", r.Name, r.Action)
+	}
+	for _, op := range r.Operations {
+		if !validSafetyRuleOperations[op] {
+			return fmt.Errorf("safety rule %q: invalid operation %q (must be one of create, update, delete, delete-bucket)", r.Name, op)
+		}
+	}
+	for _, pattern := range r.Names {
+		if _, err := path.Match(pattern, ""); err != nil {
+			return fmt.Errorf("safety rule %q: invalid name pattern %q: %w", r.Name, pattern, err)
+		}
+	}
+	if r.Owner != "" && r.Owner != SafetyRuleOwnerMine && r.Owner != SafetyRuleOwnerOthers {
+		return fmt.Errorf("safety rule %q: invalid owner %q (must be mine or others)", r.Name, r.Owner)
+	}
+	for i, w := range r.Windows {
+		if err := w.validate(); err != nil {
+			return fmt.Errorf("safety rule %q: window %d: %w", r.Name, i+1, err)
+		}
+	}
+	return nil
+}
+
+// ValidateSafetyRules checks the safety rules and required approvals of a context
+func (c *Context) ValidateSafetyRules() error {
+	for _, op := range c.RequireApproval {
+		if !validSafetyRuleOperations[op] {
+			return fmt.Errorf("require-approval: invalid operation %q (must be one of create, update, delete, delete-bucket)", op)
+		}
+	}
+	seen := make(map[string]bool, len(c.SafetyRules))
+	for _, r := range c.SafetyRules {
+		if err := r.Validate(); err != nil {
+			return err
+		}
+		if seen[r.Name] {
+			return fmt.Errorf("duplicate safety rule name %q", r.Name)
+		}
+		seen[r.Name] = true
+	}
+	return nil
+}
+
+func (w SafetyWindow) validate() error {
+	if w.Start == nil && w.End == nil && len(w.Days) == 0 && w.From == "" && w.To == "" {
+		return fmt.Errorf("empty window")
+	}
+	if w.Start != nil && w.End != nil && !w.End.After(*w.Start) {
+		return fmt.Errorf("end must be after start")
+	}
+	for _, d := range w.Days {
+		if _, ok := weekdays[strings.ToLower(d)]; !ok {
+			return fmt.Errorf("invalid day %q (must be one of mon, tue, wed, thu, fri, sat, sun)", d)
+		}
+	}
+	if (w.From == "") != (w.To == "") {
+		return fmt.Errorf("from and to must be set together")
+	}
+	for _, t := range []string{w.From, w.To} {
+		if _, err := parseTimeOfDay(t); t != "" && err != nil {
+			return err
+		}
+	}
+	if _, err := time.LoadLocation(w.Timezone); err != nil {
+		return fmt.Errorf("invalid timezone %q: %w", w.Timezone, err)
+	}
+	return nil
+}
+
+// Contains reports whether t lies within the window
+func (w SafetyWindow) Contains(t time.Time) bool {
+	if w.Start != nil && t.Before(*w.Start) {
+		return false
+	}
+	if w.End != nil && !t.Before(*w.End) {
+		return false
+	}
+	if len(w.Days) == 0 && w.From == "" {
+		return true
+	}
+
+	if w.Timezone != "" {
+		if loc, err := time.LoadLocation(w.Timezone); err == nil {
+			t = t.In(loc)
+		}
+	} else {
+		t = t.Local()
+	}
+
+	from, _ := parseTimeOfDay(w.From)
+	to, _ := parseTimeOfDay(w.To)
+	minute := t.Hour()*60 + t.Minute()
+
+	switch {
+	case w.From == "" || from == to:
+		return w.onDay(t.Weekday())
+	case from < to:
+		return w.onDay(t.Weekday()) && minute >= from && minute < to
+	default:
+		// Spans midnight: the late part belongs to today, the early part to yesterday.
+		if minute >= from {
+			return w.onDay(t.Weekday())
+		}
+		return minute < to && w.onDay((t.Weekday()+6)%7)
+	}
+}
+
+func (w SafetyWindow) onDay(day time.Weekday) bool {
+	if len(w.Days) == 0 {
+		return true
+	}
+	for _, d := range w.Days {
+		if weekdays[strings.ToLower(d)] == day {
+			return true
+		}
+	}
+	return false
+}
+
+// parseTimeOfDay parses HH:MM into minutes since midnight
+func parseTimeOfDay(s string) (int, error) {
+	t, err := time.Parse("15:04", s)
+	if err != nil {
+		return 0, fmt.Errorf("invalid time of day %q (expected HH:MM)", s)
+	}
+	return t.Hour()*60 + t.Minute(), nil
+}
@@ -0,0 +1,124 @@
+package config
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+func TestSafetyRules_YAML(t *testing.T) {
+	data := `
+environment: https://abc.apps.dynatrace.com
+token-ref: prod
+safety-level: readonly
+safety-rules:
+  - name: freeze
+    action: deny
+    operations: [create, update, delete]
+    windows:
+      - start: 2026-12-20T00:00:00Z
+        end: 2027-01-05T00:00:00Z
+  - name: edit-dashboards
+    action: allow
+    operations: [update]
+    resources: [dashboard]
+    names: ["team-*"]
+`
+	var ctx Context
+	if err := yaml.Unmarshal([]byte(data), &ctx); err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+	if len(ctx.SafetyRules) != 2 {
+		t.Fatalf("got %d rules, want 2", len(ctx.SafetyRules))
+	}
+	if w := ctx.SafetyRules[0].Windows[0]; w.Start == nil || !w.Start.Equal(time.Date(2026, 12, 20, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("window start = %v", w.Start)
+	}
+	if err := ctx.ValidateSafetyRules(); err != nil {
+		t.Errorf("ValidateSafetyRules() error = %v", err)
+	}
+}
+
+func TestSafetyRule_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		rule    SafetyRule
+		wantErr string
+	}{
+		{"valid", SafetyRule{Name: "r", Action: SafetyRuleDeny}, ""},
+		{"missing name", SafetyRule{Action: SafetyRuleDeny}, "without name"},
+		{"bad action", SafetyRule{Name: "r", Action: "block"}, "invalid action"},
+		{"bad operation", SafetyRule{Name: "r", Action: SafetyRuleDeny, Operations: []string{"remove"}}, "invalid operation"},
+		{"read operation", SafetyRule{Name: "r", Action: SafetyRuleDeny, Operations: []string{"read"}}, "invalid operation"},
+		{"bad pattern", SafetyRule{Name: "r", Action: SafetyRuleDeny, Names: []string{"["}}, "invalid name pattern"},
+		{"bad owner", SafetyRule{Name: "r", Action: SafetyRuleDeny, Owner: "me"}, "invalid owner"},
+		{"empty window", SafetyRule{Name: "r", Action: SafetyRuleDeny, Windows: []SafetyWindow{{}}}, "empty window"},
+		{"bad day", SafetyRule{Name: "r", Action: SafetyRuleDeny, Windows: []SafetyWindow{{Days: []string{"funday"}}}}, "invalid day"},
+		{"from without to", SafetyRule{Name: "r", Action: SafetyRuleDeny, Windows: []SafetyWindow{{From: "09:00"}}}, "set together"},
+		{"bad time", SafetyRule{Name: "r", Action: SafetyRuleDeny, Windows: []SafetyWindow{{From: "9am", To: "10:00"}}}, "invalid time of day"},
+		{"bad timezone", SafetyRule{Name: "r", Action: SafetyRuleDeny, Windows: []SafetyWindow{{Days: []string{"fri"}, Timezone: "Mars/Olympus"}}}, "invalid timezone"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.rule.Validate()
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("Validate() error = %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("Validate() error = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestValidateSafetyRules_DuplicateName(t *testing.T) {
+	ctx := Context{SafetyRules: []SafetyRule{
+		{Name: "r", Action: SafetyRuleDeny},
+		{Name: "r", Action: SafetyRuleAllow},
+	}}
+	if err := ctx.ValidateSafetyRules(); err == nil || !strings.Contains(err.Error(), "duplicate") {
+		t.Errorf("ValidateSafetyRules() error = %v, want duplicate", err)
+	}
+}
+
+func TestValidateSafetyRules_RequireApprovalRead(t *testing.T) {
+	ctx := Context{RequireApproval: []string{"read"}}
+	if err := ctx.ValidateSafetyRules(); err == nil || !strings.Contains(err.Error(), "invalid operation") {
+		t.Errorf("ValidateSafetyRules() error = %v, want invalid operation", err)
+	}
+}
+
+func TestSafetyWindow_Contains(t *testing.T) {
+	at := func(day, hour, minute int) time.Time {
+		// 2026-10-19 is a Monday
+		return time.Date(2026, 10, 18+day, hour, minute, 0, 0, time.UTC)
+	}
+	tests := []struct {
+		name   string
+		window SafetyWindow
+		t      time.Time
+		want   bool
+	}{
+		{"weekend day", SafetyWindow{Days: []string{"sat", "sun"}, Timezone: "UTC"}, at(6, 10, 0), true},
+		{"weekday outside", SafetyWindow{Days: []string{"sat", "sun"}, Timezone: "UTC"}, at(1, 10, 0), false},
+		{"business hours inside", SafetyWindow{Days: []string{"mon"}, From: "09:00", To: "17:00", Timezone: "UTC"}, at(1, 16, 59), true},
+		{"business hours end exclusive", SafetyWindow{Days: []string{"mon"}, From: "09:00", To: "17:00", Timezone: "UTC"}, at(1, 17, 0), false},
+		{"overnight late part", SafetyWindow{Days: []string{"fri"}, From: "22:00", To: "06:00", Timezone: "UTC"}, at(5, 23, 0), true},
+		{"overnight early part", SafetyWindow{Days: []string{"fri"}, From: "22:00", To: "06:00", Timezone: "UTC"}, at(6, 5, 0), true},
+		{"overnight early part wrong day", SafetyWindow{Days: []string{"fri"}, From: "22:00", To: "06:00", Timezone: "UTC"}, at(5, 5, 0), false},
+		{"timezone applied", SafetyWindow{From: "09:00", To: "17:00", Timezone: "America/New_York"}, at(1, 14, 0), true},
+		{"timezone applied outside", SafetyWindow{From: "09:00", To: "17:00", Timezone: "America/New_York"}, at(1, 22, 0), false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.window.Contains(tt.t); got != tt.want {
+				t.Errorf("Contains(%v) = %v, want %v", tt.t, got, tt.want)
+			}
+		})
+	}
+}
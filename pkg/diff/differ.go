@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"os"
 	"reflect"
+	"sort"
 
 	"gopkg.in/yaml.v3"
 )
@@ -38,6 +39,8 @@ type DiffResult struct {
 	Patch      string
 	LeftLabel  string
 	RightLabel string
+	// Tiles summarizes the tile changes when both sides are dashboards
+	Tiles []TileChange
 }
 
 type Change struct {
@@ -88,6 +91,7 @@ func (d *Differ) Compare(left, right interface{}, leftLabel, rightLabel string)
 		Summary:    computeSummary(changes),
 		LeftLabel:  leftLabel,
 		RightLabel: rightLabel,
+		Tiles:      DashboardTileChanges(leftNorm, rightNorm),
 	}
 
 	formatter := d.getFormatter()
@@ -181,7 +185,13 @@ func computeDiff(path string, left, right interface{}) []Change {
 			allKeys[k] = true
 		}
 
+		keys := make([]string, 0, len(allKeys))
 		for k := range allKeys {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+
+		for _, k := range keys {
 			newPath := k
 			if path != "" {
 				newPath = path + "." + k
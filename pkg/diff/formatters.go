@@ -29,6 +29,7 @@ func (f *UnifiedFormatter) Format(result *DiffResult) (string, error) {
 	for _, change := range result.Changes {
 		f.writeChange(&buf, change)
 	}
+	writeTileSummary(&buf, result.Tiles)
 
 	return buf.String(), nil
 }
@@ -67,6 +68,7 @@ func (f *SideBySideFormatter) Format(result *DiffResult) (string, error) {
 	for _, change := range result.Changes {
 		f.writeChangeSideBySide(&buf, change, colWidth)
 	}
+	writeTileSummary(&buf, result.Tiles)
 
 	return buf.String(), nil
 }
@@ -141,6 +143,8 @@ func (f *SemanticFormatter) Format(result *DiffResult) (string, error) {
 		}
 	}
 
+	writeTileSummary(&buf, result.Tiles)
+
 	buf.WriteString(fmt.Sprintf("\nSummary: %d modified, %d added, %d removed\n",
 		result.Summary.Modified, result.Summary.Added, result.Summary.Removed))
 	buf.WriteString(fmt.Sprintf("Impact: %s\n", result.Summary.Impact))
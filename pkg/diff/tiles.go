@@ -0,0 +1,160 @@
+package diff
+
+import (
+	"bytes"
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// TileChange summarizes how one dashboard tile changed
+type TileChange struct {
+	ID        string          `json:"id"`
+	Title     string          `json:"title,omitempty"`
+	Operation ChangeOperation `json:"operation"`
+	// Fields lists the changed parts of a modified tile, e.g. query,
+	// visualization, settings or layout
+	Fields []string `json:"fields,omitempty"`
+}
+
+// DashboardTileChanges compares the tiles of two dashboards (documents with
+// content, or dashboard content) and returns one change per added, removed or
+// modified tile, in tile ID order. It returns nil unless both sides are
+// dashboards.
+func DashboardTileChanges(left, right interface{}) []TileChange {
+	leftTiles, leftLayouts, ok := dashboardTiles(left)
+	if !ok {
+		return nil
+	}
+	rightTiles, rightLayouts, ok := dashboardTiles(right)
+	if !ok {
+		return nil
+	}
+
+	ids := map[string]bool{}
+	for id := range leftTiles {
+		ids[id] = true
+	}
+	for id := range rightTiles {
+		ids[id] = true
+	}
+
+	var changes []TileChange
+	for _, id := range sortedTileIDs(ids) {
+		l, inLeft := leftTiles[id].(map[string]interface{})
+		r, inRight := rightTiles[id].(map[string]interface{})
+		switch {
+		case !inLeft:
+			changes = append(changes, TileChange{ID: id, Title: tileTitle(r), Operation: ChangeOpAdd})
+		case !inRight:
+			changes = append(changes, TileChange{ID: id, Title: tileTitle(l), Operation: ChangeOpRemove})
+		default:
+			fields := changedTileFields(l, r)
+			if !reflect.DeepEqual(leftLayouts[id], rightLayouts[id]) {
+				fields = append(fields, "layout")
+			}
+			if len(fields) > 0 {
+				changes = append(changes, TileChange{ID: id, Title: tileTitle(r), Operation: ChangeOpReplace, Fields: fields})
+			}
+		}
+	}
+	return changes
+}
+
+// dashboardTiles returns the tiles and layouts of a dashboard document or
+// dashboard content
+func dashboardTiles(data interface{}) (tiles, layouts map[string]interface{}, ok bool) {
+	m, isMap := data.(map[string]interface{})
+	if !isMap {
+		return nil, nil, false
+	}
+	content := m
+	if c, hasContent := m["content"].(map[string]interface{}); hasContent {
+		content = c
+	}
+	tiles, ok = content["tiles"].(map[string]interface{})
+	if !ok && m["type"] != "dashboard" {
+		return nil, nil, false
+	}
+	layouts, _ = content["layouts"].(map[string]interface{})
+	return tiles, layouts, true
+}
+
+// changedTileFields lists the tile fields that differ, with
+// visualizationSettings shown as settings
+func changedTileFields(left, right map[string]interface{}) []string {
+	keys := map[string]bool{}
+	for k := range left {
+		keys[k] = true
+	}
+	for k := range right {
+		keys[k] = true
+	}
+	var fields []string
+	for k := range keys {
+		if reflect.DeepEqual(left[k], right[k]) {
+			continue
+		}
+		if k == "visualizationSettings" {
+			k = "settings"
+		}
+		fields = append(fields, k)
+	}
+	sort.Strings(fields)
+	return fields
+}
+
+func tileTitle(tile map[string]interface{}) string {
+	title, _ := tile["title"].(string)
+	return title
+}
+
+// sortedTileIDs returns tile IDs in numeric order, followed by other IDs in
+// alphabetical order
+func sortedTileIDs(ids map[string]bool) []string {
+	sorted := make([]string, 0, len(ids))
+	for id := range ids {
+		sorted = append(sorted, id)
+	}
+	sort.Slice(sorted, func(i, j int) bool {
+		a, errA := strconv.Atoi(sorted[i])
+		b, errB := strconv.Atoi(sorted[j])
+		switch {
+		case errA == nil && errB == nil:
+			return a < b
+		case errA == nil || errB == nil:
+			return errA == nil
+		}
+		return sorted[i] < sorted[j]
+	})
+	return sorted
+}
+
+// writeTileSummary writes the tile changes of a dashboard diff
+func writeTileSummary(buf *bytes.Buffer, tiles []TileChange) {
+	if len(tiles) == 0 {
+		return
+	}
+	counts := map[ChangeOperation]int{}
+	for _, t := range tiles {
+		counts[t.Operation]++
+	}
+	buf.WriteString(fmt.Sprintf("\nTiles: %d added, %d removed, %d modified\n",
+		counts[ChangeOpAdd], counts[ChangeOpRemove], counts[ChangeOpReplace]))
+	for _, t := range tiles {
+		label := "tile " + t.ID
+		if t.Title != "" {
+			label += fmt.Sprintf(" %q", t.Title)
+		}
+		switch t.Operation {
+		case ChangeOpAdd:
+			buf.WriteString(fmt.Sprintf("  + %s\n", label))
+		case ChangeOpRemove:
+			buf.WriteString(fmt.Sprintf("  - %s\n", label))
+		case ChangeOpReplace:
+			buf.WriteString(fmt.Sprintf("  ~ %s: %s\n", label, strings.Join(t.Fields, ", ")))
+		}
+	}
+}
@@ -0,0 +1,93 @@
+package diff
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func dashboardVersion(tiles, layouts map[string]interface{}) map[string]interface{} {
+	return map[string]interface{}{
+		"name": "Ops",
+		"type": "dashboard",
+		"content": map[string]interface{}{
+			"version": 15,
+			"tiles":   tiles,
+			"layouts": layouts,
+		},
+	}
+}
+
+func TestDashboardTileChanges(t *testing.T) {
+	left := dashboardVersion(map[string]interface{}{
+		"1":  map[string]interface{}{"type": "data", "title": "Errors", "query": "fetch logs", "visualization": "table"},
+		"2":  map[string]interface{}{"type": "markdown", "title": "Notes", "content": "# Notes"},
+		"10": map[string]interface{}{"type": "data", "title": "Same", "query": "fetch events"},
+	}, map[string]interface{}{
+		"1":  map[string]interface{}{"x": 0, "y": 0, "w": 12, "h": 6},
+		"10": map[string]interface{}{"x": 12, "y": 0, "w": 12, "h": 6},
+	})
+	right := dashboardVersion(map[string]interface{}{
+		"1": map[string]interface{}{"type": "data", "title": "Errors", "query": "fetch logs | limit 10", "visualization": "table",
+			"visualizationSettings": map[string]interface{}{"table": true}},
+		"3":  map[string]interface{}{"type": "data", "title": "Latency", "query": "timeseries avg(latency)"},
+		"10": map[string]interface{}{"type": "data", "title": "Same", "query": "fetch events"},
+	}, map[string]interface{}{
+		"1":  map[string]interface{}{"x": 0, "y": 0, "w": 24, "h": 6},
+		"10": map[string]interface{}{"x": 12, "y": 0, "w": 12, "h": 6},
+	})
+
+	got := DashboardTileChanges(left, right)
+	want := []TileChange{
+		{ID: "1", Title: "Errors", Operation: ChangeOpReplace, Fields: []string{"query", "settings", "layout"}},
+		{ID: "2", Title: "Notes", Operation: ChangeOpRemove},
+		{ID: "3", Title: "Latency", Operation: ChangeOpAdd},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("DashboardTileChanges() = %+v, want %+v", got, want)
+	}
+}
+
+func TestDashboardTileChanges_NotDashboards(t *testing.T) {
+	wf := map[string]interface{}{"title": "wf", "tasks": map[string]interface{}{}}
+	if got := DashboardTileChanges(wf, wf); got != nil {
+		t.Errorf("DashboardTileChanges() = %+v, want nil", got)
+	}
+
+	// A dashboard without tiles counts as empty
+	empty := map[string]interface{}{"type": "dashboard", "content": map[string]interface{}{}}
+	full := dashboardVersion(map[string]interface{}{"0": map[string]interface{}{"type": "markdown"}}, nil)
+	if got := DashboardTileChanges(empty, full); len(got) != 1 || got[0].Operation != ChangeOpAdd {
+		t.Errorf("DashboardTileChanges() = %+v, want one added tile", got)
+	}
+}
+
+func TestDiffer_CompareDashboards(t *testing.T) {
+	left := dashboardVersion(map[string]interface{}{
+		"0": map[string]interface{}{"type": "data", "title": "Errors", "query": "fetch logs"},
+	}, nil)
+	right := dashboardVersion(map[string]interface{}{
+		"0": map[string]interface{}{"type": "data", "title": "Errors", "query": "fetch logs | limit 5"},
+	}, nil)
+
+	for _, format := range []DiffFormat{DiffFormatUnified, DiffFormatSideBySide, DiffFormatSemantic} {
+		result, err := NewDiffer(DiffOptions{Format: format}).Compare(left, right, "v1", "v2")
+		if err != nil {
+			t.Fatalf("%s: Compare() error = %v", format, err)
+		}
+		if len(result.Tiles) != 1 {
+			t.Fatalf("%s: Tiles = %+v", format, result.Tiles)
+		}
+		if !strings.Contains(result.Patch, "Tiles: 0 added, 0 removed, 1 modified") || !strings.Contains(result.Patch, `~ tile 0 "Errors": query`) {
+			t.Errorf("%s: patch has no tile summary:\n%s", format, result.Patch)
+		}
+	}
+
+	result, err := NewDiffer(DiffOptions{Format: DiffFormatJSONPatch}).Compare(left, right, "v1", "v2")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(result.Patch, "Tiles:") {
+		t.Errorf("JSON patch contains the tile summary:\n%s", result.Patch)
+	}
+}
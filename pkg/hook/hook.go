@@ -14,6 +14,35 @@ import (
 // DefaultTimeout is the maximum time a hook is allowed to run.
 const DefaultTimeout = 30 * time.Second
 
+// Lifecycle events that can run a hook. Pre-* hooks run before the operation
+// and veto it with a non-zero exit code; post-* hooks run after it succeeded.
+const (
+	EventPreApply   = "pre-apply"
+	EventPostApply  = "post-apply"
+	EventPreCreate  = "pre-create"
+	EventPostCreate = "post-create"
+	EventPreEdit    = "pre-edit"
+	EventPostEdit   = "post-edit"
+	EventPreDelete  = "pre-delete"
+	EventPostDelete = "post-delete"
+	EventPreQuery   = "pre-query"
+	EventPostQuery  = "post-query"
+)
+
+// RejectedError is returned when a pre-* hook exits with a non-zero exit
+// code, vetoing the operation.
+type RejectedError struct {
+	Event    string
+	Command  string
+	ExitCode int
+	Stdout   string
+	Stderr   string
+}
+
+func (e *RejectedError) Error() string {
+	return fmt.Sprintf("%s hook rejected the operation\nHook command: %s\nExit code: %d", e.Event, e.Command, e.ExitCode)
+}
+
 // Result holds the outcome of a hook execution.
 type Result struct {
 	ExitCode int
@@ -70,49 +99,7 @@ func tokenizeCommand(command string) ([]string, error) {
 //
 // If command is empty, the hook is a no-op and returns ExitCode 0.
 func RunPreApply(ctx context.Context, command string, resourceType string, sourceFile string, jsonData []byte) (*Result, error) {
-	if command == "" {
-		return &Result{ExitCode: 0}, nil
-	}
-
-	ctx, cancel := context.WithTimeout(ctx, DefaultTimeout)
-	defer cancel()
-
-	tokens, err := tokenizeCommand(command)
-	if err != nil {
-		return nil, fmt.Errorf("pre-apply hook: %w", err)
-	}
-	if len(tokens) == 0 {
-		return &Result{ExitCode: 0}, nil
-	}
-	args := make([]string, 0, len(tokens)-1+2)
-	args = append(args, tokens[1:]...)
-	args = append(args, resourceType, sourceFile)
-	cmd := exec.CommandContext(ctx, tokens[0], args...)
-	cmd.Stdin = bytes.NewReader(jsonData)
-
-	var stdout, stderr bytes.Buffer
-	cmd.Stdout = &stdout
-	cmd.Stderr = &stderr
-
-	start := time.Now()
-	err = cmd.Run()
-	elapsed := time.Since(start)
-	if err != nil {
-		if ctx.Err() == context.DeadlineExceeded {
-			return nil, fmt.Errorf("pre-apply hook timed out after %s", DefaultTimeout)
-		}
-		if exitErr, ok := err.(*exec.ExitError); ok {
-			return &Result{
-				ExitCode: exitErr.ExitCode(),
-				Stdout:   stdout.String(),
-				Stderr:   stderr.String(),
-				Duration: elapsed,
-			}, nil
-		}
-		return nil, fmt.Errorf("pre-apply hook failed to execute: %w", err)
-	}
-
-	return &Result{ExitCode: 0, Stdout: stdout.String(), Stderr: stderr.String(), Duration: elapsed}, nil
+	return Run(ctx, EventPreApply, command, []string{resourceType, sourceFile}, jsonData)
 }
 
 // RunPostApply executes the post-apply hook command.
@@ -129,6 +116,23 @@ func RunPreApply(ctx context.Context, command string, resourceType string, sourc
 // dtctl apply's overall result — post-apply runs after the resource is
 // already persisted, so a hook-level failure is typically a warning.
 func RunPostApply(ctx context.Context, command string, resourceType string, sourceFile string, resultJSON []byte) (*Result, error) {
+	return Run(ctx, EventPostApply, command, []string{resourceType, sourceFile}, resultJSON)
+}
+
+// Run executes the hook command for a lifecycle event.
+//
+// The command string is tokenized with POSIX-style shell quoting (see
+// tokenizeCommand) and executed directly (NOT via "sh -c") with args appended
+// as the final arguments. stdin is piped to the process. The event name is
+// used in error messages only.
+//
+// Returns a Result with ExitCode 0 on success. A non-zero ExitCode is not an
+// error; the caller decides whether it vetoes the operation (pre-* hooks) or
+// is reported as a warning (post-* hooks). An error return indicates the hook
+// could not be executed at all (not found, timed out, etc.).
+//
+// If command is empty, the hook is a no-op and returns ExitCode 0.
+func Run(ctx context.Context, event, command string, args []string, stdin []byte) (*Result, error) {
 	if command == "" {
 		return &Result{ExitCode: 0}, nil
 	}
@@ -138,16 +142,17 @@ func RunPostApply(ctx context.Context, command string, resourceType string, sour
 
 	tokens, err := tokenizeCommand(command)
 	if err != nil {
-		return nil, fmt.Errorf("post-apply hook: %w", err)
+		return nil, fmt.Errorf("%s hook: %w", event, err)
 	}
 	if len(tokens) == 0 {
 		return &Result{ExitCode: 0}, nil
 	}
-	args := make([]string, 0, len(tokens)-1+2)
-	args = append(args, tokens[1:]...)
-	args = append(args, resourceType, sourceFile)
-	cmd := exec.CommandContext(ctx, tokens[0], args...)
-	cmd.Stdin = bytes.NewReader(resultJSON)
+
+	argv := make([]string, 0, len(tokens)-1+len(args))
+	argv = append(argv, tokens[1:]...)
+	argv = append(argv, args...)
+	cmd := exec.CommandContext(ctx, tokens[0], argv...)
+	cmd.Stdin = bytes.NewReader(stdin)
 
 	var stdout, stderr bytes.Buffer
 	cmd.Stdout = &stdout
@@ -156,9 +161,10 @@ func RunPostApply(ctx context.Context, command string, resourceType string, sour
 	start := time.Now()
 	err = cmd.Run()
 	elapsed := time.Since(start)
+
 	if err != nil {
 		if ctx.Err() == context.DeadlineExceeded {
-			return nil, fmt.Errorf("post-apply hook timed out after %s", DefaultTimeout)
+			return nil, fmt.Errorf("%s hook timed out after %s", event, DefaultTimeout)
 		}
 		if exitErr, ok := err.(*exec.ExitError); ok {
 			return &Result{
@@ -168,7 +174,7 @@ func RunPostApply(ctx context.Context, command string, resourceType string, sour
 				Duration: elapsed,
 			}, nil
 		}
-		return nil, fmt.Errorf("post-apply hook failed to execute: %w", err)
+		return nil, fmt.Errorf("%s hook failed to execute: %w", event, err)
 	}
 
 	return &Result{
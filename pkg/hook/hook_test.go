@@ -325,3 +325,27 @@ func TestRunPreApply_HighExitCode(t *testing.T) {
 		t.Errorf("ExitCode = %d, want 255", result.ExitCode)
 	}
 }
+
+func TestRun_AppendsArgsAndReportsEvent(t *testing.T) {
+	cmd := writeScript(t, `cat > /dev/null; echo "$@"`+"\n")
+	result, err := Run(context.Background(), EventPreDelete, cmd, []string{"workflow", "wf-1"}, []byte(`{}`))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if strings.TrimSpace(result.Stdout) != "workflow wf-1" {
+		t.Errorf("Stdout = %q, want %q", result.Stdout, "workflow wf-1")
+	}
+
+	_, err = Run(context.Background(), EventPreDelete, `"unterminated`, nil, nil)
+	if err == nil || !strings.HasPrefix(err.Error(), "pre-delete hook:") {
+		t.Errorf("error = %v, want it to name the pre-delete event", err)
+	}
+}
+
+func TestRejectedError(t *testing.T) {
+	err := &RejectedError{Event: EventPreDelete, Command: "policy.sh", ExitCode: 3}
+	want := "pre-delete hook rejected the operation\nHook command: policy.sh\nExit code: 3"
+	if err.Error() != want {
+		t.Errorf("Error() = %q, want %q", err.Error(), want)
+	}
+}
@@ -0,0 +1,354 @@
+package output
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// maxSnapshotValueWidth caps how much of a single captured value is printed
+// in the human-readable snapshot view. Use -o json for the full value.
+const maxSnapshotValueWidth = 200
+
+// SnapshotFrame is a single stack frame extracted from a decoded snapshot.
+type SnapshotFrame struct {
+	Function string
+	Filename string
+	Line     interface{}
+}
+
+// String renders the frame as "function() at file:line".
+func (f SnapshotFrame) String() string {
+	location := f.Filename
+	if f.Line != nil {
+		location = fmt.Sprintf("%s:%v", f.Filename, f.Line)
+	}
+	if f.Function == "" {
+		return location
+	}
+	if location == "" {
+		return f.Function + "()"
+	}
+	return f.Function + "() at " + location
+}
+
+// LineNumber returns the frame's line number, or 0 if it is unknown.
+func (f SnapshotFrame) LineNumber() int {
+	return snapshotLineNumber(f.Line)
+}
+
+// SnapshotFrames returns the captured frame followed by the traceback of a
+// decoded snapshot (the parsed_snapshot value produced by DecodeSnapshotRecords).
+// The first element is the frame that hit the breakpoint. Consecutive
+// duplicates (the hit frame usually also heads the traceback) are collapsed.
+func SnapshotFrames(parsed map[string]interface{}) []SnapshotFrame {
+	rookout, _ := parsed["rookout"].(map[string]interface{})
+	if rookout == nil {
+		return nil
+	}
+
+	var frames []SnapshotFrame
+	if frame, ok := rookout["frame"].(map[string]interface{}); ok {
+		frames = append(frames, snapshotFrameFromMap(frame))
+	}
+	if tb, ok := rookout["traceback"].([]interface{}); ok {
+		for _, item := range tb {
+			m, ok := item.(map[string]interface{})
+			if !ok {
+				continue
+			}
+			f := snapshotFrameFromMap(m)
+			if len(frames) > 0 && frames[len(frames)-1].String() == f.String() {
+				continue
+			}
+			frames = append(frames, f)
+		}
+	}
+	return frames
+}
+
+func snapshotFrameFromMap(m map[string]interface{}) SnapshotFrame {
+	fn, _ := snapshotScalar(m["function"]).(string)
+	file, _ := snapshotScalar(m["filename"]).(string)
+	return SnapshotFrame{Function: fn, Filename: file, Line: snapshotScalar(m["line"])}
+}
+
+// snapshotScalar unwraps {"type": ..., "value": ...} wrappers that remain in
+// full (non-simplified) decode output.
+func snapshotScalar(v interface{}) interface{} {
+	if m, ok := v.(map[string]interface{}); ok {
+		if inner, ok := m["value"]; ok {
+			return inner
+		}
+	}
+	return v
+}
+
+// SnapshotLocals returns the captured local variables of the hit frame.
+func SnapshotLocals(parsed map[string]interface{}) map[string]interface{} {
+	rookout, _ := parsed["rookout"].(map[string]interface{})
+	if rookout == nil {
+		return nil
+	}
+	frame, _ := rookout["frame"].(map[string]interface{})
+	if frame == nil {
+		return nil
+	}
+	locals, _ := frame["locals"].(map[string]interface{})
+	return locals
+}
+
+// SnapshotSourceLine is a line of source code shown next to a stack frame.
+type SnapshotSourceLine struct {
+	Number int
+	Text   string
+}
+
+// SnapshotDetailOptions controls the optional parts of PrintSnapshotDetailWithOptions.
+type SnapshotDetailOptions struct {
+	// Tree prints the captured locals as a nested tree instead of one
+	// compact value per line.
+	Tree bool
+	// Source, if set, returns the source lines surrounding a frame. Frames
+	// for which it returns no lines are printed without source.
+	Source func(frame SnapshotFrame) []SnapshotSourceLine
+}
+
+// PrintSnapshotDetail writes a human-readable view of a single decoded
+// snapshot record: a header line, the captured locals and the stack frames.
+// The record must have been passed through DecodeSnapshotRecords.
+func PrintSnapshotDetail(w io.Writer, record map[string]interface{}) error {
+	return PrintSnapshotDetailWithOptions(w, record, SnapshotDetailOptions{})
+}
+
+// PrintSnapshotDetailWithOptions is PrintSnapshotDetail with a variable tree
+// view and source-aware stack frames.
+func PrintSnapshotDetailWithOptions(w io.Writer, record map[string]interface{}, opts SnapshotDetailOptions) error {
+	header := []string{}
+	if ts, ok := record["timestamp"].(string); ok && ts != "" {
+		header = append(header, Colorize(Dim, ts))
+	}
+	if id, ok := record["snapshot.id"].(string); ok && id != "" {
+		header = append(header, "snapshot "+id)
+	}
+	if msg, ok := record["snapshot.message"].(string); ok && msg != "" {
+		header = append(header, msg)
+	}
+	if _, err := fmt.Fprintln(w, Colorize(Bold, strings.Join(header, "  "))); err != nil {
+		return err
+	}
+
+	if decodeErr, ok := record["snapshot.decode_error"].(string); ok {
+		_, err := fmt.Fprintf(w, "  %s %s\n\n", Colorize(Red, "decode error:"), decodeErr)
+		return err
+	}
+
+	parsed, _ := record["parsed_snapshot"].(map[string]interface{})
+	if parsed == nil {
+		_, err := fmt.Fprintln(w, "  (no snapshot data)")
+		return err
+	}
+
+	frames := SnapshotFrames(parsed)
+	if len(frames) > 0 {
+		fmt.Fprintf(w, "  at %s\n", frames[0])
+	}
+
+	if locals := SnapshotLocals(parsed); len(locals) > 0 {
+		fmt.Fprintf(w, "  %s\n", Colorize(Cyan, "Locals:"))
+		names := sortedKeys(locals)
+		if opts.Tree {
+			for i, name := range names {
+				printSnapshotTree(w, "    ", name, locals[name], i == len(names)-1)
+			}
+		} else {
+			for _, name := range names {
+				fmt.Fprintf(w, "    %s = %s\n", name, formatSnapshotValue(locals[name]))
+			}
+		}
+	}
+
+	if len(frames) > 0 {
+		fmt.Fprintf(w, "  %s\n", Colorize(Cyan, "Stack:"))
+		for i, f := range frames {
+			fmt.Fprintf(w, "    #%-2d %s\n", i, f)
+			if opts.Source == nil {
+				continue
+			}
+			hit := f.LineNumber()
+			for _, line := range opts.Source(f) {
+				marker := " "
+				text := fmt.Sprintf("%5d | %s", line.Number, line.Text)
+				if line.Number == hit {
+					marker = ">"
+					text = Colorize(Bold, text)
+				}
+				fmt.Fprintf(w, "       %s %s\n", marker, text)
+			}
+		}
+	}
+
+	_, err := fmt.Fprintln(w)
+	return err
+}
+
+// printSnapshotTree prints a captured value as a tree node. Values in full
+// decode output ({"type": ..., "value": ...} wrappers) are labelled with
+// their type; containers are expanded into child nodes.
+func printSnapshotTree(w io.Writer, prefix, name string, value interface{}, last bool) {
+	branch, indent := "├── ", "│   "
+	if last {
+		branch, indent = "└── ", "    "
+	}
+
+	label := name
+	if typed, ok := value.(map[string]interface{}); ok {
+		if typeName, ok := typed["type"].(string); ok && typeName != "" {
+			label = fmt.Sprintf("%s %s", name, Colorize(Dim, "("+typeName+")"))
+		}
+	}
+
+	children, scalar, isLeaf := snapshotTreeChildren(value)
+	if isLeaf {
+		fmt.Fprintf(w, "%s%s%s = %s\n", prefix, branch, label, formatSnapshotValue(scalar))
+		return
+	}
+	if len(children) == 0 {
+		fmt.Fprintf(w, "%s%s%s (empty)\n", prefix, branch, label)
+		return
+	}
+	fmt.Fprintf(w, "%s%s%s\n", prefix, branch, label)
+	for i, child := range children {
+		printSnapshotTree(w, prefix+indent, child.name, child.value, i == len(children)-1)
+	}
+}
+
+type snapshotTreeNode struct {
+	name  string
+	value interface{}
+}
+
+// snapshotTreeChildren returns the child nodes of a container value, or the
+// scalar to print when the value is a leaf.
+func snapshotTreeChildren(value interface{}) ([]snapshotTreeNode, interface{}, bool) {
+	switch typed := value.(type) {
+	case map[string]interface{}:
+		if _, wrapped := typed["type"]; wrapped {
+			if attrs, ok := typed["@attributes"].(map[string]interface{}); ok && len(attrs) > 0 {
+				return mapTreeNodes(attrs), nil, false
+			}
+			inner, hasValue := typed["value"]
+			if !hasValue {
+				return nil, nil, true
+			}
+			if pairs, ok := snapshotPairs(typed); ok {
+				nodes := make([]snapshotTreeNode, 0, len(pairs))
+				for _, pair := range pairs {
+					nodes = append(nodes, snapshotTreeNode{name: formatSnapshotKey(pair[0]), value: pair[1]})
+				}
+				return nodes, nil, false
+			}
+			return snapshotTreeChildren(inner)
+		}
+		return mapTreeNodes(typed), nil, false
+	case []interface{}:
+		nodes := make([]snapshotTreeNode, 0, len(typed))
+		for i, item := range typed {
+			nodes = append(nodes, snapshotTreeNode{name: fmt.Sprintf("[%d]", i), value: item})
+		}
+		return nodes, nil, false
+	default:
+		return nil, value, true
+	}
+}
+
+func mapTreeNodes(m map[string]interface{}) []snapshotTreeNode {
+	nodes := make([]snapshotTreeNode, 0, len(m))
+	for _, key := range sortedKeys(m) {
+		nodes = append(nodes, snapshotTreeNode{name: key, value: m[key]})
+	}
+	return nodes
+}
+
+// snapshotPairs recognizes map values in full decode output, which are
+// encoded as a list of [key, value] pairs.
+func snapshotPairs(wrapper map[string]interface{}) ([][2]interface{}, bool) {
+	typeName, _ := wrapper["type"].(string)
+	lower := strings.ToLower(typeName)
+	if !strings.Contains(lower, "map") && !strings.Contains(lower, "dict") {
+		return nil, false
+	}
+	items, ok := wrapper["value"].([]interface{})
+	if !ok {
+		return nil, false
+	}
+	pairs := make([][2]interface{}, 0, len(items))
+	for _, item := range items {
+		pair, ok := item.([]interface{})
+		if !ok || len(pair) != 2 {
+			return nil, false
+		}
+		pairs = append(pairs, [2]interface{}{pair[0], pair[1]})
+	}
+	return pairs, true
+}
+
+func formatSnapshotKey(key interface{}) string {
+	if s, ok := snapshotScalar(key).(string); ok {
+		return s
+	}
+	return formatSnapshotValue(snapshotScalar(key))
+}
+
+// snapshotLineNumber converts a decoded line number to an int (0 if unknown).
+func snapshotLineNumber(line interface{}) int {
+	switch typed := line.(type) {
+	case int:
+		return typed
+	case int32:
+		return int(typed)
+	case int64:
+		return int(typed)
+	case float64:
+		return int(typed)
+	case string:
+		n, _ := strconv.Atoi(typed)
+		return n
+	default:
+		return 0
+	}
+}
+
+func sortedKeys(m map[string]interface{}) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// formatSnapshotValue renders a captured value on a single line.
+func formatSnapshotValue(v interface{}) string {
+	var s string
+	switch typed := v.(type) {
+	case string:
+		s = fmt.Sprintf("%q", typed)
+	case nil:
+		s = "null"
+	default:
+		data, err := json.Marshal(typed)
+		if err != nil {
+			s = fmt.Sprintf("%v", typed)
+		} else {
+			s = string(data)
+		}
+	}
+	if len(s) > maxSnapshotValueWidth {
+		s = s[:maxSnapshotValueWidth-3] + "..."
+	}
+	return s
+}
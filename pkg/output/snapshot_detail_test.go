@@ -0,0 +1,181 @@
+package output
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func sampleParsedSnapshot() map[string]interface{} {
+	return map[string]interface{}{
+		"rookout": map[string]interface{}{
+			"frame": map[string]interface{}{
+				"function": "process",
+				"filename": "OrderController.java",
+				"line":     306,
+				"locals": map[string]interface{}{
+					"orderId": 42,
+					"name":    "widget",
+					"items":   []interface{}{"a", "b"},
+				},
+			},
+			"traceback": []interface{}{
+				map[string]interface{}{"function": "process", "filename": "OrderController.java", "line": 306},
+				map[string]interface{}{"function": "handle", "filename": "Dispatcher.java", "line": 88},
+			},
+		},
+	}
+}
+
+func TestSnapshotFrames_CollapsesDuplicateHead(t *testing.T) {
+	frames := SnapshotFrames(sampleParsedSnapshot())
+	if len(frames) != 2 {
+		t.Fatalf("expected 2 frames, got %d: %v", len(frames), frames)
+	}
+	if frames[0].String() != "process() at OrderController.java:306" {
+		t.Errorf("unexpected first frame: %s", frames[0])
+	}
+	if frames[1].String() != "handle() at Dispatcher.java:88" {
+		t.Errorf("unexpected second frame: %s", frames[1])
+	}
+}
+
+func TestSnapshotFrames_UnwrapsFullDecode(t *testing.T) {
+	parsed := map[string]interface{}{
+		"rookout": map[string]interface{}{
+			"frame": map[string]interface{}{
+				"function": map[string]interface{}{"type": "String", "value": "run"},
+				"filename": map[string]interface{}{"type": "String", "value": "Main.java"},
+				"line":     map[string]interface{}{"type": "Integer", "value": 3},
+			},
+		},
+	}
+	frames := SnapshotFrames(parsed)
+	if len(frames) != 1 || frames[0].String() != "run() at Main.java:3" {
+		t.Errorf("unexpected frames: %v", frames)
+	}
+}
+
+func TestPrintSnapshotDetail(t *testing.T) {
+	record := map[string]interface{}{
+		"timestamp":       "2026-01-02T03:04:05Z",
+		"snapshot.id":     "snap-1",
+		"parsed_snapshot": sampleParsedSnapshot(),
+	}
+
+	var buf bytes.Buffer
+	if err := PrintSnapshotDetail(&buf, record); err != nil {
+		t.Fatalf("PrintSnapshotDetail() error = %v", err)
+	}
+
+	out := buf.String()
+	for _, want := range []string{
+		"snapshot snap-1",
+		"at process() at OrderController.java:306",
+		"Locals:",
+		`items = ["a","b"]`,
+		`name = "widget"`,
+		"orderId = 42",
+		"Stack:",
+		"#1  handle() at Dispatcher.java:88",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("output missing %q:\n%s", want, out)
+		}
+	}
+	if strings.Index(out, "items =") > strings.Index(out, "orderId =") {
+		t.Errorf("expected locals sorted by name:\n%s", out)
+	}
+}
+
+func TestPrintSnapshotDetail_DecodeError(t *testing.T) {
+	record := map[string]interface{}{
+		"snapshot.id":           "snap-2",
+		"snapshot.decode_error": "failed to decode snapshot.data base64",
+	}
+
+	var buf bytes.Buffer
+	if err := PrintSnapshotDetail(&buf, record); err != nil {
+		t.Fatalf("PrintSnapshotDetail() error = %v", err)
+	}
+	if !strings.Contains(buf.String(), "decode error:") {
+		t.Errorf("expected decode error line, got:\n%s", buf.String())
+	}
+}
+
+func TestFormatSnapshotValue_Truncates(t *testing.T) {
+	long := strings.Repeat("x", 500)
+	got := formatSnapshotValue(long)
+	if len(got) != maxSnapshotValueWidth || !strings.HasSuffix(got, "...") {
+		t.Errorf("expected truncated value of width %d, got %d", maxSnapshotValueWidth, len(got))
+	}
+}
+
+func TestPrintSnapshotDetailWithOptions_Tree(t *testing.T) {
+	parsed := sampleParsedSnapshot()
+	locals := parsed["rookout"].(map[string]interface{})["frame"].(map[string]interface{})["locals"].(map[string]interface{})
+	locals["order"] = map[string]interface{}{
+		"type": "com.example.Order",
+		"@attributes": map[string]interface{}{
+			"id": map[string]interface{}{"type": "java.lang.Integer", "value": 7},
+			"tags": map[string]interface{}{"type": "java.util.HashMap", "value": []interface{}{
+				[]interface{}{map[string]interface{}{"type": "java.lang.String", "value": "k"}, map[string]interface{}{"type": "java.lang.String", "value": "v"}},
+			}},
+		},
+	}
+	record := map[string]interface{}{"snapshot.id": "snap-1", "parsed_snapshot": parsed}
+
+	var buf bytes.Buffer
+	if err := PrintSnapshotDetailWithOptions(&buf, record, SnapshotDetailOptions{Tree: true}); err != nil {
+		t.Fatalf("PrintSnapshotDetailWithOptions() error = %v", err)
+	}
+
+	out := buf.String()
+	for _, want := range []string{
+		"├── items",
+		`│   ├── [0] = "a"`,
+		"├── order (com.example.Order)",
+		"│   ├── id (java.lang.Integer) = 7",
+		"│   └── tags (java.util.HashMap)",
+		`│       └── k (java.lang.String) = "v"`,
+		"└── orderId = 42",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("output missing %q:\n%s", want, out)
+		}
+	}
+}
+
+func TestPrintSnapshotDetailWithOptions_Source(t *testing.T) {
+	record := map[string]interface{}{"snapshot.id": "snap-1", "parsed_snapshot": sampleParsedSnapshot()}
+
+	var requested []string
+	opts := SnapshotDetailOptions{
+		Source: func(frame SnapshotFrame) []SnapshotSourceLine {
+			requested = append(requested, frame.Filename)
+			if frame.Filename != "OrderController.java" {
+				return nil
+			}
+			return []SnapshotSourceLine{
+				{Number: 305, Text: "int total = 0;"},
+				{Number: 306, Text: "process(order);"},
+			}
+		},
+	}
+
+	var buf bytes.Buffer
+	if err := PrintSnapshotDetailWithOptions(&buf, record, opts); err != nil {
+		t.Fatalf("PrintSnapshotDetailWithOptions() error = %v", err)
+	}
+
+	out := buf.String()
+	if !strings.Contains(out, "    305 | int total = 0;") {
+		t.Errorf("expected context line, got:\n%s", out)
+	}
+	if !strings.Contains(out, ">   306 | process(order);") {
+		t.Errorf("expected marked hit line, got:\n%s", out)
+	}
+	if len(requested) != 2 {
+		t.Errorf("expected source lookup for every frame, got %v", requested)
+	}
+}
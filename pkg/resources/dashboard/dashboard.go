@@ -0,0 +1,144 @@
+// Package dashboard provides offline tooling for the content of Dynatrace
+// dashboards (documents of type "dashboard"): parsing tiles and variables and
+// static analysis of their DQL queries.
+package dashboard
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+)
+
+// Dashboard is the parsed content of a dashboard document
+type Dashboard struct {
+	Version   any
+	Tiles     []Tile
+	Variables []Variable
+	// Content is the raw content the dashboard was parsed from
+	Content map[string]any
+}
+
+// Tile is a tile of a dashboard
+type Tile struct {
+	// ID is the key of the tile in the content ("0", "1", ...), or its index
+	// for content with a tile list
+	ID            string
+	Type          string
+	Title         string
+	Query         string
+	Visualization string
+	// Markdown is the text of a markdown tile
+	Markdown string
+	// Raw is the tile definition as found in the content
+	Raw map[string]any
+}
+
+// IsData reports whether the tile runs a DQL query
+func (t Tile) IsData() bool {
+	return t.Type == "data"
+}
+
+// Label names the tile in messages: its title if it has one, else its ID
+func (t Tile) Label() string {
+	if t.Title != "" {
+		return fmt.Sprintf("tile %s (%s)", t.ID, t.Title)
+	}
+	return "tile " + t.ID
+}
+
+// Variable is a dashboard variable
+type Variable struct {
+	Key  string
+	Type string
+	// Input is the DQL query of a query variable, or the values of a csv variable
+	Input        string
+	Multiple     bool
+	DefaultValue any
+}
+
+// Parse parses dashboard content (the "content" of a dashboard document).
+// Tiles are ordered by ID.
+func Parse(content []byte) (*Dashboard, error) {
+	var raw map[string]any
+	if err := json.Unmarshal(content, &raw); err != nil {
+		return nil, fmt.Errorf("failed to parse dashboard content: %w", err)
+	}
+	return FromContent(raw), nil
+}
+
+// FromContent builds a Dashboard from decoded dashboard content
+func FromContent(content map[string]any) *Dashboard {
+	d := &Dashboard{Version: content["version"], Content: content}
+
+	switch tiles := content["tiles"].(type) {
+	case map[string]any:
+		for id, raw := range tiles {
+			if m, ok := raw.(map[string]any); ok {
+				d.Tiles = append(d.Tiles, newTile(id, m))
+			}
+		}
+	case []any:
+		for i, raw := range tiles {
+			if m, ok := raw.(map[string]any); ok {
+				d.Tiles = append(d.Tiles, newTile(strconv.Itoa(i), m))
+			}
+		}
+	}
+	sort.SliceStable(d.Tiles, func(i, j int) bool {
+		return lessID(d.Tiles[i].ID, d.Tiles[j].ID)
+	})
+
+	if vars, ok := content["variables"].([]any); ok {
+		for _, raw := range vars {
+			m, ok := raw.(map[string]any)
+			if !ok {
+				continue
+			}
+			v := Variable{DefaultValue: m["defaultValue"]}
+			v.Key, _ = m["key"].(string)
+			v.Type, _ = m["type"].(string)
+			v.Input, _ = m["input"].(string)
+			v.Multiple, _ = m["multiple"].(bool)
+			d.Variables = append(d.Variables, v)
+		}
+	}
+	return d
+}
+
+func newTile(id string, m map[string]any) Tile {
+	t := Tile{ID: id, Raw: m}
+	t.Type, _ = m["type"].(string)
+	t.Title, _ = m["title"].(string)
+	t.Query, _ = m["query"].(string)
+	t.Visualization, _ = m["visualization"].(string)
+	if t.Type == "markdown" {
+		t.Markdown, _ = m["content"].(string)
+	}
+	return t
+}
+
+// Variable returns the variable with the given key
+func (d *Dashboard) Variable(key string) (Variable, bool) {
+	for _, v := range d.Variables {
+		if v.Key == key {
+			return v, true
+		}
+	}
+	return Variable{}, false
+}
+
+// lessID orders numeric tile IDs numerically and others lexically
+func lessID(a, b string) bool {
+	na, errA := strconv.Atoi(a)
+	nb, errB := strconv.Atoi(b)
+	switch {
+	case errA == nil && errB == nil:
+		return na < nb
+	case errA == nil:
+		return true
+	case errB == nil:
+		return false
+	}
+	return a < b
+}
@@ -0,0 +1,262 @@
+package dashboard
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Command is one stage of a DQL pipeline, e.g. "filter status == 500"
+type Command struct {
+	Name string
+	// Args is the text after the command name, with comments removed
+	Args string
+	// Offset is the byte offset of the command name in the query
+	Offset int
+}
+
+// Query is a DQL query split into its pipeline stages
+type Query struct {
+	Text     string
+	Commands []Command
+}
+
+// QueryError is a syntax error found by ParseQuery. Line and Column are
+// 1-based.
+type QueryError struct {
+	Offset  int
+	Line    int
+	Column  int
+	Message string
+}
+
+func (e *QueryError) Error() string {
+	return fmt.Sprintf("%d:%d: %s", e.Line, e.Column, e.Message)
+}
+
+func newQueryError(query string, offset int, format string, args ...any) *QueryError {
+	line := 1 + strings.Count(query[:offset], "\n")
+	column := offset - strings.LastIndex(query[:offset], "\n")
+	return &QueryError{Offset: offset, Line: line, Column: column, Message: fmt.Sprintf(format, args...)}
+}
+
+// sourceCommands start a DQL query
+var sourceCommands = map[string]bool{
+	"fetch": true, "timeseries": true, "data": true, "describe": true, "load": true,
+	"metrics": true, "smartscapeNodes": true, "smartscapeEdges": true,
+}
+
+// processingCommands may follow a source command
+var processingCommands = map[string]bool{
+	"filter": true, "filterOut": true, "search": true,
+	"fields": true, "fieldsAdd": true, "fieldsKeep": true, "fieldsRemove": true, "fieldsRename": true,
+	"fieldsFlatten": true, "fieldsSummary": true, "fieldsSnapshot": true,
+	"summarize": true, "makeTimeseries": true, "sort": true, "limit": true, "dedup": true,
+	"parse": true, "expand": true, "append": true, "join": true, "joinNested": true, "lookup": true,
+	"traverse": true,
+}
+
+// ParseQuery splits a DQL query into its commands without calling the API.
+// It checks the lexical structure only: strings, comments and brackets must
+// be terminated, no pipeline stage may be empty and the first command must
+// be a data source. Unknown command names are not an error (see
+// Command.Known), since DQL gains commands over time.
+func ParseQuery(query string) (*Query, error) {
+	q := &Query{Text: query}
+	if strings.TrimSpace(stripComments(query)) == "" {
+		return nil, newQueryError(query, 0, "empty query")
+	}
+
+	var brackets []int
+	stageStart := 0
+	var stage strings.Builder
+	flush := func(end int) error {
+		text := stage.String()
+		stage.Reset()
+		trimmed := strings.TrimSpace(text)
+		if trimmed == "" {
+			return newQueryError(query, end, "empty pipeline stage")
+		}
+		name := trimmed
+		if i := strings.IndexFunc(trimmed, func(r rune) bool { return !isIdentRune(r) }); i >= 0 {
+			name = trimmed[:i]
+		}
+		if name == "" {
+			return newQueryError(query, stageStart+strings.Index(query[stageStart:], trimmed[:1]), "expected a command name")
+		}
+		offset := stageStart + strings.Index(query[stageStart:], name)
+		q.Commands = append(q.Commands, Command{
+			Name:   name,
+			Args:   strings.TrimSpace(trimmed[len(name):]),
+			Offset: offset,
+		})
+		return nil
+	}
+
+	for i := 0; i < len(query); i++ {
+		ch := query[i]
+		switch {
+		case ch == '"' || ch == '`':
+			end := strings.IndexByte(query[i+1:], ch)
+			for end >= 0 && ch == '"' && escaped(query, i+1+end) {
+				next := strings.IndexByte(query[i+2+end:], ch)
+				if next < 0 {
+					end = -1
+					break
+				}
+				end += 1 + next
+			}
+			if end < 0 {
+				return nil, newQueryError(query, i, "unterminated string")
+			}
+			stage.WriteString(query[i : i+2+end])
+			i += 1 + end
+		case strings.HasPrefix(query[i:], "//"):
+			end := strings.IndexByte(query[i:], '\n')
+			if end < 0 {
+				end = len(query) - i
+			}
+			stage.WriteByte(' ')
+			i += end - 1
+		case strings.HasPrefix(query[i:], "/*"):
+			end := strings.Index(query[i+2:], "*/")
+			if end < 0 {
+				return nil, newQueryError(query, i, "unterminated comment")
+			}
+			stage.WriteByte(' ')
+			i += end + 3
+		case ch == '(' || ch == '[' || ch == '{':
+			brackets = append(brackets, i)
+			stage.WriteByte(ch)
+		case ch == ')' || ch == ']' || ch == '}':
+			if len(brackets) == 0 || !matchingBracket(query[brackets[len(brackets)-1]], ch) {
+				return nil, newQueryError(query, i, "unexpected %q", ch)
+			}
+			brackets = brackets[:len(brackets)-1]
+			stage.WriteByte(ch)
+		case ch == '|' && len(brackets) == 0 && !strings.HasPrefix(query[i:], "||"):
+			if err := flush(i); err != nil {
+				return nil, err
+			}
+			stageStart = i + 1
+		default:
+			stage.WriteByte(ch)
+		}
+	}
+	if len(brackets) > 0 {
+		open := brackets[len(brackets)-1]
+		return nil, newQueryError(query, open, "unclosed %q", query[open])
+	}
+	if err := flush(len(query)); err != nil {
+		return nil, err
+	}
+
+	if first := q.Commands[0]; !sourceCommands[first.Name] {
+		return nil, newQueryError(query, first.Offset, "query must start with a data source command such as fetch or timeseries, got %q", first.Name)
+	}
+	return q, nil
+}
+
+// Known reports whether the command is a known DQL command
+func (c Command) Known() bool {
+	return sourceCommands[c.Name] || processingCommands[c.Name]
+}
+
+// Has reports whether the query contains a command
+func (q *Query) Has(name string) bool {
+	for _, c := range q.Commands {
+		if c.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+// Parameter returns the value of a top-level named parameter of a command,
+// e.g. "now()-7d" for "from:" in "fetch logs, from:now()-7d"
+func (c Command) Parameter(name string) (string, bool) {
+	for _, arg := range splitTopLevel(c.Args, ',') {
+		arg = strings.TrimSpace(arg)
+		key, value, ok := strings.Cut(arg, ":")
+		if ok && strings.TrimSpace(key) == name {
+			return strings.TrimSpace(value), true
+		}
+	}
+	return "", false
+}
+
+// stripComments removes // and /* */ comments outside of strings
+func stripComments(query string) string {
+	var b strings.Builder
+	for i := 0; i < len(query); i++ {
+		ch := query[i]
+		switch {
+		case ch == '"' || ch == '`':
+			end := i + 1
+			for end < len(query) && (query[end] != ch || (ch == '"' && escaped(query, end))) {
+				end++
+			}
+			if end >= len(query) {
+				b.WriteString(query[i:])
+				return b.String()
+			}
+			b.WriteString(query[i : end+1])
+			i = end
+		case strings.HasPrefix(query[i:], "//"):
+			for i < len(query) && query[i] != '\n' {
+				i++
+			}
+			b.WriteByte('\n')
+		case strings.HasPrefix(query[i:], "/*"):
+			end := strings.Index(query[i+2:], "*/")
+			if end < 0 {
+				return b.String()
+			}
+			b.WriteByte(' ')
+			i += end + 3
+		default:
+			b.WriteByte(ch)
+		}
+	}
+	return b.String()
+}
+
+// splitTopLevel splits s at sep outside of brackets and strings
+func splitTopLevel(s string, sep byte) []string {
+	var parts []string
+	depth, start := 0, 0
+	for i := 0; i < len(s); i++ {
+		switch ch := s[i]; {
+		case ch == '"' || ch == '`':
+			end := i + 1
+			for end < len(s) && (s[end] != ch || (ch == '"' && escaped(s, end))) {
+				end++
+			}
+			i = end
+		case ch == '(' || ch == '[' || ch == '{':
+			depth++
+		case ch == ')' || ch == ']' || ch == '}':
+			depth--
+		case ch == sep && depth == 0:
+			parts = append(parts, s[start:i])
+			start = i + 1
+		}
+	}
+	return append(parts, s[start:])
+}
+
+// escaped reports whether the character at i is preceded by an odd number of backslashes
+func escaped(s string, i int) bool {
+	n := 0
+	for j := i - 1; j >= 0 && s[j] == '\\'; j-- {
+		n++
+	}
+	return n%2 == 1
+}
+
+func matchingBracket(open, closing byte) bool {
+	return (open == '(' && closing == ')') || (open == '[' && closing == ']') || (open == '{' && closing == '}')
+}
+
+func isIdentRune(r rune) bool {
+	return r == '_' || (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z') || (r >= '0' && r <= '9')
+}
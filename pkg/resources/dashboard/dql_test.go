@@ -0,0 +1,65 @@
+package dashboard
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParseQuery(t *testing.T) {
+	q, err := ParseQuery(`fetch logs, from:now()-2h // recent only
+| filter contains(content, "a | b") and status == "ERROR"
+| summarize count(), by: {host.name}
+| limit 10`)
+	if err != nil {
+		t.Fatalf("ParseQuery() error = %v", err)
+	}
+	var names []string
+	for _, c := range q.Commands {
+		names = append(names, c.Name)
+	}
+	if want := []string{"fetch", "filter", "summarize", "limit"}; !reflect.DeepEqual(names, want) {
+		t.Errorf("commands = %v, want %v", names, want)
+	}
+	if from, ok := q.Commands[0].Parameter("from"); !ok || from != "now()-2h" {
+		t.Errorf("from = %q, %v", from, ok)
+	}
+	if _, ok := q.Commands[2].Parameter("from"); ok {
+		t.Error("summarize has no from parameter")
+	}
+	if by, _ := q.Commands[2].Parameter("by"); by != "{host.name}" {
+		t.Errorf("by = %q", by)
+	}
+	if q.Commands[1].Offset != strings.Index(q.Text, "filter") {
+		t.Errorf("filter offset = %d", q.Commands[1].Offset)
+	}
+}
+
+func TestParseQuery_Errors(t *testing.T) {
+	tests := []struct {
+		query, want string
+		line, col   int
+	}{
+		{"", "empty query", 1, 1},
+		{"// only a comment", "empty query", 1, 1},
+		{"fetch logs |", "empty pipeline stage", 1, 13},
+		{"fetch logs | filter x == \"open", "unterminated string", 1, 26},
+		{"fetch logs\n| filter (a", "unclosed '('", 2, 10},
+		{"fetch logs | filter a)", "unexpected ')'", 1, 22},
+		{"fetch logs /* comment", "unterminated comment", 1, 12},
+		{"filter a == 1", "must start with a data source", 1, 1},
+		{"fetch logs | | limit 1", "empty pipeline stage", 1, 14},
+	}
+	for _, tt := range tests {
+		t.Run(tt.query, func(t *testing.T) {
+			_, err := ParseQuery(tt.query)
+			qe, ok := err.(*QueryError)
+			if !ok {
+				t.Fatalf("ParseQuery() error = %v, want QueryError", err)
+			}
+			if !strings.Contains(qe.Message, tt.want) || qe.Line != tt.line || qe.Column != tt.col {
+				t.Errorf("ParseQuery() = %v, want %d:%d: %s", qe, tt.line, tt.col, tt.want)
+			}
+		})
+	}
+}
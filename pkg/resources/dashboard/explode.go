@@ -0,0 +1,199 @@
+package dashboard
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/dynatrace-oss/dtctl/pkg/util/format"
+)
+
+// ExplodedSpecFile is the name of the spec file written by Explode
+const ExplodedSpecFile = "dashboard.yaml"
+
+// Explode splits a dashboard into a source tree: a spec (ExplodedSpecFile),
+// one .dql file per data tile and query variable and one .md file per
+// markdown tile, keyed by their path relative to the spec. What the spec
+// fields cannot express is kept in extra fields, so that building the spec
+// gives the same content again; Explode checks this and fails rather than
+// lose anything.
+func Explode(id, title, description string, content map[string]any) (map[string][]byte, error) {
+	if content == nil {
+		return nil, fmt.Errorf("dashboard has no content")
+	}
+	e := &exploder{files: map[string][]byte{}}
+	e.builder = &specBuilder{readFile: e.read}
+
+	s := &Spec{Kind: SpecKind, ID: id, Title: title, Description: description}
+	if from, to, ok := FromContent(content).DefaultTimeframe(); ok {
+		s.Timeframe = &SpecTimeframe{From: from}
+		if to != "now()" {
+			s.Timeframe.To = to
+		}
+	}
+	variables, _ := content["variables"].([]any)
+	for _, v := range variables {
+		raw, _ := v.(map[string]any)
+		s.Variables = append(s.Variables, e.variable(raw))
+	}
+	tiles, _ := content["tiles"].(map[string]any)
+	layouts, _ := content["layouts"].(map[string]any)
+	for _, tileID := range sortedTileIDs(tiles) {
+		raw, _ := tiles[tileID].(map[string]any)
+		s.Tiles = append(s.Tiles, e.tile(tileID, raw, layouts[tileID]))
+	}
+
+	built, err := s.build(e.read)
+	if err != nil {
+		return nil, fmt.Errorf("failed to export dashboard: %w", err)
+	}
+	s.Extra = format.CreateMergePatch(built["content"].(map[string]any), content)
+
+	var buf bytes.Buffer
+	enc := yaml.NewEncoder(&buf)
+	enc.SetIndent(2)
+	if err := enc.Encode(s); err != nil {
+		return nil, fmt.Errorf("failed to write dashboard spec: %w", err)
+	}
+	if err := enc.Close(); err != nil {
+		return nil, fmt.Errorf("failed to write dashboard spec: %w", err)
+	}
+
+	// Check the round trip through the written files
+	loaded, err := LoadSpec(buf.Bytes())
+	if err == nil {
+		built, err = loaded.build(e.read)
+	}
+	if err != nil || !format.JSONEqual(built["content"], content) || built["name"] != title {
+		return nil, fmt.Errorf("dashboard cannot be exported without losing content")
+	}
+	e.files[ExplodedSpecFile] = buf.Bytes()
+	return e.files, nil
+}
+
+type exploder struct {
+	files   map[string][]byte
+	builder *specBuilder
+}
+
+func (e *exploder) read(name string) ([]byte, error) {
+	data, ok := e.files[name]
+	if !ok {
+		return nil, os.ErrNotExist
+	}
+	return data, nil
+}
+
+// addFile adds a text file to dir and returns its path. Names are made
+// unique with a number.
+func (e *exploder) addFile(dir, name, ext, text string) string {
+	if name == "" {
+		name = strings.TrimSuffix(dir, "s")
+	}
+	p := path.Join(dir, name+ext)
+	for n := 2; e.files[p] != nil; n++ {
+		p = path.Join(dir, fmt.Sprintf("%s-%d%s", name, n, ext))
+	}
+	e.files[p] = []byte(text + "\n")
+	return p
+}
+
+// variable turns a variable into a spec variable. Variables the spec fields
+// do not fit are kept as extra fields only.
+func (e *exploder) variable(raw map[string]any) SpecVariable {
+	key, _ := raw["key"].(string)
+	v := SpecVariable{Key: key}
+	v.Type, _ = raw["type"].(string)
+	v.Multiple, _ = raw["multiple"].(bool)
+	v.Default = raw["defaultValue"]
+	input, _ := raw["input"].(string)
+	switch v.Type {
+	case "csv":
+		if input != "" {
+			v.Values = strings.Split(input, ",")
+		}
+	case "query":
+		v.File = e.addFile("variables", format.Slug(key), ".dql", input)
+	}
+
+	if built, err := e.builder.variable(v); err == nil {
+		v.Extra = format.CreateMergePatch(built, raw)
+		if rebuilt, err := e.builder.variable(v); err == nil && format.JSONEqual(rebuilt, raw) {
+			return v
+		}
+	}
+	delete(e.files, v.File)
+	return SpecVariable{Key: key, Extra: format.CreateMergePatch(map[string]any{"key": key}, raw)}
+}
+
+// tile turns a tile into a spec tile. Tiles the spec fields do not fit are
+// kept as extra fields only.
+func (e *exploder) tile(id string, raw map[string]any, layout any) SpecTile {
+	t := SpecTile{ID: id, Layout: specLayout(layout)}
+	t.Title, _ = raw["title"].(string)
+	name := id
+	if slug := format.Slug(t.Title); slug != "" {
+		name += "-" + slug
+	}
+	switch raw["type"] {
+	case "data":
+		query, _ := raw["query"].(string)
+		t.File = e.addFile("tiles", name, ".dql", query)
+		t.Visualization, _ = raw["visualization"].(string)
+		t.Settings, _ = raw["visualizationSettings"].(map[string]any)
+	case "markdown":
+		text, _ := raw["content"].(string)
+		t.MarkdownFile = e.addFile("tiles", name, ".md", text)
+	}
+
+	if built, err := e.builder.tile(t); err == nil {
+		t.Extra = format.CreateMergePatch(built, raw)
+		if rebuilt, err := e.builder.tile(t); err == nil && format.JSONEqual(rebuilt, raw) {
+			return t
+		}
+	}
+	delete(e.files, t.File)
+	delete(e.files, t.MarkdownFile)
+	return SpecTile{ID: id, Layout: t.Layout, Extra: format.CreateMergePatch(map[string]any{}, raw)}
+}
+
+// specLayout converts a tile layout with integer x, y, w and h
+func specLayout(layout any) *SpecLayout {
+	m, _ := layout.(map[string]any)
+	var v [4]int
+	for i, k := range []string{"x", "y", "w", "h"} {
+		f, ok := m[k].(float64)
+		if !ok || f != float64(int(f)) {
+			return nil
+		}
+		v[i] = int(f)
+	}
+	return &SpecLayout{X: v[0], Y: v[1], W: v[2], H: v[3]}
+}
+
+// sortedTileIDs returns the tile IDs in numeric order, followed by other IDs
+// in alphabetical order
+func sortedTileIDs(tiles map[string]any) []string {
+	ids := make([]string, 0, len(tiles))
+	for id := range tiles {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool {
+		a, errA := strconv.Atoi(ids[i])
+		b, errB := strconv.Atoi(ids[j])
+		switch {
+		case errA == nil && errB == nil:
+			return a < b
+		case errA == nil || errB == nil:
+			return errA == nil
+		}
+		return ids[i] < ids[j]
+	})
+	return ids
+}
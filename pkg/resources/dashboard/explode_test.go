@@ -0,0 +1,140 @@
+package dashboard
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/dynatrace-oss/dtctl/pkg/util/format"
+)
+
+const explodeContent = `{
+  "version": 19,
+  "variables": [
+    {"version": 2, "key": "env", "type": "csv", "visible": true, "editable": true, "multiple": false, "input": "prod, stage", "defaultValue": "prod"},
+    {"version": 2, "key": "host", "type": "query", "visible": false, "editable": true, "multiple": true, "input": "fetch dt.entity.host\n| fields entity.name"},
+    {"key": "since", "type": "timeframe", "value": {"from": "now()-1d"}}
+  ],
+  "tiles": {
+    "0": {"type": "markdown", "title": "", "content": "# Overview\n\nSee the runbook.\n"},
+    "1": {"type": "data", "title": "Error rate", "query": "timeseries sum(errors)\n", "visualization": "lineChart",
+          "visualizationSettings": {"thresholds": [], "chartSettings": {"gapPolicy": "connect", "curve": null}, "legend": {"hidden": "yes", "ratio": 1.5}},
+          "querySettings": {"maxResultRecords": 1000}, "davis": {"enabled": false}},
+    "10": {"type": "data", "title": "Empty", "query": "", "visualization": "table"},
+    "2": {"type": "data", "title": "Since", "query": "fetch logs | filter timestamp > \"2024-01-01\"", "visualization": "table",
+          "visualizationSettings": {"autoSelectVisualization": true}},
+    "abc": {"type": "image", "url": "https://example.com/logo.png"}
+  },
+  "layouts": {
+    "0": {"x": 0, "y": 0, "w": 24, "h": 2},
+    "1": {"x": 0, "y": 2, "w": 12, "h": 6},
+    "2": {"x": 12, "y": 2, "w": 12, "h": 6},
+    "10": {"x": 0, "y": 8, "w": 8.5, "h": 3}
+  },
+  "settings": {"defaultTimeframe": {"enabled": true, "value": {"from": "now()-24h", "to": "now()"}}, "gridLayout": {"columnsCount": 24}},
+  "importedWithCode": false
+}`
+
+func TestExplode(t *testing.T) {
+	var content map[string]any
+	if err := json.Unmarshal([]byte(explodeContent), &content); err != nil {
+		t.Fatal(err)
+	}
+
+	files, err := Explode("dash-1", "Service Health", "Errors", content)
+	if err != nil {
+		t.Fatalf("Explode() error = %v", err)
+	}
+
+	wantFiles := map[string]string{
+		"tiles/0.md":               "# Overview\n\nSee the runbook.\n\n",
+		"tiles/1-error-rate.dql":   "timeseries sum(errors)\n\n",
+		"tiles/2-since.dql":        "fetch logs | filter timestamp > \"2024-01-01\"\n",
+		"variables/host.dql":       "fetch dt.entity.host\n| fields entity.name\n",
+		ExplodedSpecFile:           "",
+		"tiles/10-empty.dql":       "-",
+		"tiles/abc.dql":            "-",
+		"variables/since.dql":      "-",
+		"variables/variable.dql":   "-",
+		"tiles/tile.md":            "-",
+		"tiles/10-empty-2.dql":     "-",
+		"variables/host-2.dql":     "-",
+		"tiles/1-error-rate-2.dql": "-",
+	}
+	for name, want := range wantFiles {
+		got, ok := files[name]
+		switch {
+		case want == "-" && ok:
+			t.Errorf("unexpected file %s", name)
+		case want != "-" && !ok:
+			t.Errorf("missing file %s", name)
+		case want != "" && want != "-" && string(got) != want:
+			t.Errorf("%s = %q, want %q", name, got, want)
+		}
+	}
+
+	spec := string(files[ExplodedSpecFile])
+	for _, want := range []string{"kind: DashboardSpec", "id: dash-1", "title: Service Health", "from: now()-24h", "file: tiles/1-error-rate.dql", `layout: {x: 0, "y": 2, w: 12, h: 6}`, "markdownFile: tiles/0.md"} {
+		if !strings.Contains(spec, want) {
+			t.Errorf("spec does not contain %q:\n%s", want, spec)
+		}
+	}
+
+	// Building the spec from the files gives the same content
+	s, err := LoadSpec(files[ExplodedSpecFile])
+	if err != nil {
+		t.Fatalf("LoadSpec() error = %v", err)
+	}
+	doc, err := s.build(func(name string) ([]byte, error) { return files[name], nil })
+	if err != nil {
+		t.Fatalf("build() error = %v", err)
+	}
+	if !format.JSONEqual(doc["content"], content) {
+		got, _ := json.Marshal(doc["content"])
+		t.Errorf("rebuilt content differs:\n got %s", got)
+	}
+	if doc["name"] != "Service Health" || doc["description"] != "Errors" || doc["id"] != "dash-1" {
+		t.Errorf("document metadata = %v", doc)
+	}
+}
+
+func TestExplodeEditedQuery(t *testing.T) {
+	var content map[string]any
+	if err := json.Unmarshal([]byte(explodeContent), &content); err != nil {
+		t.Fatal(err)
+	}
+	files, err := Explode("", "Service Health", "", content)
+	if err != nil {
+		t.Fatalf("Explode() error = %v", err)
+	}
+
+	// Edits of the query files take effect
+	files["tiles/1-error-rate.dql"] = []byte("timeseries avg(errors)\n")
+	s, err := LoadSpec(files[ExplodedSpecFile])
+	if err != nil {
+		t.Fatalf("LoadSpec() error = %v", err)
+	}
+	doc, err := s.build(func(name string) ([]byte, error) { return files[name], nil })
+	if err != nil {
+		t.Fatalf("build() error = %v", err)
+	}
+	d := FromContent(doc["content"].(map[string]any))
+	for _, tile := range d.Tiles {
+		if tile.ID == "1" && tile.Query != "timeseries avg(errors)" {
+			t.Errorf("tile 1 query = %q", tile.Query)
+		}
+	}
+}
+
+func TestExplodeNoContent(t *testing.T) {
+	if _, err := Explode("", "x", "", nil); err == nil {
+		t.Error("expected error")
+	}
+}
+
+func TestSortedTileIDs(t *testing.T) {
+	got := sortedTileIDs(map[string]any{"10": 1, "2": 1, "b": 1, "0": 1, "a": 1})
+	if want := "0,2,10,a,b"; strings.Join(got, ",") != want {
+		t.Errorf("sortedTileIDs() = %v, want %s", got, want)
+	}
+}
@@ -0,0 +1,303 @@
+package dashboard
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// Severity levels of lint findings, from most to least severe
+const (
+	SeverityError   = "error"
+	SeverityWarning = "warning"
+	SeverityInfo    = "info"
+)
+
+// SeverityRank orders severities: error > warning > info. Unknown severities
+// rank below info.
+func SeverityRank(severity string) int {
+	switch severity {
+	case SeverityError:
+		return 3
+	case SeverityWarning:
+		return 2
+	case SeverityInfo:
+		return 1
+	}
+	return 0
+}
+
+// Lint rules
+const (
+	RuleDQLSyntax         = "dql-syntax"
+	RuleDQLVerify         = "dql-verify"
+	RuleUnknownCommand    = "unknown-command"
+	RuleLegacyDQL         = "legacy-dql"
+	RuleLegacyTile        = "legacy-tile"
+	RuleEmptyQuery        = "empty-query"
+	RuleTimeframeOverride = "timeframe-override"
+	RuleDuplicateTile     = "duplicate-tile"
+	RuleUnusedVariable    = "unused-variable"
+	RuleUndefinedVariable = "undefined-variable"
+	RuleUnboundedQuery    = "unbounded-query"
+	RuleLargeScan         = "large-scan"
+)
+
+// Finding is a problem found by Lint
+type Finding struct {
+	Severity string `json:"severity" table:"SEVERITY"`
+	Rule     string `json:"rule" table:"RULE"`
+	// Tile is the ID of the tile; empty for findings about variables
+	Tile string `json:"tile,omitempty" table:"TILE"`
+	// Variable is the key of the variable the finding is about, if any
+	Variable string `json:"variable,omitempty" table:"VARIABLE,wide"`
+	Message  string `json:"message" table:"MESSAGE"`
+	// Query, Line and Column locate syntax errors (1-based)
+	Query  string `json:"query,omitempty" table:"-"`
+	Line   int    `json:"line,omitempty" table:"-"`
+	Column int    `json:"column,omitempty" table:"-"`
+}
+
+// maxScanDays is the query timeframe above which a fetch is reported as a
+// large scan
+const maxScanDays = 35
+
+// maxScanLimitGBytes is the scan limit above which a fetch is reported as a
+// large scan
+const maxScanLimitGBytes = 500
+
+// Lint inspects the tiles and variables of a dashboard without calling the
+// API and returns its findings, variables first and then in tile order.
+//
+// Rules:
+//   - dql-syntax: the query cannot be parsed (see ParseQuery)
+//   - empty-query: a data tile has no query
+//   - unknown-command: the query uses a command dtctl does not know
+//   - legacy-dql: the query uses Dynatrace Classic constructs (classic
+//     metric keys, classicEntitySelector)
+//   - legacy-tile: the tile is in the Dashboards Classic format
+//   - timeframe-override: the query sets its own timeframe, so the dashboard
+//     timeframe selector has no effect on the tile
+//   - duplicate-tile: the tile repeats the query and visualization of an
+//     earlier tile
+//   - undefined-variable: the query references a variable the dashboard does
+//     not define
+//   - unused-variable: no tile, variable or markdown text references the
+//     variable
+//   - unbounded-query: a fetch is not aggregated or limited
+//   - large-scan: a fetch disables the scan limit, raises it above 500 GB or
+//     reads more than 35 days
+func Lint(d *Dashboard) []Finding {
+	var tileFindings []Finding
+	used := map[string]bool{}
+	defined := map[string]bool{}
+	for _, v := range d.Variables {
+		defined[v.Key] = true
+	}
+
+	// Variables may reference other variables
+	var varFindings []Finding
+	for _, v := range d.Variables {
+		if v.Type != "query" || v.Input == "" {
+			continue
+		}
+		for _, ref := range Variables(v.Input) {
+			used[ref] = true
+			if !defined[ref] {
+				varFindings = append(varFindings, Finding{Severity: SeverityError, Rule: RuleUndefinedVariable, Variable: v.Key,
+					Message: fmt.Sprintf("variable %s references undefined variable $%s", v.Key, ref)})
+			}
+		}
+		if _, err := ParseQuery(v.Input); err != nil {
+			varFindings = append(varFindings, syntaxFinding(err, Finding{Variable: v.Key, Query: v.Input}, "variable "+v.Key))
+		}
+	}
+
+	seen := map[string]Tile{}
+	for _, t := range d.Tiles {
+		if t.Type == "markdown" {
+			for _, ref := range Variables(t.Markdown) {
+				used[ref] = true
+			}
+			continue
+		}
+		if _, classic := t.Raw["tileType"]; classic {
+			tileFindings = append(tileFindings, Finding{Severity: SeverityWarning, Rule: RuleLegacyTile, Tile: t.ID,
+				Message: fmt.Sprintf("%s uses the Dashboards Classic format (tileType %v), which the Dashboards app does not render", t.Label(), t.Raw["tileType"])})
+			continue
+		}
+		if !t.IsData() {
+			continue
+		}
+		if strings.TrimSpace(t.Query) == "" {
+			tileFindings = append(tileFindings, Finding{Severity: SeverityError, Rule: RuleEmptyQuery, Tile: t.ID,
+				Message: fmt.Sprintf("%s has no query", t.Label())})
+			continue
+		}
+
+		for _, ref := range Variables(t.Query) {
+			used[ref] = true
+			if !defined[ref] {
+				tileFindings = append(tileFindings, Finding{Severity: SeverityError, Rule: RuleUndefinedVariable, Tile: t.ID, Variable: ref,
+					Message: fmt.Sprintf("%s references undefined variable $%s", t.Label(), ref)})
+			}
+		}
+
+		key := normalizeQuery(t.Query) + "\x00" + t.Visualization
+		if first, ok := seen[key]; ok {
+			tileFindings = append(tileFindings, Finding{Severity: SeverityWarning, Rule: RuleDuplicateTile, Tile: t.ID,
+				Message: fmt.Sprintf("%s duplicates the query and visualization of %s", t.Label(), first.Label())})
+		} else {
+			seen[key] = t
+		}
+
+		q, err := ParseQuery(t.Query)
+		if err != nil {
+			tileFindings = append(tileFindings, syntaxFinding(err, Finding{Tile: t.ID, Query: t.Query}, t.Label()))
+			continue
+		}
+		tileFindings = append(tileFindings, lintQuery(t, q)...)
+	}
+
+	for _, v := range d.Variables {
+		if !used[v.Key] {
+			varFindings = append(varFindings, Finding{Severity: SeverityWarning, Rule: RuleUnusedVariable, Variable: v.Key,
+				Message: fmt.Sprintf("variable %s is not used by any tile", v.Key)})
+		}
+	}
+	return append(varFindings, tileFindings...)
+}
+
+// classicMetricKey matches classic metric selectors such as builtin:host.cpu.usage
+var classicMetricKey = regexp.MustCompile(`\b(builtin|ext|calc|func):[A-Za-z0-9_.\-]+`)
+
+// lintQuery applies the query rules to a parsed tile query
+func lintQuery(t Tile, q *Query) []Finding {
+	var findings []Finding
+	add := func(severity, rule, format string, args ...any) {
+		findings = append(findings, Finding{Severity: severity, Rule: rule, Tile: t.ID, Message: fmt.Sprintf(format, args...)})
+	}
+
+	for _, c := range q.Commands {
+		if !c.Known() {
+			add(SeverityInfo, RuleUnknownCommand, "%s uses unknown command %q (check the spelling or run with --verify)", t.Label(), c.Name)
+		}
+	}
+
+	text := stripComments(q.Text)
+	if m := classicMetricKey.FindString(stripStrings(text)); m != "" {
+		add(SeverityWarning, RuleLegacyDQL, "%s uses classic metric key %s; use the Grail metric key (e.g. dt.host.cpu.usage)", t.Label(), m)
+	}
+	if strings.Contains(text, "classicEntitySelector(") {
+		add(SeverityInfo, RuleLegacyDQL, "%s uses classicEntitySelector(), which depends on the classic entity model", t.Label())
+	}
+
+	source := q.Commands[0]
+	for _, param := range []string{"from", "to", "timeframe"} {
+		if value, ok := source.Parameter(param); ok {
+			add(SeverityWarning, RuleTimeframeOverride, "%s sets %s:%s in %s, so the dashboard timeframe does not apply; use the tile timeframe setting instead",
+				t.Label(), param, value, source.Name)
+			break
+		}
+	}
+
+	if source.Name != "fetch" {
+		return findings
+	}
+	aggregated := false
+	for _, c := range q.Commands[1:] {
+		switch c.Name {
+		case "summarize", "makeTimeseries", "limit", "fieldsSummary":
+			aggregated = true
+		}
+	}
+	if !aggregated {
+		add(SeverityWarning, RuleUnboundedQuery, "%s fetches records without summarize, makeTimeseries or limit; the tile reads up to the result limit", t.Label())
+	}
+	if value, ok := source.Parameter("scanLimitGBytes"); ok {
+		if limit, err := strconv.ParseFloat(value, 64); err == nil && (limit < 0 || limit > maxScanLimitGBytes) {
+			add(SeverityWarning, RuleLargeScan, "%s sets scanLimitGBytes:%s; scans of that size are slow and expensive", t.Label(), value)
+		}
+	}
+	if value, ok := source.Parameter("from"); ok {
+		if days, ok := relativeDays(value); ok && days > maxScanDays {
+			add(SeverityWarning, RuleLargeScan, "%s reads %s of data (from:%s)", t.Label(), formatDays(days), value)
+		}
+	}
+	return findings
+}
+
+// syntaxFinding converts a ParseQuery error into a finding
+func syntaxFinding(err error, f Finding, label string) Finding {
+	f.Severity, f.Rule = SeverityError, RuleDQLSyntax
+	f.Message = fmt.Sprintf("%s: invalid DQL: %v", label, err)
+	var qe *QueryError
+	if errors.As(err, &qe) {
+		f.Message = fmt.Sprintf("%s: invalid DQL: %s", label, qe.Message)
+		f.Line, f.Column = qe.Line, qe.Column
+	}
+	return f
+}
+
+var relativeTime = regexp.MustCompile(`^(?:now\(\)\s*)?-\s*(\d+)\s*([smhdwMy])$`)
+
+// relativeDays converts a relative timeframe start like now()-30d or "-2w" to days
+func relativeDays(expr string) (float64, bool) {
+	m := relativeTime.FindStringSubmatch(strings.Trim(strings.TrimSpace(expr), `"`))
+	if m == nil {
+		return 0, false
+	}
+	n, _ := strconv.ParseFloat(m[1], 64)
+	switch m[2] {
+	case "s":
+		return n / 86400, true
+	case "m":
+		return n / 1440, true
+	case "h":
+		return n / 24, true
+	case "d":
+		return n, true
+	case "w":
+		return n * 7, true
+	case "M":
+		return n * 30, true
+	case "y":
+		return n * 365, true
+	}
+	return 0, false
+}
+
+func formatDays(days float64) string {
+	return strconv.FormatFloat(days, 'f', -1, 64) + " days"
+}
+
+// normalizeQuery collapses whitespace and drops comments so that formatting
+// differences do not hide duplicate queries
+func normalizeQuery(query string) string {
+	return strings.Join(strings.Fields(stripComments(query)), " ")
+}
+
+// stripStrings blanks out string literals, so that patterns are only matched
+// in DQL code
+func stripStrings(query string) string {
+	var b strings.Builder
+	in := byte(0)
+	for i := 0; i < len(query); i++ {
+		ch := query[i]
+		switch {
+		case in == 0 && ch == '"':
+			in = ch
+			b.WriteByte(ch)
+		case in != 0 && ch == in && !escaped(query, i):
+			in = 0
+			b.WriteByte(ch)
+		case in != 0:
+			b.WriteByte(' ')
+		default:
+			b.WriteByte(ch)
+		}
+	}
+	return b.String()
+}
@@ -0,0 +1,96 @@
+package dashboard
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+const lintContent = `{
+  "version": 15,
+  "variables": [
+    {"key": "Host", "type": "query", "input": "fetch dt.entity.host | fields entity.name | limit 100"},
+    {"key": "Unused", "type": "csv", "input": "a,b"},
+    {"key": "Note", "type": "text"}
+  ],
+  "tiles": {
+    "0": {"type": "markdown", "content": "Showing $Note"},
+    "1": {"type": "data", "title": "Errors", "query": "fetch logs | filter host.name == $Host | summarize count()", "visualization": "singleValue"},
+    "2": {"type": "data", "title": "Errors again", "query": "fetch logs\n  | filter host.name == $Host\n  | summarize count()", "visualization": "singleValue"},
+    "3": {"type": "data", "title": "Raw", "query": "fetch logs, from:now()-90d, scanLimitGBytes:-1 | filter $Service == \"x\"", "visualization": "table"},
+    "4": {"type": "data", "title": "CPU", "query": "timeseries avg(builtin:host.cpu.usage)", "visualization": "lineChart"},
+    "5": {"type": "data", "title": "Broken", "query": "fetch logs | filter (a", "visualization": "table"},
+    "6": {"type": "data", "title": "Empty", "query": "  "},
+    "10": {"name": "old", "tileType": "DATA_EXPLORER"}
+  }
+}`
+
+func TestLint(t *testing.T) {
+	d, err := Parse([]byte(lintContent))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var got []string
+	for _, f := range Lint(d) {
+		got = append(got, strings.Join([]string{f.Severity, f.Rule, f.Tile, f.Variable}, " "))
+	}
+	want := []string{
+		"warning unused-variable  Unused",
+		"warning duplicate-tile 2 ",
+		"error undefined-variable 3 Service",
+		"warning timeframe-override 3 ",
+		"warning unbounded-query 3 ",
+		"warning large-scan 3 ",
+		"warning large-scan 3 ",
+		"warning legacy-dql 4 ",
+		"error dql-syntax 5 ",
+		"error empty-query 6 ",
+		"warning legacy-tile 10 ",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Lint() =\n%s\nwant\n%s", strings.Join(got, "\n"), strings.Join(want, "\n"))
+	}
+}
+
+func TestLint_SyntaxErrorPosition(t *testing.T) {
+	d := FromContent(map[string]any{"tiles": map[string]any{
+		"1": map[string]any{"type": "data", "query": "fetch logs\n| filter (a"},
+	}})
+	findings := Lint(d)
+	if len(findings) != 1 || findings[0].Line != 2 || findings[0].Column != 10 {
+		t.Fatalf("Lint() = %+v", findings)
+	}
+	if !strings.Contains(findings[0].Message, "unclosed") {
+		t.Errorf("unexpected message: %s", findings[0].Message)
+	}
+}
+
+func TestParse_TileOrderAndList(t *testing.T) {
+	d := FromContent(map[string]any{"tiles": map[string]any{
+		"10": map[string]any{"type": "data"}, "2": map[string]any{"type": "data"}, "a": map[string]any{"type": "data"},
+	}})
+	var ids []string
+	for _, tile := range d.Tiles {
+		ids = append(ids, tile.ID)
+	}
+	if want := []string{"2", "10", "a"}; !reflect.DeepEqual(ids, want) {
+		t.Errorf("tile order = %v, want %v", ids, want)
+	}
+
+	d = FromContent(map[string]any{"tiles": []any{map[string]any{"type": "markdown", "content": "# x"}}})
+	if len(d.Tiles) != 1 || d.Tiles[0].ID != "0" || d.Tiles[0].Markdown != "# x" {
+		t.Errorf("tile list = %+v", d.Tiles)
+	}
+}
+
+func TestRelativeDays(t *testing.T) {
+	for expr, want := range map[string]float64{"now()-30d": 30, "-2w": 14, `"now()-48h"`: 2, "now() - 1y": 365} {
+		if got, ok := relativeDays(expr); !ok || got != want {
+			t.Errorf("relativeDays(%q) = %v, %v, want %v", expr, got, ok, want)
+		}
+	}
+	if _, ok := relativeDays("2024-01-01T00:00:00Z"); ok {
+		t.Error("absolute timestamps are not relative")
+	}
+}
@@ -0,0 +1,432 @@
+package dashboard
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/dynatrace-oss/dtctl/pkg/util/format"
+)
+
+// SpecKind is the kind of a dashboard spec file
+const SpecKind = "DashboardSpec"
+
+// GridColumns is the width of the dashboard grid
+const GridColumns = 24
+
+// defaultRowHeight is the height of spec rows without a height, in grid rows
+const defaultRowHeight = 6
+
+// contentVersion is the dashboard content version written by Build
+const contentVersion = 15
+
+// Spec is a compact dashboard definition: tiles are arranged in rows and
+// their queries are kept in separate .dql files, so that they can be reviewed
+// as text. Build compiles a spec into a dashboard document.
+//
+//	kind: DashboardSpec
+//	title: Service Health
+//	timeframe: {from: now()-24h, to: now()}
+//	variables:
+//	  - key: env
+//	    type: csv
+//	    values: [prod, stage]
+//	rows:
+//	  - height: 4
+//	    tiles:
+//	      - title: Error rate
+//	        file: queries/error-rate.dql
+//	        visualization: lineChart
+//	        width: 16
+//	      - markdown: "## Notes"
+//
+// Tiles may also be placed by their layout (tiles) instead of in rows, and
+// extra fields carry what the other fields cannot express, so that Explode
+// can turn any dashboard into a spec.
+type Spec struct {
+	Kind string `yaml:"kind"`
+	// ID is the document ID; set it to update an existing dashboard on apply
+	ID          string         `yaml:"id,omitempty"`
+	Title       string         `yaml:"title"`
+	Description string         `yaml:"description,omitempty"`
+	Timeframe   *SpecTimeframe `yaml:"timeframe,omitempty"`
+	Variables   []SpecVariable `yaml:"variables,omitempty"`
+	Rows        []SpecRow      `yaml:"rows,omitempty"`
+	Tiles       []SpecTile     `yaml:"tiles,omitempty"`
+	// Extra is merged into the built content as a JSON merge patch
+	Extra map[string]any `yaml:"extra,omitempty"`
+}
+
+// SpecTimeframe is the default timeframe of a dashboard
+type SpecTimeframe struct {
+	From string `yaml:"from"`
+	To   string `yaml:"to,omitempty"`
+}
+
+// SpecVariable is a dashboard variable. csv variables list their values,
+// query variables have a query or a .dql file and text variables only a
+// default.
+type SpecVariable struct {
+	Key      string   `yaml:"key"`
+	Type     string   `yaml:"type,omitempty"`
+	Values   []string `yaml:"values,omitempty"`
+	Query    string   `yaml:"query,omitempty"`
+	File     string   `yaml:"file,omitempty"`
+	Default  any      `yaml:"default,omitempty"`
+	Multiple bool     `yaml:"multiple,omitempty"`
+	// Extra is merged into the built variable as a JSON merge patch. A
+	// variable without a type is built from its extra fields only.
+	Extra map[string]any `yaml:"extra,omitempty"`
+}
+
+// SpecRow is a row of tiles. Tiles without a width share the columns the
+// other tiles leave free.
+type SpecRow struct {
+	Height int        `yaml:"height,omitempty"`
+	Tiles  []SpecTile `yaml:"tiles"`
+}
+
+// SpecTile is a data tile (query or file) or a markdown tile (markdown or
+// markdownFile)
+type SpecTile struct {
+	// ID is the tile ID; tiles without one get the next free number
+	ID            string `yaml:"id,omitempty"`
+	Title         string `yaml:"title,omitempty"`
+	File          string `yaml:"file,omitempty"`
+	Query         string `yaml:"query,omitempty"`
+	Markdown      string `yaml:"markdown,omitempty"`
+	MarkdownFile  string `yaml:"markdownFile,omitempty"`
+	Visualization string `yaml:"visualization,omitempty"`
+	Width         int    `yaml:"width,omitempty"`
+	// Layout places tiles outside of rows
+	Layout *SpecLayout `yaml:"layout,flow,omitempty"`
+	// Settings are passed through as the visualizationSettings of the tile
+	Settings map[string]any `yaml:"settings,omitempty"`
+	// Extra is merged into the built tile as a JSON merge patch. A tile
+	// without a query or markdown is built from its extra fields only.
+	Extra map[string]any `yaml:"extra,omitempty"`
+}
+
+// SpecLayout is the position and size of a tile on the grid
+type SpecLayout struct {
+	X int `yaml:"x"`
+	Y int `yaml:"y"`
+	W int `yaml:"w"`
+	H int `yaml:"h"`
+}
+
+func (t SpecTile) label() string {
+	for _, l := range []string{t.Title, t.File, t.MarkdownFile, t.ID} {
+		if l != "" {
+			return l
+		}
+	}
+	return "untitled"
+}
+
+// IsSpec reports whether data (YAML or JSON) is a dashboard spec
+func IsSpec(data []byte) bool {
+	var head struct {
+		Kind string `yaml:"kind"`
+	}
+	if err := yaml.Unmarshal(data, &head); err != nil {
+		return false
+	}
+	return head.Kind == SpecKind
+}
+
+// LoadSpec parses a dashboard spec. Unknown fields are an error, so that
+// typos do not go unnoticed.
+func LoadSpec(data []byte) (*Spec, error) {
+	dec := yaml.NewDecoder(bytes.NewReader(data))
+	dec.KnownFields(true)
+	var s Spec
+	if err := dec.Decode(&s); err != nil {
+		return nil, fmt.Errorf("invalid dashboard spec: %w", err)
+	}
+	if s.Kind != SpecKind {
+		return nil, fmt.Errorf("invalid dashboard spec: kind must be %s, got %q", SpecKind, s.Kind)
+	}
+	if s.Title == "" {
+		return nil, fmt.Errorf("invalid dashboard spec: title is required")
+	}
+	return &s, nil
+}
+
+// Build compiles the spec into a dashboard document (id, name, type,
+// description and content). Files are read relative to baseDir.
+func (s *Spec) Build(baseDir string) (map[string]any, error) {
+	return s.build(func(name string) ([]byte, error) {
+		if !filepath.IsAbs(name) {
+			name = filepath.Join(baseDir, name)
+		}
+		return os.ReadFile(name)
+	})
+}
+
+func (s *Spec) build(readFile func(name string) ([]byte, error)) (map[string]any, error) {
+	b := &specBuilder{readFile: readFile, tiles: map[string]any{}, layouts: map[string]any{}}
+
+	variables := make([]any, 0, len(s.Variables))
+	for i, v := range s.Variables {
+		variable, err := b.variable(v)
+		if err != nil {
+			return nil, fmt.Errorf("variable %d (%s): %w", i+1, v.Key, err)
+		}
+		variables = append(variables, variable)
+	}
+
+	// Rows start below the placed tiles
+	y := 0
+	for i, t := range s.Tiles {
+		var layout map[string]any
+		if l := t.Layout; l != nil {
+			layout = map[string]any{"x": l.X, "y": l.Y, "w": l.W, "h": l.H}
+			y = max(y, l.Y+l.H)
+		}
+		if err := b.add(t, layout); err != nil {
+			return nil, fmt.Errorf("tile %d (%s): %w", i+1, t.label(), err)
+		}
+	}
+	for i, row := range s.Rows {
+		height := row.Height
+		if height == 0 {
+			height = defaultRowHeight
+		}
+		if err := b.row(row, y, height); err != nil {
+			return nil, fmt.Errorf("row %d: %w", i+1, err)
+		}
+		y += height
+	}
+
+	content := map[string]any{
+		"version":   contentVersion,
+		"variables": variables,
+		"tiles":     b.tiles,
+		"layouts":   b.layouts,
+	}
+	if s.Timeframe != nil {
+		to := s.Timeframe.To
+		if to == "" {
+			to = "now()"
+		}
+		content["settings"] = map[string]any{
+			"defaultTimeframe": map[string]any{
+				"enabled": true,
+				"value":   map[string]any{"from": s.Timeframe.From, "to": to},
+			},
+		}
+	}
+	if s.Extra != nil {
+		content = format.MergePatch(content, s.Extra)
+	}
+
+	doc := map[string]any{
+		"name":    s.Title,
+		"type":    "dashboard",
+		"content": content,
+	}
+	if s.ID != "" {
+		doc["id"] = s.ID
+	}
+	if s.Description != "" {
+		doc["description"] = s.Description
+	}
+	return doc, nil
+}
+
+type specBuilder struct {
+	readFile func(name string) ([]byte, error)
+	tiles    map[string]any
+	layouts  map[string]any
+}
+
+func (b *specBuilder) variable(v SpecVariable) (map[string]any, error) {
+	if v.Key == "" {
+		return nil, fmt.Errorf("key is required")
+	}
+	if v.Type == "" && v.Extra != nil {
+		return format.MergePatch(map[string]any{"key": v.Key}, v.Extra), nil
+	}
+	out := map[string]any{
+		"version":  2,
+		"key":      v.Key,
+		"type":     v.Type,
+		"visible":  true,
+		"editable": true,
+		"multiple": v.Multiple,
+	}
+	switch v.Type {
+	case "csv":
+		if len(v.Values) == 0 {
+			return nil, fmt.Errorf("csv variables need values")
+		}
+		out["input"] = strings.Join(v.Values, ",")
+	case "query":
+		query, err := b.query(v.Query, v.File)
+		if err != nil {
+			return nil, err
+		}
+		out["input"] = query
+	case "text":
+	default:
+		return nil, fmt.Errorf("unknown type %q (use csv, query or text)", v.Type)
+	}
+	if v.Default != nil {
+		out["defaultValue"] = v.Default
+	}
+	if v.Extra != nil {
+		out = format.MergePatch(out, v.Extra)
+	}
+	return out, nil
+}
+
+func (b *specBuilder) row(row SpecRow, y, height int) error {
+	if len(row.Tiles) == 0 {
+		return fmt.Errorf("no tiles")
+	}
+	used, auto := 0, 0
+	for _, t := range row.Tiles {
+		if t.Width < 0 {
+			return fmt.Errorf("invalid width %d", t.Width)
+		}
+		used += t.Width
+		if t.Width == 0 {
+			auto++
+		}
+	}
+	if used > GridColumns || (auto > 0 && GridColumns-used < auto) {
+		return fmt.Errorf("tiles are wider than the grid (%d columns)", GridColumns)
+	}
+
+	x := 0
+	for i, t := range row.Tiles {
+		w := t.Width
+		if w == 0 {
+			// Share the free columns; the last tile takes the remainder
+			w = (GridColumns - used) / auto
+			auto--
+			used += w
+		}
+		if err := b.add(t, map[string]any{"x": x, "y": y, "w": w, "h": height}); err != nil {
+			return fmt.Errorf("tile %d (%s): %w", i+1, t.label(), err)
+		}
+		x += w
+	}
+	return nil
+}
+
+// add builds a tile and adds it with its layout, if any
+func (b *specBuilder) add(t SpecTile, layout map[string]any) error {
+	tile, err := b.tile(t)
+	if err != nil {
+		return err
+	}
+	id := t.ID
+	if id == "" {
+		for n := len(b.tiles); id == ""; n++ {
+			if _, taken := b.tiles[strconv.Itoa(n)]; !taken {
+				id = strconv.Itoa(n)
+			}
+		}
+	} else if _, taken := b.tiles[id]; taken {
+		return fmt.Errorf("duplicate tile id %q", id)
+	}
+	b.tiles[id] = tile
+	if layout != nil {
+		b.layouts[id] = layout
+	}
+	return nil
+}
+
+func (b *specBuilder) tile(t SpecTile) (map[string]any, error) {
+	markdown := t.Markdown
+	if t.MarkdownFile != "" {
+		if markdown != "" {
+			return nil, fmt.Errorf("set either markdown or markdownFile, not both")
+		}
+		text, err := b.file(t.MarkdownFile)
+		if err != nil {
+			return nil, err
+		}
+		markdown = text
+	}
+
+	var out map[string]any
+	switch {
+	case markdown != "":
+		if t.Query != "" || t.File != "" {
+			return nil, fmt.Errorf("a tile has either markdown or a query")
+		}
+		out = map[string]any{"type": "markdown", "title": t.Title, "content": markdown}
+	case t.Query == "" && t.File == "" && t.Extra != nil:
+		out = map[string]any{}
+	default:
+		data, err := b.dataTile(t)
+		if err != nil {
+			return nil, err
+		}
+		out = data
+	}
+	if t.Extra != nil {
+		out = format.MergePatch(out, t.Extra)
+	}
+	return out, nil
+}
+
+func (b *specBuilder) dataTile(t SpecTile) (map[string]any, error) {
+	query, err := b.query(t.Query, t.File)
+	if err != nil {
+		return nil, err
+	}
+	visualization := t.Visualization
+	if visualization == "" {
+		visualization = "table"
+	}
+	settings := map[string]any{"autoSelectVisualization": false}
+	for k, v := range t.Settings {
+		settings[k] = v
+	}
+	return map[string]any{
+		"type":                  "data",
+		"title":                 t.Title,
+		"query":                 query,
+		"visualization":         visualization,
+		"visualizationSettings": settings,
+	}, nil
+}
+
+// query returns an inline query or the content of a .dql file
+func (b *specBuilder) query(inline, file string) (string, error) {
+	switch {
+	case inline != "" && file != "":
+		return "", fmt.Errorf("set either query or file, not both")
+	case inline != "":
+		return strings.TrimSpace(inline), nil
+	case file == "":
+		return "", fmt.Errorf("query or file is required")
+	}
+	query, err := b.file(file)
+	if err != nil {
+		return "", err
+	}
+	if strings.TrimSpace(query) == "" {
+		return "", fmt.Errorf("query file %s is empty", file)
+	}
+	return query, nil
+}
+
+// file returns the content of a file without its final line break
+func (b *specBuilder) file(name string) (string, error) {
+	data, err := b.readFile(name)
+	if err != nil {
+		return "", fmt.Errorf("failed to read %s: %w", name, err)
+	}
+	text := strings.TrimSuffix(string(data), "\n")
+	return strings.TrimSuffix(text, "\r"), nil
+}
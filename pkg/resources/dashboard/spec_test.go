@@ -0,0 +1,155 @@
+package dashboard
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestIsSpec(t *testing.T) {
+	if !IsSpec([]byte("kind: DashboardSpec\ntitle: x\n")) {
+		t.Error("IsSpec() = false for a YAML spec")
+	}
+	if !IsSpec([]byte(`{"kind": "DashboardSpec"}`)) {
+		t.Error("IsSpec() = false for a JSON spec")
+	}
+	if IsSpec([]byte("name: x\ntype: dashboard\n")) || IsSpec([]byte("[")) {
+		t.Error("IsSpec() = true for a non-spec")
+	}
+}
+
+func TestLoadSpecErrors(t *testing.T) {
+	tests := map[string]string{
+		"unknown field": "kind: DashboardSpec\ntitle: x\nrowz: []\n",
+		"wrong kind":    "kind: Dashboard\ntitle: x\n",
+		"no title":      "kind: DashboardSpec\n",
+	}
+	for name, input := range tests {
+		if _, err := LoadSpec([]byte(input)); err == nil {
+			t.Errorf("%s: expected error", name)
+		}
+	}
+}
+
+func TestSpecBuild(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(dir, "queries"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	writeFile := func(name, content string) {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	writeFile("queries/errors.dql", "timeseries errors = sum(dt.service.request.failure_count)\n")
+	writeFile("queries/hosts.dql", "fetch dt.entity.host | fields entity.name\n")
+
+	spec, err := LoadSpec([]byte(`kind: DashboardSpec
+id: dash-1
+title: Service Health
+description: Errors and hosts
+timeframe: {from: now()-24h}
+variables:
+  - key: env
+    type: csv
+    values: [prod, stage]
+    default: prod
+  - key: host
+    type: query
+    file: queries/hosts.dql
+    multiple: true
+rows:
+  - height: 4
+    tiles:
+      - title: Errors
+        file: queries/errors.dql
+        visualization: lineChart
+        width: 12
+      - title: Logs
+        query: fetch logs | limit 10
+      - markdown: "## Notes"
+  - tiles:
+      - title: Count
+        query: fetch logs | summarize count()
+        visualization: singleValue
+        settings:
+          singleValue: {label: Logs}
+`))
+	if err != nil {
+		t.Fatalf("LoadSpec() error = %v", err)
+	}
+	doc, err := spec.Build(dir)
+	if err != nil {
+		t.Fatalf("Build() error = %v", err)
+	}
+
+	if doc["id"] != "dash-1" || doc["name"] != "Service Health" || doc["type"] != "dashboard" || doc["description"] != "Errors and hosts" {
+		t.Errorf("document metadata = %v", doc)
+	}
+	content := doc["content"].(map[string]any)
+	d := FromContent(content)
+
+	if len(d.Tiles) != 4 {
+		t.Fatalf("tiles = %d, want 4", len(d.Tiles))
+	}
+	if d.Tiles[0].Query != "timeseries errors = sum(dt.service.request.failure_count)" || d.Tiles[0].Visualization != "lineChart" {
+		t.Errorf("tile 0 = %+v", d.Tiles[0])
+	}
+	if d.Tiles[1].Visualization != "table" || d.Tiles[2].Type != "markdown" || d.Tiles[2].Markdown != "## Notes" {
+		t.Errorf("tiles 1, 2 = %+v, %+v", d.Tiles[1], d.Tiles[2])
+	}
+	settings := d.Tiles[3].Raw["visualizationSettings"].(map[string]any)
+	if settings["autoSelectVisualization"] != false || settings["singleValue"] == nil {
+		t.Errorf("tile 3 settings = %v", settings)
+	}
+
+	layouts := content["layouts"].(map[string]any)
+	wantLayouts := map[string]map[string]any{
+		"0": {"x": 0, "y": 0, "w": 12, "h": 4},
+		"1": {"x": 12, "y": 0, "w": 6, "h": 4},
+		"2": {"x": 18, "y": 0, "w": 6, "h": 4},
+		"3": {"x": 0, "y": 4, "w": 24, "h": 6},
+	}
+	for id, want := range wantLayouts {
+		if got := layouts[id]; !reflect.DeepEqual(got, want) {
+			t.Errorf("layout %s = %v, want %v", id, got, want)
+		}
+	}
+
+	if len(d.Variables) != 2 || d.Variables[0].Input != "prod,stage" || d.Variables[0].DefaultValue != "prod" {
+		t.Errorf("variables = %+v", d.Variables)
+	}
+	if v := d.Variables[1]; v.Type != "query" || !v.Multiple || !strings.HasPrefix(v.Input, "fetch dt.entity.host") {
+		t.Errorf("query variable = %+v", v)
+	}
+	if from, to, ok := d.DefaultTimeframe(); !ok || from != "now()-24h" || to != "now()" {
+		t.Errorf("DefaultTimeframe() = %q, %q, %v", from, to, ok)
+	}
+}
+
+func TestSpecBuildErrors(t *testing.T) {
+	tests := map[string]string{
+		"missing file":       "rows: [{tiles: [{file: nope.dql}]}]",
+		"query and file":     "rows: [{tiles: [{query: fetch logs, file: a.dql}]}]",
+		"no query":           "rows: [{tiles: [{title: Empty}]}]",
+		"markdown and query": "rows: [{tiles: [{markdown: x, query: fetch logs}]}]",
+		"too wide":           "rows: [{tiles: [{query: fetch logs, width: 20}, {query: fetch logs, width: 8}]}]",
+		"no room":            "rows: [{tiles: [{query: fetch logs, width: 24}, {query: fetch logs}]}]",
+		"empty row":          "rows: [{tiles: []}]",
+		"duplicate tile id":  "tiles: [{id: a, query: fetch logs}, {id: a, query: fetch logs}]",
+		"markdown and file":  "tiles: [{markdown: x, markdownFile: a.md}]",
+		"csv without values": "variables: [{key: a, type: csv}]\nrows: []",
+		"unknown type":       "variables: [{key: a, type: list}]\nrows: []",
+	}
+	for name, body := range tests {
+		spec, err := LoadSpec([]byte("kind: DashboardSpec\ntitle: x\n" + body))
+		if err != nil {
+			t.Fatalf("%s: LoadSpec() error = %v", name, err)
+		}
+		if _, err := spec.Build(t.TempDir()); err == nil {
+			t.Errorf("%s: expected error", name)
+		}
+	}
+}
@@ -0,0 +1,184 @@
+package dashboard
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// variablePattern matches variable references such as $host or $host:noquote
+var variablePattern = regexp.MustCompile(`\$([A-Za-z_][A-Za-z0-9_]*)(?::(noquote|backtick|triplequote))?`)
+
+// Variables returns the dashboard variables referenced in a query ($name),
+// in order of first use. References in comments are ignored.
+func Variables(query string) []string {
+	var names []string
+	seen := map[string]bool{}
+	for _, m := range variablePattern.FindAllStringSubmatch(stripComments(query), -1) {
+		if !seen[m[1]] {
+			seen[m[1]] = true
+			names = append(names, m[1])
+		}
+	}
+	return names
+}
+
+// SubstituteVariables replaces variable references in a query with their
+// values, the way the Dashboards app does: $name becomes a string literal, or
+// a comma-separated list of string literals for multiple values (use it as
+// array($name) or in(field, array($name))). The modifiers :noquote,
+// :backtick and :triplequote change the quoting. References without values
+// are left unchanged.
+func SubstituteVariables(query string, values map[string][]string) string {
+	return variablePattern.ReplaceAllStringFunc(query, func(ref string) string {
+		m := variablePattern.FindStringSubmatch(ref)
+		vals, ok := values[m[1]]
+		if !ok {
+			return ref
+		}
+		if len(vals) == 0 {
+			vals = []string{""}
+		}
+		quoted := make([]string, len(vals))
+		for i, v := range vals {
+			switch m[2] {
+			case "noquote":
+				quoted[i] = v
+			case "backtick":
+				quoted[i] = "`" + strings.ReplaceAll(v, "`", "``") + "`"
+			case "triplequote":
+				quoted[i] = `"""` + v + `"""`
+			default:
+				quoted[i] = strconv.Quote(v)
+			}
+		}
+		return strings.Join(quoted, ", ")
+	})
+}
+
+// QueryFunc runs the DQL query of a query variable and returns its values
+type QueryFunc func(query string) ([]string, error)
+
+// ResolveVariables computes the value of every variable. In order of
+// precedence a variable takes its value from overrides, its default value,
+// the first result of its query (when run is not nil) or the first entry of
+// its csv input. Variables are resolved in definition order, so a query
+// variable may use the variables defined before it.
+func (d *Dashboard) ResolveVariables(overrides map[string][]string, run QueryFunc) (map[string][]string, error) {
+	for key := range overrides {
+		if _, ok := d.Variable(key); !ok {
+			return nil, fmt.Errorf("dashboard has no variable %q (defined: %s)", key, strings.Join(d.variableKeys(), ", "))
+		}
+	}
+
+	values := map[string][]string{}
+	for _, v := range d.Variables {
+		if vals, ok := overrides[v.Key]; ok {
+			values[v.Key] = vals
+			continue
+		}
+		if vals := defaultValues(v.DefaultValue); len(vals) > 0 {
+			values[v.Key] = vals
+			continue
+		}
+		switch {
+		case v.Type == "query" && run != nil && strings.TrimSpace(v.Input) != "":
+			vals, err := run(SubstituteVariables(v.Input, values))
+			if err != nil {
+				return nil, fmt.Errorf("failed to resolve variable %s: %w", v.Key, err)
+			}
+			if len(vals) > 0 {
+				values[v.Key] = vals[:1]
+				continue
+			}
+		case v.Type == "csv":
+			if first, _, _ := strings.Cut(v.Input, ","); strings.TrimSpace(first) != "" {
+				values[v.Key] = []string{strings.TrimSpace(first)}
+				continue
+			}
+		}
+		values[v.Key] = []string{""}
+	}
+	return values, nil
+}
+
+func (d *Dashboard) variableKeys() []string {
+	keys := make([]string, 0, len(d.Variables))
+	for _, v := range d.Variables {
+		keys = append(keys, v.Key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// defaultValues converts the defaultValue of a variable (a string, number or
+// list) to strings
+func defaultValues(def any) []string {
+	switch val := def.(type) {
+	case nil:
+		return nil
+	case string:
+		if val == "" {
+			return nil
+		}
+		return []string{val}
+	case []any:
+		vals := make([]string, 0, len(val))
+		for _, item := range val {
+			vals = append(vals, fmt.Sprint(item))
+		}
+		return vals
+	default:
+		return []string{fmt.Sprint(val)}
+	}
+}
+
+// DefaultTimeframe returns the default timeframe of the dashboard settings
+// (e.g. "now()-2h" and "now()"), if one is set
+func (d *Dashboard) DefaultTimeframe() (from, to string, ok bool) {
+	settings, _ := d.Content["settings"].(map[string]any)
+	tf, _ := settings["defaultTimeframe"].(map[string]any)
+	if enabled, set := tf["enabled"].(bool); set && !enabled {
+		return "", "", false
+	}
+	value, _ := tf["value"].(map[string]any)
+	from, _ = value["from"].(string)
+	to, _ = value["to"].(string)
+	return from, to, from != ""
+}
+
+// ResolveTime converts a timeframe boundary to an absolute time. It accepts
+// RFC 3339 timestamps, now() and relative expressions such as now()-2h or -7d.
+func ResolveTime(expr string, now time.Time) (time.Time, error) {
+	expr = strings.TrimSpace(expr)
+	if expr == "" || expr == "now()" || expr == "now" {
+		return now, nil
+	}
+	if t, err := time.Parse(time.RFC3339, expr); err == nil {
+		return t, nil
+	}
+	m := relativeTime.FindStringSubmatch(strings.Replace(expr, "now-", "now()-", 1))
+	if m == nil {
+		return time.Time{}, fmt.Errorf("invalid time %q: use an RFC 3339 timestamp or a relative time like now()-2h", expr)
+	}
+	n, _ := strconv.Atoi(m[1])
+	switch m[2] {
+	case "s":
+		return now.Add(-time.Duration(n) * time.Second), nil
+	case "m":
+		return now.Add(-time.Duration(n) * time.Minute), nil
+	case "h":
+		return now.Add(-time.Duration(n) * time.Hour), nil
+	case "d":
+		return now.AddDate(0, 0, -n), nil
+	case "w":
+		return now.AddDate(0, 0, -7*n), nil
+	case "M":
+		return now.AddDate(0, -n, 0), nil
+	default: // "y"
+		return now.AddDate(-n, 0, 0), nil
+	}
+}
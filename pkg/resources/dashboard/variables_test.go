@@ -0,0 +1,119 @@
+package dashboard
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestVariables(t *testing.T) {
+	query := `fetch logs | filter host == $Host and in(service, array($Services:noquote)) // $Commented
+| filter content == "$Host"`
+	if got, want := Variables(query), []string{"Host", "Services"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Variables() = %v, want %v", got, want)
+	}
+}
+
+func TestSubstituteVariables(t *testing.T) {
+	values := map[string][]string{"Host": {`h"1`}, "HostGroup": {"g"}, "Services": {"a", "b"}, "Empty": nil}
+	tests := []struct {
+		query string
+		want  string
+	}{
+		{"filter a == $Host and b == $HostGroup and c == $Other", `filter a == "h\"1" and b == "g" and c == $Other`},
+		{"filter in(s, array($Services))", `filter in(s, array("a", "b"))`},
+		{"fetch $Services:noquote", "fetch a, b"},
+		{"fields $HostGroup:backtick", "fields `g`"},
+		{"filter x == $HostGroup:triplequote", `filter x == """g"""`},
+		{"filter x == $Empty", `filter x == ""`},
+	}
+	for _, tt := range tests {
+		if got := SubstituteVariables(tt.query, values); got != tt.want {
+			t.Errorf("SubstituteVariables(%q) = %q, want %q", tt.query, got, tt.want)
+		}
+	}
+}
+
+func TestResolveVariables(t *testing.T) {
+	d := FromContent(map[string]any{"variables": []any{
+		map[string]any{"key": "env", "type": "csv", "input": "prod, stage"},
+		map[string]any{"key": "host", "type": "query", "input": "fetch dt.entity.host | filter env == $env | fields entity.name"},
+		map[string]any{"key": "services", "type": "csv", "input": "a,b,c", "multiple": true, "defaultValue": []any{"a", "b"}},
+		map[string]any{"key": "text", "type": "text"},
+	}})
+
+	var queries []string
+	run := func(query string) ([]string, error) {
+		queries = append(queries, query)
+		return []string{"web-1", "web-2"}, nil
+	}
+
+	got, err := d.ResolveVariables(map[string][]string{"env": {"stage"}}, run)
+	if err != nil {
+		t.Fatalf("ResolveVariables() error = %v", err)
+	}
+	want := map[string][]string{"env": {"stage"}, "host": {"web-1"}, "services": {"a", "b"}, "text": {""}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("ResolveVariables() = %v, want %v", got, want)
+	}
+	if want := []string{`fetch dt.entity.host | filter env == "stage" | fields entity.name`}; !reflect.DeepEqual(queries, want) {
+		t.Errorf("variable queries = %v, want %v", queries, want)
+	}
+
+	got, err = d.ResolveVariables(nil, nil)
+	if err != nil {
+		t.Fatalf("ResolveVariables() error = %v", err)
+	}
+	if got["env"][0] != "prod" || got["host"][0] != "" {
+		t.Errorf("ResolveVariables() without overrides = %v", got)
+	}
+
+	if _, err := d.ResolveVariables(map[string][]string{"nope": {"x"}}, nil); err == nil {
+		t.Error("expected error for unknown variable")
+	}
+	failing := func(string) ([]string, error) { return nil, errors.New("boom") }
+	if _, err := d.ResolveVariables(nil, failing); err == nil {
+		t.Error("expected error from variable query")
+	}
+}
+
+func TestDefaultTimeframe(t *testing.T) {
+	d := FromContent(map[string]any{"settings": map[string]any{"defaultTimeframe": map[string]any{
+		"enabled": true, "value": map[string]any{"from": "now()-24h", "to": "now()"},
+	}}})
+	from, to, ok := d.DefaultTimeframe()
+	if !ok || from != "now()-24h" || to != "now()" {
+		t.Errorf("DefaultTimeframe() = %q, %q, %v", from, to, ok)
+	}
+	if _, _, ok := FromContent(map[string]any{}).DefaultTimeframe(); ok {
+		t.Error("DefaultTimeframe() ok for dashboard without settings")
+	}
+}
+
+func TestResolveTime(t *testing.T) {
+	now := time.Date(2026, 3, 10, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		expr    string
+		want    time.Time
+		wantErr bool
+	}{
+		{expr: "now()", want: now},
+		{expr: "", want: now},
+		{expr: "now()-2h", want: now.Add(-2 * time.Hour)},
+		{expr: "now-30m", want: now.Add(-30 * time.Minute)},
+		{expr: "-7d", want: now.AddDate(0, 0, -7)},
+		{expr: "2026-03-01T00:00:00Z", want: time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)},
+		{expr: "yesterday", wantErr: true},
+	}
+	for _, tt := range tests {
+		got, err := ResolveTime(tt.expr, now)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("ResolveTime(%q) error = %v", tt.expr, err)
+			continue
+		}
+		if !tt.wantErr && !got.Equal(tt.want) {
+			t.Errorf("ResolveTime(%q) = %v, want %v", tt.expr, got, tt.want)
+		}
+	}
+}
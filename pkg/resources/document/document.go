@@ -1079,6 +1079,37 @@ func (h *Handler) SetDocumentPublic(id string, version int) error {
 	return nil
 }
 
+// TransferOwner makes newOwner (a user UUID) the owner of a document. Requires
+// the current document version for optimistic-locking. With adminAccess the
+// transfer is made as effective owner, which requires the
+// document:documents:admin permission but works for documents of other users.
+func (h *Handler) TransferOwner(id string, version int, newOwner string, adminAccess bool) error {
+	req := h.client.HTTP().R().
+		SetQueryParam("optimistic-locking-version", fmt.Sprintf("%d", version)).
+		SetHeader("Content-Type", "application/json").
+		SetBody(map[string]string{"newOwner": newOwner})
+	if adminAccess {
+		req.SetQueryParam("admin-access", "true")
+	}
+	resp, err := req.Post(fmt.Sprintf("/platform/document/v1/documents/%s:transfer-owner", id))
+	if err != nil {
+		return fmt.Errorf("failed to transfer document ownership: %w", err)
+	}
+	if resp.IsError() {
+		switch resp.StatusCode() {
+		case 404:
+			return fmt.Errorf("document %q not found", id)
+		case 403:
+			return fmt.Errorf("access denied to transfer ownership of document %q", id)
+		case 409:
+			return fmt.Errorf("document was modified concurrently: %w", ErrVersionConflict)
+		default:
+			return fmt.Errorf("failed to transfer document ownership: status %d: %s", resp.StatusCode(), resp.String())
+		}
+	}
+	return nil
+}
+
 // EnsureEnvironmentShare idempotently ensures the document has an environment share at the given
 // access level, AND that the document itself is marked public (isPrivate=false). The two are
 // complementary: the share is a per-user claimable grant, isPrivate=false is the
@@ -360,6 +360,64 @@ func TestDelete_Conflict(t *testing.T) {
 	}
 }
 
+// --- TransferOwner ---
+
+func TestTransferOwner_Success(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/platform/document/v1/documents/doc-1:transfer-owner", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			t.Errorf("expected POST, got %s", r.Method)
+		}
+		if got := r.URL.Query().Get("optimistic-locking-version"); got != "4" {
+			t.Errorf("expected optimistic-locking-version 4, got %q", got)
+		}
+		if got := r.URL.Query().Get("admin-access"); got != "true" {
+			t.Errorf("expected admin-access=true, got %q", got)
+		}
+		var body map[string]string
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil || body["newOwner"] != "user-2" {
+			t.Errorf("expected newOwner user-2, got %v (%v)", body, err)
+		}
+		w.WriteHeader(http.StatusNoContent)
+	})
+	h, cleanup := newDocTestHandler(t, mux)
+	defer cleanup()
+
+	if err := h.TransferOwner("doc-1", 4, "user-2", true); err != nil {
+		t.Fatalf("TransferOwner() error = %v", err)
+	}
+}
+
+func TestTransferOwner_Errors(t *testing.T) {
+	tests := []struct {
+		status int
+		want   string
+	}{
+		{http.StatusNotFound, "not found"},
+		{http.StatusForbidden, "access denied"},
+		{http.StatusConflict, ErrVersionConflict.Error()},
+		{http.StatusBadRequest, "status 400"},
+	}
+	for _, tt := range tests {
+		t.Run(fmt.Sprint(tt.status), func(t *testing.T) {
+			mux := http.NewServeMux()
+			mux.HandleFunc("/platform/document/v1/documents/doc-1:transfer-owner", func(w http.ResponseWriter, r *http.Request) {
+				if r.URL.Query().Has("admin-access") {
+					t.Error("unexpected admin-access query param")
+				}
+				w.WriteHeader(tt.status)
+			})
+			h, cleanup := newDocTestHandler(t, mux)
+			defer cleanup()
+
+			err := h.TransferOwner("doc-1", 1, "user-2", false)
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("expected error containing %q, got %v", tt.want, err)
+			}
+		})
+	}
+}
+
 // --- Create ---
 
 func TestCreate_MissingName(t *testing.T) {
@@ -1,13 +1,18 @@
 package iam
 
 import (
+	"errors"
 	"fmt"
 	"net/url"
+	"regexp"
 	"strings"
 
 	"github.com/dynatrace-oss/dtctl/pkg/client"
 )
 
+// ErrUserNotFound is returned when a user does not exist in IAM (HTTP 404)
+var ErrUserNotFound = errors.New("user not found")
+
 // Handler handles IAM resources
 type Handler struct {
 	client *client.Client
@@ -48,6 +53,14 @@ type GroupListResponse struct {
 	TotalCount  int64   `json:"totalCount"`
 }
 
+// uuidPattern matches user UUIDs
+var uuidPattern = regexp.MustCompile(`^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$`)
+
+// IsUUID reports whether s has the form of a user UUID
+func IsUUID(s string) bool {
+	return uuidPattern.MatchString(s)
+}
+
 // extractEnvironmentID extracts the environment ID from the base URL
 func extractEnvironmentID(baseURL string) (string, error) {
 	u, err := url.Parse(baseURL)
@@ -145,7 +158,7 @@ func (h *Handler) GetUser(uuid string) (*User, error) {
 	if resp.IsError() {
 		switch resp.StatusCode() {
 		case 404:
-			return nil, fmt.Errorf("user %q not found", uuid)
+			return nil, fmt.Errorf("user %q: %w", uuid, ErrUserNotFound)
 		default:
 			return nil, fmt.Errorf("failed to get user: status %d: %s", resp.StatusCode(), resp.String())
 		}
@@ -154,6 +167,77 @@ func (h *Handler) GetUser(uuid string) (*User, error) {
 	return &result, nil
 }
 
+// FindUser finds a user by UUID or email. Other input is matched as a
+// partial name or email and must match exactly one user.
+func (h *Handler) FindUser(ref string) (*User, error) {
+	if IsUUID(ref) {
+		return h.GetUser(ref)
+	}
+
+	list, err := h.ListUsers(ref, nil, 0)
+	if err != nil {
+		return nil, err
+	}
+	for i, u := range list.Results {
+		if strings.EqualFold(u.Email, ref) {
+			return &list.Results[i], nil
+		}
+	}
+	switch len(list.Results) {
+	case 0:
+		return nil, fmt.Errorf("user %q: %w", ref, ErrUserNotFound)
+	case 1:
+		return &list.Results[0], nil
+	}
+	emails := make([]string, len(list.Results))
+	for i, u := range list.Results {
+		emails[i] = u.Email
+	}
+	return nil, fmt.Errorf("%q matches %d users (%s); use the email or UUID", ref, len(list.Results), strings.Join(emails, ", "))
+}
+
+// DisplayName returns the full name and email of the user
+func (u *User) DisplayName() string {
+	name := strings.TrimSpace(u.Name + " " + u.Surname)
+	switch {
+	case name == "":
+		return u.Email
+	case u.Email == "":
+		return name
+	}
+	return fmt.Sprintf("%s <%s>", name, u.Email)
+}
+
+// UserCache looks up users by UUID, asking IAM at most once per user
+type UserCache struct {
+	handler *Handler
+	users   map[string]*User
+	errs    map[string]error
+}
+
+// NewUserCache creates a user cache backed by the given handler
+func NewUserCache(h *Handler) *UserCache {
+	return &UserCache{handler: h, users: map[string]*User{}, errs: map[string]error{}}
+}
+
+// Get returns the user with the given UUID. Errors, including
+// ErrUserNotFound, are cached as well.
+func (c *UserCache) Get(uuid string) (*User, error) {
+	if u, ok := c.users[uuid]; ok {
+		return u, nil
+	}
+	if err, ok := c.errs[uuid]; ok {
+		return nil, err
+	}
+	u, err := c.handler.GetUser(uuid)
+	if err != nil {
+		c.errs[uuid] = err
+		return nil, err
+	}
+	c.users[uuid] = u
+	return u, nil
+}
+
 // ListGroups lists all groups in the current account with automatic pagination
 func (h *Handler) ListGroups(partialGroupName string, uuids []string, chunkSize int64) (*GroupListResponse, error) {
 	envID, err := extractEnvironmentID(h.client.HTTP().BaseURL)
@@ -2,6 +2,7 @@ package iam
 
 import (
 	"encoding/json"
+	"errors"
 	"net/http"
 	"net/http/httptest"
 	"strings"
@@ -360,6 +361,135 @@ func TestGetUser(t *testing.T) {
 	}
 }
 
+func TestFindUser(t *testing.T) {
+	users := []User{
+		{UID: "11111111-2222-3333-4444-555555555555", Email: "jane.doe@example.com", Name: "Jane", Surname: "Doe"},
+		{UID: "66666666-7777-8888-9999-000000000000", Email: "jane.roe@example.com", Name: "Jane", Surname: "Roe"},
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		for _, u := range users {
+			if strings.HasSuffix(r.URL.Path, "/users/"+u.UID) {
+				json.NewEncoder(w).Encode(u)
+				return
+			}
+		}
+		if strings.Contains(r.URL.Path, "/users/") {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		var matches []User
+		for _, u := range users {
+			if strings.Contains(u.Email, strings.ToLower(r.URL.Query().Get("partialString"))) {
+				matches = append(matches, u)
+			}
+		}
+		json.NewEncoder(w).Encode(UserListResponse{Results: matches, TotalCount: int64(len(matches))})
+	}))
+	defer server.Close()
+
+	c, err := client.NewForTesting(server.URL, "test-token")
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+	handler := NewHandler(c)
+
+	tests := []struct {
+		name          string
+		ref           string
+		wantUID       string
+		wantNotFound  bool
+		errorContains string
+	}{
+		{name: "by UUID", ref: users[1].UID, wantUID: users[1].UID},
+		{name: "by email", ref: "JANE.DOE@example.com", wantUID: users[0].UID},
+		{name: "unique partial match", ref: "roe", wantUID: users[1].UID},
+		{name: "unknown UUID", ref: "aaaaaaaa-bbbb-cccc-dddd-eeeeeeeeeeee", wantNotFound: true},
+		{name: "unknown email", ref: "nobody@example.com", wantNotFound: true},
+		{name: "ambiguous", ref: "jane", errorContains: "matches 2 users"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			user, err := handler.FindUser(tt.ref)
+			switch {
+			case tt.wantNotFound:
+				if !errors.Is(err, ErrUserNotFound) {
+					t.Errorf("expected ErrUserNotFound, got %v", err)
+				}
+			case tt.errorContains != "":
+				if err == nil || !strings.Contains(err.Error(), tt.errorContains) {
+					t.Errorf("expected error containing %q, got %v", tt.errorContains, err)
+				}
+			case err != nil:
+				t.Fatalf("FindUser() error = %v", err)
+			case user.UID != tt.wantUID:
+				t.Errorf("expected UID %q, got %q", tt.wantUID, user.UID)
+			}
+		})
+	}
+}
+
+func TestUserCache(t *testing.T) {
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.Header().Set("Content-Type", "application/json")
+		if strings.HasSuffix(r.URL.Path, "/users/gone") {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		json.NewEncoder(w).Encode(User{UID: "user-1", Email: "john@example.com"})
+	}))
+	defer server.Close()
+
+	c, err := client.NewForTesting(server.URL, "test-token")
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+	cache := NewUserCache(NewHandler(c))
+
+	for i := 0; i < 2; i++ {
+		if u, err := cache.Get("user-1"); err != nil || u.Email != "john@example.com" {
+			t.Fatalf("Get(user-1) = %v, %v", u, err)
+		}
+		if _, err := cache.Get("gone"); !errors.Is(err, ErrUserNotFound) {
+			t.Fatalf("expected ErrUserNotFound, got %v", err)
+		}
+	}
+	if requests != 2 {
+		t.Errorf("expected 2 requests, got %d", requests)
+	}
+}
+
+func TestUserDisplayName(t *testing.T) {
+	tests := []struct {
+		user User
+		want string
+	}{
+		{User{Email: "john@example.com", Name: "John", Surname: "Doe"}, "John Doe <john@example.com>"},
+		{User{Email: "john@example.com"}, "john@example.com"},
+		{User{Name: "John"}, "John"},
+	}
+	for _, tt := range tests {
+		if got := tt.user.DisplayName(); got != tt.want {
+			t.Errorf("DisplayName() = %q, want %q", got, tt.want)
+		}
+	}
+}
+
+func TestIsUUID(t *testing.T) {
+	for _, s := range []string{"11111111-2222-3333-4444-555555555555", "ABCDEF01-2345-6789-abcd-ef0123456789"} {
+		if !IsUUID(s) {
+			t.Errorf("IsUUID(%q) = false, want true", s)
+		}
+	}
+	for _, s := range []string{"", "jane.doe@example.com", "11111111-2222-3333-4444-55555555555", "11111111222233334444555555555555"} {
+		if IsUUID(s) {
+			t.Errorf("IsUUID(%q) = true, want false", s)
+		}
+	}
+}
+
 func TestListGroups(t *testing.T) {
 	tests := []struct {
 		name             string
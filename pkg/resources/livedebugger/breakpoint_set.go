@@ -0,0 +1,198 @@
+package livedebugger
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+)
+
+// BreakpointSet is a declarative description of the breakpoints and
+// workspace filters a Live Debugger workspace should contain. It is applied
+// with "dtctl apply -f", which reconciles the workspace to match the file.
+type BreakpointSet struct {
+	// Project selects the workspace. Defaults to the current directory name,
+	// the same workspace used by the breakpoint commands.
+	Project string `json:"project,omitempty" yaml:"project,omitempty"`
+	// Filters replaces the workspace filter sets when present. Omit the key
+	// to leave the current filters untouched; use an empty map to clear them.
+	Filters     map[string]FilterValues `json:"filters,omitempty" yaml:"filters,omitempty"`
+	Breakpoints []BreakpointSpec        `json:"breakpoints" yaml:"breakpoints"`
+}
+
+// BreakpointSpec describes a single breakpoint in a BreakpointSet.
+type BreakpointSpec struct {
+	Filename   string `json:"filename" yaml:"filename"`
+	LineNumber int    `json:"lineNumber" yaml:"lineNumber"`
+	Condition  string `json:"condition,omitempty" yaml:"condition,omitempty"`
+	// Enabled defaults to true when omitted.
+	Enabled *bool `json:"enabled,omitempty" yaml:"enabled,omitempty"`
+}
+
+// Location returns the breakpoint location as "filename:line".
+func (s BreakpointSpec) Location() string {
+	return fmt.Sprintf("%s:%d", s.Filename, s.LineNumber)
+}
+
+// IsEnabled reports whether the breakpoint should be active.
+func (s BreakpointSpec) IsEnabled() bool {
+	return s.Enabled == nil || *s.Enabled
+}
+
+// FilterValues holds the values of a workspace filter. It accepts either a
+// single string or a list of strings.
+type FilterValues []string
+
+// UnmarshalJSON accepts a string or an array of strings.
+func (f *FilterValues) UnmarshalJSON(data []byte) error {
+	var single string
+	if err := json.Unmarshal(data, &single); err == nil {
+		*f = FilterValues{single}
+		return nil
+	}
+	var list []string
+	if err := json.Unmarshal(data, &list); err != nil {
+		return fmt.Errorf("filter values must be a string or a list of strings")
+	}
+	*f = list
+	return nil
+}
+
+// ParseBreakpointSet decodes and validates a breakpoint set from JSON.
+func ParseBreakpointSet(data []byte) (*BreakpointSet, error) {
+	var set BreakpointSet
+	if err := json.Unmarshal(data, &set); err != nil {
+		return nil, fmt.Errorf("failed to parse breakpoint set: %w", err)
+	}
+	if err := set.Validate(); err != nil {
+		return nil, err
+	}
+	return &set, nil
+}
+
+// Validate checks that every breakpoint has a location and that no location
+// is listed twice.
+func (s *BreakpointSet) Validate() error {
+	seen := make(map[string]int, len(s.Breakpoints))
+	for i, bp := range s.Breakpoints {
+		if bp.Filename == "" {
+			return fmt.Errorf("breakpoints[%d]: filename is required", i)
+		}
+		if bp.LineNumber <= 0 {
+			return fmt.Errorf("breakpoints[%d]: lineNumber must be a positive integer", i)
+		}
+		if prev, ok := seen[bp.Location()]; ok {
+			return fmt.Errorf("breakpoints[%d]: duplicate location %s (also breakpoints[%d])", i, bp.Location(), prev)
+		}
+		seen[bp.Location()] = i
+	}
+	for field, values := range s.Filters {
+		if field == "" || len(values) == 0 {
+			return fmt.Errorf("filter %q: field and at least one value are required", field)
+		}
+	}
+	return nil
+}
+
+// FilterMap returns the filters in the form accepted by BuildFilterSets,
+// with values sorted for stable output.
+func (s *BreakpointSet) FilterMap() map[string][]string {
+	filters := make(map[string][]string, len(s.Filters))
+	for field, values := range s.Filters {
+		sorted := append([]string(nil), values...)
+		sort.Strings(sorted)
+		filters[field] = sorted
+	}
+	return filters
+}
+
+// BreakpointChange is an existing rule that needs to be modified to match
+// its BreakpointSpec.
+type BreakpointChange struct {
+	Rule             BreakpointRule
+	Spec             BreakpointSpec
+	ConditionChanged bool
+	EnabledChanged   bool
+}
+
+// BreakpointSetPlan lists the rule changes needed to reconcile a workspace
+// with a BreakpointSet.
+type BreakpointSetPlan struct {
+	Create    []BreakpointSpec
+	Update    []BreakpointChange
+	Delete    []BreakpointRule
+	Unchanged int
+}
+
+// HasRuleChanges reports whether applying the plan modifies any rule.
+func (p BreakpointSetPlan) HasRuleChanges() bool {
+	return len(p.Create) > 0 || len(p.Update) > 0 || len(p.Delete) > 0
+}
+
+// PlanBreakpointSet compares the desired breakpoints with the rules currently
+// in the workspace. Rules are matched by filename and line number; the first
+// rule at a location is kept and any further rules at the same location are
+// deleted, as are rules at locations not listed in the set.
+func PlanBreakpointSet(set *BreakpointSet, rules []BreakpointRule) BreakpointSetPlan {
+	byLocation := make(map[string][]BreakpointRule)
+	for _, rule := range rules {
+		filename, line, ok := RuleLocation(rule)
+		if !ok || rule.ID == "" {
+			continue
+		}
+		key := fmt.Sprintf("%s:%d", filename, line)
+		byLocation[key] = append(byLocation[key], rule)
+	}
+
+	var plan BreakpointSetPlan
+	wanted := make(map[string]bool, len(set.Breakpoints))
+	for _, spec := range set.Breakpoints {
+		wanted[spec.Location()] = true
+		matches := byLocation[spec.Location()]
+		if len(matches) == 0 {
+			plan.Create = append(plan.Create, spec)
+			continue
+		}
+
+		rule := matches[0]
+		plan.Delete = append(plan.Delete, matches[1:]...)
+		change := BreakpointChange{
+			Rule:             rule,
+			Spec:             spec,
+			ConditionChanged: RuleCondition(rule) != spec.Condition,
+			EnabledChanged:   rule.IsDisabled == spec.IsEnabled(),
+		}
+		if change.ConditionChanged || change.EnabledChanged {
+			plan.Update = append(plan.Update, change)
+		} else {
+			plan.Unchanged++
+		}
+	}
+
+	for _, rule := range rules {
+		filename, line, ok := RuleLocation(rule)
+		if !ok || rule.ID == "" {
+			continue
+		}
+		if !wanted[fmt.Sprintf("%s:%d", filename, line)] {
+			plan.Delete = append(plan.Delete, rule)
+		}
+	}
+
+	return plan
+}
+
+// DefaultProjectPath returns the workspace project path for the current
+// directory: its base name, or "no-project" when it cannot be determined.
+func DefaultProjectPath() string {
+	cwd, err := os.Getwd()
+	if err != nil {
+		return "no-project"
+	}
+	project := filepath.Base(cwd)
+	if project == "" || project == "." || project == string(filepath.Separator) {
+		return "no-project"
+	}
+	return project
+}
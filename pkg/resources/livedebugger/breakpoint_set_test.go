@@ -0,0 +1,124 @@
+package livedebugger
+
+import (
+	"strings"
+	"testing"
+)
+
+func testRule(id, filename string, line int, condition string, disabled bool) BreakpointRule {
+	aug := map[string]interface{}{
+		"location": map[string]interface{}{"filename": filename, "lineno": float64(line)},
+	}
+	if condition != "" {
+		aug["conditional"] = condition
+	}
+	return BreakpointRule{ID: id, IsDisabled: disabled, AugJSON: aug}
+}
+
+func TestParseBreakpointSet(t *testing.T) {
+	set, err := ParseBreakpointSet([]byte(`{
+		"project": "orders",
+		"filters": {"k8s.namespace.name": "prod", "dt.entity.host": ["HOST-2", "HOST-1"]},
+		"breakpoints": [
+			{"filename": "A.java", "lineNumber": 10, "condition": "x > 1"},
+			{"filename": "B.java", "lineNumber": 20, "enabled": false}
+		]
+	}`))
+	if err != nil {
+		t.Fatalf("ParseBreakpointSet returned error: %v", err)
+	}
+	if set.Project != "orders" || len(set.Breakpoints) != 2 {
+		t.Fatalf("unexpected set: %+v", set)
+	}
+	if !set.Breakpoints[0].IsEnabled() || set.Breakpoints[1].IsEnabled() {
+		t.Fatalf("unexpected enabled state: %+v", set.Breakpoints)
+	}
+
+	filters := set.FilterMap()
+	if got := filters["k8s.namespace.name"]; len(got) != 1 || got[0] != "prod" {
+		t.Fatalf("unexpected scalar filter: %#v", got)
+	}
+	if got := filters["dt.entity.host"]; len(got) != 2 || got[0] != "HOST-1" {
+		t.Fatalf("expected sorted filter values, got %#v", got)
+	}
+}
+
+func TestParseBreakpointSet_Invalid(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  string
+	}{
+		{"missing filename", `{"breakpoints": [{"lineNumber": 1}]}`, "filename is required"},
+		{"bad line", `{"breakpoints": [{"filename": "A.java", "lineNumber": 0}]}`, "lineNumber"},
+		{"duplicate", `{"breakpoints": [{"filename": "A.java", "lineNumber": 1}, {"filename": "A.java", "lineNumber": 1}]}`, "duplicate location A.java:1"},
+		{"empty filter", `{"filters": {"k8s.namespace.name": []}, "breakpoints": []}`, "at least one value"},
+		{"bad filter type", `{"filters": {"k8s.namespace.name": 1}, "breakpoints": []}`, "string or a list"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ParseBreakpointSet([]byte(tt.input))
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Fatalf("expected error containing %q, got %v", tt.want, err)
+			}
+		})
+	}
+}
+
+func TestPlanBreakpointSet(t *testing.T) {
+	disabled := false
+	set := &BreakpointSet{Breakpoints: []BreakpointSpec{
+		{Filename: "A.java", LineNumber: 10},
+		{Filename: "B.java", LineNumber: 20, Condition: "x > 1"},
+		{Filename: "C.java", LineNumber: 30, Enabled: &disabled},
+		{Filename: "D.java", LineNumber: 40},
+	}}
+	rules := []BreakpointRule{
+		testRule("r-a", "A.java", 10, "", false),
+		testRule("r-a2", "A.java", 10, "", false),
+		testRule("r-b", "B.java", 20, "", false),
+		testRule("r-c", "C.java", 30, "", false),
+		testRule("r-x", "X.java", 99, "", false),
+		{ID: "r-nolocation"},
+	}
+
+	plan := PlanBreakpointSet(set, rules)
+
+	if len(plan.Create) != 1 || plan.Create[0].Location() != "D.java:40" {
+		t.Fatalf("unexpected creates: %+v", plan.Create)
+	}
+	if plan.Unchanged != 1 {
+		t.Fatalf("expected 1 unchanged, got %d", plan.Unchanged)
+	}
+	if len(plan.Update) != 2 {
+		t.Fatalf("expected 2 updates, got %+v", plan.Update)
+	}
+	if u := plan.Update[0]; u.Rule.ID != "r-b" || !u.ConditionChanged || u.EnabledChanged {
+		t.Fatalf("unexpected condition update: %+v", u)
+	}
+	if u := plan.Update[1]; u.Rule.ID != "r-c" || u.ConditionChanged || !u.EnabledChanged {
+		t.Fatalf("unexpected enabled update: %+v", u)
+	}
+
+	deleted := make([]string, 0, len(plan.Delete))
+	for _, rule := range plan.Delete {
+		deleted = append(deleted, rule.ID)
+	}
+	if strings.Join(deleted, ",") != "r-a2,r-x" {
+		t.Fatalf("unexpected deletes: %v", deleted)
+	}
+	if !plan.HasRuleChanges() {
+		t.Fatal("expected plan to have rule changes")
+	}
+}
+
+func TestPlanBreakpointSet_Unchanged(t *testing.T) {
+	set := &BreakpointSet{Breakpoints: []BreakpointSpec{{Filename: "A.java", LineNumber: 10, Condition: "x > 1"}}}
+	rule := testRule("r-a", "A.java", 10, "x>1", false)
+	rule.AugJSON["originalCondition"] = "x > 1"
+
+	plan := PlanBreakpointSet(set, []BreakpointRule{rule})
+	if plan.HasRuleChanges() || plan.Unchanged != 1 {
+		t.Fatalf("expected no changes, got %+v", plan)
+	}
+}
@@ -79,6 +79,41 @@ func (h *Handler) GetOrCreateWorkspace(projectPath string) (map[string]interface
 	return resp, workspaceID, nil
 }
 
+// FindWorkspace looks up the user workspace of a project path without
+// creating it. The returned ID is empty when no workspace exists yet.
+func (h *Handler) FindWorkspace(projectPath string) (string, error) {
+	query := `query FindUserWorkspaceV2($orgId: ID!, $workspaceInput: WorkspaceGetOrCreateInput) {
+  org(id: $orgId) {
+    id
+    userWorkspaceV2(workspaceInput: $workspaceInput) {
+      id
+    }
+  }
+}`
+
+	variables := map[string]interface{}{
+		"orgId": h.orgID,
+		"workspaceInput": map[string]interface{}{
+			"clientName":  "dtctl",
+			"projectPath": projectPath,
+		},
+	}
+
+	resp, err := h.executeGraphQL(query, variables)
+	if err != nil {
+		return "", err
+	}
+
+	var decoded GraphQLWorkspaceResponse
+	if err := decodeGraphQLResponse(resp, &decoded); err != nil {
+		return "", err
+	}
+	if decoded.Data.Org.UserWorkspace == nil {
+		return "", nil
+	}
+	return decoded.Data.Org.UserWorkspace.ID, nil
+}
+
 func (h *Handler) UpdateWorkspaceFilters(workspaceID string, filterSets []map[string]interface{}) (map[string]interface{}, error) {
 	mutation := `mutation UpdateWorkspaceV2($orgId: ID!, $workspaceId: ID!, $data: WorkspaceInputV2!) {
   org(orgId: $orgId) {
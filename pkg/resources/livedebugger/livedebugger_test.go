@@ -231,6 +231,8 @@ func TestHandlerMethods(t *testing.T) {
 					},
 				},
 			}
+		case strings.Contains(query, "FindUserWorkspaceV2"):
+			return map[string]interface{}{"data": map[string]interface{}{"org": map[string]interface{}{"userWorkspaceV2": nil}}}
 		case strings.Contains(query, "UpdateWorkspaceV2"):
 			return map[string]interface{}{"data": map[string]interface{}{"org": map[string]interface{}{"updateWorkspaceV2": map[string]interface{}{"id": "ws-1"}}}}
 		case strings.Contains(query, "CreateRule"):
@@ -257,6 +259,9 @@ func TestHandlerMethods(t *testing.T) {
 		t.Fatalf("GetOrCreateWorkspace failed: id=%q err=%v resp=%#v", workspaceID, err, workspaceResp)
 	}
 
+	if workspaceID, err := h.FindWorkspace("proj"); err != nil || workspaceID != "" {
+		t.Fatalf("FindWorkspace failed: id=%q err=%v", workspaceID, err)
+	}
 	if _, err := h.UpdateWorkspaceFilters("ws-1", BuildFilterSets(map[string][]string{"k": {"v"}})); err != nil {
 		t.Fatalf("UpdateWorkspaceFilters failed: %v", err)
 	}
@@ -0,0 +1,211 @@
+package livedebugger
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+const (
+	DefaultOutputMessage = "Hit on {store.rookout.frame.filename}:{store.rookout.frame.line}"
+	RookoutTargetID      = "Rookout"
+
+	rookoutTargetName       = "send_rookout"
+	rookoutOnPremTargetName = "send_rookout_data_on_prem"
+)
+
+// RuleLocation returns the source file and line number a rule is set on.
+// ok is false when the rule has no usable location.
+func RuleLocation(rule BreakpointRule) (string, int, bool) {
+	if rule.AugJSON == nil {
+		return "", 0, false
+	}
+	location, ok := rule.AugJSON["location"].(map[string]interface{})
+	if !ok {
+		return "", 0, false
+	}
+	filename := stringValue(location["filename"])
+	if filename == "" {
+		return "", 0, false
+	}
+	return filename, intValue(location["lineno"], 0), true
+}
+
+// RuleCondition returns the condition currently configured on a rule,
+// preferring the expression as originally entered by the user.
+func RuleCondition(rule BreakpointRule) string {
+	if rule.AugJSON == nil {
+		return ""
+	}
+	if original := stringValue(rule.AugJSON["originalCondition"]); original != "" {
+		return original
+	}
+	return stringValue(rule.AugJSON["conditional"])
+}
+
+// BuildEditRuleSettings builds the EditRuleV2Input payload for a rule,
+// carrying over all existing rule settings. When conditionChanged is true the
+// condition is replaced by the given value.
+func BuildEditRuleSettings(rule BreakpointRule, condition string, conditionChanged bool) (map[string]interface{}, error) {
+	if rule.ID == "" {
+		return nil, fmt.Errorf("rule missing mutable rule id")
+	}
+
+	aug := rule.AugJSON
+	if aug == nil {
+		return nil, fmt.Errorf("rule %s missing aug_json", rule.ID)
+	}
+
+	if _, hasLocation := aug["location"].(map[string]interface{}); !hasLocation {
+		return nil, fmt.Errorf("rule %s missing aug_json", rule.ID)
+	}
+
+	paths := extractOperationPaths(aug)
+	currentCondition := stringValue(aug["conditional"])
+	if conditionChanged {
+		currentCondition = condition
+	}
+
+	settings := map[string]interface{}{
+		"mutableRuleId":               rule.ID,
+		"collectLocalsMethod":         stringValue(paths["store.rookout.frame"]),
+		"stackTraceCollection":        stringValue(paths["store.rookout.traceback"]) == "stack.traceback()",
+		"ttlHitLimit":                 intValue(aug["globalHitLimit"], 100),
+		"ttlTimeLimit":                stringValue(aug["globalDisableAfterTime"]),
+		"ttlTimeLimitInterval":        0,
+		"collectedVariables":          extractCollectedVariables(paths),
+		"targetConfiguration":         map[string]interface{}{"targetId": extractTargetID(rule)},
+		"outputMessage":               extractOutputMessage(rule),
+		"condition":                   currentCondition,
+		"rateLimit":                   stringValue(aug["rateLimit"]),
+		"tracingCollection":           stringValue(paths["store.rookout.tracing"]) == "trace.dump()",
+		"processMonitoringCollection": stringValue(paths["store.rookout.processMonitoring"]) == "state.dump()",
+	}
+
+	return settings, nil
+}
+
+func extractOperationPaths(aug map[string]interface{}) map[string]interface{} {
+	paths := map[string]interface{}{}
+	action, ok := aug["action"].(map[string]interface{})
+	if !ok {
+		return paths
+	}
+	operations, ok := action["operations"].([]interface{})
+	if !ok {
+		return paths
+	}
+	for _, operationIfc := range operations {
+		operation, ok := operationIfc.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		opPaths, ok := operation["paths"].(map[string]interface{})
+		if !ok {
+			continue
+		}
+		for key, value := range opPaths {
+			paths[key] = value
+		}
+	}
+	return paths
+}
+
+func extractCollectedVariables(paths map[string]interface{}) []string {
+	variables := make([]string, 0)
+	for key, value := range paths {
+		if !strings.HasPrefix(key, "store.rookout.variables.") {
+			continue
+		}
+		pathValue := stringValue(value)
+		if pathValue == "" {
+			continue
+		}
+		variables = append(variables, formatCollectedVariable(pathValue))
+	}
+	sort.Strings(variables)
+	return variables
+}
+
+func formatCollectedVariable(path string) string {
+	trimmed := strings.TrimPrefix(path, "frame.")
+	if className, member, ok := parseThreadLocalPath(trimmed); ok {
+		return className + "." + member
+	}
+	return trimmed
+}
+
+func parseThreadLocalPath(path string) (string, string, bool) {
+	if !strings.HasPrefix(path, "utils.class(\"") {
+		return "", "", false
+	}
+	rest := strings.TrimPrefix(path, "utils.class(\"")
+	className, remainder, found := strings.Cut(rest, "\")")
+	if !found || className == "" {
+		return "", "", false
+	}
+	member := strings.TrimPrefix(remainder, ".")
+	if member == "" {
+		return "", "", false
+	}
+	return className, member, true
+}
+
+func extractOutputMessage(rule BreakpointRule) string {
+	operations, ok := rule.Processing["operations"].([]interface{})
+	if !ok {
+		return DefaultOutputMessage
+	}
+	for _, operationIfc := range operations {
+		operation, ok := operationIfc.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		if stringValue(operation["path"]) == "temp.message.rookout.message" {
+			format := stringValue(operation["format"])
+			if format != "" {
+				return format
+			}
+		}
+	}
+	return DefaultOutputMessage
+}
+
+func extractTargetID(rule BreakpointRule) string {
+	operations, ok := rule.Processing["operations"].([]interface{})
+	if !ok || len(operations) == 0 {
+		return RookoutTargetID
+	}
+	lastOperation, ok := operations[len(operations)-1].(map[string]interface{})
+	if !ok {
+		return RookoutTargetID
+	}
+	name := stringValue(lastOperation["name"])
+	if name == rookoutTargetName || name == rookoutOnPremTargetName {
+		return RookoutTargetID
+	}
+	if targetID := stringValue(lastOperation["target_id"]); targetID != "" {
+		return targetID
+	}
+	return RookoutTargetID
+}
+
+func stringValue(value interface{}) string {
+	stringVal, _ := value.(string)
+	return stringVal
+}
+
+func intValue(value interface{}, defaultValue int) int {
+	switch typed := value.(type) {
+	case int:
+		return typed
+	case int32:
+		return int(typed)
+	case int64:
+		return int(typed)
+	case float64:
+		return int(typed)
+	default:
+		return defaultValue
+	}
+}
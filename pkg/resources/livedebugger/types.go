@@ -10,6 +10,7 @@ type GraphQLWorkspaceResponse struct {
 		Org struct {
 			Workspace            Workspace        `json:"workspace"`
 			GetOrCreateWorkspace Workspace        `json:"getOrCreateUserWorkspaceV2"`
+			UserWorkspace        *Workspace       `json:"userWorkspaceV2"`
 			RuleStatuses         []RuleStatusNode `json:"ruleStatuses"`
 		} `json:"org"`
 	} `json:"data"`
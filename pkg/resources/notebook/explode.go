@@ -0,0 +1,114 @@
+package notebook
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/dynatrace-oss/dtctl/pkg/util/format"
+)
+
+// ExplodedSpecFile is the name of the spec file written by Explode
+const ExplodedSpecFile = "notebook.yaml"
+
+// Explode splits a notebook into a source tree: a spec (ExplodedSpecFile) and
+// one .dql or .md file per section, numbered in section order and keyed by
+// their path relative to the spec. What the spec fields cannot express is
+// kept in extra fields, so that building the spec gives the same content
+// again; Explode checks this and fails rather than lose anything.
+func Explode(id, title, description string, content map[string]any) (map[string][]byte, error) {
+	if content == nil {
+		return nil, fmt.Errorf("notebook has no content")
+	}
+	files := map[string][]byte{}
+	read := func(name string) ([]byte, error) {
+		data, ok := files[name]
+		if !ok {
+			return nil, os.ErrNotExist
+		}
+		return data, nil
+	}
+
+	s := &Spec{Kind: SpecKind, ID: id, Title: title, Description: description}
+	if tf, ok := content["defaultTimeframe"].(map[string]any); ok {
+		if from, ok := tf["from"].(string); ok {
+			s.Timeframe = &SpecTimeframe{From: from}
+			if to, _ := tf["to"].(string); to != "now()" {
+				s.Timeframe.To = to
+			}
+		}
+	}
+	sections, _ := content["sections"].([]any)
+	for i, item := range sections {
+		raw, _ := item.(map[string]any)
+		s.Sections = append(s.Sections, explodeSection(raw, i, files, read))
+	}
+
+	built, err := s.build(read)
+	if err != nil {
+		return nil, fmt.Errorf("failed to export notebook: %w", err)
+	}
+	s.Extra = format.CreateMergePatch(built["content"].(map[string]any), content)
+
+	var buf bytes.Buffer
+	enc := yaml.NewEncoder(&buf)
+	enc.SetIndent(2)
+	if err := enc.Encode(s); err != nil {
+		return nil, fmt.Errorf("failed to write notebook spec: %w", err)
+	}
+	if err := enc.Close(); err != nil {
+		return nil, fmt.Errorf("failed to write notebook spec: %w", err)
+	}
+
+	// Check the round trip through the written files
+	loaded, err := LoadSpec(buf.Bytes())
+	if err == nil {
+		built, err = loaded.build(read)
+	}
+	if err != nil || !format.JSONEqual(built["content"], content) || built["name"] != title {
+		return nil, fmt.Errorf("notebook cannot be exported without losing content")
+	}
+	files[ExplodedSpecFile] = buf.Bytes()
+	return files, nil
+}
+
+// explodeSection turns a section into a spec section. Sections the spec
+// fields do not fit are kept as extra fields only.
+func explodeSection(raw map[string]any, index int, files map[string][]byte, read func(string) ([]byte, error)) SpecSection {
+	s := SpecSection{}
+	s.ID, _ = raw["id"].(string)
+	s.Title, _ = raw["title"].(string)
+	name := fmt.Sprintf("%02d", index+1)
+	if slug := format.Slug(s.Title); slug != "" {
+		name += "-" + slug
+	}
+	file := ""
+	switch raw["type"] {
+	case "dql":
+		state, _ := raw["state"].(map[string]any)
+		input, _ := state["input"].(map[string]any)
+		query, _ := input["value"].(string)
+		file = path.Join("sections", name+".dql")
+		files[file] = []byte(query + "\n")
+		s.File = file
+		s.Visualization, _ = state["visualization"].(string)
+		s.Settings, _ = state["visualizationSettings"].(map[string]any)
+	case "markdown":
+		text, _ := raw["markdown"].(string)
+		file = path.Join("sections", name+".md")
+		files[file] = []byte(text + "\n")
+		s.MarkdownFile = file
+	}
+
+	if built, err := buildSection(s, index, read); err == nil {
+		s.Extra = format.CreateMergePatch(built, raw)
+		if rebuilt, err := buildSection(s, index, read); err == nil && format.JSONEqual(rebuilt, raw) {
+			return s
+		}
+	}
+	delete(files, file)
+	return SpecSection{ID: s.ID, Extra: format.CreateMergePatch(map[string]any{"id": s.ID}, raw)}
+}
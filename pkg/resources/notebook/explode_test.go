@@ -0,0 +1,84 @@
+package notebook
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/dynatrace-oss/dtctl/pkg/util/format"
+)
+
+const explodeContent = `{
+  "version": "7",
+  "defaultTimeframe": {"from": "now()-2h", "to": "now()"},
+  "defaultSegments": [],
+  "sections": [
+    {"id": "a1", "type": "markdown", "markdown": "# Error Analysis\n"},
+    {"id": "b2", "type": "dql", "title": "Errors by namespace", "showTitle": true, "drilldownPath": [],
+     "state": {
+       "input": {"value": "fetch logs\n| filter loglevel == \"ERROR\"", "timeframe": {"from": "now()-2h", "to": "now()"}},
+       "visualization": "table",
+       "visualizationSettings": {"table": {"columnWidths": {}}},
+       "querySettings": {"maxResultRecords": 1000, "enableSampling": false},
+       "davis": {"includeLogs": true},
+       "result": {"code": 200, "value": {"records": [{"n": null}]}}
+     }},
+    {"id": "c3", "type": "function", "state": {"input": {"value": "export default function () {}"}}},
+    {"type": "dql", "showTitle": false, "state": {"input": {"value": "fetch events"}}}
+  ]
+}`
+
+func TestExplode(t *testing.T) {
+	var content map[string]any
+	if err := json.Unmarshal([]byte(explodeContent), &content); err != nil {
+		t.Fatal(err)
+	}
+
+	files, err := Explode("nb-1", "Error Analysis", "", content)
+	if err != nil {
+		t.Fatalf("Explode() error = %v", err)
+	}
+
+	wantFiles := map[string]string{
+		"sections/01.md":                      "# Error Analysis\n\n",
+		"sections/02-errors-by-namespace.dql": "fetch logs\n| filter loglevel == \"ERROR\"\n",
+		"sections/04.dql":                     "fetch events\n",
+	}
+	for name, want := range wantFiles {
+		if got := string(files[name]); got != want {
+			t.Errorf("%s = %q, want %q", name, got, want)
+		}
+	}
+	if _, ok := files["sections/03.dql"]; ok {
+		t.Error("function section was written as a query file")
+	}
+	if len(files) != len(wantFiles)+1 {
+		t.Errorf("files = %d, want %d", len(files), len(wantFiles)+1)
+	}
+
+	spec := string(files[ExplodedSpecFile])
+	for _, want := range []string{"kind: NotebookSpec", "id: nb-1", "file: sections/02-errors-by-namespace.dql", "markdownFile: sections/01.md"} {
+		if !strings.Contains(spec, want) {
+			t.Errorf("spec does not contain %q:\n%s", want, spec)
+		}
+	}
+
+	s, err := LoadSpec(files[ExplodedSpecFile])
+	if err != nil {
+		t.Fatalf("LoadSpec() error = %v", err)
+	}
+	doc, err := s.build(func(name string) ([]byte, error) { return files[name], nil })
+	if err != nil {
+		t.Fatalf("build() error = %v", err)
+	}
+	if !format.JSONEqual(doc["content"], content) {
+		got, _ := json.Marshal(doc["content"])
+		t.Errorf("rebuilt content differs:\n got %s", got)
+	}
+}
+
+func TestExplodeNoContent(t *testing.T) {
+	if _, err := Explode("", "x", "", nil); err == nil {
+		t.Error("expected error")
+	}
+}
@@ -0,0 +1,235 @@
+// Package notebook provides specs, a compact source form of notebooks.
+package notebook
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/dynatrace-oss/dtctl/pkg/util/format"
+)
+
+// SpecKind is the kind of a notebook spec file
+const SpecKind = "NotebookSpec"
+
+// Spec is a compact notebook definition: a list of sections whose queries and
+// markdown are kept in separate files, so that they can be reviewed as text.
+// Build compiles a spec into a notebook document.
+//
+//	kind: NotebookSpec
+//	title: Error Analysis
+//	timeframe: {from: now()-2h}
+//	sections:
+//	  - markdownFile: sections/01-intro.md
+//	  - title: Errors by namespace
+//	    file: sections/02-errors.dql
+//	    visualization: table
+//
+// Extra fields carry what the other fields cannot express, so that Explode
+// can turn any notebook into a spec.
+type Spec struct {
+	Kind string `yaml:"kind"`
+	// ID is the document ID; set it to update an existing notebook on apply
+	ID          string         `yaml:"id,omitempty"`
+	Title       string         `yaml:"title"`
+	Description string         `yaml:"description,omitempty"`
+	Timeframe   *SpecTimeframe `yaml:"timeframe,omitempty"`
+	Sections    []SpecSection  `yaml:"sections"`
+	// Extra is merged into the built content as a JSON merge patch
+	Extra map[string]any `yaml:"extra,omitempty"`
+}
+
+// SpecTimeframe is the default timeframe of a notebook
+type SpecTimeframe struct {
+	From string `yaml:"from"`
+	To   string `yaml:"to,omitempty"`
+}
+
+// SpecSection is a DQL section (query or file) or a markdown section
+// (markdown or markdownFile)
+type SpecSection struct {
+	// ID is the section ID; sections without one are numbered
+	ID            string `yaml:"id,omitempty"`
+	Title         string `yaml:"title,omitempty"`
+	File          string `yaml:"file,omitempty"`
+	Query         string `yaml:"query,omitempty"`
+	Markdown      string `yaml:"markdown,omitempty"`
+	MarkdownFile  string `yaml:"markdownFile,omitempty"`
+	Visualization string `yaml:"visualization,omitempty"`
+	// Settings are passed through as the visualizationSettings of the section
+	Settings map[string]any `yaml:"settings,omitempty"`
+	// Extra is merged into the built section as a JSON merge patch. A
+	// section without a query or markdown is built from its extra fields
+	// only.
+	Extra map[string]any `yaml:"extra,omitempty"`
+}
+
+// IsSpec reports whether data (YAML or JSON) is a notebook spec
+func IsSpec(data []byte) bool {
+	var head struct {
+		Kind string `yaml:"kind"`
+	}
+	if err := yaml.Unmarshal(data, &head); err != nil {
+		return false
+	}
+	return head.Kind == SpecKind
+}
+
+// LoadSpec parses a notebook spec. Unknown fields are an error, so that typos
+// do not go unnoticed.
+func LoadSpec(data []byte) (*Spec, error) {
+	dec := yaml.NewDecoder(bytes.NewReader(data))
+	dec.KnownFields(true)
+	var s Spec
+	if err := dec.Decode(&s); err != nil {
+		return nil, fmt.Errorf("invalid notebook spec: %w", err)
+	}
+	if s.Kind != SpecKind {
+		return nil, fmt.Errorf("invalid notebook spec: kind must be %s, got %q", SpecKind, s.Kind)
+	}
+	if s.Title == "" {
+		return nil, fmt.Errorf("invalid notebook spec: title is required")
+	}
+	return &s, nil
+}
+
+// Build compiles the spec into a notebook document (id, name, type,
+// description and content). Files are read relative to baseDir.
+func (s *Spec) Build(baseDir string) (map[string]any, error) {
+	return s.build(func(name string) ([]byte, error) {
+		if !filepath.IsAbs(name) {
+			name = filepath.Join(baseDir, name)
+		}
+		return os.ReadFile(name)
+	})
+}
+
+func (s *Spec) build(readFile func(name string) ([]byte, error)) (map[string]any, error) {
+	sections := make([]any, 0, len(s.Sections))
+	for i, sec := range s.Sections {
+		section, err := buildSection(sec, i, readFile)
+		if err != nil {
+			return nil, fmt.Errorf("section %d (%s): %w", i+1, sec.label(), err)
+		}
+		sections = append(sections, section)
+	}
+
+	content := map[string]any{"sections": sections}
+	if s.Timeframe != nil {
+		to := s.Timeframe.To
+		if to == "" {
+			to = "now()"
+		}
+		content["defaultTimeframe"] = map[string]any{"from": s.Timeframe.From, "to": to}
+	}
+	if s.Extra != nil {
+		content = format.MergePatch(content, s.Extra)
+	}
+
+	doc := map[string]any{
+		"name":    s.Title,
+		"type":    "notebook",
+		"content": content,
+	}
+	if s.ID != "" {
+		doc["id"] = s.ID
+	}
+	if s.Description != "" {
+		doc["description"] = s.Description
+	}
+	return doc, nil
+}
+
+func (s SpecSection) label() string {
+	for _, l := range []string{s.Title, s.File, s.MarkdownFile, s.ID} {
+		if l != "" {
+			return l
+		}
+	}
+	return "untitled"
+}
+
+func buildSection(s SpecSection, index int, readFile func(name string) ([]byte, error)) (map[string]any, error) {
+	id := s.ID
+	if id == "" {
+		id = fmt.Sprintf("section-%d", index+1)
+	}
+
+	markdown := s.Markdown
+	if s.MarkdownFile != "" {
+		if markdown != "" {
+			return nil, fmt.Errorf("set either markdown or markdownFile, not both")
+		}
+		text, err := readText(s.MarkdownFile, readFile)
+		if err != nil {
+			return nil, err
+		}
+		markdown = text
+	}
+
+	var out map[string]any
+	switch {
+	case markdown != "":
+		if s.Query != "" || s.File != "" {
+			return nil, fmt.Errorf("a section has either markdown or a query")
+		}
+		out = map[string]any{"id": id, "type": "markdown", "markdown": markdown}
+	case s.Query == "" && s.File == "" && s.Extra != nil:
+		out = map[string]any{"id": id}
+	default:
+		query, err := sectionQuery(s, readFile)
+		if err != nil {
+			return nil, err
+		}
+		state := map[string]any{"input": map[string]any{"value": query}}
+		if s.Visualization != "" {
+			state["visualization"] = s.Visualization
+		}
+		if s.Settings != nil {
+			state["visualizationSettings"] = s.Settings
+		}
+		out = map[string]any{"id": id, "type": "dql", "showTitle": s.Title != "", "state": state}
+		if s.Title != "" {
+			out["title"] = s.Title
+		}
+	}
+	if s.Extra != nil {
+		out = format.MergePatch(out, s.Extra)
+	}
+	return out, nil
+}
+
+// sectionQuery returns the inline query or the content of the .dql file of a
+// section
+func sectionQuery(s SpecSection, readFile func(name string) ([]byte, error)) (string, error) {
+	switch {
+	case s.Query != "" && s.File != "":
+		return "", fmt.Errorf("set either query or file, not both")
+	case s.Query != "":
+		return strings.TrimSpace(s.Query), nil
+	case s.File == "":
+		return "", fmt.Errorf("query, file or markdown is required")
+	}
+	query, err := readText(s.File, readFile)
+	if err != nil {
+		return "", err
+	}
+	if strings.TrimSpace(query) == "" {
+		return "", fmt.Errorf("query file %s is empty", s.File)
+	}
+	return query, nil
+}
+
+// readText returns the content of a file without its final line break
+func readText(name string, readFile func(name string) ([]byte, error)) (string, error) {
+	data, err := readFile(name)
+	if err != nil {
+		return "", fmt.Errorf("failed to read %s: %w", name, err)
+	}
+	text := strings.TrimSuffix(string(data), "\n")
+	return strings.TrimSuffix(text, "\r"), nil
+}
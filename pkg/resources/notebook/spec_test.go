@@ -0,0 +1,101 @@
+package notebook
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestIsSpec(t *testing.T) {
+	if !IsSpec([]byte("kind: NotebookSpec\ntitle: x\n")) {
+		t.Error("IsSpec() = false for a spec")
+	}
+	if IsSpec([]byte("kind: DashboardSpec\n")) || IsSpec([]byte("name: x\ntype: notebook\n")) {
+		t.Error("IsSpec() = true for a non-spec")
+	}
+}
+
+func TestLoadSpecErrors(t *testing.T) {
+	tests := map[string]string{
+		"unknown field": "kind: NotebookSpec\ntitle: x\ncells: []\n",
+		"wrong kind":    "kind: Notebook\ntitle: x\n",
+		"no title":      "kind: NotebookSpec\n",
+	}
+	for name, input := range tests {
+		if _, err := LoadSpec([]byte(input)); err == nil {
+			t.Errorf("%s: expected error", name)
+		}
+	}
+}
+
+func TestSpecBuild(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "errors.dql"), []byte("fetch logs\n| filter loglevel == \"ERROR\"\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	spec, err := LoadSpec([]byte(`kind: NotebookSpec
+id: nb-1
+title: Error Analysis
+timeframe: {from: now()-2h}
+sections:
+  - markdown: "# Errors"
+  - title: Errors
+    file: errors.dql
+    visualization: table
+  - id: q
+    query: fetch logs | limit 1
+`))
+	if err != nil {
+		t.Fatalf("LoadSpec() error = %v", err)
+	}
+	doc, err := spec.Build(dir)
+	if err != nil {
+		t.Fatalf("Build() error = %v", err)
+	}
+	if doc["id"] != "nb-1" || doc["name"] != "Error Analysis" || doc["type"] != "notebook" {
+		t.Errorf("document metadata = %v", doc)
+	}
+
+	content := doc["content"].(map[string]any)
+	tf := content["defaultTimeframe"].(map[string]any)
+	if tf["from"] != "now()-2h" || tf["to"] != "now()" {
+		t.Errorf("defaultTimeframe = %v", tf)
+	}
+	sections := content["sections"].([]any)
+	if len(sections) != 3 {
+		t.Fatalf("sections = %d, want 3", len(sections))
+	}
+	md := sections[0].(map[string]any)
+	if md["id"] != "section-1" || md["type"] != "markdown" || md["markdown"] != "# Errors" {
+		t.Errorf("section 1 = %v", md)
+	}
+	dql := sections[1].(map[string]any)
+	state := dql["state"].(map[string]any)
+	if dql["type"] != "dql" || dql["title"] != "Errors" || dql["showTitle"] != true || state["visualization"] != "table" {
+		t.Errorf("section 2 = %v", dql)
+	}
+	if q := state["input"].(map[string]any)["value"]; q != "fetch logs\n| filter loglevel == \"ERROR\"" {
+		t.Errorf("section 2 query = %q", q)
+	}
+	if sections[2].(map[string]any)["id"] != "q" {
+		t.Errorf("section 3 = %v", sections[2])
+	}
+}
+
+func TestSpecBuildErrors(t *testing.T) {
+	tests := map[string]string{
+		"missing file":       "sections: [{file: nope.dql}]",
+		"query and file":     "sections: [{query: fetch logs, file: a.dql}]",
+		"empty section":      "sections: [{title: Empty}]",
+		"markdown and query": "sections: [{markdown: x, query: fetch logs}]",
+	}
+	for name, body := range tests {
+		spec, err := LoadSpec([]byte("kind: NotebookSpec\ntitle: x\n" + body))
+		if err != nil {
+			t.Fatalf("%s: LoadSpec() error = %v", name, err)
+		}
+		if _, err := spec.Build(t.TempDir()); err == nil {
+			t.Errorf("%s: expected error", name)
+		}
+	}
+}
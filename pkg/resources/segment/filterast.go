@@ -21,10 +21,10 @@ func FilterToAST(dql string) (string, error) {
 	p := newParser(dql)
 	node, err := p.parseExpression()
 	if err != nil {
-		return "", fmt.Errorf("failed to parse filter expression: %w", err)
+		return "", fmt.Errorf("failed to parse filter expression: %w", newFilterError(dql, p.pos, err.Error()))
 	}
 	if p.pos < len(p.input) {
-		return "", fmt.Errorf("unexpected input at position %d: %q", p.pos, p.input[p.pos:])
+		return "", newFilterError(dql, p.pos, fmt.Sprintf("unexpected input at position %d: %q", p.pos, p.input[p.pos:]))
 	}
 
 	// Wrap in an implicit root group.
@@ -55,6 +55,34 @@ func FilterFromAST(ast string) (string, error) {
 	return result, nil
 }
 
+// FilterError is returned (wrapped) by FilterToAST when a DQL filter
+// expression cannot be parsed. Offset is the 0-based byte offset into the
+// trimmed expression; Line and Column are 1-based.
+type FilterError struct {
+	Offset int
+	Line   int
+	Column int
+	Msg    string
+}
+
+func (e *FilterError) Error() string {
+	return e.Msg
+}
+
+func newFilterError(input string, offset int, msg string) *FilterError {
+	offset = min(max(offset, 0), len(input))
+	line, col := 1, 1
+	for i := 0; i < offset; i++ {
+		if input[i] == '\n' {
+			line++
+			col = 1
+			continue
+		}
+		col++
+	}
+	return &FilterError{Offset: offset, Line: line, Column: col, Msg: msg}
+}
+
 // isFilterAST returns true if the filter string is a JSON AST (starts with '{').
 // Plain DQL filter expressions never start with '{'.
 func isFilterAST(filter string) bool {
@@ -345,7 +373,7 @@ func (p *parser) parseAnd() (*filterNode, error) {
 		// implicit AND
 		right, err := p.parseFactor()
 		if err != nil {
-			break
+			return nil, err
 		}
 		extra = append(extra, andTerm{sep: nil, node: right})
 	}
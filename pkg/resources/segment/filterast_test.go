@@ -2,6 +2,7 @@ package segment
 
 import (
 	"encoding/json"
+	"errors"
 	"strings"
 	"testing"
 )
@@ -448,6 +449,11 @@ func TestFilterToAST_Errors(t *testing.T) {
 			input:         `status = $var`,
 			errorContains: "unsupported filter syntax",
 		},
+		{
+			name:          "incomplete implicit AND statement",
+			input:         `status = ERROR level`,
+			errorContains: "expected operator",
+		},
 	}
 
 	for _, tt := range tests {
@@ -462,6 +468,32 @@ func TestFilterToAST_Errors(t *testing.T) {
 	}
 }
 
+func TestFilterToAST_ErrorPosition(t *testing.T) {
+	tests := []struct {
+		name   string
+		input  string
+		line   int
+		column int
+	}{
+		{"operator", `status == "ERROR"`, 1, 8},
+		{"second line", "a = 1 AND\n  b = *", 2, 7},
+		{"trailing input", `(a = 1))`, 1, 8},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := FilterToAST(tt.input)
+			var fe *FilterError
+			if !errors.As(err, &fe) {
+				t.Fatalf("expected *FilterError, got %v", err)
+			}
+			if fe.Line != tt.line || fe.Column != tt.column {
+				t.Errorf("expected %d:%d, got %d:%d (%v)", tt.line, tt.column, fe.Line, fe.Column, err)
+			}
+		})
+	}
+}
+
 func TestFilterToAST_AlreadyAST(t *testing.T) {
 	astInput := `{"type":"Group","logicalOperator":"AND","explicit":false,"children":[]}`
 	result, err := FilterToAST(astInput)
@@ -0,0 +1,133 @@
+package segment
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// FilterToDQL translates a segment include filter (DQL filter text or JSON
+// AST) into a DQL boolean expression that can be used in a "| filter"
+// command, e.g. to verify the filter against a data object with the query API.
+//
+// Segment filters use their own comparison syntax ("=" for equality, "in"
+// with a single value); these are rewritten to their DQL equivalents.
+func FilterToDQL(filter string) (string, error) {
+	ast, err := FilterToAST(filter)
+	if err != nil {
+		return "", err
+	}
+	result, err := dqlFromGroup([]byte(ast))
+	if err != nil {
+		return "", fmt.Errorf("failed to translate filter to DQL: %w", err)
+	}
+	return result, nil
+}
+
+// FetchQueryForInclude returns a DQL query that applies filter to the data
+// object of an include. Data objects that cannot be fetched directly
+// (AllDataObjects and metrics) return false.
+func FetchQueryForInclude(dataObject, filter string) (string, bool, error) {
+	if dataObject == "" || dataObject == AllDataObjects || dataObject == "metrics" {
+		return "", false, nil
+	}
+	expr, err := FilterToDQL(filter)
+	if err != nil {
+		return "", false, err
+	}
+	return fmt.Sprintf("fetch %s\n| filter %s", dataObject, expr), true, nil
+}
+
+func dqlFromGroup(data []byte) (string, error) {
+	var g astGroupJSON
+	if err := json.Unmarshal(data, &g); err != nil {
+		return "", fmt.Errorf("failed to unmarshal Group: %w", err)
+	}
+
+	var parts []string
+	// Adjacent operands without a LogicalOperator separator are an implicit AND.
+	needsOperator := false
+	for _, childRaw := range g.Children {
+		var peek struct {
+			Type string `json:"type"`
+		}
+		if err := json.Unmarshal(childRaw, &peek); err != nil {
+			return "", err
+		}
+
+		if peek.Type == "LogicalOperator" {
+			var lo astLogicalOperatorJSON
+			if err := json.Unmarshal(childRaw, &lo); err != nil {
+				return "", err
+			}
+			parts = append(parts, strings.ToLower(lo.Value))
+			needsOperator = false
+			continue
+		}
+
+		var operand string
+		switch peek.Type {
+		case "Group":
+			sub, err := dqlFromGroup(childRaw)
+			if err != nil {
+				return "", err
+			}
+			// Always parenthesize nested groups so the DQL precedence of
+			// "and" over "or" cannot change the meaning.
+			operand = "(" + sub + ")"
+		case "Statement":
+			s, err := dqlFromStatement(childRaw)
+			if err != nil {
+				return "", err
+			}
+			operand = s
+		default:
+			return "", fmt.Errorf("unknown child node type in group: %q", peek.Type)
+		}
+
+		if needsOperator {
+			parts = append(parts, "and")
+		}
+		parts = append(parts, operand)
+		needsOperator = true
+	}
+
+	return strings.Join(parts, " "), nil
+}
+
+func dqlFromStatement(data []byte) (string, error) {
+	var s astStatementJSON
+	if err := json.Unmarshal(data, &s); err != nil {
+		return "", fmt.Errorf("failed to unmarshal Statement: %w", err)
+	}
+
+	var key, op, val astLeafJSON
+	if err := json.Unmarshal(s.Key, &key); err != nil {
+		return "", fmt.Errorf("failed to unmarshal key: %w", err)
+	}
+	if err := json.Unmarshal(s.Operator, &op); err != nil {
+		return "", fmt.Errorf("failed to unmarshal operator: %w", err)
+	}
+	if err := json.Unmarshal(s.Value, &val); err != nil {
+		return "", fmt.Errorf("failed to unmarshal value: %w", err)
+	}
+
+	keyText := key.Value
+	if needsBacktickEscape(keyText) {
+		keyText = "`" + keyText + "`"
+	}
+	valText := renderValueText(val)
+
+	switch op.Value {
+	case "=":
+		return fmt.Sprintf("%s == %s", keyText, valText), nil
+	case "!=", "<", "<=", ">", ">=":
+		return fmt.Sprintf("%s %s %s", keyText, op.Value, valText), nil
+	case "in":
+		return fmt.Sprintf("in(%s, array(%s))", keyText, valText), nil
+	case "not in":
+		return fmt.Sprintf("not in(%s, array(%s))", keyText, valText), nil
+	default:
+		return "", fmt.Errorf("unsupported comparison operator %q", op.Value)
+	}
+}
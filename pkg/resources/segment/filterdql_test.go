@@ -0,0 +1,69 @@
+package segment
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestFilterToDQL(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  string
+	}{
+		{"equality", `status = "ERROR"`, `status == "ERROR"`},
+		{"numeric", `http.status_code >= 500`, `http.status_code >= 500`},
+		{"implicit and", `a = x b != y`, `a == "x" and b != "y"`},
+		{"or", `a = x OR b = y`, `a == "x" or b == "y"`},
+		{"nested", `a = x AND (b = y OR c = z)`, `a == "x" and (b == "y" or c == "z")`},
+		{"in", `k8s.namespace.name in prod`, `in(k8s.namespace.name, array("prod"))`},
+		{"not in", `k8s.namespace.name not in prod`, `not in(k8s.namespace.name, array("prod"))`},
+		{"backtick key", "`my field` = x", "`my field` == \"x\""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := FilterToDQL(tt.input)
+			if err != nil {
+				t.Fatalf("FilterToDQL() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("FilterToDQL() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFilterToDQL_FromAST(t *testing.T) {
+	ast, err := FilterToAST(`a = x OR b = y c = z`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err := FilterToDQL(ast)
+	if err != nil {
+		t.Fatalf("FilterToDQL() error = %v", err)
+	}
+	if got != `a == "x" or (b == "y" and c == "z")` {
+		t.Errorf("unexpected DQL: %q", got)
+	}
+}
+
+func TestFetchQueryForInclude(t *testing.T) {
+	query, ok, err := FetchQueryForInclude("logs", `status = ERROR`)
+	if err != nil || !ok {
+		t.Fatalf("FetchQueryForInclude() = %v, %v", ok, err)
+	}
+	if query != "fetch logs\n| filter status == \"ERROR\"" {
+		t.Errorf("unexpected query: %q", query)
+	}
+
+	for _, dataObject := range []string{AllDataObjects, "metrics", ""} {
+		if _, ok, err := FetchQueryForInclude(dataObject, `status = ERROR`); ok || err != nil {
+			t.Errorf("expected %q to be skipped, got ok=%v err=%v", dataObject, ok, err)
+		}
+	}
+
+	if _, _, err := FetchQueryForInclude("logs", `status ==`); err == nil || !strings.Contains(err.Error(), "unsupported") {
+		t.Errorf("expected parse error, got %v", err)
+	}
+}
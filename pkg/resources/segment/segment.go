@@ -45,6 +45,9 @@ type Include struct {
 	Filter     string `json:"filter"`
 }
 
+// AllDataObjects is the include data object that applies a filter to every data object.
+const AllDataObjects = "_all_data_object"
+
 // Variables holds the variable configuration for a segment.
 type Variables struct {
 	Type  string `json:"type"`  // Variable type, e.g. "query"
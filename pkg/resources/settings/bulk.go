@@ -0,0 +1,76 @@
+package settings
+
+import (
+	"sort"
+	"sync"
+)
+
+// DefaultConcurrency is the default number of parallel requests of bulk operations
+const DefaultConcurrency = 4
+
+// SchemaListError records a schema whose objects could not be listed
+type SchemaListError struct {
+	SchemaID string
+	Err      error
+}
+
+func (e SchemaListError) Error() string {
+	return e.SchemaID + ": " + e.Err.Error()
+}
+
+// ListAllObjects lists the settings objects of all schemas, optionally in one
+// scope, querying up to concurrency schemas in parallel. Objects are sorted by
+// schema ID. Schemas that cannot be listed (e.g. for lack of permissions) are
+// returned as errors alongside the objects of the other schemas; only a
+// failure to list the schemas themselves is returned as error.
+func (h *Handler) ListAllObjects(scope string, chunkSize int64, concurrency int) ([]SettingsObject, []SchemaListError, error) {
+	schemas, err := h.ListSchemas()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	lists := make([][]SettingsObject, len(schemas.Items))
+	errs := make([]error, len(schemas.Items))
+	ForEach(len(schemas.Items), concurrency, func(i int) {
+		list, err := h.ListObjects(schemas.Items[i].SchemaID, scope, chunkSize)
+		if err != nil {
+			errs[i] = err
+			return
+		}
+		lists[i] = list.Items
+	})
+
+	var objects []SettingsObject
+	var failed []SchemaListError
+	for i, schema := range schemas.Items {
+		if errs[i] != nil {
+			failed = append(failed, SchemaListError{SchemaID: schema.SchemaID, Err: errs[i]})
+			continue
+		}
+		objects = append(objects, lists[i]...)
+	}
+	sort.SliceStable(objects, func(i, j int) bool {
+		return objects[i].SchemaID < objects[j].SchemaID
+	})
+	return objects, failed, nil
+}
+
+// ForEach runs fn for every item index with up to concurrency calls in
+// parallel and waits for all of them.
+func ForEach(n, concurrency int, fn func(i int)) {
+	if concurrency < 1 {
+		concurrency = 1
+	}
+	sem := make(chan struct{}, concurrency)
+	var wg sync.WaitGroup
+	for i := 0; i < n; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			sem <- struct{}{}
+			defer func() { <-sem }()
+			fn(i)
+		}(i)
+	}
+	wg.Wait()
+}
@@ -0,0 +1,86 @@
+package settings
+
+import (
+	"encoding/json"
+	"net/http"
+	"sync/atomic"
+	"testing"
+)
+
+func TestListAllObjects(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/platform/classic/environment-api/v2/settings/schemas", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(SchemaList{
+			Items: []Schema{
+				{SchemaID: "builtin:z"},
+				{SchemaID: "builtin:denied"},
+				{SchemaID: "builtin:a"},
+			},
+			TotalCount: 3,
+		})
+	})
+	mux.HandleFunc("/platform/classic/environment-api/v2/settings/objects", func(w http.ResponseWriter, r *http.Request) {
+		schemaID := r.URL.Query().Get("schemaIds")
+		if scope := r.URL.Query().Get("scopes"); scope != "HOST-1" {
+			t.Errorf("expected scope HOST-1, got %q", scope)
+		}
+		if schemaID == "builtin:denied" {
+			w.WriteHeader(http.StatusForbidden)
+			w.Write([]byte(`{"error":{"code":403,"message":"forbidden"}}`))
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(SettingsObjectsList{
+			Items:      []SettingsObject{{ObjectID: schemaID + "-obj", SchemaID: schemaID, Scope: "HOST-1"}},
+			TotalCount: 1,
+		})
+	})
+	h, cleanup := newTestHandler(t, mux)
+	defer cleanup()
+
+	objects, failed, err := h.ListAllObjects("HOST-1", 0, 2)
+	if err != nil {
+		t.Fatalf("ListAllObjects() error = %v", err)
+	}
+	if len(objects) != 2 || objects[0].SchemaID != "builtin:a" || objects[1].SchemaID != "builtin:z" {
+		t.Errorf("unexpected objects: %+v", objects)
+	}
+	if len(failed) != 1 || failed[0].SchemaID != "builtin:denied" {
+		t.Errorf("unexpected failed schemas: %+v", failed)
+	}
+}
+
+func TestListAllObjects_SchemaListError(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/platform/classic/environment-api/v2/settings/schemas", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	})
+	h, cleanup := newTestHandler(t, mux)
+	defer cleanup()
+
+	if _, _, err := h.ListAllObjects("", 0, 2); err == nil {
+		t.Fatal("expected error when schemas cannot be listed")
+	}
+}
+
+func TestForEach_BoundsConcurrency(t *testing.T) {
+	var running, peak, calls int32
+	ForEach(20, 3, func(i int) {
+		n := atomic.AddInt32(&running, 1)
+		for {
+			p := atomic.LoadInt32(&peak)
+			if n <= p || atomic.CompareAndSwapInt32(&peak, p, n) {
+				break
+			}
+		}
+		atomic.AddInt32(&calls, 1)
+		atomic.AddInt32(&running, -1)
+	})
+	if calls != 20 {
+		t.Errorf("expected 20 calls, got %d", calls)
+	}
+	if peak > 3 {
+		t.Errorf("expected at most 3 parallel calls, got %d", peak)
+	}
+}
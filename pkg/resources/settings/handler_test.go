@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 
 	"github.com/dynatrace-oss/dtctl/pkg/client"
@@ -105,6 +106,37 @@ func TestGetSchema_Success(t *testing.T) {
 	}
 }
 
+func TestGetSchemaVersion(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/platform/classic/environment-api/v2/settings/schemas/builtin:alerting.profile", func(w http.ResponseWriter, r *http.Request) {
+		version := r.URL.Query().Get("schemaVersion")
+		if version == "0.9" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]any{
+			"schemaId": "builtin:alerting.profile",
+			"version":  version,
+		})
+	})
+	h, cleanup := newTestHandler(t, mux)
+	defer cleanup()
+
+	result, err := h.GetSchemaVersion("builtin:alerting.profile", "1.2")
+	if err != nil {
+		t.Fatalf("GetSchemaVersion() error = %v", err)
+	}
+	if result["version"] != "1.2" {
+		t.Errorf("expected version 1.2 to be requested, got %v", result["version"])
+	}
+
+	_, err = h.GetSchemaVersion("builtin:alerting.profile", "0.9")
+	if err == nil || !strings.Contains(err.Error(), "version 0.9 not found") {
+		t.Errorf("expected version not found error, got %v", err)
+	}
+}
+
 func TestGetSchema_NotFound(t *testing.T) {
 	mux := http.NewServeMux()
 	mux.HandleFunc("/platform/classic/environment-api/v2/settings/schemas/unknown", func(w http.ResponseWriter, r *http.Request) {
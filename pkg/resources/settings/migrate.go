@@ -0,0 +1,342 @@
+package settings
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// SchemaChange is a property difference between two versions of a schema
+type SchemaChange struct {
+	// Kind is one of "added", "removed", "renamed" or "changed"
+	Kind string `json:"kind"`
+	// Path is the property path in the new version (the old one for
+	// removed properties); properties of nested types are prefixed with the
+	// type name, e.g. "SeverityRule.severityLevel"
+	Path string `json:"path"`
+	// From is the old path of a renamed property
+	From   string `json:"from,omitempty"`
+	Detail string `json:"detail,omitempty"`
+}
+
+func (c SchemaChange) String() string {
+	s := c.Kind + " " + c.Path
+	if c.From != "" {
+		s = fmt.Sprintf("%s %s -> %s", c.Kind, c.From, c.Path)
+	}
+	if c.Detail != "" {
+		s += " (" + c.Detail + ")"
+	}
+	return s
+}
+
+// DiffSchemas compares two versions of a schema definition as returned by
+// GetSchema. It reports added, removed and changed properties of the schema
+// and of the nested types present in both versions.
+//
+// Renames cannot be told apart from a removal plus an addition for sure; a
+// removed and an added property are reported as renamed if they have the
+// same display name, or if they are the only removed and added properties of
+// the same type and nullability in an object.
+func DiffSchemas(oldSchema, newSchema map[string]any) []SchemaChange {
+	oldProps, _ := oldSchema["properties"].(map[string]any)
+	newProps, _ := newSchema["properties"].(map[string]any)
+	changes := diffProperties("", oldProps, newProps)
+
+	oldTypes, _ := oldSchema["types"].(map[string]any)
+	newTypes, _ := newSchema["types"].(map[string]any)
+	for _, name := range sortedKeys(newTypes) {
+		oldType, ok := oldTypes[name].(map[string]any)
+		if !ok {
+			continue
+		}
+		newType, _ := newTypes[name].(map[string]any)
+		oldProps, _ := oldType["properties"].(map[string]any)
+		newProps, _ := newType["properties"].(map[string]any)
+		changes = append(changes, diffProperties(name, oldProps, newProps)...)
+	}
+	return changes
+}
+
+// diffProperties compares the properties of one object
+func diffProperties(path string, oldProps, newProps map[string]any) []SchemaChange {
+	renames := detectRenames(oldProps, newProps)
+	renamedTo := map[string]bool{}
+	for _, to := range renames {
+		renamedTo[to] = true
+	}
+
+	var changes []SchemaChange
+	for _, name := range sortedKeys(oldProps) {
+		if _, ok := newProps[name]; ok {
+			continue
+		}
+		if to, ok := renames[name]; ok {
+			changes = append(changes, SchemaChange{Kind: "renamed", Path: joinPath(path, to), From: joinPath(path, name)})
+			continue
+		}
+		changes = append(changes, SchemaChange{Kind: "removed", Path: joinPath(path, name)})
+	}
+	for _, name := range sortedKeys(newProps) {
+		newDef, _ := newProps[name].(map[string]any)
+		oldDef, existed := oldProps[name].(map[string]any)
+		switch {
+		case renamedTo[name]:
+		case !existed:
+			detail := "optional"
+			if required(newDef) {
+				detail = "required"
+				if d, ok := newDef["default"]; ok {
+					detail += ", default " + formatValue(d)
+				}
+			}
+			changes = append(changes, SchemaChange{Kind: "added", Path: joinPath(path, name), Detail: detail})
+		case typeSignature(oldDef) != typeSignature(newDef):
+			changes = append(changes, SchemaChange{Kind: "changed", Path: joinPath(path, name),
+				Detail: fmt.Sprintf("type %s -> %s", typeSignature(oldDef), typeSignature(newDef))})
+		case required(oldDef) != required(newDef):
+			detail := "now optional"
+			if required(newDef) {
+				detail = "now required"
+			}
+			changes = append(changes, SchemaChange{Kind: "changed", Path: joinPath(path, name), Detail: detail})
+		}
+	}
+	return changes
+}
+
+// detectRenames maps removed to added property names (see DiffSchemas)
+func detectRenames(oldProps, newProps map[string]any) map[string]string {
+	var removed, added []string
+	for _, name := range sortedKeys(oldProps) {
+		if _, ok := newProps[name]; !ok {
+			removed = append(removed, name)
+		}
+	}
+	for _, name := range sortedKeys(newProps) {
+		if _, ok := oldProps[name]; !ok {
+			added = append(added, name)
+		}
+	}
+
+	renames := map[string]string{}
+	taken := map[string]bool{}
+	for _, from := range removed {
+		oldDef, _ := oldProps[from].(map[string]any)
+		oldName, _ := oldDef["displayName"].(string)
+		if oldName == "" {
+			continue
+		}
+		for _, to := range added {
+			newDef, _ := newProps[to].(map[string]any)
+			if newName, _ := newDef["displayName"].(string); !taken[to] && strings.EqualFold(oldName, newName) {
+				renames[from], taken[to] = to, true
+				break
+			}
+		}
+	}
+
+	// Otherwise pair up the only removed and added property of a type (and
+	// nullability)
+	bySignature := func(props map[string]any, names []string, done func(string) bool) map[string][]string {
+		m := map[string][]string{}
+		for _, name := range names {
+			if done(name) {
+				continue
+			}
+			def, _ := props[name].(map[string]any)
+			sig := fmt.Sprintf("%s/%t", typeSignature(def), required(def))
+			m[sig] = append(m[sig], name)
+		}
+		return m
+	}
+	removedBySig := bySignature(oldProps, removed, func(n string) bool { _, ok := renames[n]; return ok })
+	addedBySig := bySignature(newProps, added, func(n string) bool { return taken[n] })
+	for sig, from := range removedBySig {
+		if to := addedBySig[sig]; len(from) == 1 && len(to) == 1 {
+			renames[from[0]] = to[0]
+		}
+	}
+	return renames
+}
+
+// Migration is the result of migrating a settings value to a new schema version
+type Migration struct {
+	Value map[string]any
+	// Applied lists the automatic changes made to the value
+	Applied []string
+	// FollowUps lists the problems left for manual changes
+	FollowUps []ValidationError
+}
+
+// Changed reports whether the migration changed the value
+func (m *Migration) Changed() bool {
+	return len(m.Applied) > 0
+}
+
+// Migrate transforms a settings value written for the old version of a
+// schema to the new version. oldSchema may be nil if the old version is unknown, in
+// which case renames are not detected and properties not in the new schema
+// are kept (and reported as follow-ups) rather than dropped.
+//
+// Safe transformations are applied automatically: values of renamed
+// properties are moved, properties no longer in the schema are dropped and
+// missing required properties with a default get the default. Everything the
+// new version still rejects (as checked by Validate) is returned as follow-up.
+// value is not modified.
+func Migrate(oldSchema, newSchema map[string]any, value map[string]any) *Migration {
+	m := &migrator{oldSchema: oldSchema, newSchema: newSchema}
+	migrated, _ := deepCopy(value).(map[string]any)
+	if migrated == nil {
+		migrated = map[string]any{}
+	}
+	oldProps, _ := oldSchema["properties"].(map[string]any)
+	newProps, _ := newSchema["properties"].(map[string]any)
+	m.object("", oldProps, newProps, migrated)
+
+	return &Migration{Value: migrated, Applied: m.applied, FollowUps: Validate(newSchema, migrated)}
+}
+
+type migrator struct {
+	oldSchema, newSchema map[string]any
+	applied              []string
+}
+
+func (m *migrator) apply(format string, args ...any) {
+	m.applied = append(m.applied, fmt.Sprintf(format, args...))
+}
+
+// object migrates one JSON object; oldProps is nil if the old version is unknown
+func (m *migrator) object(path string, oldProps, newProps map[string]any, obj map[string]any) {
+	// Without the old version, an unknown property may be a renamed one whose
+	// value must not be lost; it is kept and Validate reports it instead.
+	if oldProps != nil {
+		renames := detectRenames(oldProps, newProps)
+		for _, from := range sortedKeys(renames) {
+			to := renames[from]
+			val, ok := obj[from]
+			if !ok {
+				continue
+			}
+			if _, exists := obj[to]; exists {
+				m.apply("removed %s (renamed to %s, which is already set)", joinPath(path, from), joinPath(path, to))
+			} else {
+				obj[to] = val
+				m.apply("renamed %s to %s", joinPath(path, from), joinPath(path, to))
+			}
+			delete(obj, from)
+		}
+
+		for _, name := range sortedKeys(obj) {
+			if _, ok := newProps[name]; !ok {
+				m.apply("removed %s (was %s)", joinPath(path, name), formatValue(obj[name]))
+				delete(obj, name)
+			}
+		}
+	}
+
+	for _, name := range sortedKeys(newProps) {
+		def, _ := newProps[name].(map[string]any)
+		if pre, ok := def["precondition"].(map[string]any); ok && !preconditionMet(pre, obj) {
+			continue
+		}
+		val, present := obj[name]
+		if !present || val == nil {
+			if d, ok := def["default"]; ok && required(def) {
+				obj[name] = deepCopy(d)
+				m.apply("added %s with default %s", joinPath(path, name), formatValue(d))
+			}
+			continue
+		}
+
+		// Recurse into nested types present in both versions
+		var oldDef map[string]any
+		if oldProps != nil {
+			oldDef, _ = oldProps[name].(map[string]any)
+		}
+		m.nested(joinPath(path, name), oldDef, def, val)
+	}
+}
+
+// nested migrates a value of a nested type, or the items of a list of them
+func (m *migrator) nested(path string, oldDef, newDef map[string]any, val any) {
+	if items, ok := val.([]any); ok {
+		oldItems, _ := oldDef["items"].(map[string]any)
+		newItems, _ := newDef["items"].(map[string]any)
+		for i, item := range items {
+			m.nested(fmt.Sprintf("%s[%d]", path, i), oldItems, newItems, item)
+		}
+		return
+	}
+	obj, ok := val.(map[string]any)
+	if !ok {
+		return
+	}
+	newProps := typeProperties(m.newSchema, newDef)
+	if newProps == nil {
+		return
+	}
+	m.object(path, typeProperties(m.oldSchema, oldDef), newProps, obj)
+}
+
+// typeProperties returns the properties of the type a property refers to
+func typeProperties(schema, def map[string]any) map[string]any {
+	typ, _ := def["type"].(map[string]any)
+	ref, _ := typ["$ref"].(string)
+	name, ok := strings.CutPrefix(ref, "#/types/")
+	if !ok {
+		return nil
+	}
+	types, _ := schema["types"].(map[string]any)
+	t, _ := types[name].(map[string]any)
+	props, _ := t["properties"].(map[string]any)
+	return props
+}
+
+// typeSignature renders the type of a property, e.g. "text", "list of #/types/Rule"
+func typeSignature(def map[string]any) string {
+	switch typ := def["type"].(type) {
+	case map[string]any:
+		return fmt.Sprint(typ["$ref"])
+	case string:
+		if typ == "list" || typ == "set" {
+			items, _ := def["items"].(map[string]any)
+			return typ + " of " + typeSignature(items)
+		}
+		return typ
+	}
+	return "unknown"
+}
+
+func required(def map[string]any) bool {
+	nullable, _ := def["nullable"].(bool)
+	return !nullable
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// deepCopy copies a JSON value
+func deepCopy(v any) any {
+	switch c := v.(type) {
+	case map[string]any:
+		out := make(map[string]any, len(c))
+		for k, val := range c {
+			out[k] = deepCopy(val)
+		}
+		return out
+	case []any:
+		out := make([]any, len(c))
+		for i, val := range c {
+			out[i] = deepCopy(val)
+		}
+		return out
+	}
+	return v
+}
@@ -0,0 +1,124 @@
+package settings
+
+import (
+	"reflect"
+	"testing"
+)
+
+const migrateOldSchema = `{
+  "schemaId": "builtin:test",
+  "version": "1.0",
+  "properties": {
+    "enabled": {"type": "boolean", "displayName": "Enabled"},
+    "hostName": {"type": "text", "displayName": "Host"},
+    "legacy": {"type": "text", "nullable": true},
+    "rules": {"type": "list", "items": {"type": {"$ref": "#/types/Rule"}}}
+  },
+  "types": {
+    "Rule": {"properties": {"pattern": {"type": "text"}}}
+  }
+}`
+
+const migrateNewSchema = `{
+  "schemaId": "builtin:test",
+  "version": "2.0",
+  "properties": {
+    "enabled": {"type": "boolean", "displayName": "Enabled"},
+    "host": {"type": "text", "displayName": "Host"},
+    "mode": {"type": "text", "default": "auto"},
+    "owner": {"type": "text"},
+    "timeout": {"type": "integer", "nullable": true},
+    "rules": {"type": "list", "items": {"type": {"$ref": "#/types/Rule"}}}
+  },
+  "types": {
+    "Rule": {"properties": {"expression": {"type": "text"}, "caseSensitive": {"type": "boolean", "default": false}}}
+  }
+}`
+
+func TestDiffSchemas(t *testing.T) {
+	changes := DiffSchemas(mustJSON(t, migrateOldSchema), mustJSON(t, migrateNewSchema))
+
+	var got []string
+	for _, c := range changes {
+		got = append(got, c.String())
+	}
+	want := []string{
+		"renamed hostName -> host",
+		"removed legacy",
+		"added mode (required, default \"auto\")",
+		"added owner (required)",
+		"added timeout (optional)",
+		"renamed Rule.pattern -> Rule.expression",
+		"added Rule.caseSensitive (required, default false)",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("DiffSchemas() =\n%q\nwant\n%q", got, want)
+	}
+}
+
+func TestMigrate(t *testing.T) {
+	value := map[string]any{
+		"enabled":  true,
+		"hostName": "web-1",
+		"legacy":   "x",
+		"rules":    []any{map[string]any{"pattern": "a*"}},
+	}
+	m := Migrate(mustJSON(t, migrateOldSchema), mustJSON(t, migrateNewSchema), value)
+
+	want := map[string]any{
+		"enabled": true,
+		"host":    "web-1",
+		"mode":    "auto",
+		"rules":   []any{map[string]any{"expression": "a*", "caseSensitive": false}},
+	}
+	if !reflect.DeepEqual(m.Value, want) {
+		t.Errorf("Migrate() value = %v, want %v", m.Value, want)
+	}
+	wantApplied := []string{
+		"renamed hostName to host",
+		"removed legacy (was \"x\")",
+		"added mode with default \"auto\"",
+		"renamed rules[0].pattern to rules[0].expression",
+		"added rules[0].caseSensitive with default false",
+	}
+	if !reflect.DeepEqual(m.Applied, wantApplied) {
+		t.Errorf("Migrate() applied =\n%q\nwant\n%q", m.Applied, wantApplied)
+	}
+	if len(m.FollowUps) != 1 || m.FollowUps[0].Path != "owner" {
+		t.Errorf("Migrate() follow-ups = %v, want missing owner", m.FollowUps)
+	}
+	if _, ok := value["hostName"]; !ok {
+		t.Error("Migrate() must not modify the input value")
+	}
+}
+
+func TestMigrate_UnknownOldVersion(t *testing.T) {
+	value := map[string]any{"enabled": true, "hostName": "web-1", "owner": "team", "rules": []any{}}
+	m := Migrate(nil, mustJSON(t, migrateNewSchema), value)
+
+	if _, ok := m.Value["host"]; ok {
+		t.Error("renames must not be detected without the old schema")
+	}
+	if m.Value["hostName"] != "web-1" {
+		t.Error("unknown property must be kept without the old schema")
+	}
+	if want := []string{"added mode with default \"auto\""}; !reflect.DeepEqual(m.Applied, want) {
+		t.Errorf("Migrate() applied = %q, want %q", m.Applied, want)
+	}
+	var paths []string
+	for _, f := range m.FollowUps {
+		paths = append(paths, f.Path)
+	}
+	if want := []string{"host", "hostName"}; !reflect.DeepEqual(paths, want) {
+		t.Errorf("Migrate() follow-ups = %v, want %v", m.FollowUps, want)
+	}
+}
+
+func TestMigrate_SameVersionUnchanged(t *testing.T) {
+	schema := mustJSON(t, migrateNewSchema)
+	value := map[string]any{"enabled": true, "host": "h", "mode": "manual", "owner": "team", "rules": []any{}}
+	m := Migrate(schema, schema, value)
+	if m.Changed() || len(m.FollowUps) != 0 {
+		t.Errorf("Migrate() applied=%v follow-ups=%v, want no changes", m.Applied, m.FollowUps)
+	}
+}
@@ -0,0 +1,343 @@
+package settings
+
+import (
+	"bytes"
+	"fmt"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Scaffold renders a commented YAML skeleton of a settings object for a schema
+// definition as returned by GetSchema. The skeleton has the apply format
+// (schemaId, scope, value); defaults are filled in, enum values are listed,
+// required properties are marked and nested types are expanded. Lists of
+// nested types get one example item.
+//
+// If scope is empty, the first scope of the schema is used.
+func Scaffold(schema map[string]any, scope string) ([]byte, error) {
+	schemaID, _ := schema["schemaId"].(string)
+	if schemaID == "" {
+		return nil, fmt.Errorf("schema definition has no schemaId")
+	}
+
+	var scopes []string
+	if raw, ok := schema["scopes"].([]any); ok {
+		for _, s := range raw {
+			scopes = append(scopes, fmt.Sprint(s))
+		}
+	}
+	if scope == "" {
+		scope = "environment"
+		if len(scopes) > 0 {
+			scope = scopes[0]
+		}
+	}
+
+	s := &scaffolder{schema: schema, expanding: map[string]bool{}}
+	properties, _ := schema["properties"].(map[string]any)
+
+	header := schemaID
+	if version, _ := schema["version"].(string); version != "" {
+		header += " (version " + version + ")"
+	}
+	if name, _ := schema["displayName"].(string); name != "" {
+		header += " - " + name
+	}
+	header += "\nFill in the values, then run: dtctl apply -f <file>"
+
+	scopeNode := scalarNode(scope)
+	if len(scopes) > 1 {
+		scopeNode.LineComment = "scopes: " + strings.Join(scopes, ", ")
+	}
+
+	root := &yaml.Node{Kind: yaml.MappingNode}
+	root.HeadComment = header
+	root.Content = append(root.Content,
+		scalarNode("schemaId"), scalarNode(schemaID),
+		scalarNode("scope"), scopeNode,
+	)
+	if version, _ := schema["version"].(string); version != "" {
+		root.Content = append(root.Content, scalarNode("schemaVersion"), scalarNode(version))
+	}
+	root.Content = append(root.Content, scalarNode("value"), s.object(properties))
+
+	var buf bytes.Buffer
+	enc := yaml.NewEncoder(&buf)
+	enc.SetIndent(2)
+	if err := enc.Encode(&yaml.Node{Kind: yaml.DocumentNode, Content: []*yaml.Node{root}}); err != nil {
+		return nil, fmt.Errorf("failed to render scaffold: %w", err)
+	}
+	if err := enc.Close(); err != nil {
+		return nil, fmt.Errorf("failed to render scaffold: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+type scaffolder struct {
+	schema map[string]any
+	// expanding guards against recursive type references
+	expanding map[string]bool
+}
+
+// object renders a mapping with one commented entry per property
+func (s *scaffolder) object(properties map[string]any) *yaml.Node {
+	node := &yaml.Node{Kind: yaml.MappingNode}
+	names := make([]string, 0, len(properties))
+	for name := range properties {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		def, _ := properties[name].(map[string]any)
+		key := scalarNode(name)
+		key.HeadComment = s.comment(def)
+		node.Content = append(node.Content, key, s.value(def))
+	}
+	return node
+}
+
+// value renders the placeholder value of a property
+func (s *scaffolder) value(def map[string]any) *yaml.Node {
+	if d, ok := def["default"]; ok {
+		return valueNode(d)
+	}
+	if nullable, _ := def["nullable"].(bool); nullable {
+		if _, isRef := def["type"].(map[string]any); !isRef {
+			return &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!null", Value: "null"}
+		}
+	}
+
+	switch typ := def["type"].(type) {
+	case map[string]any:
+		ref, _ := typ["$ref"].(string)
+		kind, name, _ := strings.Cut(strings.TrimPrefix(ref, "#/"), "/")
+		refDef, _ := s.definitions(kind)[name].(map[string]any)
+		switch kind {
+		case "enums":
+			if values := enumValues(refDef); len(values) > 0 {
+				return valueNode(values[0])
+			}
+		case "types":
+			if s.expanding[name] {
+				return &yaml.Node{Kind: yaml.MappingNode, Style: yaml.FlowStyle}
+			}
+			s.expanding[name] = true
+			defer delete(s.expanding, name)
+			properties, _ := refDef["properties"].(map[string]any)
+			return s.object(properties)
+		}
+	case string:
+		switch typ {
+		case "boolean":
+			return valueNode(false)
+		case "integer":
+			if min, ok := rangeMinimum(def); ok {
+				return valueNode(min)
+			}
+			return valueNode(0)
+		case "float":
+			if min, ok := rangeMinimum(def); ok {
+				return valueNode(min)
+			}
+			return &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!float", Value: "0.0"}
+		case "list", "set":
+			list := &yaml.Node{Kind: yaml.SequenceNode}
+			items, _ := def["items"].(map[string]any)
+			if itemType, ok := items["type"].(map[string]any); ok && strings.HasPrefix(fmt.Sprint(itemType["$ref"]), "#/types/") {
+				list.Content = append(list.Content, s.value(items))
+			} else {
+				list.Style = yaml.FlowStyle
+			}
+			return list
+		}
+	}
+	return &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: "", Style: yaml.DoubleQuotedStyle}
+}
+
+// comment describes a property: display name, required, type, allowed
+// values, constraints and precondition
+func (s *scaffolder) comment(def map[string]any) string {
+	var parts []string
+	if name, _ := def["displayName"].(string); name != "" {
+		parts = append(parts, name)
+	}
+
+	var info []string
+	if nullable, _ := def["nullable"].(bool); !nullable {
+		if _, hasPrecondition := def["precondition"]; hasPrecondition {
+			info = append(info, "required when used")
+		} else {
+			info = append(info, "required")
+		}
+	} else {
+		info = append(info, "optional")
+	}
+	info = append(info, s.typeName(def))
+	if c := constraintSummary(def["constraints"]); c != "" {
+		info = append(info, c)
+	}
+	parts = append(parts, "("+strings.Join(info, ", ")+")")
+	line := strings.Join(parts, " ")
+
+	var lines []string
+	lines = append(lines, line)
+	if desc, _ := def["description"].(string); desc != "" {
+		lines = append(lines, firstLine(desc))
+	}
+	if values := s.enumValuesOf(def); len(values) > 0 {
+		strs := make([]string, len(values))
+		for i, v := range values {
+			strs[i] = fmt.Sprint(v)
+		}
+		lines = append(lines, "One of: "+strings.Join(strs, ", "))
+	}
+	if pre, ok := def["precondition"].(map[string]any); ok {
+		lines = append(lines, "Only used when "+describePrecondition(pre))
+	}
+	return strings.Join(lines, "\n")
+}
+
+func (s *scaffolder) typeName(def map[string]any) string {
+	switch typ := def["type"].(type) {
+	case map[string]any:
+		ref, _ := typ["$ref"].(string)
+		_, name, _ := strings.Cut(strings.TrimPrefix(ref, "#/"), "/")
+		return name
+	case string:
+		if typ == "list" || typ == "set" {
+			items, _ := def["items"].(map[string]any)
+			return typ + " of " + s.typeName(items)
+		}
+		return typ
+	}
+	return "value"
+}
+
+func (s *scaffolder) enumValuesOf(def map[string]any) []any {
+	if typ, ok := def["type"].(map[string]any); ok {
+		ref, _ := typ["$ref"].(string)
+		if name, ok := strings.CutPrefix(ref, "#/enums/"); ok {
+			enum, _ := s.definitions("enums")[name].(map[string]any)
+			return enumValues(enum)
+		}
+	}
+	if items, ok := def["items"].(map[string]any); ok {
+		return s.enumValuesOf(items)
+	}
+	return nil
+}
+
+func (s *scaffolder) definitions(kind string) map[string]any {
+	defs, _ := s.schema[kind].(map[string]any)
+	return defs
+}
+
+func enumValues(enum map[string]any) []any {
+	items, _ := enum["items"].([]any)
+	values := make([]any, 0, len(items))
+	for _, item := range items {
+		if m, ok := item.(map[string]any); ok {
+			values = append(values, m["value"])
+		}
+	}
+	return values
+}
+
+// constraintSummary renders the LENGTH, RANGE and PATTERN constraints of a property
+func constraintSummary(raw any) string {
+	list, _ := raw.([]any)
+	var parts []string
+	for _, c := range list {
+		def, _ := c.(map[string]any)
+		switch def["type"] {
+		case "LENGTH":
+			parts = append(parts, "length "+bounds(def["minLength"], def["maxLength"]))
+		case "RANGE":
+			parts = append(parts, "range "+bounds(def["minimum"], def["maximum"]))
+		case "PATTERN":
+			parts = append(parts, fmt.Sprintf("pattern %v", def["pattern"]))
+		case "NOT_BLANK":
+			parts = append(parts, "not blank")
+		}
+	}
+	return strings.Join(parts, ", ")
+}
+
+func bounds(min, max any) string {
+	switch {
+	case min != nil && max != nil:
+		return fmt.Sprintf("%v-%v", min, max)
+	case min != nil:
+		return fmt.Sprintf(">= %v", min)
+	case max != nil:
+		return fmt.Sprintf("<= %v", max)
+	}
+	return "any"
+}
+
+func rangeMinimum(def map[string]any) (float64, bool) {
+	list, _ := def["constraints"].([]any)
+	for _, c := range list {
+		m, _ := c.(map[string]any)
+		if m["type"] == "RANGE" {
+			if min, ok := m["minimum"].(float64); ok && min > 0 {
+				return min, true
+			}
+		}
+	}
+	return 0, false
+}
+
+// describePrecondition renders a precondition as a short expression
+func describePrecondition(pre map[string]any) string {
+	prop, _ := pre["property"].(string)
+	switch pre["type"] {
+	case "EQUALS":
+		return fmt.Sprintf("%s == %v", prop, pre["expectedValue"])
+	case "NOT_EQUALS":
+		return fmt.Sprintf("%s != %v", prop, pre["expectedValue"])
+	case "IN":
+		return fmt.Sprintf("%s in %v", prop, pre["expectedValues"])
+	case "NOT_IN":
+		return fmt.Sprintf("%s not in %v", prop, pre["expectedValues"])
+	case "NULL":
+		return prop + " is not set"
+	case "NOT_NULL":
+		return prop + " is set"
+	case "REGEX_MATCH":
+		return fmt.Sprintf("%s matches %v", prop, pre["pattern"])
+	case "AND", "OR":
+		nested, _ := pre["preconditions"].([]any)
+		parts := make([]string, 0, len(nested))
+		for _, n := range nested {
+			m, _ := n.(map[string]any)
+			parts = append(parts, describePrecondition(m))
+		}
+		return "(" + strings.Join(parts, " "+strings.ToLower(fmt.Sprint(pre["type"]))+" ") + ")"
+	case "NOT":
+		nested, _ := pre["precondition"].(map[string]any)
+		return "not " + describePrecondition(nested)
+	}
+	return fmt.Sprint(pre["type"])
+}
+
+func firstLine(s string) string {
+	line, _, _ := strings.Cut(strings.TrimSpace(s), "\n")
+	return line
+}
+
+func scalarNode(value string) *yaml.Node {
+	return &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: value}
+}
+
+// valueNode converts a JSON value (e.g. a schema default) to a YAML node
+func valueNode(v any) *yaml.Node {
+	node := &yaml.Node{}
+	if err := node.Encode(v); err != nil {
+		return scalarNode(fmt.Sprint(v))
+	}
+	return node
+}
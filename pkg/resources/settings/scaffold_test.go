@@ -0,0 +1,82 @@
+package settings
+
+import (
+	"strings"
+	"testing"
+
+	"gopkg.in/yaml.v3"
+)
+
+func TestScaffold(t *testing.T) {
+	schema := mustJSON(t, testSchema)
+	schema["scopes"] = []any{"HOST", "environment"}
+	schema["types"].(map[string]any)["Rule"].(map[string]any)["properties"].(map[string]any)["children"] = map[string]any{
+		"type":  "list",
+		"items": map[string]any{"type": map[string]any{"$ref": "#/types/Rule"}},
+	}
+
+	out, err := Scaffold(schema, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	text := string(out)
+	for _, want := range []string{
+		"# builtin:test.pipelines (version 1.2.0)",
+		"scope: HOST # scopes: HOST, environment",
+		"# (required, Mode)\n  # One of: ALL, MATCH\n  mode: ALL",
+		"# (required, text, length 1-10)\n  name: \"\"",
+		"# Only used when mode == MATCH",
+		"port: 1",
+		"rules:\n    - # (required, list of Rule)\n      children:\n        - {}\n      # (required, text)\n      id: \"\"",
+	} {
+		if !strings.Contains(text, want) {
+			t.Errorf("scaffold does not contain %q:\n%s", want, text)
+		}
+	}
+
+	// The skeleton parses back to an apply-format settings object.
+	var obj struct {
+		SchemaID string         `yaml:"schemaId"`
+		Scope    string         `yaml:"scope"`
+		Value    map[string]any `yaml:"value"`
+	}
+	if err := yaml.Unmarshal(out, &obj); err != nil {
+		t.Fatal(err)
+	}
+	if obj.SchemaID != "builtin:test.pipelines" || obj.Scope != "HOST" || obj.Value["enabled"] != false {
+		t.Errorf("parsed scaffold = %+v", obj)
+	}
+	rules, _ := obj.Value["rules"].([]any)
+	if len(rules) != 1 {
+		t.Fatalf("rules = %v, want one example item", obj.Value["rules"])
+	}
+	// Recursive types are not expanded again
+	children, _ := rules[0].(map[string]any)["children"].([]any)
+	if len(children) != 1 || len(children[0].(map[string]any)) != 0 {
+		t.Errorf("children = %v, want one empty item", children)
+	}
+}
+
+func TestScaffold_DefaultsAndScope(t *testing.T) {
+	schema := map[string]any{
+		"schemaId": "builtin:x",
+		"properties": map[string]any{
+			"threshold": map[string]any{"type": "float", "default": 2.5},
+			"tags":      map[string]any{"type": "set", "items": map[string]any{"type": "text"}},
+		},
+	}
+	out, err := Scaffold(schema, "environment")
+	if err != nil {
+		t.Fatal(err)
+	}
+	text := string(out)
+	for _, want := range []string{"scope: environment\n", "threshold: 2.5", "tags: []"} {
+		if !strings.Contains(text, want) {
+			t.Errorf("scaffold does not contain %q:\n%s", want, text)
+		}
+	}
+
+	if _, err := Scaffold(map[string]any{}, ""); err == nil {
+		t.Error("expected error for a schema without schemaId")
+	}
+}
@@ -0,0 +1,160 @@
+package settings
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// SchemaCache stores settings schema definitions on disk, one file per schema
+// version, so that settings values can be validated without network access.
+//
+// Layout: <Dir>/<escaped schema ID>/<version>.json
+type SchemaCache struct {
+	Dir string
+}
+
+// NewSchemaCache creates a schema cache rooted at dir
+func NewSchemaCache(dir string) *SchemaCache {
+	return &SchemaCache{Dir: dir}
+}
+
+// WithSchemaCache makes GetSchema store every fetched schema in cache
+func (h *Handler) WithSchemaCache(cache *SchemaCache) *Handler {
+	h.cache = cache
+	return h
+}
+
+// Store writes a schema definition as returned by GetSchema to the cache
+func (c *SchemaCache) Store(schema map[string]any) error {
+	schemaID, _ := schema["schemaId"].(string)
+	version, _ := schema["version"].(string)
+	if schemaID == "" || version == "" {
+		return fmt.Errorf("schema definition has no schemaId or version")
+	}
+
+	data, err := json.Marshal(schema)
+	if err != nil {
+		return fmt.Errorf("failed to encode schema %q: %w", schemaID, err)
+	}
+	dir := c.schemaDir(schemaID)
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return fmt.Errorf("failed to create schema cache directory: %w", err)
+	}
+	tmp, err := os.CreateTemp(dir, ".schema-*")
+	if err != nil {
+		return fmt.Errorf("failed to write schema cache: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return fmt.Errorf("failed to write schema cache: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to write schema cache: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), filepath.Join(dir, url.PathEscape(version)+".json")); err != nil {
+		return fmt.Errorf("failed to write schema cache: %w", err)
+	}
+	return nil
+}
+
+// Versions returns the cached versions of a schema, newest first
+func (c *SchemaCache) Versions(schemaID string) ([]string, error) {
+	entries, err := os.ReadDir(c.schemaDir(schemaID))
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read schema cache: %w", err)
+	}
+
+	var versions []string
+	for _, e := range entries {
+		name := e.Name()
+		if e.IsDir() || strings.HasPrefix(name, ".") || !strings.HasSuffix(name, ".json") {
+			continue
+		}
+		if v, err := url.PathUnescape(strings.TrimSuffix(name, ".json")); err == nil {
+			versions = append(versions, v)
+		}
+	}
+	sort.Slice(versions, func(i, j int) bool {
+		return compareSchemaVersion(versions[i], versions[j]) > 0
+	})
+	return versions, nil
+}
+
+// Load returns a cached schema definition. An empty version loads the newest
+// cached version. Returns ErrSchemaNotCached if the schema (version) is not
+// in the cache.
+func (c *SchemaCache) Load(schemaID, version string) (map[string]any, error) {
+	if version == "" {
+		versions, err := c.Versions(schemaID)
+		if err != nil {
+			return nil, err
+		}
+		if len(versions) == 0 {
+			return nil, &SchemaNotCachedError{SchemaID: schemaID}
+		}
+		version = versions[0]
+	}
+
+	data, err := os.ReadFile(filepath.Join(c.schemaDir(schemaID), url.PathEscape(version)+".json"))
+	if os.IsNotExist(err) {
+		return nil, &SchemaNotCachedError{SchemaID: schemaID, Version: version}
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read schema cache: %w", err)
+	}
+
+	var schema map[string]any
+	if err := json.Unmarshal(data, &schema); err != nil {
+		return nil, fmt.Errorf("cached schema %q (%s) is corrupt: %w", schemaID, version, err)
+	}
+	return schema, nil
+}
+
+func (c *SchemaCache) schemaDir(schemaID string) string {
+	return filepath.Join(c.Dir, url.PathEscape(schemaID))
+}
+
+// SchemaNotCachedError is returned by SchemaCache.Load when a schema is not cached
+type SchemaNotCachedError struct {
+	SchemaID string
+	Version  string
+}
+
+func (e *SchemaNotCachedError) Error() string {
+	if e.Version != "" {
+		return fmt.Sprintf("schema %q version %s is not cached", e.SchemaID, e.Version)
+	}
+	return fmt.Sprintf("schema %q is not cached", e.SchemaID)
+}
+
+// compareSchemaVersion compares dotted numeric versions such as "1.12.3"
+func compareSchemaVersion(a, b string) int {
+	aParts := strings.Split(a, ".")
+	bParts := strings.Split(b, ".")
+	for i := 0; i < len(aParts) || i < len(bParts); i++ {
+		var aVal, bVal int
+		if i < len(aParts) {
+			aVal, _ = strconv.Atoi(aParts[i])
+		}
+		if i < len(bParts) {
+			bVal, _ = strconv.Atoi(bParts[i])
+		}
+		if aVal != bVal {
+			if aVal > bVal {
+				return 1
+			}
+			return -1
+		}
+	}
+	return strings.Compare(a, b)
+}
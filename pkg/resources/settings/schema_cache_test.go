@@ -0,0 +1,63 @@
+package settings
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+)
+
+func TestSchemaCache_StoreLoad(t *testing.T) {
+	cache := NewSchemaCache(t.TempDir())
+	for _, version := range []string{"1.9.0", "1.10.2", "1.2.0"} {
+		if err := cache.Store(map[string]any{"schemaId": "builtin:a/b", "version": version}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	versions, err := cache.Versions("builtin:a/b")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(versions) != 3 || versions[0] != "1.10.2" || versions[2] != "1.2.0" {
+		t.Errorf("Versions() = %v, want newest first", versions)
+	}
+
+	latest, err := cache.Load("builtin:a/b", "")
+	if err != nil || latest["version"] != "1.10.2" {
+		t.Errorf("Load(latest) = %v, %v", latest, err)
+	}
+	exact, err := cache.Load("builtin:a/b", "1.9.0")
+	if err != nil || exact["version"] != "1.9.0" {
+		t.Errorf("Load(1.9.0) = %v, %v", exact, err)
+	}
+
+	var notCached *SchemaNotCachedError
+	if _, err := cache.Load("builtin:a/b", "2.0.0"); !errors.As(err, &notCached) || notCached.Version != "2.0.0" {
+		t.Errorf("Load(2.0.0) error = %v, want SchemaNotCachedError", err)
+	}
+	if _, err := cache.Load("builtin:other", ""); !errors.As(err, &notCached) {
+		t.Errorf("Load(other) error = %v, want SchemaNotCachedError", err)
+	}
+
+	if err := cache.Store(map[string]any{"schemaId": "builtin:x"}); err == nil {
+		t.Error("expected error storing a schema without version")
+	}
+}
+
+func TestGetSchema_StoresInCache(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/platform/classic/environment-api/v2/settings/schemas/builtin:test", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"schemaId":"builtin:test","version":"3.1.4","properties":{}}`))
+	})
+	h, cleanup := newTestHandler(t, mux)
+	defer cleanup()
+
+	cache := NewSchemaCache(t.TempDir())
+	if _, err := h.WithSchemaCache(cache).GetSchema("builtin:test"); err != nil {
+		t.Fatal(err)
+	}
+	if schema, err := cache.Load("builtin:test", "3.1.4"); err != nil || schema["schemaId"] != "builtin:test" {
+		t.Errorf("cached schema = %v, %v", schema, err)
+	}
+}
@@ -0,0 +1,293 @@
+package settings
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Selector matches settings objects by a field of the object, e.g.
+// "value.enabled==false", "scope=~^HOST-" or "value.port>=8000".
+//
+// The field is a path into the object as returned by the API (objectId,
+// schemaId, scope, summary, value, ...), with dots between properties and
+// [n] for list items: "value.rules[0].name". The literal is parsed as a YAML
+// scalar, so true, 42, null and "quoted strings" have their JSON type.
+type Selector struct {
+	Path     []string
+	Operator string
+	Value    any
+	regex    *regexp.Regexp
+}
+
+// selectorOperators in matching order: two-character operators first
+var selectorOperators = []string{"==", "!=", "=~", "!~", ">=", "<=", ">", "<"}
+
+// ParseSelector parses a selector expression "<path><op><literal>" with op one
+// of ==, !=, =~ (regular expression), !~, >, >=, <, <=.
+func ParseSelector(expr string) (*Selector, error) {
+	for i := range expr {
+		for _, op := range selectorOperators {
+			if !strings.HasPrefix(expr[i:], op) {
+				continue
+			}
+			path, err := parsePath(strings.TrimSpace(expr[:i]))
+			if err != nil {
+				return nil, fmt.Errorf("invalid selector %q: %w", expr, err)
+			}
+			literal := strings.TrimSpace(expr[i+len(op):])
+			s := &Selector{Path: path, Operator: op}
+			if op == "=~" || op == "!~" {
+				if s.regex, err = regexp.Compile(literal); err != nil {
+					return nil, fmt.Errorf("invalid selector %q: %w", expr, err)
+				}
+				s.Value = literal
+				return s, nil
+			}
+			if s.Value, err = parseLiteral(literal); err != nil {
+				return nil, fmt.Errorf("invalid selector %q: %w", expr, err)
+			}
+			return s, nil
+		}
+	}
+	return nil, fmt.Errorf("invalid selector %q: expected <path><op><value> with op one of %s", expr, strings.Join(selectorOperators, ", "))
+}
+
+// Match reports whether a settings object matches the selector. obj is the
+// object in its JSON form (see ObjectMap).
+func (s *Selector) Match(obj map[string]any) bool {
+	val, found := lookupPath(obj, s.Path)
+	switch s.Operator {
+	case "==":
+		return reflect.DeepEqual(val, s.Value)
+	case "!=":
+		return !reflect.DeepEqual(val, s.Value)
+	case "=~", "!~":
+		str, isStr := val.(string)
+		if !isStr && found && val != nil {
+			str = fmt.Sprint(val)
+		}
+		return s.regex.MatchString(str) == (s.Operator == "=~")
+	}
+
+	a, aok := val.(float64)
+	b, bok := s.Value.(float64)
+	if !aok || !bok {
+		return false
+	}
+	switch s.Operator {
+	case ">":
+		return a > b
+	case ">=":
+		return a >= b
+	case "<":
+		return a < b
+	case "<=":
+		return a <= b
+	}
+	return false
+}
+
+// Assignment sets a property of a settings value, e.g. "value.enabled=true".
+type Assignment struct {
+	Path  []string
+	Value any
+}
+
+// ParseAssignment parses "<path>=<literal>". The path must start with
+// "value", since only the value of a settings object can be changed; the
+// literal is parsed like a selector literal.
+func ParseAssignment(expr string) (*Assignment, error) {
+	pathStr, literal, ok := strings.Cut(expr, "=")
+	if !ok {
+		return nil, fmt.Errorf("invalid assignment %q: expected <path>=<value>", expr)
+	}
+	path, err := parsePath(strings.TrimSpace(pathStr))
+	if err != nil {
+		return nil, fmt.Errorf("invalid assignment %q: %w", expr, err)
+	}
+	if path[0] != "value" || len(path) < 2 {
+		return nil, fmt.Errorf("invalid assignment %q: only properties below 'value' can be set", expr)
+	}
+	value, err := parseLiteral(strings.TrimSpace(literal))
+	if err != nil {
+		return nil, fmt.Errorf("invalid assignment %q: %w", expr, err)
+	}
+	return &Assignment{Path: path, Value: value}, nil
+}
+
+// String renders the assignment path, e.g. "value.rules[0].enabled"
+func (a *Assignment) String() string {
+	return formatPath(a.Path)
+}
+
+// Apply sets the property in obj, creating missing objects along the path.
+// It returns the previous value and whether the object changed.
+func (a *Assignment) Apply(obj map[string]any) (any, bool, error) {
+	var cur any = obj
+	for i, seg := range a.Path[:len(a.Path)-1] {
+		next, err := child(cur, seg, a.Path[i+1])
+		if err != nil {
+			return nil, false, fmt.Errorf("%s: %w", formatPath(a.Path[:i+1]), err)
+		}
+		cur = next
+	}
+
+	last := a.Path[len(a.Path)-1]
+	switch c := cur.(type) {
+	case map[string]any:
+		old, had := c[last]
+		if had && reflect.DeepEqual(old, a.Value) {
+			return old, false, nil
+		}
+		c[last] = a.Value
+		return old, true, nil
+	case []any:
+		idx, err := index(last, len(c))
+		if err != nil {
+			return nil, false, fmt.Errorf("%s: %w", formatPath(a.Path), err)
+		}
+		old := c[idx]
+		if reflect.DeepEqual(old, a.Value) {
+			return old, false, nil
+		}
+		c[idx] = a.Value
+		return old, true, nil
+	}
+	return nil, false, fmt.Errorf("%s: not an object or list", formatPath(a.Path[:len(a.Path)-1]))
+}
+
+// child returns the element seg of cur, creating an empty object for a
+// missing property (nextSeg tells whether a list index follows)
+func child(cur any, seg, nextSeg string) (any, error) {
+	switch c := cur.(type) {
+	case map[string]any:
+		if v, ok := c[seg]; ok && v != nil {
+			return v, nil
+		}
+		if isIndex(nextSeg) {
+			return nil, fmt.Errorf("list does not exist")
+		}
+		m := map[string]any{}
+		c[seg] = m
+		return m, nil
+	case []any:
+		idx, err := index(seg, len(c))
+		if err != nil {
+			return nil, err
+		}
+		return c[idx], nil
+	}
+	return nil, fmt.Errorf("not an object or list")
+}
+
+// ObjectMap returns the JSON form of a settings object, as matched by selectors
+func ObjectMap(obj SettingsObject) map[string]any {
+	data, _ := json.Marshal(obj)
+	var m map[string]any
+	_ = json.Unmarshal(data, &m)
+	return m
+}
+
+// lookupPath resolves a path in a JSON value
+func lookupPath(v any, path []string) (any, bool) {
+	cur := v
+	for _, seg := range path {
+		switch c := cur.(type) {
+		case map[string]any:
+			next, ok := c[seg]
+			if !ok {
+				return nil, false
+			}
+			cur = next
+		case []any:
+			idx, err := index(seg, len(c))
+			if err != nil {
+				return nil, false
+			}
+			cur = c[idx]
+		default:
+			return nil, false
+		}
+	}
+	return cur, true
+}
+
+// parsePath splits "value.rules[0].name" into ["value", "rules", "[0]", "name"]
+func parsePath(s string) ([]string, error) {
+	if s == "" {
+		return nil, fmt.Errorf("empty path")
+	}
+	var path []string
+	for _, part := range strings.Split(s, ".") {
+		name, rest, _ := strings.Cut(part, "[")
+		if name == "" && (len(path) == 0 || rest == "") {
+			return nil, fmt.Errorf("empty property name in path %q", s)
+		}
+		if name != "" {
+			path = append(path, name)
+		}
+		for rest != "" {
+			idx, after, ok := strings.Cut(rest, "]")
+			if _, err := strconv.Atoi(idx); !ok || err != nil {
+				return nil, fmt.Errorf("invalid list index in path %q", s)
+			}
+			path = append(path, "["+idx+"]")
+			rest = strings.TrimPrefix(after, "[")
+			if after != "" && !strings.HasPrefix(after, "[") {
+				return nil, fmt.Errorf("invalid list index in path %q", s)
+			}
+		}
+	}
+	return path, nil
+}
+
+func formatPath(path []string) string {
+	var b strings.Builder
+	for i, seg := range path {
+		if i > 0 && !isIndex(seg) {
+			b.WriteByte('.')
+		}
+		b.WriteString(seg)
+	}
+	return b.String()
+}
+
+func isIndex(seg string) bool {
+	return strings.HasPrefix(seg, "[")
+}
+
+func index(seg string, length int) (int, error) {
+	if !isIndex(seg) {
+		return 0, fmt.Errorf("expected a list index, got %q", seg)
+	}
+	idx, _ := strconv.Atoi(strings.Trim(seg, "[]"))
+	if idx < 0 || idx >= length {
+		return 0, fmt.Errorf("index %d out of range (list has %d items)", idx, length)
+	}
+	return idx, nil
+}
+
+// parseLiteral parses a YAML scalar or flow value into its JSON form
+func parseLiteral(s string) (any, error) {
+	if s == "" {
+		return "", nil
+	}
+	var v any
+	if err := yaml.Unmarshal([]byte(s), &v); err != nil {
+		return nil, fmt.Errorf("invalid value %q: %w", s, err)
+	}
+	// Normalize YAML types (int, map[string]interface{}) to their JSON form
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, fmt.Errorf("invalid value %q: %w", s, err)
+	}
+	var out any
+	_ = json.Unmarshal(data, &out)
+	return out, nil
+}
@@ -0,0 +1,192 @@
+package settings
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParsePath(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    []string
+		wantErr bool
+	}{
+		{in: "value.enabled", want: []string{"value", "enabled"}},
+		{in: "value.rules[0].name", want: []string{"value", "rules", "[0]", "name"}},
+		{in: "value.matrix[1][2]", want: []string{"value", "matrix", "[1]", "[2]"}},
+		{in: "scope", want: []string{"scope"}},
+		{in: "", wantErr: true},
+		{in: "value..enabled", wantErr: true},
+		{in: "value.rules[x]", wantErr: true},
+		{in: "value.rules[0", wantErr: true},
+		{in: "value.rules[0]x", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			got, err := parsePath(tt.in)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parsePath(%q) error = %v, wantErr %v", tt.in, err, tt.wantErr)
+			}
+			if !tt.wantErr && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("parsePath(%q) = %v, want %v", tt.in, got, tt.want)
+			}
+			if !tt.wantErr && formatPath(got) != tt.in {
+				t.Errorf("formatPath(%v) = %q, want %q", got, formatPath(got), tt.in)
+			}
+		})
+	}
+}
+
+func TestSelector_Match(t *testing.T) {
+	obj := ObjectMap(SettingsObject{
+		ObjectID: "obj-1",
+		SchemaID: "builtin:test",
+		Scope:    "HOST-123",
+		Value: map[string]any{
+			"enabled": false,
+			"port":    8080,
+			"name":    "web",
+			"rules":   []any{map[string]any{"name": "first"}},
+		},
+	})
+
+	tests := []struct {
+		expr string
+		want bool
+	}{
+		{"value.enabled==false", true},
+		{"value.enabled == true", false},
+		{"value.enabled!=true", true},
+		{"value.missing==null", true},
+		{"value.missing!=null", false},
+		{"value.port==8080", true},
+		{"value.port>=8080", true},
+		{"value.port>8080", false},
+		{"value.port<9000", true},
+		{"value.port<=80", false},
+		{"value.name>1", false},
+		{"value.name==web", true},
+		{`value.name=="web"`, true},
+		{"scope=~^HOST-", true},
+		{"scope!~^HOST-", false},
+		{"value.port=~^80", true},
+		{"value.rules[0].name==first", true},
+		{"value.rules[1].name==first", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.expr, func(t *testing.T) {
+			s, err := ParseSelector(tt.expr)
+			if err != nil {
+				t.Fatalf("ParseSelector(%q) error = %v", tt.expr, err)
+			}
+			if got := s.Match(obj); got != tt.want {
+				t.Errorf("Match(%q) = %v, want %v", tt.expr, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseSelector_Errors(t *testing.T) {
+	for _, expr := range []string{"value.enabled", "==true", "scope=~[", "value.x==[unclosed"} {
+		if _, err := ParseSelector(expr); err == nil {
+			t.Errorf("ParseSelector(%q) expected error", expr)
+		}
+	}
+}
+
+func TestParseAssignment(t *testing.T) {
+	a, err := ParseAssignment("value.rules[0].name=second")
+	if err != nil {
+		t.Fatalf("ParseAssignment() error = %v", err)
+	}
+	if a.String() != "value.rules[0].name" || a.Value != "second" {
+		t.Errorf("ParseAssignment() = %s=%v", a, a.Value)
+	}
+
+	for _, expr := range []string{"value.enabled", "scope=HOST-1", "value=true", "=true"} {
+		if _, err := ParseAssignment(expr); err == nil {
+			t.Errorf("ParseAssignment(%q) expected error", expr)
+		}
+	}
+}
+
+func TestAssignment_Apply(t *testing.T) {
+	obj := map[string]any{
+		"value": map[string]any{
+			"enabled": false,
+			"rules":   []any{map[string]any{"name": "first"}},
+		},
+	}
+
+	apply := func(expr string) (any, bool, error) {
+		t.Helper()
+		a, err := ParseAssignment(expr)
+		if err != nil {
+			t.Fatalf("ParseAssignment(%q) error = %v", expr, err)
+		}
+		return a.Apply(obj)
+	}
+
+	old, changed, err := apply("value.enabled=true")
+	if err != nil || !changed || old != false {
+		t.Errorf("set enabled: old=%v changed=%v err=%v", old, changed, err)
+	}
+	if _, changed, _ := apply("value.enabled=true"); changed {
+		t.Error("setting the same value again should not change the object")
+	}
+	if _, changed, err := apply("value.rules[0].name=second"); err != nil || !changed {
+		t.Errorf("set list item: changed=%v err=%v", changed, err)
+	}
+	old, changed, err = apply("value.nested.deep.port=8080")
+	if err != nil || !changed || old != nil {
+		t.Errorf("set nested: old=%v changed=%v err=%v", old, changed, err)
+	}
+
+	value := obj["value"].(map[string]any)
+	if value["enabled"] != true {
+		t.Errorf("enabled = %v, want true", value["enabled"])
+	}
+	if name := value["rules"].([]any)[0].(map[string]any)["name"]; name != "second" {
+		t.Errorf("rules[0].name = %v, want second", name)
+	}
+	if port := value["nested"].(map[string]any)["deep"].(map[string]any)["port"]; port != float64(8080) {
+		t.Errorf("nested.deep.port = %v (%T), want 8080", port, port)
+	}
+
+	for expr, want := range map[string]string{
+		"value.rules[3].name=x":  "out of range",
+		"value.missing[0]=x":     "list does not exist",
+		"value.enabled.nested=x": "not an object or list",
+	} {
+		if _, _, err := apply(expr); err == nil || !strings.Contains(err.Error(), want) {
+			t.Errorf("Apply(%q) error = %v, want %q", expr, err, want)
+		}
+	}
+}
+
+func TestParseLiteral(t *testing.T) {
+	tests := []struct {
+		in   string
+		want any
+	}{
+		{"true", true},
+		{"42", float64(42)},
+		{"1.5", 1.5},
+		{"null", nil},
+		{"text", "text"},
+		{`"42"`, "42"},
+		{"", ""},
+		{"[a, b]", []any{"a", "b"}},
+		{"{a: 1}", map[string]any{"a": float64(1)}},
+	}
+	for _, tt := range tests {
+		got, err := parseLiteral(tt.in)
+		if err != nil {
+			t.Fatalf("parseLiteral(%q) error = %v", tt.in, err)
+		}
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("parseLiteral(%q) = %#v, want %#v", tt.in, got, tt.want)
+		}
+	}
+}
@@ -11,6 +11,7 @@ import (
 // Handler handles settings resources
 type Handler struct {
 	client *client.Client
+	cache  *SchemaCache
 }
 
 // NewHandler creates a new settings handler
@@ -134,8 +135,17 @@ func (h *Handler) ListSchemas() (*SchemaList, error) {
 
 // GetSchema gets a specific schema definition
 func (h *Handler) GetSchema(schemaID string) (map[string]any, error) {
-	resp, err := h.client.HTTP().R().
-		Get(fmt.Sprintf("/platform/classic/environment-api/v2/settings/schemas/%s", schemaID))
+	return h.GetSchemaVersion(schemaID, "")
+}
+
+// GetSchemaVersion gets a specific version of a schema definition. An empty
+// version gets the latest version.
+func (h *Handler) GetSchemaVersion(schemaID, version string) (map[string]any, error) {
+	req := h.client.HTTP().R()
+	if version != "" {
+		req.SetQueryParam("schemaVersion", version)
+	}
+	resp, err := req.Get(fmt.Sprintf("/platform/classic/environment-api/v2/settings/schemas/%s", schemaID))
 
 	if err != nil {
 		return nil, fmt.Errorf("failed to get schema: %w", err)
@@ -144,6 +154,9 @@ func (h *Handler) GetSchema(schemaID string) (map[string]any, error) {
 	if resp.IsError() {
 		switch resp.StatusCode() {
 		case 404:
+			if version != "" {
+				return nil, fmt.Errorf("schema %q version %s not found", schemaID, version)
+			}
 			return nil, fmt.Errorf("schema %q not found", schemaID)
 		default:
 			return nil, fmt.Errorf("failed to get schema: status %d: %s", resp.StatusCode(), resp.String())
@@ -155,6 +168,11 @@ func (h *Handler) GetSchema(schemaID string) (map[string]any, error) {
 		return nil, fmt.Errorf("failed to parse schema response: %w", err)
 	}
 
+	// Caching is best effort; a read-only cache directory must not fail the request
+	if h.cache != nil {
+		_ = h.cache.Store(result)
+	}
+
 	return result, nil
 }
 
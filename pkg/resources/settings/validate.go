@@ -0,0 +1,422 @@
+package settings
+
+import (
+	"fmt"
+	"math"
+	"reflect"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+)
+
+// ValidationError is a problem with one property of a settings value
+type ValidationError struct {
+	// Path locates the property in the value, e.g. "rules[2].name"
+	Path    string `json:"path"`
+	Message string `json:"message"`
+}
+
+func (e ValidationError) Error() string {
+	if e.Path == "" {
+		return e.Message
+	}
+	return e.Path + ": " + e.Message
+}
+
+// Validate checks a settings value against a schema definition as returned by
+// GetSchema, without calling the API. It checks property types, enum values,
+// required (non-nullable) properties, unknown properties, list sizes and the
+// LENGTH, RANGE, PATTERN, NOT_BLANK, TRIMMED, NO_WHITESPACE and UNIQUE
+// constraints. Properties whose precondition is not met are not required and
+// not checked. Constraints the server evaluates with custom code (such as
+// CUSTOM_VALIDATOR_REF) are skipped.
+//
+// Errors are reported in property name order, with unknown properties of an
+// object last. An empty result means the value is valid as far as it can be
+// checked offline.
+func Validate(schema map[string]any, value map[string]any) []ValidationError {
+	v := &validator{schema: schema}
+	properties, _ := schema["properties"].(map[string]any)
+	v.object("", properties, value)
+	return v.errs
+}
+
+type validator struct {
+	schema map[string]any
+	errs   []ValidationError
+}
+
+func (v *validator) fail(path, format string, args ...any) {
+	v.errs = append(v.errs, ValidationError{Path: path, Message: fmt.Sprintf(format, args...)})
+}
+
+// object validates a JSON object against a set of property definitions
+func (v *validator) object(path string, properties map[string]any, obj map[string]any) {
+	names := make([]string, 0, len(properties))
+	for name := range properties {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		def, _ := properties[name].(map[string]any)
+		propPath := joinPath(path, name)
+		if pre, ok := def["precondition"].(map[string]any); ok && !preconditionMet(pre, obj) {
+			continue
+		}
+		val, present := obj[name]
+		if !present || val == nil {
+			if nullable, _ := def["nullable"].(bool); !nullable {
+				v.fail(propPath, "required property is missing")
+			}
+			continue
+		}
+		v.property(propPath, def, val)
+	}
+
+	var unknown []string
+	for name := range obj {
+		if _, ok := properties[name]; !ok {
+			unknown = append(unknown, name)
+		}
+	}
+	sort.Strings(unknown)
+	for _, name := range unknown {
+		v.fail(joinPath(path, name), "unknown property")
+	}
+}
+
+// property validates a present, non-null value against a property definition
+func (v *validator) property(path string, def map[string]any, val any) {
+	switch typ := def["type"].(type) {
+	case map[string]any:
+		ref, _ := typ["$ref"].(string)
+		v.ref(path, ref, val)
+	case string:
+		switch typ {
+		case "list", "set":
+			v.list(path, typ, def, val)
+		default:
+			if !v.scalar(path, typ, val) {
+				return
+			}
+		}
+	}
+	v.constraints(path, def["constraints"], val)
+}
+
+// ref validates a value against "#/enums/<name>" or "#/types/<name>"
+func (v *validator) ref(path, ref string, val any) {
+	kind, name, _ := strings.Cut(strings.TrimPrefix(ref, "#/"), "/")
+	defs, _ := v.schema[kind].(map[string]any)
+	def, ok := defs[name].(map[string]any)
+	if !ok {
+		return
+	}
+
+	switch kind {
+	case "enums":
+		items, _ := def["items"].([]any)
+		allowed := make([]string, 0, len(items))
+		for _, item := range items {
+			m, _ := item.(map[string]any)
+			if reflect.DeepEqual(m["value"], val) {
+				return
+			}
+			allowed = append(allowed, fmt.Sprint(m["value"]))
+		}
+		v.fail(path, "invalid value %s, must be one of: %s", formatValue(val), strings.Join(allowed, ", "))
+	case "types":
+		obj, ok := val.(map[string]any)
+		if !ok {
+			v.fail(path, "expected an object, got %s", jsonType(val))
+			return
+		}
+		properties, _ := def["properties"].(map[string]any)
+		v.object(path, properties, obj)
+		v.constraints(path, def["constraints"], val)
+	}
+}
+
+// list validates a list or set property and its items
+func (v *validator) list(path, typ string, def map[string]any, val any) {
+	items, ok := val.([]any)
+	if !ok {
+		v.fail(path, "expected a %s, got %s", typ, jsonType(val))
+		return
+	}
+	if min, ok := number(def["minObjects"]); ok && float64(len(items)) < min {
+		v.fail(path, "must contain at least %v item(s), got %d", min, len(items))
+	}
+	if max, ok := number(def["maxObjects"]); ok && float64(len(items)) > max {
+		v.fail(path, "must contain at most %v item(s), got %d", max, len(items))
+	}
+
+	itemDef, _ := def["items"].(map[string]any)
+	for i, item := range items {
+		itemPath := fmt.Sprintf("%s[%d]", path, i)
+		if item == nil {
+			v.fail(itemPath, "null items are not allowed")
+			continue
+		}
+		if itemDef != nil {
+			v.property(itemPath, itemDef, item)
+		}
+	}
+	if typ == "set" {
+		for i := 1; i < len(items); i++ {
+			for j := 0; j < i; j++ {
+				if reflect.DeepEqual(items[i], items[j]) {
+					v.fail(fmt.Sprintf("%s[%d]", path, i), "duplicate of %s[%d] in a set", path, j)
+				}
+			}
+		}
+	}
+}
+
+// scalar checks the JSON type of a primitive property. Returns false if the
+// type does not match, in which case constraints are not checked.
+func (v *validator) scalar(path, typ string, val any) bool {
+	var ok bool
+	switch typ {
+	case "boolean":
+		_, ok = val.(bool)
+	case "integer":
+		f, isNum := val.(float64)
+		ok = isNum && f == math.Trunc(f)
+	case "float":
+		_, ok = val.(float64)
+	case "text", "secret", "time_zone", "zoned_date_time":
+		_, ok = val.(string)
+	case "local_date":
+		s, isStr := val.(string)
+		if ok = isStr; ok {
+			if _, err := time.Parse("2006-01-02", s); err != nil {
+				v.fail(path, "invalid date %q, expected YYYY-MM-DD", s)
+				return false
+			}
+		}
+	case "local_time":
+		s, isStr := val.(string)
+		if ok = isStr; ok {
+			if _, err := time.Parse("15:04:05", s); err != nil {
+				if _, err := time.Parse("15:04", s); err != nil {
+					v.fail(path, "invalid time %q, expected HH:MM[:SS]", s)
+					return false
+				}
+			}
+		}
+	default:
+		// Unknown or server-side types (e.g. "setting") are not checked
+		return true
+	}
+	if !ok {
+		want := typ
+		switch typ {
+		case "text", "secret", "time_zone", "zoned_date_time", "local_date", "local_time":
+			want = "string"
+		case "float":
+			want = "number"
+		}
+		v.fail(path, "expected %s, got %s", want, jsonType(val))
+	}
+	return ok
+}
+
+// constraints evaluates the constraints of a property or type
+func (v *validator) constraints(path string, raw any, val any) {
+	list, _ := raw.([]any)
+	for _, c := range list {
+		def, _ := c.(map[string]any)
+		custom, _ := def["customMessage"].(string)
+		fail := func(format string, args ...any) {
+			if custom != "" {
+				v.fail(path, "%s", custom)
+				return
+			}
+			v.fail(path, format, args...)
+		}
+
+		s, isStr := val.(string)
+		switch def["type"] {
+		case "LENGTH":
+			n := float64(len([]rune(s)))
+			if items, ok := val.([]any); ok {
+				n = float64(len(items))
+			} else if !isStr {
+				continue
+			}
+			if min, ok := number(def["minLength"]); ok && n < min {
+				fail("length must be at least %v, got %v", min, n)
+			}
+			if max, ok := number(def["maxLength"]); ok && n > max {
+				fail("length must be at most %v, got %v", max, n)
+			}
+		case "RANGE":
+			f, ok := val.(float64)
+			if !ok {
+				continue
+			}
+			if min, ok := number(def["minimum"]); ok && f < min {
+				fail("must be at least %v, got %v", min, f)
+			}
+			if max, ok := number(def["maximum"]); ok && f > max {
+				fail("must be at most %v, got %v", max, f)
+			}
+		case "PATTERN":
+			pattern, _ := def["pattern"].(string)
+			// Schema patterns are Java regular expressions; skip the ones RE2 cannot compile
+			re, err := regexp.Compile("^(?:" + pattern + ")$")
+			if isStr && err == nil && !re.MatchString(s) {
+				fail("must match pattern %q", pattern)
+			}
+		case "NOT_BLANK":
+			if isStr && strings.TrimSpace(s) == "" {
+				fail("must not be blank")
+			}
+		case "TRIMMED":
+			if isStr && strings.TrimSpace(s) != s {
+				fail("must not have leading or trailing whitespace")
+			}
+		case "NO_WHITESPACE":
+			if isStr && strings.IndexFunc(s, isSpace) >= 0 {
+				fail("must not contain whitespace")
+			}
+		case "UNIQUE":
+			v.unique(path, def, val, custom)
+		}
+	}
+}
+
+// unique checks a UNIQUE constraint on a list, optionally on a set of
+// properties of its items
+func (v *validator) unique(path string, def map[string]any, val any, custom string) {
+	items, ok := val.([]any)
+	if !ok {
+		return
+	}
+	props, _ := def["uniqueProperties"].([]any)
+	key := func(item any) any {
+		if len(props) == 0 {
+			return item
+		}
+		obj, _ := item.(map[string]any)
+		k := make([]any, len(props))
+		for i, p := range props {
+			name, _ := p.(string)
+			k[i] = obj[name]
+		}
+		return k
+	}
+
+	for i := 1; i < len(items); i++ {
+		for j := 0; j < i; j++ {
+			if !reflect.DeepEqual(key(items[i]), key(items[j])) {
+				continue
+			}
+			itemPath := fmt.Sprintf("%s[%d]", path, i)
+			switch {
+			case custom != "":
+				v.fail(itemPath, "%s", custom)
+			case len(props) > 0:
+				v.fail(itemPath, "duplicate of %s[%d] (properties %v must be unique)", path, j, props)
+			default:
+				v.fail(itemPath, "duplicate of %s[%d]", path, j)
+			}
+			break
+		}
+	}
+}
+
+// preconditionMet evaluates a property precondition against the object that
+// contains the property
+func preconditionMet(pre map[string]any, obj map[string]any) bool {
+	prop, _ := pre["property"].(string)
+	val := obj[prop]
+
+	switch pre["type"] {
+	case "EQUALS":
+		return reflect.DeepEqual(val, pre["expectedValue"])
+	case "NOT_EQUALS":
+		return !reflect.DeepEqual(val, pre["expectedValue"])
+	case "IN", "NOT_IN":
+		expected, _ := pre["expectedValues"].([]any)
+		in := false
+		for _, e := range expected {
+			if reflect.DeepEqual(val, e) {
+				in = true
+				break
+			}
+		}
+		return in == (pre["type"] == "IN")
+	case "NULL":
+		return val == nil
+	case "NOT_NULL":
+		return val != nil
+	case "REGEX_MATCH":
+		s, _ := val.(string)
+		pattern, _ := pre["pattern"].(string)
+		re, err := regexp.Compile(pattern)
+		return err != nil || re.MatchString(s)
+	case "AND", "OR":
+		nested, _ := pre["preconditions"].([]any)
+		for _, n := range nested {
+			m, _ := n.(map[string]any)
+			met := preconditionMet(m, obj)
+			if pre["type"] == "AND" && !met {
+				return false
+			}
+			if pre["type"] == "OR" && met {
+				return true
+			}
+		}
+		return pre["type"] == "AND"
+	case "NOT":
+		nested, _ := pre["precondition"].(map[string]any)
+		return !preconditionMet(nested, obj)
+	}
+	// Unknown precondition types are evaluated by the server; assume met
+	return true
+}
+
+func joinPath(path, name string) string {
+	if path == "" {
+		return name
+	}
+	return path + "." + name
+}
+
+func number(v any) (float64, bool) {
+	f, ok := v.(float64)
+	return f, ok
+}
+
+func isSpace(r rune) bool {
+	return r == ' ' || r == '\t' || r == '\n' || r == '\r' || r == '\f' || r == '\v'
+}
+
+func jsonType(val any) string {
+	switch val.(type) {
+	case bool:
+		return "boolean"
+	case float64:
+		return "number"
+	case string:
+		return "string"
+	case []any:
+		return "list"
+	case map[string]any:
+		return "object"
+	case nil:
+		return "null"
+	}
+	return fmt.Sprintf("%T", val)
+}
+
+func formatValue(val any) string {
+	if s, ok := val.(string); ok {
+		return fmt.Sprintf("%q", s)
+	}
+	return fmt.Sprint(val)
+}
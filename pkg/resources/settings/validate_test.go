@@ -0,0 +1,124 @@
+package settings
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+const testSchema = `{
+  "schemaId": "builtin:test.pipelines",
+  "version": "1.2.0",
+  "properties": {
+    "enabled": {"type": "boolean"},
+    "name": {"type": "text", "constraints": [{"type": "LENGTH", "minLength": 1, "maxLength": 10}, {"type": "TRIMMED"}]},
+    "port": {"type": "integer", "constraints": [{"type": "RANGE", "minimum": 1, "maximum": 65535}]},
+    "mode": {"type": {"$ref": "#/enums/Mode"}},
+    "pattern": {"type": "text", "nullable": true, "precondition": {"type": "EQUALS", "property": "mode", "expectedValue": "MATCH"}},
+    "host": {"type": "text", "nullable": true, "constraints": [{"type": "PATTERN", "pattern": "[a-z.]+", "customMessage": "host must be lower case"}]},
+    "rules": {
+      "type": "list",
+      "minObjects": 1,
+      "items": {"type": {"$ref": "#/types/Rule"}},
+      "constraints": [{"type": "UNIQUE", "uniqueProperties": ["id"]}]
+    }
+  },
+  "enums": {"Mode": {"items": [{"value": "ALL"}, {"value": "MATCH"}]}},
+  "types": {
+    "Rule": {
+      "properties": {
+        "id": {"type": "text", "constraints": [{"type": "NO_WHITESPACE"}]},
+        "weight": {"type": "float", "nullable": true}
+      }
+    }
+  }
+}`
+
+func mustJSON(t *testing.T, s string) map[string]any {
+	t.Helper()
+	var m map[string]any
+	if err := json.Unmarshal([]byte(s), &m); err != nil {
+		t.Fatal(err)
+	}
+	return m
+}
+
+func TestValidate(t *testing.T) {
+	schema := mustJSON(t, testSchema)
+	tests := []struct {
+		name  string
+		value string
+		want  []ValidationError
+	}{
+		{
+			name:  "valid",
+			value: `{"enabled": true, "name": "logs", "port": 443, "mode": "ALL", "rules": [{"id": "a"}, {"id": "b", "weight": 0.5}]}`,
+		},
+		{
+			name:  "precondition met requires property",
+			value: `{"enabled": true, "name": "logs", "port": 443, "mode": "MATCH", "pattern": "x", "rules": [{"id": "a"}]}`,
+		},
+		{
+			name:  "types and required",
+			value: `{"enabled": "yes", "port": 1.5, "mode": "ALL", "rules": [{"id": "a"}]}`,
+			want: []ValidationError{
+				{Path: "enabled", Message: "expected boolean, got string"},
+				{Path: "name", Message: "required property is missing"},
+				{Path: "port", Message: "expected integer, got number"},
+			},
+		},
+		{
+			name:  "constraints, enums and unknown properties",
+			value: `{"enabled": true, "name": " very long name ", "port": 70000, "mode": "SOME", "host": "Example.com", "extra": 1, "rules": [{"id": "a b"}, {"id": "a b", "x": 1}]}`,
+			want: []ValidationError{
+				{Path: "host", Message: "host must be lower case"},
+				{Path: "mode", Message: `invalid value "SOME", must be one of: ALL, MATCH`},
+				{Path: "name", Message: "length must be at most 10, got 16"},
+				{Path: "name", Message: "must not have leading or trailing whitespace"},
+				{Path: "port", Message: "must be at most 65535, got 70000"},
+				{Path: "rules[0].id", Message: "must not contain whitespace"},
+				{Path: "rules[1].id", Message: "must not contain whitespace"},
+				{Path: "rules[1].x", Message: "unknown property"},
+				{Path: "rules[1]", Message: "duplicate of rules[0] (properties [id] must be unique)"},
+				{Path: "extra", Message: "unknown property"},
+			},
+		},
+		{
+			name:  "list size",
+			value: `{"enabled": false, "name": "x", "port": 1, "mode": "ALL", "rules": []}`,
+			want:  []ValidationError{{Path: "rules", Message: "must contain at least 1 item(s), got 0"}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := Validate(schema, mustJSON(t, tt.value))
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Validate() =\n%v\nwant\n%v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPreconditionMet(t *testing.T) {
+	obj := map[string]any{"mode": "MATCH", "count": float64(3)}
+	tests := []struct {
+		pre  string
+		want bool
+	}{
+		{`{"type": "EQUALS", "property": "mode", "expectedValue": "MATCH"}`, true},
+		{`{"type": "NOT_EQUALS", "property": "mode", "expectedValue": "MATCH"}`, false},
+		{`{"type": "IN", "property": "count", "expectedValues": [1, 3]}`, true},
+		{`{"type": "NOT_IN", "property": "count", "expectedValues": [1, 3]}`, false},
+		{`{"type": "NULL", "property": "missing"}`, true},
+		{`{"type": "NOT_NULL", "property": "missing"}`, false},
+		{`{"type": "REGEX_MATCH", "property": "mode", "pattern": "^MA"}`, true},
+		{`{"type": "AND", "preconditions": [{"type": "NULL", "property": "missing"}, {"type": "NULL", "property": "mode"}]}`, false},
+		{`{"type": "OR", "preconditions": [{"type": "NULL", "property": "missing"}, {"type": "NULL", "property": "mode"}]}`, true},
+		{`{"type": "NOT", "precondition": {"type": "NULL", "property": "mode"}}`, true},
+	}
+	for _, tt := range tests {
+		if got := preconditionMet(mustJSON(t, tt.pre), obj); got != tt.want {
+			t.Errorf("preconditionMet(%s) = %v, want %v", tt.pre, got, tt.want)
+		}
+	}
+}
@@ -1,6 +1,7 @@
 package workflow
 
 import (
+	"encoding/json"
 	"fmt"
 
 	"github.com/dynatrace-oss/dtctl/pkg/client"
@@ -138,6 +139,25 @@ func (h *Handler) Update(id string, data []byte) (*Workflow, error) {
 	return &result, nil
 }
 
+// TransferOwner makes newOwner (a user UUID) the owner of a workflow. The
+// actor the workflow runs as is not changed.
+func (h *Handler) TransferOwner(id, newOwner string) (*Workflow, error) {
+	raw, err := h.GetRaw(id)
+	if err != nil {
+		return nil, err
+	}
+	var wf map[string]interface{}
+	if err := json.Unmarshal(raw, &wf); err != nil {
+		return nil, fmt.Errorf("failed to parse workflow: %w", err)
+	}
+	wf["owner"] = newOwner
+	data, err := json.Marshal(wf)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode workflow: %w", err)
+	}
+	return h.Update(id, data)
+}
+
 // Create creates a new workflow
 func (h *Handler) Create(data []byte) (*Workflow, error) {
 	var result Workflow
@@ -430,6 +430,43 @@ func TestHandler_Update(t *testing.T) {
 	}
 }
 
+func TestHandler_TransferOwner(t *testing.T) {
+	var put map[string]interface{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/platform/automation/v1/workflows/wf-123" {
+			t.Errorf("unexpected path: %s", r.URL.Path)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		switch r.Method {
+		case http.MethodGet:
+			_, _ = w.Write([]byte(`{"id":"wf-123","title":"Nightly","owner":"user-1","actor":"user-1","tasks":{"a":{}}}`))
+		case http.MethodPut:
+			if err := json.NewDecoder(r.Body).Decode(&put); err != nil {
+				t.Fatalf("failed to decode body: %v", err)
+			}
+			_ = json.NewEncoder(w).Encode(put)
+		default:
+			t.Errorf("unexpected method %s", r.Method)
+		}
+	}))
+	defer server.Close()
+
+	c, err := client.NewForTesting(server.URL, "test-token")
+	if err != nil {
+		t.Fatalf("client.New() error = %v", err)
+	}
+	wf, err := NewHandler(c).TransferOwner("wf-123", "user-2")
+	if err != nil {
+		t.Fatalf("TransferOwner() error = %v", err)
+	}
+	if wf.Owner != "user-2" {
+		t.Errorf("Owner = %q, want user-2", wf.Owner)
+	}
+	if put["actor"] != "user-1" || put["tasks"] == nil {
+		t.Errorf("expected other fields to be kept, got %v", put)
+	}
+}
+
 func TestHandler_Create(t *testing.T) {
 	tests := []struct {
 		name       string
@@ -0,0 +1,105 @@
+package safety
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/dynatrace-oss/dtctl/pkg/approval"
+	"github.com/dynatrace-oss/dtctl/pkg/config"
+)
+
+// ApprovalRequiredError is returned by CheckError when an operation requires
+// two-person approval and no countersigned change request was provided.
+// Request describes the operation; it still has to be signed by the requester.
+type ApprovalRequiredError struct {
+	Request *approval.ChangeRequest
+}
+
+func (e *ApprovalRequiredError) Error() string {
+	target := e.Request.Resource
+	if len(e.Request.Targets) > 0 {
+		target = fmt.Sprintf("%s %q", target, e.Request.Targets[0])
+	}
+	return fmt.Sprintf("%s on %s in context '%s' requires two-person approval", e.Request.Operation, target, e.Request.Context)
+}
+
+// WithApprovals returns a copy of the checker that verifies change requests
+// against the trusted approver keys. request is the countersigned change
+// request for the current operation, or nil.
+func (c *Checker) WithApprovals(approvers []config.Approver, request *approval.ChangeRequest) *Checker {
+	scoped := *c
+	scoped.approvers = approvers
+	scoped.approval = request
+	return &scoped
+}
+
+// WithApprovalLedger returns a copy of the checker that consumes approved
+// change requests in ledger, so that each approval authorizes a single run.
+func (c *Checker) WithApprovalLedger(ledger *approval.Ledger) *Checker {
+	scoped := *c
+	scoped.ledger = ledger
+	return &scoped
+}
+
+// WithChangeDiff returns a copy of the checker that includes diff (a
+// description of the change) in new change requests.
+func (c *Checker) WithChangeDiff(diff string) *Checker {
+	scoped := *c
+	scoped.diff = diff
+	return &scoped
+}
+
+// RequiresApproval reports whether op needs two-person approval in this context
+func (c *Checker) RequiresApproval(op Operation) bool {
+	return slices.Contains(c.requireApproval, string(op))
+}
+
+func (c *Checker) checkApproval(op Operation) error {
+	if !c.RequiresApproval(op) {
+		return nil
+	}
+
+	var targets []string
+	if c.name != "" {
+		targets = []string{c.name}
+	}
+	if c.approval == nil {
+		request := approval.NewChangeRequest(c.contextName, c.environment, string(op), c.resource, targets)
+		request.SetDiff(c.diff)
+		return &ApprovalRequiredError{Request: request}
+	}
+
+	fail := func(format string, args ...interface{}) error {
+		return &SafetyError{
+			ContextName: c.contextName,
+			SafetyLevel: c.safetyLevel,
+			Operation:   op,
+			Reason:      fmt.Sprintf(format, args...),
+			Suggestions: []string{"Re-run without --approval to create a new change request"},
+		}
+	}
+	if !c.approval.Covers(c.contextName, c.environment, string(op), c.resource, targets, c.diff) {
+		return fail("Change request %s is for %s on %s %v in context '%s' with the approved changes, not for this operation",
+			c.approval.ID, c.approval.Operation, c.approval.Resource, c.approval.Targets, c.approval.Context)
+	}
+	keys, err := approval.TrustedKeys(c.approvers)
+	if err != nil {
+		return fail("Invalid approval configuration: %v", err)
+	}
+	if err := c.approval.Verify(keys, c.now()); err != nil {
+		return fail("Change request not approved: %v", err)
+	}
+	if c.ledger != nil {
+		used, err := c.ledger.Used(c.approval.ID)
+		if err != nil {
+			return fail("Cannot check whether change request %s was used: %v", c.approval.ID, err)
+		}
+		if used {
+			return fail("Change request %s was already used; an approval authorizes a single run", c.approval.ID)
+		}
+		if err := c.ledger.Consume(c.approval.ID); err != nil {
+			return fail("Cannot record the use of change request %s: %v", c.approval.ID, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,88 @@
+package safety
+
+import (
+	"crypto/ed25519"
+	"encoding/base64"
+	"errors"
+	"path/filepath"
+	"testing"
+
+	"github.com/dynatrace-oss/dtctl/pkg/approval"
+	"github.com/dynatrace-oss/dtctl/pkg/config"
+)
+
+func TestChecker_RequireApproval(t *testing.T) {
+	alicePub, alicePriv, _ := ed25519.GenerateKey(nil)
+	bobPub, bobPriv, _ := ed25519.GenerateKey(nil)
+	approvers := []config.Approver{
+		{Name: "alice", PublicKey: base64.StdEncoding.EncodeToString(alicePub)},
+		{Name: "bob", PublicKey: base64.StdEncoding.EncodeToString(bobPub)},
+	}
+	ctx := &config.Context{
+		Environment:     "https://prod.example",
+		SafetyLevel:     config.SafetyLevelDangerouslyUnrestricted,
+		RequireApproval: []string{"delete-bucket"},
+	}
+	checker := NewChecker("prod", ctx).ForResource("bucket", "logs").WithChangeDiff("- bucket logs")
+
+	// Operations without approval requirement are unaffected
+	if err := checker.CheckError(OperationDelete, OwnershipUnknown); err != nil {
+		t.Errorf("CheckError(delete) error = %v", err)
+	}
+
+	// Without a change request, a new one is returned
+	err := checker.WithApprovals(approvers, nil).CheckError(OperationDeleteBucket, OwnershipUnknown)
+	var approvalErr *ApprovalRequiredError
+	if !errors.As(err, &approvalErr) {
+		t.Fatalf("expected ApprovalRequiredError, got %v", err)
+	}
+	request := approvalErr.Request
+	if request.Operation != "delete-bucket" || request.Resource != "bucket" || len(request.Targets) != 1 || request.Targets[0] != "logs" || request.Diff != "- bucket logs" {
+		t.Errorf("unexpected change request %+v", request)
+	}
+
+	// Signed but not approved
+	request.Sign("alice", alicePriv)
+	var safetyErr *SafetyError
+	if err := checker.WithApprovals(approvers, request).CheckError(OperationDeleteBucket, OwnershipUnknown); !errors.As(err, &safetyErr) {
+		t.Errorf("expected SafetyError for unapproved request, got %v", err)
+	}
+
+	// Approved
+	if err := request.Approve("bob", bobPriv); err != nil {
+		t.Fatal(err)
+	}
+	if err := checker.WithApprovals(approvers, request).CheckError(OperationDeleteBucket, OwnershipUnknown); err != nil {
+		t.Errorf("CheckError() with approval error = %v", err)
+	}
+
+	// The approval does not cover another payload
+	changed := checker.WithChangeDiff("- bucket logs-archive").WithApprovals(approvers, request)
+	if err := changed.CheckError(OperationDeleteBucket, OwnershipUnknown); !errors.As(err, &safetyErr) {
+		t.Errorf("expected SafetyError for a changed payload, got %v", err)
+	}
+
+	// An approval is consumed by the run that uses it
+	ledgerPath := filepath.Join(t.TempDir(), "approvals-used")
+	first := checker.WithApprovals(approvers, request).WithApprovalLedger(&approval.Ledger{Path: ledgerPath})
+	if err := first.CheckError(OperationDeleteBucket, OwnershipUnknown); err != nil {
+		t.Errorf("CheckError() with unused approval error = %v", err)
+	}
+	second := checker.WithApprovals(approvers, request).WithApprovalLedger(&approval.Ledger{Path: ledgerPath})
+	if err := second.CheckError(OperationDeleteBucket, OwnershipUnknown); !errors.As(err, &safetyErr) {
+		t.Errorf("expected SafetyError for a used approval, got %v", err)
+	}
+
+	// The approval does not cover another bucket
+	other := checker.ForResource("bucket", "traces").WithApprovals(approvers, request)
+	if err := other.CheckError(OperationDeleteBucket, OwnershipUnknown); !errors.As(err, &safetyErr) {
+		t.Errorf("expected SafetyError for other bucket, got %v", err)
+	}
+
+	// Safety level and rules are still checked first
+	ctx.SafetyLevel = config.SafetyLevelReadWriteAll
+	blocked := NewChecker("prod", ctx).ForResource("bucket", "logs").WithApprovals(approvers, request)
+	if err := blocked.CheckError(OperationDeleteBucket, OwnershipUnknown); !errors.As(err, &safetyErr) {
+		t.Errorf("expected safety level to block, got %v", err)
+	}
+}
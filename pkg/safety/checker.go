@@ -3,7 +3,9 @@ package safety
 import (
 	"fmt"
 	"strings"
+	"time"
 
+	"github.com/dynatrace-oss/dtctl/pkg/approval"
 	"github.com/dynatrace-oss/dtctl/pkg/config"
 )
 
@@ -40,19 +42,36 @@ type CheckResult struct {
 	Allowed     bool
 	Reason      string
 	Suggestions []string
+	// Rule is the name of the safety rule that decided the result, if any
+	Rule string
 }
 
 // Checker performs safety level checks for operations
 type Checker struct {
 	contextName string
+	environment string
 	safetyLevel config.SafetyLevel
+	rules       []config.SafetyRule
+	resource    string // resource type for rule matching (e.g. "workflow")
+	name        string // resource name for rule matching
+	now         func() time.Time
+
+	requireApproval []string
+	approvers       []config.Approver
+	approval        *approval.ChangeRequest // countersigned request passed via --approval
+	ledger          *approval.Ledger        // records used change requests
+	diff            string                  // change payload, recorded in and compared with change requests
 }
 
 // NewChecker creates a new safety checker for a context
 func NewChecker(contextName string, ctx *config.Context) *Checker {
 	return &Checker{
-		contextName: contextName,
-		safetyLevel: ctx.GetEffectiveSafetyLevel(),
+		contextName:     contextName,
+		environment:     ctx.Environment,
+		safetyLevel:     ctx.GetEffectiveSafetyLevel(),
+		rules:           ctx.SafetyRules,
+		now:             time.Now,
+		requireApproval: ctx.RequireApproval,
 	}
 }
 
@@ -61,6 +80,7 @@ func NewCheckerWithLevel(contextName string, level config.SafetyLevel) *Checker
 	return &Checker{
 		contextName: contextName,
 		safetyLevel: level,
+		now:         time.Now,
 	}
 }
 
@@ -74,8 +94,17 @@ func (c *Checker) ContextName() string {
 	return c.contextName
 }
 
-// Check verifies if an operation is allowed under the current safety level
+// Check verifies if an operation is allowed under the context's safety rules
+// and safety level. The first matching rule decides; without a matching rule
+// the safety level applies.
 func (c *Checker) Check(op Operation, ownership ResourceOwnership) CheckResult {
+	if rule := c.matchRule(op, ownership); rule != nil {
+		if rule.Action == config.SafetyRuleAllow {
+			return CheckResult{Allowed: true, Rule: rule.Name}
+		}
+		return c.denyByRule(rule, op)
+	}
+
 	switch c.safetyLevel {
 	case config.SafetyLevelReadOnly:
 		return c.checkReadOnly(op)
@@ -158,20 +187,19 @@ func (c *Checker) FormatError(result CheckResult) string {
 		return ""
 	}
 
-	var b strings.Builder
-	fmt.Fprintf(&b, "Operation not allowed:\n   Context: %s (%s)\n   Reason: %s", c.contextName, c.safetyLevel, result.Reason)
-
-	if len(result.Suggestions) > 0 {
-		b.WriteString("\n\nSuggestions:")
-		for _, s := range result.Suggestions {
-			fmt.Fprintf(&b, "\n  • %s", s)
-		}
-	}
-
-	return b.String()
+	return (&SafetyError{
+		ContextName: c.contextName,
+		SafetyLevel: c.safetyLevel,
+		Reason:      result.Reason,
+		Suggestions: result.Suggestions,
+		Rule:        result.Rule,
+	}).Error()
 }
 
 // CheckError performs a safety check and returns a *SafetyError if not allowed.
+// Operations that require two-person approval additionally need a valid
+// countersigned change request (see WithApprovals); without one an
+// *ApprovalRequiredError is returned.
 func (c *Checker) CheckError(op Operation, ownership ResourceOwnership) error {
 	result := c.Check(op, ownership)
 	if !result.Allowed {
@@ -181,9 +209,10 @@ func (c *Checker) CheckError(op Operation, ownership ResourceOwnership) error {
 			Operation:   op,
 			Reason:      result.Reason,
 			Suggestions: result.Suggestions,
+			Rule:        result.Rule,
 		}
 	}
-	return nil
+	return c.checkApproval(op)
 }
 
 // SafetyError represents a safety check failure
@@ -193,12 +222,17 @@ type SafetyError struct {
 	Operation   Operation
 	Reason      string
 	Suggestions []string
+	// Rule is the name of the safety rule that blocked the operation, if any
+	Rule string
 }
 
 func (e *SafetyError) Error() string {
 	var b strings.Builder
-	fmt.Fprintf(&b, "Operation not allowed:\n   Context: %s (%s)\n   Reason: %s",
-		e.ContextName, e.SafetyLevel, e.Reason)
+	fmt.Fprintf(&b, "Operation not allowed:\n   Context: %s (%s)\n", e.ContextName, e.SafetyLevel)
+	if e.Rule != "" {
+		fmt.Fprintf(&b, "   Rule: %s\n", e.Rule)
+	}
+	fmt.Fprintf(&b, "   Reason: %s", e.Reason)
 
 	if len(e.Suggestions) > 0 {
 		b.WriteString("\n\nSuggestions:")
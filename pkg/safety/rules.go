@@ -0,0 +1,118 @@
+package safety
+
+import (
+	"fmt"
+	"path"
+	"slices"
+	"strings"
+
+	"github.com/dynatrace-oss/dtctl/pkg/config"
+)
+
+// ForResource returns a copy of the checker that matches safety rules against
+// the given resource type (e.g. "workflow", "dashboard") and name. With an
+// empty name, deny rules with name patterns match and allow rules with name
+// patterns do not.
+func (c *Checker) ForResource(resource, name string) *Checker {
+	scoped := *c
+	scoped.resource = resource
+	scoped.name = name
+	return &scoped
+}
+
+// matchRule returns the first safety rule matching the operation, or nil
+func (c *Checker) matchRule(op Operation, ownership ResourceOwnership) *config.SafetyRule {
+	for i := range c.rules {
+		if c.ruleMatches(&c.rules[i], op, ownership) {
+			return &c.rules[i]
+		}
+	}
+	return nil
+}
+
+func (c *Checker) ruleMatches(rule *config.SafetyRule, op Operation, ownership ResourceOwnership) bool {
+	if len(rule.Operations) > 0 && !slices.Contains(rule.Operations, string(op)) {
+		return false
+	}
+	if len(rule.Resources) > 0 && !slices.ContainsFunc(rule.Resources, func(r string) bool {
+		return resourceMatches(r, c.resource)
+	}) {
+		return false
+	}
+	// When the name is unknown, a deny rule with names matches (fails closed)
+	// while an allow rule does not.
+	if len(rule.Names) > 0 && c.name == "" {
+		if rule.Action != config.SafetyRuleDeny {
+			return false
+		}
+	} else if len(rule.Names) > 0 && !slices.ContainsFunc(rule.Names, func(pattern string) bool {
+		ok, _ := path.Match(pattern, c.name)
+		return ok
+	}) {
+		return false
+	}
+	// Likewise, when ownership is unknown, a deny rule with an owner matches
+	// while an allow rule does not.
+	if rule.Owner != "" && ownership == OwnershipUnknown {
+		if rule.Action != config.SafetyRuleDeny {
+			return false
+		}
+	} else {
+		switch rule.Owner {
+		case config.SafetyRuleOwnerMine:
+			if ownership != OwnershipOwn {
+				return false
+			}
+		case config.SafetyRuleOwnerOthers:
+			if ownership != OwnershipShared {
+				return false
+			}
+		}
+	}
+	if len(rule.Windows) > 0 {
+		now := c.now()
+		if !slices.ContainsFunc(rule.Windows, func(w config.SafetyWindow) bool { return w.Contains(now) }) {
+			return false
+		}
+	}
+	return true
+}
+
+func (c *Checker) denyByRule(rule *config.SafetyRule, op Operation) CheckResult {
+	target := "resources"
+	if c.resource != "" {
+		target = c.resource
+	}
+	if c.name != "" {
+		target = fmt.Sprintf("%s %q", target, c.name)
+	}
+
+	reason := fmt.Sprintf("Safety rule '%s' of context '%s' denies %s on %s", rule.Name, c.contextName, op, target)
+	if rule.Message != "" {
+		reason += ": " + rule.Message
+	}
+	return CheckResult{
+		Allowed: false,
+		Reason:  reason,
+		Rule:    rule.Name,
+		Suggestions: []string{
+			fmt.Sprintf("Review the safety-rules of context '%s' ('dtctl ctx describe %s')", c.contextName, c.contextName),
+			"Switch to a context without this rule",
+		},
+	}
+}
+
+// resourceMatches compares a rule resource with a resource type, ignoring
+// case, plural forms and '-'/'_'. "document" matches dashboards and notebooks.
+func resourceMatches(ruleResource, resource string) bool {
+	r, res := normalizeResource(ruleResource), normalizeResource(resource)
+	if r == "*" || r == res {
+		return true
+	}
+	return r == "document" && (res == "dashboard" || res == "notebook")
+}
+
+func normalizeResource(s string) string {
+	s = strings.ReplaceAll(strings.ToLower(strings.TrimSpace(s)), "_", "-")
+	return strings.TrimSuffix(s, "s")
+}
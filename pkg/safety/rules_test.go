@@ -0,0 +1,154 @@
+package safety
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/dynatrace-oss/dtctl/pkg/config"
+)
+
+func newRuleChecker(level config.SafetyLevel, rules ...config.SafetyRule) *Checker {
+	return NewChecker("prod", &config.Context{SafetyLevel: level, SafetyRules: rules})
+}
+
+func TestChecker_Rules_AllowAndDeny(t *testing.T) {
+	// Prod: readonly, but dashboards may be edited; workflows may never be deleted.
+	checker := newRuleChecker(config.SafetyLevelReadOnly,
+		config.SafetyRule{Name: "no-workflow-delete", Action: config.SafetyRuleDeny, Operations: []string{"delete"}, Resources: []string{"workflows"}},
+		config.SafetyRule{Name: "edit-dashboards", Action: config.SafetyRuleAllow, Operations: []string{"create", "update"}, Resources: []string{"dashboard"}},
+	)
+
+	tests := []struct {
+		name     string
+		resource string
+		op       Operation
+		allowed  bool
+		rule     string
+	}{
+		{"update dashboard allowed by rule", "dashboard", OperationUpdate, true, "edit-dashboards"},
+		{"delete dashboard falls back to level", "dashboard", OperationDelete, false, ""},
+		{"delete workflow denied by rule", "workflow", OperationDelete, false, "no-workflow-delete"},
+		{"update workflow falls back to level", "workflow", OperationUpdate, false, ""},
+		{"read allowed by level", "workflow", OperationRead, true, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := checker.ForResource(tt.resource, "").Check(tt.op, OwnershipUnknown)
+			if result.Allowed != tt.allowed || result.Rule != tt.rule {
+				t.Errorf("Check() = allowed %v, rule %q; want %v, %q", result.Allowed, result.Rule, tt.allowed, tt.rule)
+			}
+		})
+	}
+}
+
+func TestChecker_Rules_DenyOverridesUnrestricted(t *testing.T) {
+	checker := newRuleChecker(config.SafetyLevelDangerouslyUnrestricted,
+		config.SafetyRule{Name: "keep-buckets", Action: config.SafetyRuleDeny, Operations: []string{"delete-bucket"}, Message: "talk to the data team"},
+	)
+
+	err := checker.ForResource("bucket", "logs").CheckError(OperationDeleteBucket, OwnershipUnknown)
+	var safetyErr *SafetyError
+	if !errors.As(err, &safetyErr) {
+		t.Fatalf("expected SafetyError, got %v", err)
+	}
+	if safetyErr.Rule != "keep-buckets" {
+		t.Errorf("Rule = %q, want keep-buckets", safetyErr.Rule)
+	}
+	for _, want := range []string{"Rule: keep-buckets", `bucket "logs"`, "talk to the data team"} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("error %q does not contain %q", err.Error(), want)
+		}
+	}
+}
+
+func TestChecker_Rules_NamesAndOwner(t *testing.T) {
+	checker := newRuleChecker(config.SafetyLevelReadWriteAll,
+		config.SafetyRule{Name: "protect-critical", Action: config.SafetyRuleDeny, Names: []string{"critical-*"}},
+		config.SafetyRule{Name: "not-others", Action: config.SafetyRuleDeny, Operations: []string{"delete"}, Owner: config.SafetyRuleOwnerOthers},
+	)
+
+	if r := checker.ForResource("workflow", "critical-alerts").Check(OperationUpdate, OwnershipOwn); r.Allowed {
+		t.Error("expected name pattern to deny critical-alerts")
+	}
+	if r := checker.ForResource("workflow", "x").Check(OperationDelete, OwnershipShared); r.Allowed {
+		t.Error("expected owner rule to deny deleting others' resources")
+	}
+}
+
+func TestChecker_Rules_NamesUnknown(t *testing.T) {
+	deny := newRuleChecker(config.SafetyLevelReadWriteAll,
+		config.SafetyRule{Name: "protect-critical", Action: config.SafetyRuleDeny, Names: []string{"critical-*"}},
+	)
+	if r := deny.ForResource("workflow", "").Check(OperationUpdate, OwnershipOwn); r.Allowed || r.Rule != "protect-critical" {
+		t.Errorf("deny rule with names should match an unknown name, got allowed %v, rule %q", r.Allowed, r.Rule)
+	}
+
+	allow := newRuleChecker(config.SafetyLevelReadOnly,
+		config.SafetyRule{Name: "edit-sandbox", Action: config.SafetyRuleAllow, Names: []string{"sandbox-*"}},
+	)
+	if r := allow.ForResource("workflow", "").Check(OperationUpdate, OwnershipOwn); r.Allowed {
+		t.Error("allow rule with names should not match an unknown name")
+	}
+}
+
+func TestChecker_Rules_OwnerUnknown(t *testing.T) {
+	for _, owner := range []string{config.SafetyRuleOwnerMine, config.SafetyRuleOwnerOthers} {
+		t.Run(owner, func(t *testing.T) {
+			deny := newRuleChecker(config.SafetyLevelReadWriteAll,
+				config.SafetyRule{Name: "owner-deny", Action: config.SafetyRuleDeny, Operations: []string{"delete"}, Owner: owner},
+			)
+			if r := deny.ForResource("workflow", "x").Check(OperationDelete, OwnershipUnknown); r.Allowed || r.Rule != "owner-deny" {
+				t.Errorf("deny rule with owner %q should match unknown ownership, got allowed %v, rule %q", owner, r.Allowed, r.Rule)
+			}
+
+			allow := newRuleChecker(config.SafetyLevelReadOnly,
+				config.SafetyRule{Name: "owner-allow", Action: config.SafetyRuleAllow, Operations: []string{"update"}, Owner: owner},
+			)
+			if r := allow.ForResource("workflow", "x").Check(OperationUpdate, OwnershipUnknown); r.Allowed {
+				t.Errorf("allow rule with owner %q should not match unknown ownership", owner)
+			}
+		})
+	}
+}
+
+func TestChecker_Rules_ChangeFreeze(t *testing.T) {
+	start := time.Date(2026, 12, 20, 0, 0, 0, 0, time.UTC)
+	end := time.Date(2027, 1, 5, 0, 0, 0, 0, time.UTC)
+	checker := newRuleChecker(config.SafetyLevelReadWriteAll,
+		config.SafetyRule{Name: "year-end-freeze", Action: config.SafetyRuleDeny, Operations: []string{"create", "update", "delete"},
+			Windows: []config.SafetyWindow{{Start: &start, End: &end}}},
+	)
+
+	checker.now = func() time.Time { return time.Date(2026, 12, 24, 12, 0, 0, 0, time.UTC) }
+	if r := checker.Check(OperationUpdate, OwnershipOwn); r.Allowed || r.Rule != "year-end-freeze" {
+		t.Errorf("expected freeze to block updates, got %+v", r)
+	}
+
+	checker.now = func() time.Time { return time.Date(2027, 1, 5, 0, 0, 0, 0, time.UTC) }
+	if r := checker.Check(OperationUpdate, OwnershipOwn); !r.Allowed {
+		t.Errorf("expected update after freeze to be allowed, got %+v", r)
+	}
+}
+
+func TestResourceMatches(t *testing.T) {
+	tests := []struct {
+		rule, resource string
+		want           bool
+	}{
+		{"workflow", "workflows", true},
+		{"Workflows", "workflow", true},
+		{"extension-config", "extension_config", true},
+		{"document", "dashboard", true},
+		{"document", "notebook", true},
+		{"dashboard", "notebook", false},
+		{"*", "bucket", true},
+		{"workflow", "", false},
+	}
+	for _, tt := range tests {
+		if got := resourceMatches(tt.rule, tt.resource); got != tt.want {
+			t.Errorf("resourceMatches(%q, %q) = %v, want %v", tt.rule, tt.resource, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,181 @@
+// Package undo records pre-change snapshots of mutated resources and reverts
+// recorded operations for 'dtctl undo'.
+package undo
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/dynatrace-oss/dtctl/pkg/config"
+)
+
+// EnvUndo disables undo recording when set to "off".
+const EnvUndo = "DTCTL_UNDO"
+
+// DefaultMaxEntries is the number of operations kept in the undo journal
+const DefaultMaxEntries = 50
+
+// Action is the kind of change an entry records
+type Action string
+
+const (
+	// ActionCreate records a created resource; undo deletes it
+	ActionCreate Action = "create"
+	// ActionUpdate records an updated resource; undo restores the previous version
+	ActionUpdate Action = "update"
+	// ActionDelete records a deleted resource; undo re-creates it
+	ActionDelete Action = "delete"
+)
+
+// Entry is one recorded operation
+type Entry struct {
+	ID          string    `json:"id" table:"ID"`
+	Time        time.Time `json:"time" table:"TIME"`
+	Context     string    `json:"context" table:"CONTEXT,wide"`
+	Environment string    `json:"environment" table:"-"`
+	Command     string    `json:"command" table:"COMMAND,wide"`
+	Action      Action    `json:"action" table:"ACTION"`
+	// Resource is "workflow" or the document type (dashboard, notebook, ...)
+	Resource   string `json:"resource" table:"RESOURCE"`
+	ResourceID string `json:"resourceId" table:"RESOURCE-ID"`
+	Name       string `json:"name,omitempty" table:"NAME"`
+	// Description is the document description before the change
+	Description string `json:"description,omitempty" table:"-"`
+	// Snapshot is the workflow JSON or document content before the change
+	Snapshot []byte `json:"snapshot,omitempty" table:"-"`
+	// Version is the latest workflow history version or the document version
+	// before the change
+	Version int `json:"version,omitempty" table:"-"`
+	// SnapshotVersion is a document snapshot holding the state before the change
+	SnapshotVersion int `json:"snapshotVersion,omitempty" table:"-"`
+	// VersionAfter is the version reported after the change, if any
+	VersionAfter string     `json:"versionAfter,omitempty" table:"-"`
+	UndoneAt     *time.Time `json:"undoneAt,omitempty" table:"-"`
+}
+
+// Journal is the local undo journal, one JSON entry per line, oldest first
+type Journal struct {
+	Path       string
+	MaxEntries int
+}
+
+// DefaultJournal returns the undo journal in the dtctl data directory
+func DefaultJournal() *Journal {
+	return &Journal{
+		Path:       filepath.Join(config.DataDir(), "undo.jsonl"),
+		MaxEntries: DefaultMaxEntries,
+	}
+}
+
+// Enabled reports whether undo recording is enabled (DTCTL_UNDO is not "off")
+func Enabled() bool {
+	return !strings.EqualFold(os.Getenv(EnvUndo), "off")
+}
+
+// Entries returns all entries, oldest first. Malformed lines are skipped.
+func (j *Journal) Entries() ([]Entry, error) {
+	f, err := os.Open(j.Path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to open undo journal: %w", err)
+	}
+	defer f.Close()
+
+	var entries []Entry
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 64*1024), 64<<20)
+	for scanner.Scan() {
+		var e Entry
+		if json.Unmarshal(scanner.Bytes(), &e) == nil {
+			entries = append(entries, e)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read undo journal: %w", err)
+	}
+	return entries, nil
+}
+
+// Append adds an entry, dropping the oldest entries beyond MaxEntries
+func (j *Journal) Append(e Entry) error {
+	entries, err := j.Entries()
+	if err != nil {
+		return err
+	}
+	entries = append(entries, e)
+	if j.MaxEntries > 0 && len(entries) > j.MaxEntries {
+		entries = entries[len(entries)-j.MaxEntries:]
+	}
+	return j.write(entries)
+}
+
+// MarkUndone records that the entry with the given ID was reverted
+func (j *Journal) MarkUndone(id string, at time.Time) error {
+	entries, err := j.Entries()
+	if err != nil {
+		return err
+	}
+	for i := range entries {
+		if entries[i].ID == id {
+			entries[i].UndoneAt = &at
+			return j.write(entries)
+		}
+	}
+	return fmt.Errorf("undo journal entry %s not found", id)
+}
+
+// Pending returns the entries of a context and environment that have not
+// been undone, newest first.
+func (j *Journal) Pending(contextName, environment string) ([]Entry, error) {
+	entries, err := j.Entries()
+	if err != nil {
+		return nil, err
+	}
+	var pending []Entry
+	for i := len(entries) - 1; i >= 0; i-- {
+		e := entries[i]
+		if e.UndoneAt == nil && e.Context == contextName && e.Environment == environment {
+			pending = append(pending, e)
+		}
+	}
+	return pending, nil
+}
+
+// write replaces the journal atomically
+func (j *Journal) write(entries []Entry) error {
+	if err := os.MkdirAll(filepath.Dir(j.Path), 0700); err != nil {
+		return fmt.Errorf("failed to create undo journal directory: %w", err)
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(j.Path), ".undo-*.jsonl")
+	if err != nil {
+		return fmt.Errorf("failed to write undo journal: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+
+	w := bufio.NewWriter(tmp)
+	enc := json.NewEncoder(w)
+	for _, e := range entries {
+		if err := enc.Encode(e); err != nil {
+			tmp.Close()
+			return fmt.Errorf("failed to write undo journal: %w", err)
+		}
+	}
+	if err := w.Flush(); err != nil {
+		tmp.Close()
+		return fmt.Errorf("failed to write undo journal: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to write undo journal: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), j.Path); err != nil {
+		return fmt.Errorf("failed to write undo journal: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,64 @@
+package undo
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestJournal_AppendPendingMarkUndone(t *testing.T) {
+	j := &Journal{Path: filepath.Join(t.TempDir(), "undo.jsonl"), MaxEntries: 3}
+
+	for _, e := range []Entry{
+		{ID: "a", Context: "prod", Environment: "https://prod"},
+		{ID: "b", Context: "dev", Environment: "https://dev"},
+		{ID: "c", Context: "prod", Environment: "https://prod", Snapshot: []byte(`{"title":"x"}`)},
+		{ID: "d", Context: "prod", Environment: "https://prod"},
+	} {
+		if err := j.Append(e); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	entries, err := j.Entries()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 3 || entries[0].ID != "b" {
+		t.Fatalf("entries = %v, want the newest 3 starting with b", ids(entries))
+	}
+	if string(entries[1].Snapshot) != `{"title":"x"}` {
+		t.Errorf("snapshot = %q", entries[1].Snapshot)
+	}
+
+	if err := j.MarkUndone("d", time.Now()); err != nil {
+		t.Fatal(err)
+	}
+	pending, err := j.Pending("prod", "https://prod")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := ids(pending); len(got) != 1 || got[0] != "c" {
+		t.Errorf("pending = %v, want [c]", got)
+	}
+
+	if err := j.MarkUndone("missing", time.Now()); err == nil {
+		t.Error("expected error for unknown entry")
+	}
+}
+
+func TestJournal_Missing(t *testing.T) {
+	j := &Journal{Path: filepath.Join(t.TempDir(), "undo.jsonl")}
+	pending, err := j.Pending("prod", "https://prod")
+	if err != nil || len(pending) != 0 {
+		t.Errorf("Pending() = %v, %v; want empty", pending, err)
+	}
+}
+
+func ids(entries []Entry) []string {
+	var out []string
+	for _, e := range entries {
+		out = append(out, e.ID)
+	}
+	return out
+}
@@ -0,0 +1,243 @@
+package undo
+
+import (
+	"cmp"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/dynatrace-oss/dtctl/pkg/client"
+	"github.com/dynatrace-oss/dtctl/pkg/resources/document"
+	"github.com/dynatrace-oss/dtctl/pkg/resources/workflow"
+)
+
+const (
+	workflowsPath = "/platform/automation/v1/workflows"
+	documentsPath = "/platform/document/v1/documents"
+)
+
+// target is a resource changed by an API request
+type target struct {
+	kind   string // "workflow" or "document"
+	id     string // empty for creates
+	action Action
+}
+
+// parseTarget maps a state-changing request to the resource it changes.
+// Requests that cannot be undone (runs, shares, snapshot deletion) are ignored.
+func parseTarget(method, path string) (target, bool) {
+	for kind, base := range map[string]string{"workflow": workflowsPath, "document": documentsPath} {
+		if path == base {
+			return target{kind: kind, action: ActionCreate}, method == "POST"
+		}
+		rest, ok := strings.CutPrefix(path, base+"/")
+		if !ok {
+			continue
+		}
+		parts := strings.Split(rest, "/")
+		id := parts[0]
+		switch {
+		case len(parts) == 1 && method == "DELETE":
+			return target{kind: kind, id: id, action: ActionDelete}, true
+		case len(parts) == 1 && (method == "PUT" || method == "PATCH"):
+			return target{kind: kind, id: id, action: ActionUpdate}, true
+		case kind == "workflow" && len(parts) == 4 && parts[1] == "history" && parts[3] == "restore" && method == "POST":
+			return target{kind: kind, id: id, action: ActionUpdate}, true
+		case kind == "document" && len(parts) == 3 && parts[1] == "snapshots" && strings.HasSuffix(parts[2], ":restore") && method == "POST":
+			return target{kind: kind, id: id, action: ActionUpdate}, true
+		}
+	}
+	return target{}, false
+}
+
+// Recorder snapshots workflows and documents before they are changed and
+// appends an entry to the journal for every successful change.
+type Recorder struct {
+	client      *client.Client
+	journal     *Journal
+	context     string
+	environment string
+	command     string
+
+	mu      sync.Mutex
+	pending map[string]*pendingEntry
+	// OnError is called when a snapshot or journal write fails
+	OnError func(error)
+}
+
+// pendingEntry is a snapshot taken before a request whose response is outstanding
+type pendingEntry struct {
+	entry *Entry
+	err   error
+}
+
+// NewRecorder creates a recorder for the given client and context
+func NewRecorder(c *client.Client, journal *Journal, contextName, environment, command string) *Recorder {
+	return &Recorder{
+		client:      c,
+		journal:     journal,
+		context:     contextName,
+		environment: environment,
+		command:     command,
+		pending:     make(map[string]*pendingEntry),
+		OnError:     func(error) {},
+	}
+}
+
+// Attach registers the recorder's hooks on the client
+func (r *Recorder) Attach() {
+	r.client.OnBeforeMutation(r.before)
+	r.client.OnMutation(r.after)
+}
+
+func (r *Recorder) before(method, path string) {
+	t, ok := parseTarget(method, path)
+	if !ok {
+		return
+	}
+	key := method + " " + path
+
+	r.mu.Lock()
+	_, seen := r.pending[key]
+	r.mu.Unlock()
+	if seen {
+		// Retried request: keep the snapshot taken before the first attempt.
+		return
+	}
+
+	entry := &Entry{
+		Time:        time.Now().UTC(),
+		Context:     r.context,
+		Environment: r.environment,
+		Command:     r.command,
+		Action:      t.action,
+		Resource:    t.kind,
+		ResourceID:  t.id,
+	}
+	var err error
+	if t.action != ActionCreate {
+		err = r.snapshot(entry)
+	}
+
+	r.mu.Lock()
+	r.pending[key] = &pendingEntry{entry: entry, err: err}
+	r.mu.Unlock()
+}
+
+// snapshot records the current state of the entry's resource
+func (r *Recorder) snapshot(e *Entry) error {
+	switch e.Resource {
+	case "workflow":
+		h := workflow.NewHandler(r.client)
+		raw, err := h.GetRaw(e.ResourceID)
+		if err != nil {
+			return err
+		}
+		e.Snapshot = raw
+		var wf workflow.Workflow
+		if json.Unmarshal(raw, &wf) == nil {
+			e.Name = wf.Title
+		}
+		if history, err := h.ListHistory(e.ResourceID); err == nil {
+			for _, rec := range history.Results {
+				e.Version = max(e.Version, rec.Version)
+			}
+		}
+	default:
+		h := document.NewHandler(r.client)
+		doc, err := h.Get(e.ResourceID)
+		if err != nil {
+			return err
+		}
+		e.Resource = doc.Type
+		e.Name = doc.Name
+		e.Description = doc.Description
+		e.Version = doc.Version
+		e.Snapshot = doc.Content
+		if snapshots, err := h.ListSnapshots(e.ResourceID); err == nil {
+			for _, s := range snapshots.Snapshots {
+				if s.DocumentVersion == doc.Version {
+					e.SnapshotVersion = max(e.SnapshotVersion, s.SnapshotVersion)
+				}
+			}
+		}
+	}
+	return nil
+}
+
+func (r *Recorder) after(m client.Mutation) {
+	key := m.Method + " " + m.Path
+	r.mu.Lock()
+	p, ok := r.pending[key]
+	if ok && m.StatusCode < 300 {
+		delete(r.pending, key)
+	}
+	r.mu.Unlock()
+	if !ok || m.StatusCode >= 300 {
+		return
+	}
+	if p.err != nil {
+		// Only report snapshot failures of changes that actually happened.
+		r.OnError(p.err)
+		return
+	}
+
+	entry := p.entry
+	entry.VersionAfter = m.VersionAfter
+	if entry.Action == ActionCreate {
+		fillCreated(entry, m)
+		if entry.ResourceID == "" {
+			return
+		}
+	}
+	entry.ID = newEntryID()
+	if err := r.journal.Append(*entry); err != nil {
+		r.OnError(err)
+	}
+}
+
+// fillCreated sets the ID, name and type of a created resource from the response
+func fillCreated(e *Entry, m client.Mutation) {
+	if m.Response == nil {
+		return
+	}
+	if e.Resource == "document" && strings.HasPrefix(m.Response.Header().Get("Content-Type"), "multipart/") {
+		if doc, err := document.ParseMultipartDocument(m.Response); err == nil {
+			e.ResourceID, e.Name, e.Resource = doc.ID, doc.Name, cmp.Or(doc.Type, e.Resource)
+		}
+		return
+	}
+
+	var body struct {
+		ID               string `json:"id"`
+		Title            string `json:"title"`
+		DocumentMetadata struct {
+			ID   string `json:"id"`
+			Name string `json:"name"`
+			Type string `json:"type"`
+		} `json:"documentMetadata"`
+		Name string `json:"name"`
+		Type string `json:"type"`
+	}
+	if json.Unmarshal(m.Response.Body(), &body) != nil {
+		return
+	}
+	switch {
+	case e.Resource == "workflow":
+		e.ResourceID, e.Name = body.ID, body.Title
+	case body.DocumentMetadata.ID != "":
+		md := body.DocumentMetadata
+		e.ResourceID, e.Name, e.Resource = md.ID, md.Name, cmp.Or(md.Type, e.Resource)
+	default:
+		e.ResourceID, e.Name, e.Resource = body.ID, body.Name, cmp.Or(body.Type, e.Resource)
+	}
+}
+
+func newEntryID() string {
+	id := make([]byte, 4)
+	_, _ = rand.Read(id)
+	return hex.EncodeToString(id)
+}
@@ -0,0 +1,191 @@
+package undo
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strconv"
+
+	"github.com/dynatrace-oss/dtctl/pkg/client"
+	"github.com/dynatrace-oss/dtctl/pkg/diff"
+	"github.com/dynatrace-oss/dtctl/pkg/resources/document"
+	"github.com/dynatrace-oss/dtctl/pkg/resources/workflow"
+	"github.com/dynatrace-oss/dtctl/pkg/safety"
+)
+
+// Plan describes how a journal entry is reverted
+type Plan struct {
+	Entry Entry
+	// Operation is the operation the revert performs, for the safety check
+	Operation safety.Operation
+	// Owner is the current owner of the resource, if known
+	Owner string
+	// Summary describes the revert in one line
+	Summary string
+	// Diff previews the change from the current state to the reverted state
+	Diff string
+	// Warnings report changes made after the recorded operation
+	Warnings []string
+	// NewID is the ID of a resource re-created by Revert under a new ID
+	NewID string
+
+	currentVersion int
+}
+
+// Reverter plans and reverts journal entries
+type Reverter struct {
+	client *client.Client
+}
+
+// NewReverter creates a reverter
+func NewReverter(c *client.Client) *Reverter {
+	return &Reverter{client: c}
+}
+
+// Plan inspects the current state of the entry's resource and describes the revert
+func (r *Reverter) Plan(e Entry) (*Plan, error) {
+	p := &Plan{Entry: e}
+	label := fmt.Sprintf("%s %q (%s)", e.Resource, e.Name, e.ResourceID)
+
+	if e.Resource == "workflow" {
+		h := workflow.NewHandler(r.client)
+		current, err := h.GetRaw(e.ResourceID)
+		switch e.Action {
+		case ActionCreate:
+			if err != nil {
+				return nil, err
+			}
+			p.Operation, p.Summary = safety.OperationDelete, "delete "+label
+			p.Owner = workflowOwner(current)
+			p.Diff = fmt.Sprintf("- %s\n", label)
+		case ActionUpdate:
+			if err != nil {
+				return nil, err
+			}
+			p.Operation, p.Owner = safety.OperationUpdate, workflowOwner(current)
+			p.Summary = "restore " + label + " to its previous state"
+			if e.Version > 0 {
+				p.Summary = fmt.Sprintf("restore %s to version %d", label, e.Version)
+			}
+			p.Diff = diffContent(current, e.Snapshot)
+		case ActionDelete:
+			if err == nil {
+				return nil, fmt.Errorf("%s exists again and cannot be re-created", label)
+			}
+			// The Automation API assigns the ID of new workflows.
+			p.Operation, p.Summary = safety.OperationCreate, "re-create "+label+" under a new ID"
+			p.Diff = fmt.Sprintf("+ %s\n", label)
+			p.Warnings = append(p.Warnings, fmt.Sprintf("%s gets a new ID; update references to %s", label, e.ResourceID))
+		}
+		return p, nil
+	}
+
+	h := document.NewHandler(r.client)
+	switch e.Action {
+	case ActionCreate, ActionUpdate:
+		current, err := h.Get(e.ResourceID)
+		if err != nil {
+			return nil, err
+		}
+		p.Owner, p.currentVersion = current.Owner, current.Version
+		if e.Action == ActionCreate {
+			p.Operation, p.Summary = safety.OperationDelete, "move "+label+" to the trash"
+			p.Diff = fmt.Sprintf("- %s\n", label)
+			break
+		}
+		p.Operation = safety.OperationUpdate
+		p.Summary = fmt.Sprintf("restore %s to version %d", label, e.Version)
+		if e.SnapshotVersion > 0 {
+			p.Summary = fmt.Sprintf("restore %s from snapshot %d", label, e.SnapshotVersion)
+		}
+		p.Diff = diffContent(current.Content, e.Snapshot)
+		if current.Name != e.Name {
+			p.Diff = fmt.Sprintf("- name: %s\n+ name: %s\n", current.Name, e.Name) + p.Diff
+		}
+		if e.VersionAfter != "" && e.VersionAfter != strconv.Itoa(current.Version) {
+			p.Warnings = append(p.Warnings, fmt.Sprintf("%s was changed again after this operation (version %s, now %d); those changes are reverted too", label, e.VersionAfter, current.Version))
+		}
+	case ActionDelete:
+		trashed, err := document.NewTrashHandler(r.client).Get(e.ResourceID)
+		if err != nil {
+			return nil, fmt.Errorf("%s cannot be restored: %w", label, err)
+		}
+		p.Operation, p.Owner = safety.OperationCreate, trashed.Owner
+		p.Summary = "restore " + label + " from the trash"
+		p.Diff = fmt.Sprintf("+ %s\n", label)
+	}
+	return p, nil
+}
+
+// Revert performs a planned revert
+func (r *Reverter) Revert(p *Plan) error {
+	e := p.Entry
+	if e.Resource == "workflow" {
+		h := workflow.NewHandler(r.client)
+		var err error
+		switch e.Action {
+		case ActionCreate:
+			err = h.Delete(e.ResourceID)
+		case ActionUpdate:
+			if e.Version > 0 {
+				_, err = h.RestoreHistory(e.ResourceID, e.Version)
+			} else {
+				_, err = h.Update(e.ResourceID, e.Snapshot)
+			}
+		case ActionDelete:
+			var created *workflow.Workflow
+			if created, err = h.Create(e.Snapshot); err == nil {
+				p.NewID = created.ID
+			}
+		}
+		return err
+	}
+
+	h := document.NewHandler(r.client)
+	// Re-read the version: an earlier revert of the same document in this
+	// run has changed it since the plan was made.
+	if e.Action == ActionCreate || (e.Action == ActionUpdate && e.SnapshotVersion == 0) {
+		current, err := h.GetMetadata(e.ResourceID)
+		if err != nil {
+			return err
+		}
+		p.currentVersion = current.Version
+	}
+	var err error
+	switch e.Action {
+	case ActionCreate:
+		err = h.Delete(e.ResourceID, p.currentVersion)
+	case ActionUpdate:
+		if e.SnapshotVersion > 0 {
+			_, err = h.RestoreSnapshot(e.ResourceID, e.SnapshotVersion)
+		} else {
+			_, err = h.UpdateWithMetadata(e.ResourceID, p.currentVersion, e.Snapshot, "application/json", e.Name, e.Description)
+		}
+	case ActionDelete:
+		err = document.NewTrashHandler(r.client).Restore(e.ResourceID, document.RestoreOptions{})
+	}
+	return err
+}
+
+func workflowOwner(raw []byte) string {
+	var wf workflow.Workflow
+	_ = json.Unmarshal(raw, &wf)
+	return wf.Owner
+}
+
+// diffContent returns a unified diff of two JSON documents, or a short note
+// if the content is not JSON.
+func diffContent(current, target []byte) string {
+	var left, right interface{}
+	if json.Unmarshal(current, &left) != nil || json.Unmarshal(target, &right) != nil {
+		if bytes.Equal(current, target) {
+			return ""
+		}
+		return fmt.Sprintf("~ content (%d bytes -> %d bytes)\n", len(current), len(target))
+	}
+	result, err := diff.NewDiffer(diff.DiffOptions{IgnoreMetadata: true}).Compare(left, right, "current", "after undo")
+	if err != nil {
+		return ""
+	}
+	return result.Patch
+}
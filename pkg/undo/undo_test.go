@@ -0,0 +1,227 @@
+package undo
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/dynatrace-oss/dtctl/pkg/client"
+	"github.com/dynatrace-oss/dtctl/pkg/resources/workflow"
+	"github.com/dynatrace-oss/dtctl/pkg/safety"
+)
+
+func TestParseTarget(t *testing.T) {
+	tests := []struct {
+		method, path string
+		want         target
+		ok           bool
+	}{
+		{"POST", "/platform/automation/v1/workflows", target{kind: "workflow", action: ActionCreate}, true},
+		{"PUT", "/platform/automation/v1/workflows/wf-1", target{kind: "workflow", id: "wf-1", action: ActionUpdate}, true},
+		{"DELETE", "/platform/automation/v1/workflows/wf-1", target{kind: "workflow", id: "wf-1", action: ActionDelete}, true},
+		{"POST", "/platform/automation/v1/workflows/wf-1/history/3/restore", target{kind: "workflow", id: "wf-1", action: ActionUpdate}, true},
+		{"POST", "/platform/automation/v1/workflows/wf-1/run", target{}, false},
+		{"POST", "/platform/document/v1/documents", target{kind: "document", action: ActionCreate}, true},
+		{"PATCH", "/platform/document/v1/documents/doc-1", target{kind: "document", id: "doc-1", action: ActionUpdate}, true},
+		{"DELETE", "/platform/document/v1/documents/doc-1", target{kind: "document", id: "doc-1", action: ActionDelete}, true},
+		{"POST", "/platform/document/v1/documents/doc-1/snapshots/2:restore", target{kind: "document", id: "doc-1", action: ActionUpdate}, true},
+		{"DELETE", "/platform/document/v1/documents/doc-1/snapshots/2", target{}, false},
+		{"POST", "/platform/document/v1/direct-shares", target{}, false},
+		{"POST", "/platform/storage/query/v1/query:execute", target{}, false},
+	}
+	for _, tt := range tests {
+		got, ok := parseTarget(tt.method, tt.path)
+		if ok != tt.ok || (ok && got != tt.want) {
+			t.Errorf("parseTarget(%s %s) = %+v, %v; want %+v, %v", tt.method, tt.path, got, ok, tt.want, tt.ok)
+		}
+	}
+}
+
+// fakeWorkflows is a minimal in-memory workflow API
+type fakeWorkflows struct {
+	mu        sync.Mutex
+	workflows map[string]string
+	restored  []string
+}
+
+func (f *fakeWorkflows) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	w.Header().Set("Content-Type", "application/json")
+	id := strings.Split(strings.TrimPrefix(r.URL.Path, workflowsPath+"/"), "/")[0]
+	body, _ := io.ReadAll(r.Body)
+
+	switch {
+	case r.Method == http.MethodPost && r.URL.Path == workflowsPath:
+		// The API assigns the ID of new workflows.
+		var wf map[string]interface{}
+		_ = json.Unmarshal(body, &wf)
+		wf["id"] = "wf-new"
+		created, _ := json.Marshal(wf)
+		f.workflows["wf-new"] = string(created)
+		_, _ = io.WriteString(w, f.workflows["wf-new"])
+	case strings.HasSuffix(r.URL.Path, "/history"):
+		_, _ = io.WriteString(w, `{"count":2,"results":[{"version":2},{"version":1}]}`)
+	case strings.HasSuffix(r.URL.Path, "/restore"):
+		f.restored = append(f.restored, r.URL.Path)
+		_, _ = io.WriteString(w, f.workflows[id])
+	case r.Method == http.MethodGet:
+		wf, ok := f.workflows[id]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_, _ = io.WriteString(w, wf)
+	case r.Method == http.MethodPut:
+		f.workflows[id] = string(body)
+		_, _ = w.Write(body)
+	case r.Method == http.MethodDelete:
+		if _, ok := f.workflows[id]; !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		delete(f.workflows, id)
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+func TestRecorderAndReverter_Workflows(t *testing.T) {
+	api := &fakeWorkflows{workflows: map[string]string{
+		"wf-1": `{"id":"wf-1","title":"Nightly","owner":"u1","description":"old"}`,
+	}}
+	server := httptest.NewServer(api)
+	defer server.Close()
+
+	c, err := client.NewForTesting(server.URL, "test-token")
+	if err != nil {
+		t.Fatal(err)
+	}
+	journal := &Journal{Path: filepath.Join(t.TempDir(), "undo.jsonl")}
+	recorder := NewRecorder(c, journal, "prod", server.URL, "dtctl apply -f wf.yaml")
+	recorder.OnError = func(err error) { t.Errorf("recorder error: %v", err) }
+	recorder.Attach()
+
+	h := workflow.NewHandler(c)
+	if _, err := h.Update("wf-1", []byte(`{"id":"wf-1","title":"Nightly","owner":"u1","description":"new"}`)); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := h.Create([]byte(`{"title":"Created"}`)); err != nil {
+		t.Fatal(err)
+	}
+	if err := h.Delete("wf-1"); err != nil {
+		t.Fatal(err)
+	}
+	// Failed requests are not recorded.
+	if err := h.Delete("missing"); err == nil {
+		t.Fatal("expected error deleting a missing workflow")
+	}
+
+	pending, err := journal.Pending("prod", server.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(pending) != 3 {
+		t.Fatalf("got %d entries, want 3", len(pending))
+	}
+	deleted, created, updated := pending[0], pending[1], pending[2]
+	if deleted.Action != ActionDelete || deleted.Name != "Nightly" || !strings.Contains(string(deleted.Snapshot), `"new"`) {
+		t.Errorf("delete entry = %+v", deleted)
+	}
+	if created.Action != ActionCreate || created.ResourceID != "wf-new" || created.Name != "Created" {
+		t.Errorf("create entry = %+v", created)
+	}
+	if updated.Action != ActionUpdate || updated.Version != 2 || !strings.Contains(string(updated.Snapshot), `"old"`) {
+		t.Errorf("update entry = %+v", updated)
+	}
+
+	reverter := NewReverter(c)
+
+	// Undo the delete: the workflow is re-created from the snapshot.
+	plan, err := reverter.Plan(deleted)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if plan.Operation != safety.OperationCreate || !strings.Contains(plan.Summary, "new ID") || len(plan.Warnings) != 1 {
+		t.Errorf("plan = %+v, want a create that announces the new ID", plan)
+	}
+	if err := reverter.Revert(plan); err != nil {
+		t.Fatal(err)
+	}
+	if plan.NewID != "wf-new" {
+		t.Errorf("NewID = %q, want wf-new", plan.NewID)
+	}
+	var recreated map[string]interface{}
+	_ = json.Unmarshal([]byte(api.workflows["wf-new"]), &recreated)
+	if recreated["description"] != "new" {
+		t.Errorf("re-created workflow = %s", api.workflows["wf-new"])
+	}
+
+	// Undo the update: the workflow is restored from its history.
+	api.workflows["wf-1"] = `{"id":"wf-1","title":"Nightly","owner":"u1","description":"new"}`
+	plan, err = reverter.Plan(updated)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if plan.Owner != "u1" || !strings.Contains(plan.Diff, "description") {
+		t.Errorf("plan = %+v", plan)
+	}
+	if err := reverter.Revert(plan); err != nil {
+		t.Fatal(err)
+	}
+	if len(api.restored) != 1 || !strings.HasSuffix(api.restored[0], "/wf-1/history/2/restore") {
+		t.Errorf("restored = %v", api.restored)
+	}
+
+	// A deleted resource that exists again cannot be re-created.
+	if _, err := reverter.Plan(Entry{Action: ActionDelete, Resource: "workflow", ResourceID: "wf-1"}); err == nil {
+		t.Error("expected error re-creating an existing workflow")
+	}
+}
+
+func TestReverter_DocumentRevertsRereadVersion(t *testing.T) {
+	version := 3
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/platform/document/v1/documents/d1/metadata":
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{"id": "d1", "version": version})
+		case r.Method == http.MethodPatch && r.URL.Path == "/platform/document/v1/documents/d1":
+			if r.URL.Query().Get("optimistic-locking-version") != strconv.Itoa(version) {
+				w.WriteHeader(http.StatusConflict)
+				return
+			}
+			version++
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{"id": "d1", "version": version})
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	c, err := client.NewForTesting(server.URL, "test-token")
+	if err != nil {
+		t.Fatal(err)
+	}
+	reverter := NewReverter(c)
+
+	// Two updates of the same document undone in one run, both planned
+	// against version 3.
+	plans := []*Plan{
+		{Entry: Entry{Action: ActionUpdate, Resource: "dashboard", ResourceID: "d1", Name: "Prod", Snapshot: []byte(`{"v":2}`)}, currentVersion: 3},
+		{Entry: Entry{Action: ActionUpdate, Resource: "dashboard", ResourceID: "d1", Name: "Prod", Snapshot: []byte(`{"v":1}`)}, currentVersion: 3},
+	}
+	for i, plan := range plans {
+		if err := reverter.Revert(plan); err != nil {
+			t.Fatalf("revert %d: %v", i+1, err)
+		}
+	}
+	if version != 5 {
+		t.Errorf("version = %d, want 5", version)
+	}
+}
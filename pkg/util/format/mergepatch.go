@@ -0,0 +1,115 @@
+package format
+
+import (
+	"bytes"
+	"encoding/json"
+	"reflect"
+	"strings"
+)
+
+// MergePatch applies a JSON merge patch (RFC 7386) to target and returns the
+// result: objects are merged recursively, null removes a field and any other
+// value replaces it. Unlike RFC 7386, fields the target does not have are
+// added as they are, including null values inside them, so that
+// CreateMergePatch round-trips. target is not modified.
+func MergePatch(target, patch map[string]any) map[string]any {
+	out := make(map[string]any, len(target)+len(patch))
+	for k, v := range target {
+		out[k] = v
+	}
+	for k, v := range patch {
+		if v == nil {
+			delete(out, k)
+			continue
+		}
+		p, pIsMap := v.(map[string]any)
+		t, tIsMap := out[k].(map[string]any)
+		if pIsMap && tIsMap {
+			out[k] = MergePatch(t, p)
+			continue
+		}
+		out[k] = v
+	}
+	return out
+}
+
+// CreateMergePatch returns the JSON merge patch that turns original into
+// modified, or nil if they are equal. Values are compared by their JSON
+// encoding, so 1 and 1.0 are equal.
+func CreateMergePatch(original, modified map[string]any) map[string]any {
+	original, modified = normalizeJSON(original), normalizeJSON(modified)
+	patch := map[string]any{}
+	for k, o := range original {
+		m, ok := modified[k]
+		if !ok {
+			patch[k] = nil
+			continue
+		}
+		om, oIsMap := o.(map[string]any)
+		mm, mIsMap := m.(map[string]any)
+		if oIsMap && mIsMap {
+			if sub := CreateMergePatch(om, mm); sub != nil {
+				patch[k] = sub
+			}
+			continue
+		}
+		if !reflect.DeepEqual(o, m) {
+			patch[k] = m
+		}
+	}
+	for k, m := range modified {
+		if _, ok := original[k]; !ok {
+			patch[k] = m
+		}
+	}
+	if len(patch) == 0 {
+		return nil
+	}
+	return patch
+}
+
+// normalizeJSON converts a value to its JSON representation (maps, slices,
+// float64, string, bool and nil)
+func normalizeJSON(m map[string]any) map[string]any {
+	if m == nil {
+		return map[string]any{}
+	}
+	data, err := json.Marshal(m)
+	if err != nil {
+		return m
+	}
+	var out map[string]any
+	if err := json.Unmarshal(data, &out); err != nil {
+		return m
+	}
+	return out
+}
+
+// JSONEqual reports whether a and b have the same JSON encoding
+func JSONEqual(a, b any) bool {
+	x, errA := json.Marshal(a)
+	y, errB := json.Marshal(b)
+	return errA == nil && errB == nil && bytes.Equal(x, y)
+}
+
+// Slug converts a title to a lowercase file name part of letters, digits and
+// dashes, at most 40 characters long
+func Slug(title string) string {
+	var b strings.Builder
+	dash := false
+	for _, r := range strings.ToLower(title) {
+		if (r >= 'a' && r <= 'z') || (r >= '0' && r <= '9') {
+			if dash && b.Len() > 0 {
+				b.WriteByte('-')
+			}
+			b.WriteRune(r)
+			dash = false
+		} else {
+			dash = true
+		}
+		if b.Len() >= 40 {
+			break
+		}
+	}
+	return b.String()
+}
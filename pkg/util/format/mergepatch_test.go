@@ -0,0 +1,92 @@
+package format
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestMergePatch(t *testing.T) {
+	target := map[string]any{"a": 1, "b": map[string]any{"c": 2, "d": 3}, "e": "x"}
+	patch := map[string]any{"a": nil, "b": map[string]any{"c": nil, "f": 4}, "g": map[string]any{"h": nil}}
+
+	got := MergePatch(target, patch)
+	want := map[string]any{"b": map[string]any{"d": 3, "f": 4}, "e": "x", "g": map[string]any{"h": nil}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("MergePatch() = %v, want %v", got, want)
+	}
+	if _, ok := target["a"]; !ok {
+		t.Error("MergePatch() modified the target")
+	}
+}
+
+func TestCreateMergePatch(t *testing.T) {
+	tests := []struct {
+		name     string
+		original map[string]any
+		modified map[string]any
+	}{
+		{
+			name:     "equal",
+			original: map[string]any{"a": 1, "b": []any{"x"}},
+			modified: map[string]any{"a": 1.0, "b": []any{"x"}},
+		},
+		{
+			name:     "added, removed and changed fields",
+			original: map[string]any{"a": 1, "b": "x", "n": map[string]any{"c": true, "d": 1}},
+			modified: map[string]any{"a": 2, "n": map[string]any{"c": true, "e": []any{1}}, "z": "new"},
+		},
+		{
+			name:     "emptied object",
+			original: map[string]any{"n": map[string]any{"c": 1}},
+			modified: map[string]any{"n": map[string]any{}},
+		},
+		{
+			name:     "new object with nulls",
+			original: map[string]any{},
+			modified: map[string]any{"n": map[string]any{"c": nil}},
+		},
+		{
+			name:     "object replaced by a value",
+			original: map[string]any{"n": map[string]any{"c": 1}},
+			modified: map[string]any{"n": "flat"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			patch := CreateMergePatch(tt.original, tt.modified)
+			got := normalizeJSON(MergePatch(tt.original, patch))
+			if want := normalizeJSON(tt.modified); !reflect.DeepEqual(got, want) {
+				t.Errorf("MergePatch(original, CreateMergePatch()) = %v, want %v (patch %v)", got, want, patch)
+			}
+		})
+	}
+
+	if patch := CreateMergePatch(map[string]any{"a": 1}, map[string]any{"a": 1.0}); patch != nil {
+		t.Errorf("CreateMergePatch() of equal maps = %v, want nil", patch)
+	}
+}
+
+func TestJSONEqual(t *testing.T) {
+	if !JSONEqual(map[string]any{"a": 1, "b": []any{"x"}}, map[string]any{"b": []any{"x"}, "a": 1.0}) {
+		t.Error("JSONEqual() = false for equal values")
+	}
+	if JSONEqual(map[string]any{"a": 1}, map[string]any{"a": "1"}) {
+		t.Error("JSONEqual() = true for different values")
+	}
+}
+
+func TestSlug(t *testing.T) {
+	tests := map[string]string{
+		"Error rate":                  "error-rate",
+		"  P95 Latency (ms) / host ":  "p95-latency-ms-host",
+		"## Notes":                    "notes",
+		"":                            "",
+		strings.Repeat("abcdefgh", 8): strings.Repeat("abcdefgh", 5),
+	}
+	for in, want := range tests {
+		if got := Slug(in); got != want {
+			t.Errorf("Slug(%q) = %q, want %q", in, got, want)
+		}
+	}
+}